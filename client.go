@@ -42,6 +42,8 @@ type InternalClient interface {
 
 	ClaimActualLRP(logger lager.Logger, processGuid string, index int, instanceKey *models.ActualLRPInstanceKey) error
 	StartActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) error
+	ActualLRPLifecycleBatch(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]error, error)
+	ClaimActualLRPs(logger lager.Logger, processGuid string, claims []models.IndexInstanceKey) ([]error, error)
 	CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) error
 	FailActualLRP(logger lager.Logger, key *models.ActualLRPKey, errorMessage string) error
 	RemoveActualLRP(logger lager.Logger, processGuid string, index int, instanceKey *models.ActualLRPInstanceKey) error
@@ -72,6 +74,10 @@ type Client interface {
 	// Returns true if the BBS server is reachable
 	Ping(logger lager.Logger) bool
 
+	// Returns true once the BBS has completed an LRP convergence since it
+	// started
+	ConvergenceReady(logger lager.Logger) (bool, error)
+
 	// Lists all Cells
 	Cells(logger lager.Logger) ([]*models.CellPresence, error)
 }
@@ -132,11 +138,18 @@ type ExternalActualLRPClient interface {
 	// Returns all ActualLRPGroups that have the given process guid
 	ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string) ([]*models.ActualLRPGroup, error)
 
+	// Returns the ActualLRPGroups of every Actual LRP in the given state
+	ActualLRPGroupsByState(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error)
+
 	// Returns the ActualLRPGroup with the given process guid and instance index
 	ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int) (*models.ActualLRPGroup, error)
 
 	// Shuts down the ActualLRP matching the given ActualLRPKey, but does not modify the desired state
 	RetireActualLRP(logger lager.Logger, key *models.ActualLRPKey) error
+
+	// Shuts down all ActualLRPs with the given process guid, but does not modify the desired state.
+	// Returns the number of ActualLRPs retired.
+	RetireActualLRPsByProcessGuid(logger lager.Logger, processGuid string) (int, error)
 }
 
 /*
@@ -152,12 +165,22 @@ type ExternalDesiredLRPClient interface {
 	// Returns all DesiredLRPSchedulingInfos that match the given DesiredLRPFilter
 	DesiredLRPSchedulingInfos(lager.Logger, models.DesiredLRPFilter) ([]*models.DesiredLRPSchedulingInfo, error)
 
+	// Returns the process guids of all DesiredLRPs
+	DesiredLRPProcessGuids(lager.Logger) ([]string, error)
+
 	// Creates the given DesiredLRP and its corresponding ActualLRPs
 	DesireLRP(lager.Logger, *models.DesiredLRP) error
 
+	// Creates all of the given DesiredLRPs and their corresponding ActualLRPs,
+	// or none of them if any fails to be created
+	DesireLRPs(lager.Logger, []*models.DesiredLRP) error
+
 	// Updates the DesiredLRP matching the given process guid
 	UpdateDesiredLRP(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) error
 
+	// Replaces only the routes of the DesiredLRP matching the given process guid
+	UpdateDesiredLRPRoutes(logger lager.Logger, processGuid string, routes *models.Routes) error
+
 	// Removes the DesiredLRP matching the given process guid
 	RemoveDesiredLRP(logger lager.Logger, processGuid string) error
 }
@@ -234,6 +257,15 @@ func (c *client) Ping(logger lager.Logger) bool {
 	return response.Available
 }
 
+func (c *client) ConvergenceReady(logger lager.Logger) (bool, error) {
+	response := models.ConvergenceReadinessResponse{}
+	err := c.doRequest(logger, ConvergenceReadinessRoute, nil, nil, nil, &response)
+	if err != nil {
+		return false, err
+	}
+	return response.Converged, nil
+}
+
 func (c *client) Domains(logger lager.Logger) ([]string, error) {
 	response := models.DomainsResponse{}
 	err := c.doRequest(logger, DomainsRoute, nil, nil, nil, &response)
@@ -283,6 +315,19 @@ func (c *client) ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid s
 	return response.ActualLrpGroups, response.Error.ToError()
 }
 
+func (c *client) ActualLRPGroupsByState(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error) {
+	request := models.ActualLRPGroupsByStateRequest{
+		State: string(state),
+	}
+	response := models.ActualLRPGroupsResponse{}
+	err := c.doRequest(logger, ActualLRPGroupsByStateRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ActualLrpGroups, response.Error.ToError()
+}
+
 func (c *client) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int) (*models.ActualLRPGroup, error) {
 	request := models.ActualLRPGroupByProcessGuidAndIndexRequest{
 		ProcessGuid: processGuid,
@@ -326,6 +371,44 @@ func (c *client) StartActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 	return response.Error.ToError()
 }
 
+func (c *client) ActualLRPLifecycleBatch(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]error, error) {
+	request := models.ActualLRPLifecycleBatchRequest{
+		Operations: operations,
+	}
+	response := models.ActualLRPLifecycleBatchResponse{}
+	err := c.doRequest(logger, ActualLRPLifecycleBatchRoute, nil, nil, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, response.Error.ToError()
+	}
+
+	errs := make([]error, 0, len(response.Results))
+	for _, result := range response.Results {
+		errs = append(errs, result.Error.ToError())
+	}
+	return errs, nil
+}
+
+// ClaimActualLRPs claims the given indices of the process guid with a single
+// lifecycle batch and returns the error of each claim, nil for those that
+// succeeded. Claiming an index already claimed by the same instance key
+// succeeds without changing it.
+func (c *client) ClaimActualLRPs(logger lager.Logger, processGuid string, claims []models.IndexInstanceKey) ([]error, error) {
+	operations := make([]*models.ActualLRPLifecycleOperation, 0, len(claims))
+	for _, claim := range claims {
+		operations = append(operations, &models.ActualLRPLifecycleOperation{
+			Claim: &models.ClaimActualLRPRequest{
+				ProcessGuid:          processGuid,
+				Index:                claim.Index,
+				ActualLrpInstanceKey: claim.InstanceKey,
+			},
+		})
+	}
+	return c.ActualLRPLifecycleBatch(logger, operations)
+}
+
 func (c *client) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) error {
 	request := models.CrashActualLRPRequest{
 		ActualLrpKey:         key,
@@ -368,6 +451,19 @@ func (c *client) RetireActualLRP(logger lager.Logger, key *models.ActualLRPKey)
 	return response.Error.ToError()
 }
 
+func (c *client) RetireActualLRPsByProcessGuid(logger lager.Logger, processGuid string) (int, error) {
+	request := models.RetireActualLRPsByProcessGuidRequest{
+		ProcessGuid: processGuid,
+	}
+	response := models.RetireActualLRPsByProcessGuidResponse{}
+	err := c.doRequest(logger, RetireActualLRPsByProcessGuidRoute, nil, nil, &request, &response)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(response.RetiredCount), response.Error.ToError()
+}
+
 func (c *client) RemoveActualLRP(logger lager.Logger, processGuid string, index int, instanceKey *models.ActualLRPInstanceKey) error {
 	request := models.RemoveActualLRPRequest{
 		ProcessGuid:          processGuid,
@@ -470,6 +566,16 @@ func (c *client) DesiredLRPSchedulingInfos(logger lager.Logger, filter models.De
 	return response.DesiredLrpSchedulingInfos, response.Error.ToError()
 }
 
+func (c *client) DesiredLRPProcessGuids(logger lager.Logger) ([]string, error) {
+	response := models.DesiredLRPProcessGuidsResponse{}
+	err := c.doRequest(logger, DesiredLRPProcessGuidsRoute, nil, nil, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ProcessGuids, response.Error.ToError()
+}
+
 func (c *client) doDesiredLRPLifecycleRequest(logger lager.Logger, route string, request proto.Message) error {
 	response := models.DesiredLRPLifecycleResponse{}
 	err := c.doRequest(logger, route, nil, nil, request, &response)
@@ -486,6 +592,13 @@ func (c *client) DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP) e
 	return c.doDesiredLRPLifecycleRequest(logger, DesireDesiredLRPRoute, &request)
 }
 
+func (c *client) DesireLRPs(logger lager.Logger, desiredLRPs []*models.DesiredLRP) error {
+	request := models.DesireLRPsRequest{
+		DesiredLrps: desiredLRPs,
+	}
+	return c.doDesiredLRPLifecycleRequest(logger, DesireDesiredLRPsRoute, &request)
+}
+
 func (c *client) UpdateDesiredLRP(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) error {
 	request := models.UpdateDesiredLRPRequest{
 		ProcessGuid: processGuid,
@@ -494,6 +607,14 @@ func (c *client) UpdateDesiredLRP(logger lager.Logger, processGuid string, updat
 	return c.doDesiredLRPLifecycleRequest(logger, UpdateDesiredLRPRoute, &request)
 }
 
+func (c *client) UpdateDesiredLRPRoutes(logger lager.Logger, processGuid string, routes *models.Routes) error {
+	request := models.UpdateDesiredLRPRoutesRequest{
+		ProcessGuid: processGuid,
+		Routes:      routes,
+	}
+	return c.doDesiredLRPLifecycleRequest(logger, UpdateDesiredLRPRoutesRoute, &request)
+}
+
 func (c *client) RemoveDesiredLRP(logger lager.Logger, processGuid string) error {
 	request := models.RemoveDesiredLRPRequest{
 		ProcessGuid: processGuid,
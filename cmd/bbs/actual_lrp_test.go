@@ -111,6 +111,7 @@ var _ = Describe("ActualLRP API", func() {
 			ActualLRPInstanceKey: baseLRPInstanceKey,
 			ActualLRPNetInfo:     netInfo,
 			State:                models.ActualLRPStateRunning,
+			Annotation:           "some-annotation",
 		}
 
 		evacuatingLRP = &models.ActualLRP{
@@ -118,6 +119,7 @@ var _ = Describe("ActualLRP API", func() {
 			ActualLRPInstanceKey: evacuatingLRPInstanceKey,
 			ActualLRPNetInfo:     netInfo,
 			State:                models.ActualLRPStateRunning,
+			Annotation:           "some-annotation",
 		}
 
 		evacuatingInstanceLRP = &models.ActualLRP{
@@ -130,6 +132,7 @@ var _ = Describe("ActualLRP API", func() {
 			ActualLRPInstanceKey: otherLRPInstanceKey,
 			ActualLRPNetInfo:     netInfo,
 			State:                models.ActualLRPStateRunning,
+			Annotation:           "some-annotation",
 		}
 
 		unclaimedLRP = &models.ActualLRP{
@@ -16,46 +16,82 @@ import (
 type BBSConfig struct {
 	AccessLogPath             string                `json:"access_log_path,omitempty"`
 	AdvertiseURL              string                `json:"advertise_url,omitempty"`
+	AllowedImageHosts         []string              `json:"allowed_image_hosts,omitempty"`
+	AllowedImageSchemes       []string              `json:"allowed_image_schemes,omitempty"`
 	AuctioneerAddress         string                `json:"auctioneer_address,omitempty"`
 	AuctioneerCACert          string                `json:"auctioneer_ca_cert,omitempty"`
 	AuctioneerClientCert      string                `json:"auctioneer_client_cert,omitempty"`
 	AuctioneerClientKey       string                `json:"auctioneer_client_key,omitempty"`
 	AuctioneerRequireTLS      bool                  `json:"auctioneer_require_tls,omitempty"`
 	UUID                      string                `json:"uuid,omitempty"`
+	BufferStartRequests       bool                  `json:"buffer_start_requests,omitempty"`
 	CaFile                    string                `json:"ca_file,omitempty"`
 	CertFile                  string                `json:"cert_file,omitempty"`
 	CommunicationTimeout      durationjson.Duration `json:"communication_timeout,omitempty"`
+	CompactConvergenceLogs    bool                  `json:"compact_convergence_logs,omitempty"`
 	ConsulCluster             string                `json:"consul_cluster,omitempty"`
 	ConvergeRepeatInterval    durationjson.Duration `json:"converge_repeat_interval,omitempty"`
+	ConvergenceBatchSize      int                   `json:"convergence_batch_size,omitempty"`
+	ConvergenceDrainTimeout   durationjson.Duration `json:"convergence_drain_timeout,omitempty"`
+	ConvergenceHistorySize    int                   `json:"convergence_history_size,omitempty"`
+	ConvergenceLockTTL        durationjson.Duration `json:"convergence_lock_ttl,omitempty"`
 	ConvergenceWorkers        int                   `json:"convergence_workers,omitempty"`
 	DatabaseConnectionString  string                `json:"database_connection_string"`
 	DatabaseDriver            string                `json:"database_driver,omitempty"`
 	DesiredLRPCreationTimeout durationjson.Duration `json:"desired_lrp_creation_timeout,omitempty"`
+	DomainStaleWindow         durationjson.Duration `json:"domain_stale_window,omitempty"`
 	DropsondePort             int                   `json:"dropsonde_port,omitempty"`
+	EncodingPolicy            map[string]string     `json:"encoding_policy,omitempty"`
+	EncryptionScheme          string                `json:"encryption_scheme,omitempty"`
 	ETCDConfig
-	ExpireCompletedTaskDuration durationjson.Duration `json:"expire_completed_task_duration,omitempty"`
-	ExpirePendingTaskDuration   durationjson.Duration `json:"expire_pending_task_duration,omitempty"`
-	HealthAddress               string                `json:"health_address,omitempty"`
-	KeyFile                     string                `json:"key_file,omitempty"`
-	KickTaskDuration            durationjson.Duration `json:"kick_task_duration,omitempty"`
-	ListenAddress               string                `json:"listen_address,omitempty"`
-	LockRetryInterval           durationjson.Duration `json:"lock_retry_interval,omitempty"`
-	LockTTL                     durationjson.Duration `json:"lock_ttl,omitempty"`
-	MaxIdleDatabaseConnections  int                   `json:"max_idle_database_connections,omitempty"`
-	MaxOpenDatabaseConnections  int                   `json:"max_open_database_connections,omitempty"`
-	RepCACert                   string                `json:"rep_ca_cert,omitempty"`
-	RepClientCert               string                `json:"rep_client_cert,omitempty"`
-	RepClientKey                string                `json:"rep_client_key,omitempty"`
-	RepClientSessionCacheSize   int                   `json:"rep_client_session_cache_size,omitempty"`
-	RepRequireTLS               bool                  `json:"rep_require_tls,omitempty"`
-	ReportInterval              durationjson.Duration `json:"report_interval,omitempty"`
-	RequireSSL                  bool                  `json:"require_ssl,omitempty"`
-	SQLCACertFile               string                `json:"sql_ca_cert_file,omitempty"`
-	SessionName                 string                `json:"session_name,omitempty"`
-	SkipConsulLock              bool                  `json:"skip_consul_lock,omitempty"`
-	TaskCallbackWorkers         int                   `json:"task_callback_workers,omitempty"`
-	UpdateWorkers               int                   `json:"update_workers,omitempty"`
-	LoggregatorConfig           loggregator_v2.Config `json:"loggregator"`
+	ExpireCompletedTaskDuration   durationjson.Duration `json:"expire_completed_task_duration,omitempty"`
+	ExpirePendingTaskDuration     durationjson.Duration `json:"expire_pending_task_duration,omitempty"`
+	ExtraLRPRetirementMinAge      durationjson.Duration `json:"extra_lrp_retirement_min_age,omitempty"`
+	HealthAddress                 string                `json:"health_address,omitempty"`
+	IgnoreEmptyCellSet            bool                  `json:"ignore_empty_cell_set,omitempty"`
+	KeyFile                       string                `json:"key_file,omitempty"`
+	KickTaskDuration              durationjson.Duration `json:"kick_task_duration,omitempty"`
+	KnownPlacementTags            []string              `json:"known_placement_tags,omitempty"`
+	LRPFirstCrashEvents           bool                  `json:"lrp_first_crash_events,omitempty"`
+	LRPPlacementFairnessMetric    bool                  `json:"lrp_placement_fairness_metric,omitempty"`
+	LazyEncodingUpgrades          bool                  `json:"lazy_encoding_upgrades,omitempty"`
+	ListenAddress                 string                `json:"listen_address,omitempty"`
+	LockRetryInterval             durationjson.Duration `json:"lock_retry_interval,omitempty"`
+	LockTTL                       durationjson.Duration `json:"lock_ttl,omitempty"`
+	MaxAuctionBatchBytes          int                   `json:"max_auction_batch_bytes,omitempty"`
+	MaxCrashBackoffDuration       durationjson.Duration `json:"max_crash_backoff_duration,omitempty"`
+	MaxCrashRestarts              int                   `json:"max_crash_restarts,omitempty"`
+	MaxConcurrentListRequests     int                   `json:"max_concurrent_list_requests,omitempty"`
+	MaxDatabaseConnectionIdleTime durationjson.Duration `json:"max_database_connection_idle_time,omitempty"`
+	MaxDesiredLRPRoutes           int                   `json:"max_desired_lrp_routes,omitempty"`
+	MaxIdleDatabaseConnections    int                   `json:"max_idle_database_connections,omitempty"`
+	MaxInstanceLifetime           durationjson.Duration `json:"max_instance_lifetime,omitempty"`
+	MaxOpenDatabaseConnections    int                   `json:"max_open_database_connections,omitempty"`
+	MaxStartRequestRetries        int                   `json:"max_start_request_retries,omitempty"`
+	MaxStartRequestsPerTick       int                   `json:"max_start_requests_per_tick,omitempty"`
+	MetricPrefix                  string                `json:"metric_prefix,omitempty"`
+	NonceReuseCacheSize           int                   `json:"nonce_reuse_cache_size,omitempty"`
+	PrewarmDatabaseConnections    int                   `json:"prewarm_database_connections,omitempty"`
+	ReEncryptionWorkers           int                   `json:"re_encryption_workers,omitempty"`
+	RepCACert                     string                `json:"rep_ca_cert,omitempty"`
+	RepClientCert                 string                `json:"rep_client_cert,omitempty"`
+	RepClientKey                  string                `json:"rep_client_key,omitempty"`
+	RepClientSessionCacheSize     int                   `json:"rep_client_session_cache_size,omitempty"`
+	RepRequireTLS                 bool                  `json:"rep_require_tls,omitempty"`
+	ReportInterval                durationjson.Duration `json:"report_interval,omitempty"`
+	RequestLogSampleRate          int                   `json:"request_log_sample_rate,omitempty"`
+	RequireSSL                    bool                  `json:"require_ssl,omitempty"`
+	RetireNonRestartableCrashes   bool                  `json:"retire_non_restartable_crashes,omitempty"`
+	SQLCACertFile                 string                `json:"sql_ca_cert_file,omitempty"`
+	SessionName                   string                `json:"session_name,omitempty"`
+	SkipConsulLock                bool                  `json:"skip_consul_lock,omitempty"`
+	SkipIdleConvergence           bool                  `json:"skip_idle_convergence,omitempty"`
+	StaleUnclaimedDuration        durationjson.Duration `json:"stale_unclaimed_duration,omitempty"`
+	TaskCallbackWorkers           int                   `json:"task_callback_workers,omitempty"`
+	TraceRequests                 bool                  `json:"trace_requests,omitempty"`
+	TransactionRetryBudget        int                   `json:"transaction_retry_budget,omitempty"`
+	UpdateWorkers                 int                   `json:"update_workers,omitempty"`
+	LoggregatorConfig             loggregator_v2.Config `json:"loggregator"`
 	debugserver.DebugServerConfig
 	encryption.EncryptionConfig
 	lagerflags.LagerConfig
@@ -64,30 +100,32 @@ type BBSConfig struct {
 
 func DefaultConfig() BBSConfig {
 	return BBSConfig{
-		SessionName:                 "bbs",
-		CommunicationTimeout:        durationjson.Duration(10 * time.Second),
-		RequireSSL:                  false,
-		DesiredLRPCreationTimeout:   durationjson.Duration(1 * time.Minute),
-		ExpireCompletedTaskDuration: durationjson.Duration(2 * time.Minute),
-		ExpirePendingTaskDuration:   durationjson.Duration(30 * time.Minute),
-		ConvergeRepeatInterval:      durationjson.Duration(30 * time.Second),
-		KickTaskDuration:            durationjson.Duration(30 * time.Second),
-		LockTTL:                     durationjson.Duration(locket.DefaultSessionTTL),
-		LockRetryInterval:           durationjson.Duration(locket.RetryInterval),
-		ReportInterval:              durationjson.Duration(1 * time.Minute),
-		ConvergenceWorkers:          20,
-		UpdateWorkers:               1000,
-		TaskCallbackWorkers:         1000,
-		DropsondePort:               3457,
-		DatabaseDriver:              "mysql",
-		MaxOpenDatabaseConnections:  200,
-		MaxIdleDatabaseConnections:  200,
-		AuctioneerRequireTLS:        false,
-		RepClientSessionCacheSize:   0,
-		RepRequireTLS:               false,
-		ETCDConfig:                  DefaultETCDConfig(),
-		EncryptionConfig:            encryption.DefaultEncryptionConfig(),
-		LagerConfig:                 lagerflags.DefaultLagerConfig(),
+		SessionName:                   "bbs",
+		CommunicationTimeout:          durationjson.Duration(10 * time.Second),
+		RequireSSL:                    false,
+		DesiredLRPCreationTimeout:     durationjson.Duration(1 * time.Minute),
+		ExpireCompletedTaskDuration:   durationjson.Duration(2 * time.Minute),
+		ExpirePendingTaskDuration:     durationjson.Duration(30 * time.Minute),
+		ConvergeRepeatInterval:        durationjson.Duration(30 * time.Second),
+		ConvergenceDrainTimeout:       durationjson.Duration(30 * time.Second),
+		KickTaskDuration:              durationjson.Duration(30 * time.Second),
+		LockTTL:                       durationjson.Duration(locket.DefaultSessionTTL),
+		LockRetryInterval:             durationjson.Duration(locket.RetryInterval),
+		ReportInterval:                durationjson.Duration(1 * time.Minute),
+		ConvergenceWorkers:            20,
+		UpdateWorkers:                 1000,
+		TaskCallbackWorkers:           1000,
+		DropsondePort:                 3457,
+		DatabaseDriver:                "mysql",
+		MaxOpenDatabaseConnections:    200,
+		MaxIdleDatabaseConnections:    200,
+		MaxDatabaseConnectionIdleTime: durationjson.Duration(5 * time.Minute),
+		AuctioneerRequireTLS:          false,
+		RepClientSessionCacheSize:     0,
+		RepRequireTLS:                 false,
+		ETCDConfig:                    DefaultETCDConfig(),
+		EncryptionConfig:              encryption.DefaultEncryptionConfig(),
+		LagerConfig:                   lagerflags.DefaultLagerConfig(),
 	}
 }
 
@@ -26,23 +26,34 @@ var _ = Describe("BBSConfig", func() {
 			"access_log_path": "/var/vcap/sys/log/bbs/access.log",
 			"active_key_label": "label",
 			"advertise_url": "bbs.service.cf.internal",
+			"allowed_image_hosts": ["registry.example.com"],
+			"allowed_image_schemes": ["docker", "preloaded"],
 			"auctioneer_address": "https://auctioneer.service.cf.internal:9016",
 			"auctioneer_ca_cert": "/var/vcap/jobs/bbs/config/auctioneer.ca",
 			"auctioneer_client_cert": "/var/vcap/jobs/bbs/config/auctioneer.crt",
 			"auctioneer_client_key": "/var/vcap/jobs/bbs/config/auctioneer.key",
 			"auctioneer_require_tls": true,
 			"uuid": "bosh-boshy-bosh-bosh",
+			"buffer_start_requests": true,
 			"ca_file": "/var/vcap/jobs/bbs/config/ca.crt",
 			"cert_file": "/var/vcap/jobs/bbs/config/bbs.crt",
 			"communication_timeout": "20s",
+			"compact_convergence_logs": true,
 			"consul_cluster": "",
 			"converge_repeat_interval": "30s",
+			"convergence_batch_size": 500,
+			"convergence_drain_timeout": "10s",
+			"convergence_history_size": 20,
+			"convergence_lock_ttl": "1m",
 			"convergence_workers": 20,
 			"database_connection_string": "",
 			"database_driver": "postgres",
 			"debug_address": "127.0.0.1:17017",
 			"desired_lrp_creation_timeout": "1m0s",
+			"domain_stale_window": "30s",
 			"dropsonde_port": 3457,
+			"encoding_policy": {"TaskDefinition": "base64"},
+			"encryption_scheme": "chacha20-poly1305",
 			"encryption_keys": {"label": "key"},
 			"etcd_ca_file": "/var/vcap/jobs/bbs/config/etcd.ca",
 			"etcd_cert_file": "/var/vcap/jobs/bbs/config/etcd.crt",
@@ -52,9 +63,15 @@ var _ = Describe("BBSConfig", func() {
 			"etcd_max_idle_conns_per_host": 10,
 			"expire_completed_task_duration": "2m0s",
 			"expire_pending_task_duration": "30m0s",
+			"extra_lrp_retirement_min_age": "30s",
 			"health_address": "127.0.0.1:8890",
+			"ignore_empty_cell_set": true,
 			"key_file": "/var/vcap/jobs/bbs/config/bbs.key",
 			"kick_task_duration": "30s",
+			"known_placement_tags": ["red-tag", "blue-tag"],
+			"lrp_first_crash_events": true,
+			"lrp_placement_fairness_metric": true,
+			"lazy_encoding_upgrades": true,
 			"listen_address": "0.0.0.0:8889",
 			"lock_retry_interval": "5s",
 			"lock_ttl": "15s",
@@ -75,19 +92,38 @@ var _ = Describe("BBSConfig", func() {
         "loggregator_job_ip": "job-ip",
         "loggregator_job_origin": "job-origin"
       },
+			"max_auction_batch_bytes": 1048576,
+			"max_crash_backoff_duration": "8m0s",
+			"max_crash_restarts": 50,
+			"max_concurrent_list_requests": 8,
+			"max_database_connection_idle_time": "10m0s",
+			"max_desired_lrp_routes": 1000,
 			"max_idle_database_connections": 50,
+			"max_instance_lifetime": "24h0m0s",
 			"max_open_database_connections": 200,
+			"max_start_request_retries": 10,
+			"max_start_requests_per_tick": 5000,
+			"metric_prefix": "diego-west.",
+			"nonce_reuse_cache_size": 1000,
+			"prewarm_database_connections": 20,
+			"re_encryption_workers": 4,
 			"rep_ca_cert": "/var/vcap/jobs/bbs/config/rep.ca",
 			"rep_client_cert": "/var/vcap/jobs/bbs/config/rep.crt",
 			"rep_client_key": "/var/vcap/jobs/bbs/config/rep.key",
 			"rep_client_session_cache_size": 10,
 			"rep_require_tls": true,
 			"report_interval": "1m0s",
+			"request_log_sample_rate": 100,
 			"require_ssl": true,
+			"retire_non_restartable_crashes": true,
 			"session_name": "bbs-session",
 			"skip_consul_lock": true,
+			"skip_idle_convergence": true,
+			"stale_unclaimed_duration": "1m0s",
 			"sql_ca_cert_file": "/var/vcap/jobs/bbs/config/sql.ca",
 			"task_callback_workers": 1000,
+			"trace_requests": true,
+			"transaction_retry_budget": 50,
 			"update_workers": 1000
 		}`
 	})
@@ -115,12 +151,15 @@ var _ = Describe("BBSConfig", func() {
 		config := config.BBSConfig{
 			AccessLogPath:        "/var/vcap/sys/log/bbs/access.log",
 			AdvertiseURL:         "bbs.service.cf.internal",
+			AllowedImageHosts:    []string{"registry.example.com"},
+			AllowedImageSchemes:  []string{"docker", "preloaded"},
 			AuctioneerAddress:    "https://auctioneer.service.cf.internal:9016",
 			AuctioneerCACert:     "/var/vcap/jobs/bbs/config/auctioneer.ca",
 			AuctioneerClientCert: "/var/vcap/jobs/bbs/config/auctioneer.crt",
 			AuctioneerClientKey:  "/var/vcap/jobs/bbs/config/auctioneer.key",
 			AuctioneerRequireTLS: true,
 			UUID:                 "bosh-boshy-bosh-bosh",
+			BufferStartRequests:  true,
 			CaFile:               "/var/vcap/jobs/bbs/config/ca.crt",
 			CertFile:             "/var/vcap/jobs/bbs/config/bbs.crt",
 			ClientLocketConfig: locket.ClientLocketConfig{
@@ -129,15 +168,23 @@ var _ = Describe("BBSConfig", func() {
 				LocketClientCertFile: "locket-client-cert",
 				LocketClientKeyFile:  "locket-client-key",
 			},
-			CommunicationTimeout:   durationjson.Duration(20 * time.Second),
-			ConvergeRepeatInterval: durationjson.Duration(30 * time.Second),
-			ConvergenceWorkers:     20,
-			DatabaseDriver:         "postgres",
+			CommunicationTimeout:    durationjson.Duration(20 * time.Second),
+			CompactConvergenceLogs:  true,
+			ConvergeRepeatInterval:  durationjson.Duration(30 * time.Second),
+			ConvergenceBatchSize:    500,
+			ConvergenceDrainTimeout: durationjson.Duration(10 * time.Second),
+			ConvergenceHistorySize:  20,
+			ConvergenceLockTTL:      durationjson.Duration(time.Minute),
+			ConvergenceWorkers:      20,
+			DatabaseDriver:          "postgres",
 			DebugServerConfig: debugserver.DebugServerConfig{
 				DebugAddress: "127.0.0.1:17017",
 			},
 			DesiredLRPCreationTimeout: durationjson.Duration(1 * time.Minute),
+			DomainStaleWindow:         durationjson.Duration(30 * time.Second),
 			DropsondePort:             3457,
+			EncodingPolicy:            map[string]string{"TaskDefinition": "base64"},
+			EncryptionScheme:          "chacha20-poly1305",
 			EncryptionConfig: encryption.EncryptionConfig{
 				ActiveKeyLabel: "label",
 				EncryptionKeys: map[string]string{
@@ -154,9 +201,12 @@ var _ = Describe("BBSConfig", func() {
 			},
 			ExpireCompletedTaskDuration: durationjson.Duration(2 * time.Minute),
 			ExpirePendingTaskDuration:   durationjson.Duration(30 * time.Minute),
+			ExtraLRPRetirementMinAge:    durationjson.Duration(30 * time.Second),
 			HealthAddress:               "127.0.0.1:8890",
+			IgnoreEmptyCellSet:          true,
 			KeyFile:                     "/var/vcap/jobs/bbs/config/bbs.key",
 			KickTaskDuration:            durationjson.Duration(30 * time.Second),
+			KnownPlacementTags:          []string{"red-tag", "blue-tag"},
 			LagerConfig: lagerflags.LagerConfig{
 				LogLevel: "debug",
 			},
@@ -172,23 +222,45 @@ var _ = Describe("BBSConfig", func() {
 				JobIP:         "job-ip",
 				JobOrigin:     "job-origin",
 			},
-			ListenAddress:              "0.0.0.0:8889",
-			LockRetryInterval:          durationjson.Duration(locket.RetryInterval),
-			LockTTL:                    durationjson.Duration(locket.DefaultSessionTTL),
-			MaxIdleDatabaseConnections: 50,
-			MaxOpenDatabaseConnections: 200,
-			RepCACert:                  "/var/vcap/jobs/bbs/config/rep.ca",
-			RepClientCert:              "/var/vcap/jobs/bbs/config/rep.crt",
-			RepClientKey:               "/var/vcap/jobs/bbs/config/rep.key",
-			RepClientSessionCacheSize:  10,
-			RepRequireTLS:              true,
-			ReportInterval:             durationjson.Duration(1 * time.Minute),
-			RequireSSL:                 true,
-			SQLCACertFile:              "/var/vcap/jobs/bbs/config/sql.ca",
-			SessionName:                "bbs-session",
-			TaskCallbackWorkers:        1000,
-			UpdateWorkers:              1000,
-			SkipConsulLock:             true,
+			LRPFirstCrashEvents:           true,
+			LRPPlacementFairnessMetric:    true,
+			LazyEncodingUpgrades:          true,
+			ListenAddress:                 "0.0.0.0:8889",
+			LockRetryInterval:             durationjson.Duration(locket.RetryInterval),
+			LockTTL:                       durationjson.Duration(locket.DefaultSessionTTL),
+			MaxAuctionBatchBytes:          1048576,
+			MaxCrashBackoffDuration:       durationjson.Duration(8 * time.Minute),
+			MaxCrashRestarts:              50,
+			MaxConcurrentListRequests:     8,
+			MaxDatabaseConnectionIdleTime: durationjson.Duration(10 * time.Minute),
+			MaxDesiredLRPRoutes:           1000,
+			MaxIdleDatabaseConnections:    50,
+			MaxInstanceLifetime:           durationjson.Duration(24 * time.Hour),
+			MaxOpenDatabaseConnections:    200,
+			MaxStartRequestRetries:        10,
+			MaxStartRequestsPerTick:       5000,
+			MetricPrefix:                  "diego-west.",
+			NonceReuseCacheSize:           1000,
+			PrewarmDatabaseConnections:    20,
+			ReEncryptionWorkers:           4,
+			RepCACert:                     "/var/vcap/jobs/bbs/config/rep.ca",
+			RepClientCert:                 "/var/vcap/jobs/bbs/config/rep.crt",
+			RepClientKey:                  "/var/vcap/jobs/bbs/config/rep.key",
+			RepClientSessionCacheSize:     10,
+			RepRequireTLS:                 true,
+			ReportInterval:                durationjson.Duration(1 * time.Minute),
+			RequestLogSampleRate:          100,
+			RequireSSL:                    true,
+			RetireNonRestartableCrashes:   true,
+			SQLCACertFile:                 "/var/vcap/jobs/bbs/config/sql.ca",
+			SessionName:                   "bbs-session",
+			TaskCallbackWorkers:           1000,
+			TraceRequests:                 true,
+			TransactionRetryBudget:        50,
+			UpdateWorkers:                 1000,
+			SkipConsulLock:                true,
+			SkipIdleConvergence:           true,
+			StaleUnclaimedDuration:        durationjson.Duration(time.Minute),
 		}
 
 		Expect(bbsConfig).To(Equal(config))
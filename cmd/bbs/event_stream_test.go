@@ -329,8 +329,10 @@ var _ = Describe("Events API", func() {
 							Expect(err).NotTo(HaveOccurred())
 
 							Eventually(eventChannel).Should(Receive(Equal(&models.ActualLRPChangedEvent{
-								Before: before,
-								After:  actualLRPGroup,
+								Before:       before,
+								After:        actualLRPGroup,
+								StateChanged: true,
+								CellChanged:  true,
 							})))
 
 							Expect(actualLRPGroup.GetInstance().GetCellId()).To(Equal(cellID))
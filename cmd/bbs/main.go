@@ -28,6 +28,7 @@ import (
 	"code.cloudfoundry.org/bbs/format"
 	"code.cloudfoundry.org/bbs/guidprovider"
 	"code.cloudfoundry.org/bbs/handlers"
+	"code.cloudfoundry.org/bbs/handlers/middleware"
 	"code.cloudfoundry.org/bbs/metrics"
 	"code.cloudfoundry.org/bbs/migration"
 	"code.cloudfoundry.org/bbs/models"
@@ -68,6 +69,8 @@ var configFilePath = flag.String(
 const (
 	dropsondeOrigin = "bbs"
 	bbsLockKey      = "bbs"
+
+	sqlPrewarmTimeout = 30 * time.Second
 )
 
 func main() {
@@ -79,6 +82,8 @@ func main() {
 	}
 
 	cfhttp.Initialize(time.Duration(bbsConfig.CommunicationTimeout))
+	models.SetMaximumRouteCount(bbsConfig.MaxDesiredLRPRoutes)
+	models.SetImageSourceAllowlist(bbsConfig.AllowedImageSchemes, bbsConfig.AllowedImageHosts)
 
 	logger, reconfigurableSink := lagerflags.NewFromConfig(bbsConfig.SessionName, bbsConfig.LagerConfig)
 	logger.Info("starting")
@@ -88,6 +93,8 @@ func main() {
 		logger.Error("failed-to-initialize-metron-client", err)
 		os.Exit(1)
 	}
+	openMetricsRecorder := metrics.NewOpenMetricsRecorder(metronClient, metrics.DefaultMaxOpenMetrics)
+	metronClient = openMetricsRecorder
 
 	clock := clock.NewClock()
 
@@ -157,17 +164,27 @@ func main() {
 			logger.Fatal("failed-to-open-sql", err)
 		}
 		defer sqlConn.Close()
-		sqlConn.SetMaxOpenConns(bbsConfig.MaxOpenDatabaseConnections)
-		sqlConn.SetMaxIdleConns(bbsConfig.MaxIdleDatabaseConnections)
+		sqldb.ConfigureConnectionPool(sqlConn,
+			bbsConfig.MaxOpenDatabaseConnections,
+			bbsConfig.MaxIdleDatabaseConnections,
+			time.Duration(bbsConfig.MaxDatabaseConnectionIdleTime),
+		)
 
 		err = sqlConn.Ping()
 		if err != nil {
 			logger.Fatal("sql-failed-to-connect", err)
 		}
 
+		err = sqldb.PrewarmConnections(sqlConn, bbsConfig.PrewarmDatabaseConnections, sqlPrewarmTimeout)
+		if err != nil {
+			logger.Fatal("sql-failed-to-prewarm-connections", err)
+		}
+
 		sqlDB = sqldb.NewSQLDB(sqlConn,
 			bbsConfig.ConvergenceWorkers,
+			bbsConfig.ConvergenceBatchSize,
 			bbsConfig.UpdateWorkers,
+			time.Duration(bbsConfig.StaleUnclaimedDuration),
 			format.ENCRYPTED_PROTO,
 			cryptor,
 			guidprovider.DefaultGuidProvider,
@@ -175,6 +192,30 @@ func main() {
 			bbsConfig.DatabaseDriver,
 			metronClient,
 		)
+		sqlDB.SetSkipMissingCellsWhenCellSetEmpty(bbsConfig.IgnoreEmptyCellSet)
+		sqlDB.SetTransactionRetryBudget(bbsConfig.TransactionRetryBudget)
+		err = sqlDB.SetEncryptionScheme(encryption.NewChaCha20Cryptor(keyManager, rand.Reader), initializeEncryptionScheme(logger, bbsConfig))
+		if err != nil {
+			logger.Fatal("invalid-encryption-scheme", err)
+		}
+		err = sqlDB.SetEncodingPolicy(initializeEncodingPolicy(logger, bbsConfig))
+		if err != nil {
+			logger.Fatal("invalid-encoding-policy", err)
+		}
+		sqlDB.SetNonceReuseDetection(logger, bbsConfig.NonceReuseCacheSize)
+		sqlDB.SetReEncryptionWorkers(bbsConfig.ReEncryptionWorkers)
+		sqlDB.SetLazyEncodingUpgrades(bbsConfig.LazyEncodingUpgrades)
+		sqlDB.SetDomainStaleWindow(time.Duration(bbsConfig.DomainStaleWindow))
+		sqlDB.SetExtraLRPRetirementMinAge(time.Duration(bbsConfig.ExtraLRPRetirementMinAge))
+		sqlDB.SetMaxInstanceLifetime(time.Duration(bbsConfig.MaxInstanceLifetime))
+		sqlDB.SetRestartPolicy(initializeRestartPolicy(logger, bbsConfig))
+		sqlDB.SetRetireNonRestartableCrashes(bbsConfig.RetireNonRestartableCrashes)
+		sqlDB.SetMaxStartRequestsPerTick(bbsConfig.MaxStartRequestsPerTick)
+		sqlDB.SetMaxStartRequestRetries(bbsConfig.MaxStartRequestRetries)
+		sqlDB.SetCompactConvergenceLogs(bbsConfig.CompactConvergenceLogs)
+		sqlDB.SetFirstCrashEvents(bbsConfig.LRPFirstCrashEvents)
+		sqlDB.SetPlacementFairnessMetric(bbsConfig.LRPPlacementFairnessMetric)
+		sqlDB.SetSkipIdleConvergence(bbsConfig.SkipIdleConvergence)
 		err = sqlDB.CreateConfigurationsTable(logger)
 		if err != nil {
 			logger.Fatal("sql-failed-create-configurations-table", err)
@@ -298,12 +339,41 @@ func main() {
 	metricsTicker := clock.NewTicker(time.Duration(bbsConfig.ReportInterval))
 	requestStatMetronNotifier := metrics.NewRequestStatMetronNotifier(logger, metricsTicker, metronClient)
 
+	actualLRPController := controllers.NewActualLRPLifecycleController(activeDB, activeDB, activeDB, auctioneerClient, serviceClient, repClientFactory, actualHub)
+	lrpConvergenceController := controllers.NewLRPConvergenceController(logger,
+		activeDB,
+		actualHub,
+		auctioneerClient,
+		serviceClient,
+		actualLRPController,
+		bbsConfig.ConvergenceWorkers,
+	)
+	lrpConvergenceController.SetMaxAuctionBatchBytes(bbsConfig.MaxAuctionBatchBytes)
+	if bbsConfig.ConvergenceHistorySize != 0 {
+		lrpConvergenceController.SetConvergenceHistorySize(bbsConfig.ConvergenceHistorySize)
+	}
+	if bbsConfig.BufferStartRequests && sqlDB != nil {
+		lrpConvergenceController.SetStartRequestBuffer(sqlDB)
+	}
+	if bbsConfig.ConvergenceLockTTL > 0 && sqlDB != nil {
+		lrpConvergenceController.SetConvergenceLock(sqlDB, bbsConfig.UUID, time.Duration(bbsConfig.ConvergenceLockTTL))
+	}
+
+	var tracer middleware.Tracer
+	if bbsConfig.TraceRequests {
+		tracer = middleware.NewLagerTracer(logger.Session("trace"))
+	}
+
 	handler := handlers.New(
 		logger,
 		accessLogger,
 		bbsConfig.UpdateWorkers,
 		bbsConfig.ConvergenceWorkers,
+		bbsConfig.RequestLogSampleRate,
+		bbsConfig.MaxConcurrentListRequests,
+		bbsConfig.KnownPlacementTags,
 		requestStatMetronNotifier,
+		tracer,
 		activeDB,
 		desiredHub,
 		actualHub,
@@ -312,21 +382,14 @@ func main() {
 		serviceClient,
 		auctioneerClient,
 		repClientFactory,
+		lrpConvergenceController,
+		lrpConvergenceController,
 		migrationsDone,
 		exitChan,
 	)
 
 	bbsElectionMetronNotifier := metrics.NewBBSElectionMetronNotifier(logger, metronClient)
 
-	actualLRPController := controllers.NewActualLRPLifecycleController(activeDB, activeDB, activeDB, auctioneerClient, serviceClient, repClientFactory, actualHub)
-	lrpConvergenceController := controllers.NewLRPConvergenceController(logger,
-		activeDB,
-		actualHub,
-		auctioneerClient,
-		serviceClient,
-		actualLRPController,
-		bbsConfig.ConvergenceWorkers,
-	)
 	taskController := controllers.NewTaskController(activeDB, cbWorkPool, auctioneerClient, serviceClient, repClientFactory, taskHub)
 
 	convergerProcess := converger.New(
@@ -339,6 +402,7 @@ func main() {
 		time.Duration(bbsConfig.KickTaskDuration),
 		time.Duration(bbsConfig.ExpirePendingTaskDuration),
 		time.Duration(bbsConfig.ExpireCompletedTaskDuration),
+		time.Duration(bbsConfig.ConvergenceDrainTimeout),
 	)
 
 	var server ifrit.Runner
@@ -348,7 +412,10 @@ func main() {
 		server = http_server.New(bbsConfig.ListenAddress, handler)
 	}
 
-	healthcheckServer := http_server.New(bbsConfig.HealthAddress, http.HandlerFunc(healthCheckHandler))
+	healthcheckMux := http.NewServeMux()
+	healthcheckMux.HandleFunc("/", healthCheckHandler)
+	healthcheckMux.Handle("/metrics", openMetricsRecorder)
+	healthcheckServer := http_server.New(bbsConfig.HealthAddress, healthcheckMux)
 
 	members := grouper.Members{
 		{"healthcheck", healthcheckServer},
@@ -516,6 +583,61 @@ func initializeLockMaintainer(
 	)
 }
 
+func initializeRestartPolicy(logger lager.Logger, bbsConfig config.BBSConfig) models.RestartPolicy {
+	maxBackoffDuration := models.DefaultMaxBackoffDuration
+	if bbsConfig.MaxCrashBackoffDuration > 0 {
+		maxBackoffDuration = time.Duration(bbsConfig.MaxCrashBackoffDuration)
+	}
+
+	maxRestarts := int32(models.DefaultMaxRestarts)
+	if bbsConfig.MaxCrashRestarts > 0 {
+		maxRestarts = int32(bbsConfig.MaxCrashRestarts)
+	}
+
+	restartCalculator := models.NewRestartCalculator(models.DefaultImmediateRestarts, maxBackoffDuration, maxRestarts)
+	err := restartCalculator.Validate()
+	if err != nil {
+		logger.Fatal("invalid-crash-restart-policy", err)
+	}
+
+	return models.NewRestartPolicy(restartCalculator)
+}
+
+// The encrypted encoding the records are written with, AES-GCM unless the
+// encryption_scheme is chacha20-poly1305.
+func initializeEncryptionScheme(logger lager.Logger, bbsConfig config.BBSConfig) format.Encoding {
+	switch bbsConfig.EncryptionScheme {
+	case "", "aes-gcm":
+		return format.BASE64_ENCRYPTED
+	case "chacha20-poly1305":
+		return format.CHACHA20_ENCRYPTED
+	default:
+		logger.Fatal("invalid-encryption-scheme", fmt.Errorf("unknown encryption scheme %q", bbsConfig.EncryptionScheme))
+		return format.BASE64_ENCRYPTED
+	}
+}
+
+// The encoding each record named in the encoding_policy is written with:
+// unencoded, base64, base64-compressed or encrypted.
+func initializeEncodingPolicy(logger lager.Logger, bbsConfig config.BBSConfig) format.EncodingPolicy {
+	policy := format.EncodingPolicy{}
+	for record, encoding := range bbsConfig.EncodingPolicy {
+		switch encoding {
+		case "unencoded":
+			policy[record] = format.UNENCODED
+		case "base64":
+			policy[record] = format.BASE64
+		case "base64-compressed":
+			policy[record] = format.BASE64_COMPRESSED
+		case "encrypted":
+			policy[record] = format.BASE64_ENCRYPTED
+		default:
+			logger.Fatal("invalid-encoding-policy", fmt.Errorf("unknown encoding %q for %s", encoding, record))
+		}
+	}
+	return policy
+}
+
 func initializeAuctioneerClient(logger lager.Logger, bbsConfig *config.BBSConfig) auctioneer.Client {
 	if bbsConfig.AuctioneerAddress == "" {
 		logger.Fatal("auctioneer-address-validation-failed", errors.New("auctioneerAddress is required"))
@@ -550,7 +672,7 @@ func initializeMetron(logger lager.Logger, bbsConfig config.BBSConfig) (loggrega
 		initializeDropsonde(logger, bbsConfig.DropsondePort)
 	}
 
-	return client, nil
+	return metrics.NewPrefixedMetronClient(client, bbsConfig.MetricPrefix), nil
 }
 
 func initializeDropsonde(logger lager.Logger, dropsondePort int) {
@@ -14,10 +14,25 @@ import (
 	"github.com/tedsuo/ifrit/ginkgomon"
 )
 
+// DefaultLogLevel is the log level of the BBS when the config sets none.
+const DefaultLogLevel = "debug"
+
+// DefaultStartCheck is the log line New waits for before the BBS is ready.
+const DefaultStartCheck = "bbs.started"
+
 func New(binPath string, bbsConfig config.BBSConfig) *ginkgomon.Runner {
+	return WaitFor(binPath, bbsConfig, DefaultStartCheck)
+}
+
+// WaitFor is like New, but the BBS is ready once it logs startCheck. Tests
+// asserting on a quieter log level should pick a line logged at that level.
+func WaitFor(binPath string, bbsConfig config.BBSConfig, startCheck string) *ginkgomon.Runner {
 	if bbsConfig.ReportInterval == 0 {
 		bbsConfig.ReportInterval = durationjson.Duration(time.Minute)
 	}
+	if bbsConfig.LogLevel == "" {
+		bbsConfig.LogLevel = DefaultLogLevel
+	}
 
 	f, err := ioutil.TempFile("", "bbs.config")
 	Expect(err).NotTo(HaveOccurred())
@@ -28,7 +43,7 @@ func New(binPath string, bbsConfig config.BBSConfig) *ginkgomon.Runner {
 	return ginkgomon.New(ginkgomon.Config{
 		Name:              "bbs",
 		Command:           exec.Command(binPath, "-config", f.Name()),
-		StartCheck:        "bbs.started",
+		StartCheck:        startCheck,
 		StartCheckTimeout: 10 * time.Second,
 		Cleanup: func() {
 			// do not use Expect otherwise a race condition will happen
@@ -38,7 +53,5 @@ func New(binPath string, bbsConfig config.BBSConfig) *ginkgomon.Runner {
 }
 
 func WaitForMigration(binPath string, bbsConfig config.BBSConfig) *ginkgomon.Runner {
-	runner := New(binPath, bbsConfig)
-	runner.StartCheck = "finished-migrations"
-	return runner
+	return WaitFor(binPath, bbsConfig, "finished-migrations")
 }
@@ -0,0 +1,65 @@
+package testrunner_test
+
+import (
+	"encoding/json"
+	"os"
+
+	"code.cloudfoundry.org/bbs/cmd/bbs/config"
+	"code.cloudfoundry.org/bbs/cmd/bbs/testrunner"
+	"code.cloudfoundry.org/lager/lagerflags"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Runner", func() {
+	var bbsConfig config.BBSConfig
+
+	BeforeEach(func() {
+		bbsConfig = config.BBSConfig{}
+	})
+
+	// The config is passed to the BBS as the file following -config.
+	writtenConfig := func(runner *ginkgomon.Runner) config.BBSConfig {
+		args := runner.Command.Args
+		Expect(args).To(HaveLen(3))
+		Expect(args[1]).To(Equal("-config"))
+		defer os.RemoveAll(args[2])
+
+		f, err := os.Open(args[2])
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+
+		var written config.BBSConfig
+		Expect(json.NewDecoder(f).Decode(&written)).To(Succeed())
+		return written
+	}
+
+	Describe("New", func() {
+		It("defaults the log level to debug", func() {
+			runner := testrunner.New("bbs", bbsConfig)
+			Expect(writtenConfig(runner).LogLevel).To(Equal("debug"))
+		})
+
+		It("keeps an explicit log level", func() {
+			bbsConfig.LagerConfig = lagerflags.LagerConfig{LogLevel: "info"}
+			runner := testrunner.New("bbs", bbsConfig)
+			Expect(writtenConfig(runner).LogLevel).To(Equal("info"))
+		})
+
+		It("waits for the BBS to start", func() {
+			runner := testrunner.New("bbs", bbsConfig)
+			writtenConfig(runner)
+			Expect(runner.StartCheck).To(Equal("bbs.started"))
+		})
+	})
+
+	Describe("WaitFor", func() {
+		It("waits for the given start check", func() {
+			runner := testrunner.WaitFor("bbs", bbsConfig, "bbs.migration-manager.finished-migrations")
+			writtenConfig(runner)
+			Expect(runner.StartCheck).To(Equal("bbs.migration-manager.finished-migrations"))
+		})
+	})
+})
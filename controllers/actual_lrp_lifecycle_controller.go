@@ -46,17 +46,26 @@ func (h *ActualLRPLifecycleController) ClaimActualLRP(logger lager.Logger, proce
 		return err
 	}
 
-	if !after.Equal(before) {
+	h.claimedActualLRP(before, after)
+	return nil
+}
+
+func (h *ActualLRPLifecycleController) claimedActualLRP(before, after *models.ActualLRPGroup) {
+	if before.Diff(after).Changed() {
 		go h.actualHub.Emit(models.NewActualLRPChangedEvent(before, after))
 	}
-	return nil
 }
+
 func (h *ActualLRPLifecycleController) StartActualLRP(logger lager.Logger, actualLRPKey *models.ActualLRPKey, actualLRPInstanceKey *models.ActualLRPInstanceKey, actualLRPNetInfo *models.ActualLRPNetInfo) error {
 	before, after, err := h.db.StartActualLRP(logger, actualLRPKey, actualLRPInstanceKey, actualLRPNetInfo)
 	if err != nil {
 		return err
 	}
 
+	return h.startedActualLRP(logger, actualLRPKey, before, after)
+}
+
+func (h *ActualLRPLifecycleController) startedActualLRP(logger lager.Logger, actualLRPKey *models.ActualLRPKey, before, after *models.ActualLRPGroup) error {
 	lrpGroup, err := h.db.ActualLRPGroupByProcessGuidAndIndex(logger, actualLRPKey.ProcessGuid, actualLRPKey.Index)
 	if err != nil {
 		return err
@@ -66,10 +75,11 @@ func (h *ActualLRPLifecycleController) StartActualLRP(logger lager.Logger, actua
 		h.evacuationDB.RemoveEvacuatingActualLRP(logger, &lrpGroup.Evacuating.ActualLRPKey, &lrpGroup.Evacuating.ActualLRPInstanceKey)
 	}
 
+	change := before.Diff(after)
 	go func() {
 		if before == nil {
 			h.actualHub.Emit(models.NewActualLRPCreatedEvent(after))
-		} else if !before.Equal(after) {
+		} else if change.Changed() {
 			h.actualHub.Emit(models.NewActualLRPChangedEvent(before, after))
 		}
 		if lrpGroup.Evacuating != nil {
@@ -79,6 +89,31 @@ func (h *ActualLRPLifecycleController) StartActualLRP(logger lager.Logger, actua
 	return nil
 }
 
+// ApplyActualLRPLifecycleOperations applies a batch of claims and starts and
+// returns the error of each operation, nil for those that succeeded. The
+// operations that succeed have the same effects as ClaimActualLRP and
+// StartActualLRP.
+func (h *ActualLRPLifecycleController) ApplyActualLRPLifecycleOperations(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]error, error) {
+	results, err := h.db.ApplyActualLRPLifecycleOperations(logger, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(results))
+	for i, result := range results {
+		switch {
+		case result.Error != nil:
+			errs[i] = result.Error
+		case operations[i].Claim != nil:
+			h.claimedActualLRP(result.Before, result.After)
+		default:
+			errs[i] = h.startedActualLRP(logger, operations[i].Start.ActualLrpKey, result.Before, result.After)
+		}
+	}
+
+	return errs, nil
+}
+
 func (h *ActualLRPLifecycleController) CrashActualLRP(logger lager.Logger, actualLRPKey *models.ActualLRPKey, actualLRPInstanceKey *models.ActualLRPInstanceKey, errorMessage string) error {
 	before, after, shouldRestart, err := h.db.CrashActualLRP(logger, actualLRPKey, actualLRPInstanceKey, errorMessage)
 	if err != nil {
@@ -189,3 +224,35 @@ func (h *ActualLRPLifecycleController) RetireActualLRP(logger lager.Logger, key
 
 	return err
 }
+
+// RetireActualLRPsByProcessGuid retires every instance of the given process
+// guid and returns the number of instances retired. Instances that disappear
+// while being retired are not counted, so retiring an app that has no
+// instances left is a no-op.
+func (h *ActualLRPLifecycleController) RetireActualLRPsByProcessGuid(logger lager.Logger, processGuid string) (int, error) {
+	logger = logger.Session("retire-actual-lrps-by-process-guid", lager.Data{"process_guid": processGuid})
+
+	lrpGroups, err := h.db.ActualLRPGroupsByProcessGuid(logger, processGuid)
+	if err != nil {
+		return 0, err
+	}
+
+	retired := 0
+	for _, lrpGroup := range lrpGroups {
+		if lrpGroup.Instance == nil {
+			continue
+		}
+
+		key := lrpGroup.Instance.ActualLRPKey
+		err = h.RetireActualLRP(logger, &key)
+		if err == models.ErrResourceNotFound {
+			continue
+		}
+		if err != nil {
+			return retired, err
+		}
+		retired++
+	}
+
+	return retired, nil
+}
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/bbs/events/eventfakes"
 	"code.cloudfoundry.org/bbs/models"
 	"code.cloudfoundry.org/bbs/serviceclient/serviceclientfakes"
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"code.cloudfoundry.org/rep/repfakes"
 	. "github.com/onsi/ginkgo"
@@ -110,6 +111,15 @@ var _ = Describe("ActualLRP Lifecycle Controller", func() {
 				Expect(changedEvent.Before).To(Equal(newActualLRPGroup(&actualLRP, nil)))
 				Expect(changedEvent.After).To(Equal(newActualLRPGroup(&afterActualLRP, nil)))
 			})
+
+			It("marks the change as a state change", func() {
+				Eventually(actualHub.EmitCallCount).Should(Equal(1))
+				changedEvent := actualHub.EmitArgsForCall(0).(*models.ActualLRPChangedEvent)
+				Expect(changedEvent.StateChanged).To(BeTrue())
+				Expect(changedEvent.NetInfoChanged).To(BeFalse())
+				Expect(changedEvent.CellChanged).To(BeFalse())
+			})
+
 			Context("when the actual lrp did not actually change", func() {
 				BeforeEach(func() {
 					fakeActualLRPDB.ClaimActualLRPReturns(
@@ -239,6 +249,31 @@ var _ = Describe("ActualLRP Lifecycle Controller", func() {
 					Expect(changedEvent.Before).To(Equal(newActualLRPGroup(&actualLRP, nil)))
 					Expect(changedEvent.After).To(Equal(newActualLRPGroup(&afterActualLRP, nil)))
 				})
+
+				It("marks the change as a state, net info and cell change", func() {
+					Eventually(actualHub.EmitCallCount).Should(Equal(1))
+					changedEvent := actualHub.EmitArgsForCall(0).(*models.ActualLRPChangedEvent)
+					Expect(changedEvent.StateChanged).To(BeTrue())
+					Expect(changedEvent.NetInfoChanged).To(BeTrue())
+					Expect(changedEvent.CellChanged).To(BeTrue())
+				})
+			})
+
+			Context("when only the net info of the running actual lrp changed", func() {
+				BeforeEach(func() {
+					actualLRP = afterActualLRP
+					actualLRP.ActualLRPNetInfo = models.NewActualLRPNetInfo("1.1.1.1", "3.3.3.3", models.NewPortMapping(10, 20))
+					fakeActualLRPDB.StartActualLRPReturns(newActualLRPGroup(&actualLRP, nil), newActualLRPGroup(&afterActualLRP, nil), nil)
+				})
+
+				It("emits a single change event marked as only a net info change", func() {
+					Eventually(actualHub.EmitCallCount).Should(Equal(1))
+					Consistently(actualHub.EmitCallCount).Should(Equal(1))
+					changedEvent := actualHub.EmitArgsForCall(0).(*models.ActualLRPChangedEvent)
+					Expect(changedEvent.StateChanged).To(BeFalse())
+					Expect(changedEvent.NetInfoChanged).To(BeTrue())
+					Expect(changedEvent.CellChanged).To(BeFalse())
+				})
 			})
 
 			Context("when the actual lrp wasn't updated", func() {
@@ -268,6 +303,68 @@ var _ = Describe("ActualLRP Lifecycle Controller", func() {
 		})
 	})
 
+	Describe("ApplyActualLRPLifecycleOperations", func() {
+		var (
+			operations []*models.ActualLRPLifecycleOperation
+			errs       []error
+		)
+
+		BeforeEach(func() {
+			key := models.NewActualLRPKey("process-guid", 1, "domain-0")
+			instanceKey := models.NewActualLRPInstanceKey("instance-guid-0", "cell-id-0")
+			netInfo := models.NewActualLRPNetInfo("1.1.1.1", "2.2.2.2", models.NewPortMapping(10, 20))
+			operations = []*models.ActualLRPLifecycleOperation{
+				{Claim: &models.ClaimActualLRPRequest{ProcessGuid: "process-guid", Index: 1, ActualLrpInstanceKey: &instanceKey}},
+				{Claim: &models.ClaimActualLRPRequest{}},
+				{Start: &models.StartActualLRPRequest{ActualLrpKey: &key, ActualLrpInstanceKey: &instanceKey, ActualLrpNetInfo: &netInfo}},
+			}
+
+			unclaimedLRP := models.ActualLRP{ActualLRPKey: key, State: models.ActualLRPStateUnclaimed}
+			claimedLRP := models.ActualLRP{ActualLRPKey: key, ActualLRPInstanceKey: instanceKey, State: models.ActualLRPStateClaimed}
+			runningLRP := models.ActualLRP{ActualLRPKey: key, ActualLRPInstanceKey: instanceKey, ActualLRPNetInfo: netInfo, State: models.ActualLRPStateRunning}
+
+			fakeActualLRPDB.ApplyActualLRPLifecycleOperationsReturns([]*models.ActualLRPLifecycleOperationResult{
+				{Before: newActualLRPGroup(&unclaimedLRP, nil), After: newActualLRPGroup(&claimedLRP, nil)},
+				{Error: models.ErrBadRequest},
+				{Before: newActualLRPGroup(&claimedLRP, nil), After: newActualLRPGroup(&runningLRP, nil)},
+			}, nil)
+			fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexReturns(newActualLRPGroup(&runningLRP, nil), nil)
+		})
+
+		JustBeforeEach(func() {
+			errs, err = controller.ApplyActualLRPLifecycleOperations(logger, operations)
+		})
+
+		It("applies the operations in the DB and returns the error of each", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeActualLRPDB.ApplyActualLRPLifecycleOperationsCallCount()).To(Equal(1))
+			_, actualOperations := fakeActualLRPDB.ApplyActualLRPLifecycleOperationsArgsForCall(0)
+			Expect(actualOperations).To(Equal(operations))
+
+			Expect(errs).To(Equal([]error{nil, models.ErrBadRequest, nil}))
+		})
+
+		It("emits a change event for each operation that changed an actual lrp", func() {
+			Eventually(actualHub.EmitCallCount).Should(Equal(2))
+			Consistently(actualHub.EmitCallCount).Should(Equal(2))
+			for i := 0; i < 2; i++ {
+				Expect(actualHub.EmitArgsForCall(i)).To(BeAssignableToTypeOf(&models.ActualLRPChangedEvent{}))
+			}
+		})
+
+		Context("when the DB fails the whole batch", func() {
+			BeforeEach(func() {
+				fakeActualLRPDB.ApplyActualLRPLifecycleOperationsReturns(nil, models.ErrUnknownError)
+			})
+
+			It("returns the error and emits no events", func() {
+				Expect(err).To(Equal(models.ErrUnknownError))
+				Expect(errs).To(BeNil())
+				Consistently(actualHub.EmitCallCount).Should(Equal(0))
+			})
+		})
+	})
+
 	Describe("CrashActualLRP", func() {
 		var (
 			processGuid  = "process-guid"
@@ -913,6 +1010,110 @@ var _ = Describe("ActualLRP Lifecycle Controller", func() {
 			})
 		})
 	})
+
+	Describe("RetireActualLRPsByProcessGuid", func() {
+		var (
+			processGuid = "process-guid"
+
+			unclaimedLRP models.ActualLRP
+			runningLRP   models.ActualLRP
+			retired      int
+		)
+
+		BeforeEach(func() {
+			unclaimedLRP = models.ActualLRP{
+				ActualLRPKey: models.NewActualLRPKey(processGuid, 0, "domain-0"),
+				State:        models.ActualLRPStateUnclaimed,
+				Since:        1138,
+			}
+			runningLRP = models.ActualLRP{
+				ActualLRPKey:         models.NewActualLRPKey(processGuid, 1, "domain-0"),
+				ActualLRPInstanceKey: models.NewActualLRPInstanceKey("instance-guid-1", "cell-id-1"),
+				State:                models.ActualLRPStateRunning,
+				Since:                1138,
+			}
+
+			groups := []*models.ActualLRPGroup{
+				newActualLRPGroup(&unclaimedLRP, nil),
+				newActualLRPGroup(&runningLRP, nil),
+			}
+			fakeActualLRPDB.ActualLRPGroupsByProcessGuidReturns(groups, nil)
+			fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexStub = func(_ lager.Logger, _ string, index int32) (*models.ActualLRPGroup, error) {
+				return groups[index], nil
+			}
+
+			cellPresence := models.NewCellPresence(
+				"cell-id-1",
+				"cell1.addr",
+				"",
+				"the-zone",
+				models.NewCellCapacity(128, 1024, 6),
+				[]string{},
+				[]string{},
+				[]string{},
+				[]string{},
+			)
+			fakeServiceClient.CellByIdReturns(&cellPresence, nil)
+		})
+
+		JustBeforeEach(func() {
+			retired, err = controller.RetireActualLRPsByProcessGuid(logger, processGuid)
+		})
+
+		It("retires every instance of the process guid", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retired).To(Equal(2))
+
+			_, guid := fakeActualLRPDB.ActualLRPGroupsByProcessGuidArgsForCall(0)
+			Expect(guid).To(Equal(processGuid))
+
+			Expect(fakeActualLRPDB.RemoveActualLRPCallCount()).To(Equal(1))
+			_, removedGuid, removedIndex, _ := fakeActualLRPDB.RemoveActualLRPArgsForCall(0)
+			Expect(removedGuid).To(Equal(processGuid))
+			Expect(removedIndex).To(BeEquivalentTo(0))
+
+			Expect(fakeRepClient.StopLRPInstanceCallCount()).To(Equal(1))
+			_, stoppedKey, stoppedInstanceKey := fakeRepClient.StopLRPInstanceArgsForCall(0)
+			Expect(stoppedKey).To(Equal(runningLRP.ActualLRPKey))
+			Expect(stoppedInstanceKey).To(Equal(runningLRP.ActualLRPInstanceKey))
+		})
+
+		Context("when the process guid has no instances", func() {
+			BeforeEach(func() {
+				fakeActualLRPDB.ActualLRPGroupsByProcessGuidReturns([]*models.ActualLRPGroup{}, nil)
+			})
+
+			It("retires nothing", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(retired).To(Equal(0))
+				Expect(fakeActualLRPDB.RemoveActualLRPCallCount()).To(Equal(0))
+				Expect(fakeRepClient.StopLRPInstanceCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when an instance disappears while being retired", func() {
+			BeforeEach(func() {
+				fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexReturns(nil, models.ErrResourceNotFound)
+				fakeActualLRPDB.ActualLRPGroupByProcessGuidAndIndexStub = nil
+			})
+
+			It("does not count it as retired", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(retired).To(Equal(0))
+			})
+		})
+
+		Context("when fetching the instances fails", func() {
+			BeforeEach(func() {
+				fakeActualLRPDB.ActualLRPGroupsByProcessGuidReturns(nil, models.ErrUnknownError)
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(models.ErrUnknownError))
+				Expect(retired).To(Equal(0))
+			})
+		})
+	})
 })
 
 func newActualLRPGroup(instance, evacuating *models.ActualLRP) *models.ActualLRPGroup {
@@ -1,7 +1,10 @@
 package controllers
 
 import (
+	"encoding/json"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"code.cloudfoundry.org/auctioneer"
 	"code.cloudfoundry.org/bbs/db"
@@ -17,6 +20,37 @@ type Retirer interface {
 	RetireActualLRP(logger lager.Logger, key *models.ActualLRPKey) error
 }
 
+//go:generate counterfeiter -o fakes/fake_start_request_buffer.go . StartRequestBuffer
+
+// StartRequestBuffer durably stores the convergence start requests that could
+// not be delivered to the auctioneer until a later convergence delivers them.
+type StartRequestBuffer interface {
+	BufferStartRequests(logger lager.Logger, startRequests []*auctioneer.LRPStartRequest) error
+	BufferedStartRequests(logger lager.Logger) ([]*auctioneer.LRPStartRequest, error)
+	AcknowledgeStartRequests(logger lager.Logger, processGuids []string) error
+}
+
+//go:generate counterfeiter -o fakes/fake_convergence_lock.go . ConvergenceLock
+
+// ConvergenceLock lets only one of several BBS instances converge at a time.
+type ConvergenceLock interface {
+	AcquireConvergenceLock(logger lager.Logger, holderID string, ttl time.Duration) (bool, error)
+}
+
+// DefaultConvergenceHistorySize is the number of convergence summaries kept
+// unless SetConvergenceHistorySize is called.
+const DefaultConvergenceHistorySize = 10
+
+// ConvergenceSummary describes a completed LRP convergence run.
+type ConvergenceSummary struct {
+	CompletedAt              time.Time     `json:"completed_at"`
+	Duration                 time.Duration `json:"duration"`
+	StartRequests            int           `json:"start_requests"`
+	UndeliveredStartRequests int           `json:"undelivered_start_requests"`
+	LRPsWithMissingCells     int           `json:"lrps_with_missing_cells"`
+	LRPsRetired              int           `json:"lrps_retired"`
+}
+
 type LRPConvergenceController struct {
 	logger                 lager.Logger
 	db                     db.LRPDB
@@ -25,6 +59,17 @@ type LRPConvergenceController struct {
 	serviceClient          serviceclient.ServiceClient
 	retirer                Retirer
 	convergenceWorkersSize int
+	maxAuctionBatchBytes   int
+	startRequestBuffer     StartRequestBuffer
+	convergenceLock        ConvergenceLock
+	convergenceLockHolder  string
+	convergenceLockTTL     time.Duration
+	converged              int32
+
+	historyLock sync.Mutex
+	historySize int
+	history     []ConvergenceSummary
+	historyNext int
 }
 
 func NewLRPConvergenceController(
@@ -44,13 +89,111 @@ func NewLRPConvergenceController(
 		serviceClient:          serviceClient,
 		retirer:                retirer,
 		convergenceWorkersSize: convergenceWorkersSize,
+		historySize:            DefaultConvergenceHistorySize,
+	}
+}
+
+// SetMaxAuctionBatchBytes splits the start requests sent to the auctioneer
+// after convergence into batches whose estimated size does not exceed
+// maxBytes. A non-positive value sends all start requests in a single batch.
+func (h *LRPConvergenceController) SetMaxAuctionBatchBytes(maxBytes int) {
+	h.maxAuctionBatchBytes = maxBytes
+}
+
+// SetStartRequestBuffer makes convergence persist the start requests the
+// auctioneer could not be reached for and retry them on every subsequent
+// convergence until they are delivered.
+func (h *LRPConvergenceController) SetStartRequestBuffer(buffer StartRequestBuffer) {
+	h.startRequestBuffer = buffer
+}
+
+// SetConvergenceLock makes convergence acquire, or renew, the lock as holderID
+// first, and skip the run when another holder acquired it less than ttl ago.
+// Runs are skipped too when the lock cannot be acquired, as convergence runs
+// again later.
+func (h *LRPConvergenceController) SetConvergenceLock(lock ConvergenceLock, holderID string, ttl time.Duration) {
+	h.convergenceLock = lock
+	h.convergenceLockHolder = holderID
+	h.convergenceLockTTL = ttl
+}
+
+// Converged reports whether a convergence run has completed since the
+// controller was created. Deployment tooling polls it to wait for the first
+// convergence after startup.
+func (h *LRPConvergenceController) Converged() bool {
+	return atomic.LoadInt32(&h.converged) == 1
+}
+
+// SetConvergenceHistorySize sets how many of the most recent convergence
+// summaries are kept, discarding the ones kept so far. A non-positive size
+// keeps none.
+func (h *LRPConvergenceController) SetConvergenceHistorySize(size int) {
+	h.historyLock.Lock()
+	defer h.historyLock.Unlock()
+
+	h.historySize = size
+	h.history = nil
+	h.historyNext = 0
+}
+
+// ConvergenceHistory returns the summaries of the most recent convergence
+// runs, newest first.
+func (h *LRPConvergenceController) ConvergenceHistory() []ConvergenceSummary {
+	h.historyLock.Lock()
+	defer h.historyLock.Unlock()
+
+	history := make([]ConvergenceSummary, 0, len(h.history))
+	for i := 1; i <= len(h.history); i++ {
+		history = append(history, h.history[(h.historyNext-i+len(h.history))%len(h.history)])
+	}
+	return history
+}
+
+func (h *LRPConvergenceController) recordConvergence(summary ConvergenceSummary) {
+	h.historyLock.Lock()
+	defer h.historyLock.Unlock()
+
+	if h.historySize <= 0 {
+		return
 	}
+
+	if len(h.history) < h.historySize {
+		h.history = append(h.history, summary)
+	} else {
+		h.history[h.historyNext] = summary
+	}
+	h.historyNext = (h.historyNext + 1) % h.historySize
+}
+
+// Reports whether this controller may converge, which it always may unless a
+// convergence lock is set.
+func (h *LRPConvergenceController) acquireConvergenceLock(logger lager.Logger) bool {
+	if h.convergenceLock == nil {
+		return true
+	}
+
+	acquired, err := h.convergenceLock.AcquireConvergenceLock(logger, h.convergenceLockHolder, h.convergenceLockTTL)
+	if err != nil {
+		logger.Error("failed-acquiring-convergence-lock", err)
+		return false
+	}
+	if !acquired {
+		logger.Info("skipping-convergence-lock-held-by-another-holder")
+		return false
+	}
+	return true
 }
 
 func (h *LRPConvergenceController) ConvergeLRPs(logger lager.Logger) error {
 	logger = h.logger.Session("converge-lrps")
+	convergeStart := time.Now()
 	var err error
 
+	if !h.acquireConvergenceLock(logger) {
+		// convergence should run again later
+		return nil
+	}
+
 	logger.Debug("listing-cells")
 	var cellSet models.CellSet
 	cellSet, err = h.serviceClient.Cells(logger)
@@ -71,9 +214,9 @@ func (h *LRPConvergenceController) ConvergeLRPs(logger lager.Logger) error {
 	for _, key := range keysToRetire {
 		key := key
 		works = append(works, func() {
-			err := h.retirer.RetireActualLRP(retireLogger, key)
+			err := h.retirer.RetireActualLRP(retireLogger, key.Key)
 			if err != nil {
-				logger.Error("retiring-lrp-failed", err)
+				logger.Error("retiring-lrp-failed", err, lager.Data{"process_guid": key.Key.ProcessGuid, "index": key.Key.Index, "reason": key.Reason})
 			}
 		})
 	}
@@ -123,15 +266,150 @@ func (h *LRPConvergenceController) ConvergeLRPs(logger lager.Logger) error {
 	default:
 	}
 
+	if h.startRequestBuffer != nil {
+		bufferedStartRequests, err := h.startRequestBuffer.BufferedStartRequests(logger)
+		if err != nil {
+			logger.Error("failed-fetching-buffered-start-requests", err)
+		} else {
+			startRequests = mergeStartRequests(bufferedStartRequests, startRequests)
+		}
+	}
+
+	undelivered := []*auctioneer.LRPStartRequest{}
 	startLogger := logger.WithData(lager.Data{"start_requests_count": len(startRequests)})
 	if len(startRequests) > 0 {
+		deliveredGuids := []string{}
+
 		startLogger.Debug("requesting-start-auctions")
-		err = h.auctioneerClient.RequestLRPAuctions(logger, startRequests)
-		if err != nil {
-			startLogger.Error("failed-to-request-starts", err, lager.Data{"lrp_start_auctions": startRequests})
+		for _, batch := range batchStartRequests(startRequests, h.maxAuctionBatchBytes) {
+			err = h.auctioneerClient.RequestLRPAuctions(logger, batch)
+			if err != nil {
+				startLogger.Error("failed-to-request-starts", err, lager.Data{"lrp_start_auctions": batch})
+				undelivered = append(undelivered, batch...)
+				continue
+			}
+			for _, startRequest := range batch {
+				deliveredGuids = append(deliveredGuids, startRequest.ProcessGuid)
+			}
 		}
 		startLogger.Debug("done-requesting-start-auctions")
+
+		if h.startRequestBuffer != nil {
+			err = h.startRequestBuffer.AcknowledgeStartRequests(logger, deliveredGuids)
+			if err != nil {
+				startLogger.Error("failed-acknowledging-start-requests", err)
+			}
+			if len(undelivered) > 0 {
+				err = h.startRequestBuffer.BufferStartRequests(logger, undelivered)
+				if err != nil {
+					startLogger.Error("failed-buffering-start-requests", err)
+				}
+			}
+		}
 	}
 
+	now := time.Now()
+	h.recordConvergence(ConvergenceSummary{
+		CompletedAt:              now,
+		Duration:                 now.Sub(convergeStart),
+		StartRequests:            len(startRequests),
+		UndeliveredStartRequests: len(undelivered),
+		LRPsWithMissingCells:     len(keysWithMissingCells),
+		LRPsRetired:              len(keysToRetire),
+	})
+
+	atomic.StoreInt32(&h.converged, 1)
 	return nil
 }
+
+// Combines buffered start requests with the ones from the current convergence
+// into a single start request per process guid. The current start request
+// takes precedence, and the indices of both are requested.
+func mergeStartRequests(buffered, current []*auctioneer.LRPStartRequest) []*auctioneer.LRPStartRequest {
+	if len(buffered) == 0 {
+		return current
+	}
+
+	merged := make([]*auctioneer.LRPStartRequest, 0, len(buffered)+len(current))
+	byGuid := map[string]*auctioneer.LRPStartRequest{}
+	for _, startRequest := range current {
+		if existing, ok := byGuid[startRequest.ProcessGuid]; ok {
+			existing.Indices = append(existing.Indices, startRequest.Indices...)
+			continue
+		}
+		byGuid[startRequest.ProcessGuid] = startRequest
+		merged = append(merged, startRequest)
+	}
+
+	for _, startRequest := range buffered {
+		existing, ok := byGuid[startRequest.ProcessGuid]
+		if !ok {
+			byGuid[startRequest.ProcessGuid] = startRequest
+			merged = append(merged, startRequest)
+			continue
+		}
+
+		for _, index := range startRequest.Indices {
+			if !containsIndex(existing.Indices, index) {
+				existing.Indices = append(existing.Indices, index)
+			}
+		}
+	}
+
+	return merged
+}
+
+func containsIndex(indices []int, index int) bool {
+	for _, i := range indices {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+// EstimatedStartRequestSize returns the size in bytes of the start request as
+// it is sent to the auctioneer, which encodes start requests as JSON.
+func EstimatedStartRequestSize(startRequest *auctioneer.LRPStartRequest) int {
+	payload, err := json.Marshal(startRequest)
+	if err != nil {
+		return 0
+	}
+	return len(payload)
+}
+
+// Groups the start requests into batches whose JSON array encoding fits in
+// maxBytes. A start request that is too large on its own gets its own batch.
+func batchStartRequests(startRequests []*auctioneer.LRPStartRequest, maxBytes int) [][]*auctioneer.LRPStartRequest {
+	if maxBytes <= 0 {
+		return [][]*auctioneer.LRPStartRequest{startRequests}
+	}
+
+	batches := [][]*auctioneer.LRPStartRequest{}
+	batch := []*auctioneer.LRPStartRequest{}
+	batchSize := len("[]")
+
+	for _, startRequest := range startRequests {
+		size := EstimatedStartRequestSize(startRequest)
+		if len(batch) > 0 {
+			// account for the separating comma
+			size++
+		}
+
+		if len(batch) > 0 && batchSize+size > maxBytes {
+			batches = append(batches, batch)
+			batch = []*auctioneer.LRPStartRequest{}
+			batchSize = len("[]")
+			size--
+		}
+
+		batch = append(batch, startRequest)
+		batchSize += size
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
@@ -1,7 +1,10 @@
 package controllers_test
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/auctioneer"
 	"code.cloudfoundry.org/auctioneer/auctioneerfakes"
@@ -30,7 +33,7 @@ var _ = Describe("LRP Convergence Controllers", func() {
 		fakeAuctioneerClient *auctioneerfakes.FakeClient
 
 		keysToAuction        []*auctioneer.LRPStartRequest
-		keysToRetire         []*models.ActualLRPKey
+		keysToRetire         []*models.ActualLRPKeyWithRetireReason
 		keysWithMissingCells []*models.ActualLRPKeyWithSchedulingInfo
 
 		retiringActualLRP1 *models.ActualLRP
@@ -56,7 +59,10 @@ var _ = Describe("LRP Convergence Controllers", func() {
 
 		retiringActualLRP1 = model_helpers.NewValidActualLRP("to-retire-1", 0)
 		retiringActualLRP2 = model_helpers.NewValidActualLRP("to-retire-2", 1)
-		keysToRetire = []*models.ActualLRPKey{&retiringActualLRP1.ActualLRPKey, &retiringActualLRP2.ActualLRPKey}
+		keysToRetire = []*models.ActualLRPKeyWithRetireReason{
+			{Key: &retiringActualLRP1.ActualLRPKey, Reason: models.RetireReasonExtraInstance},
+			{Key: &retiringActualLRP2.ActualLRPKey, Reason: models.RetireReasonNoDesiredLRP},
+		}
 
 		desiredLRP1 = model_helpers.NewValidDesiredLRP("to-unclaim-1").DesiredLRPSchedulingInfo()
 		unclaimingActualLRP1 = model_helpers.NewValidActualLRP("to-unclaim-1", 0)
@@ -135,6 +141,16 @@ var _ = Describe("LRP Convergence Controllers", func() {
 		Expect(actualCellSet).To(BeEquivalentTo(cellSet))
 	})
 
+	It("reports converged only once a convergence has completed", func() {
+		Expect(controller.Converged()).To(BeTrue())
+
+		controller = controllers.NewLRPConvergenceController(logger, fakeLRPDB, actualHub, fakeAuctioneerClient, fakeServiceClient, retirer, 2)
+		Expect(controller.Converged()).To(BeFalse())
+
+		Expect(controller.ConvergeLRPs(logger)).To(Succeed())
+		Expect(controller.Converged()).To(BeTrue())
+	})
+
 	Context("when fetching the cells fails", func() {
 		BeforeEach(func() {
 			fakeServiceClient.CellsReturns(nil, errors.New("kaboom"))
@@ -151,6 +167,10 @@ var _ = Describe("LRP Convergence Controllers", func() {
 		It("logs the error", func() {
 			Eventually(logger).Should(gbytes.Say("failed-listing-cells"))
 		})
+
+		It("does not report converged", func() {
+			Expect(controller.Converged()).To(BeFalse())
+		})
 	})
 
 	Context("when fetching the cells returns ErrResourceNotFound", func() {
@@ -166,6 +186,49 @@ var _ = Describe("LRP Convergence Controllers", func() {
 		})
 	})
 
+	Context("when a convergence lock is set", func() {
+		var lock *fakes.FakeConvergenceLock
+
+		BeforeEach(func() {
+			lock = new(fakes.FakeConvergenceLock)
+			lock.AcquireConvergenceLockReturns(true, nil)
+			controller.SetConvergenceLock(lock, "bbs-0", time.Minute)
+		})
+
+		It("acquires the lock as the holder before converging", func() {
+			Expect(lock.AcquireConvergenceLockCallCount()).To(Equal(1))
+			_, holderID, ttl := lock.AcquireConvergenceLockArgsForCall(0)
+			Expect(holderID).To(Equal("bbs-0"))
+			Expect(ttl).To(Equal(time.Minute))
+			Expect(fakeLRPDB.ConvergeLRPsCallCount()).To(Equal(1))
+		})
+
+		Context("when another holder has the lock", func() {
+			BeforeEach(func() {
+				lock.AcquireConvergenceLockReturns(false, nil)
+			})
+
+			It("does not converge", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeLRPDB.ConvergeLRPsCallCount()).To(Equal(0))
+				Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(0))
+				Expect(logger).To(gbytes.Say("skipping-convergence-lock-held-by-another-holder"))
+			})
+		})
+
+		Context("when acquiring the lock fails", func() {
+			BeforeEach(func() {
+				lock.AcquireConvergenceLockReturns(false, errors.New("boom"))
+			})
+
+			It("logs the error and does not converge", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeLRPDB.ConvergeLRPsCallCount()).To(Equal(0))
+				Expect(logger).To(gbytes.Say("failed-acquiring-convergence-lock"))
+			})
+		})
+	})
+
 	It("auctions off the returned keys", func() {
 		Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
 
@@ -180,6 +243,43 @@ var _ = Describe("LRP Convergence Controllers", func() {
 		Expect(startAuctions).To(ConsistOf(expectedStartRequests))
 	})
 
+	Context("when a max auction batch size is set", func() {
+		var maxBatchBytes int
+
+		BeforeEach(func() {
+			keysToAuction = []*auctioneer.LRPStartRequest{}
+			for i := 0; i < 20; i++ {
+				request := auctioneer.NewLRPStartRequestFromModel(model_helpers.NewValidDesiredLRP(fmt.Sprintf("to-auction-%d", i)), 0, 1)
+				keysToAuction = append(keysToAuction, &request)
+			}
+			fakeLRPDB.ConvergeLRPsReturns(keysToAuction, keysWithMissingCells, keysToRetire)
+
+			maxBatchBytes = 3 * controllers.EstimatedStartRequestSize(keysToAuction[0])
+			controller.SetMaxAuctionBatchBytes(maxBatchBytes)
+		})
+
+		It("splits the start requests into batches under the configured size", func() {
+			Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(BeNumerically(">", 1))
+
+			startAuctions := []*auctioneer.LRPStartRequest{}
+			for i := 0; i < fakeAuctioneerClient.RequestLRPAuctionsCallCount(); i++ {
+				_, batch := fakeAuctioneerClient.RequestLRPAuctionsArgsForCall(i)
+				Expect(batch).NotTo(BeEmpty())
+
+				payload, err := json.Marshal(batch)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(payload)).To(BeNumerically("<=", maxBatchBytes))
+
+				startAuctions = append(startAuctions, batch...)
+			}
+
+			unclaimedStartRequest1 := auctioneer.NewLRPStartRequestFromSchedulingInfo(&desiredLRP1, 0)
+			unclaimedStartRequest2 := auctioneer.NewLRPStartRequestFromSchedulingInfo(&desiredLRP2, 1)
+			expectedStartRequests := append(keysToAuction, &unclaimedStartRequest1, &unclaimedStartRequest2)
+			Expect(startAuctions).To(ConsistOf(expectedStartRequests))
+		})
+	})
+
 	Context("when no lrps to auction", func() {
 		BeforeEach(func() {
 			fakeLRPDB.ConvergeLRPsReturns(nil, nil, nil)
@@ -190,6 +290,78 @@ var _ = Describe("LRP Convergence Controllers", func() {
 		})
 	})
 
+	Context("when a start request buffer is set", func() {
+		var buffered map[string]*auctioneer.LRPStartRequest
+
+		auctionedGuids := func(call int) []string {
+			_, startRequests := fakeAuctioneerClient.RequestLRPAuctionsArgsForCall(call)
+			guids := []string{}
+			for _, startRequest := range startRequests {
+				guids = append(guids, startRequest.ProcessGuid)
+			}
+			return guids
+		}
+
+		BeforeEach(func() {
+			buffered = map[string]*auctioneer.LRPStartRequest{}
+			buffer := new(fakes.FakeStartRequestBuffer)
+			buffer.BufferStartRequestsStub = func(_ lager.Logger, startRequests []*auctioneer.LRPStartRequest) error {
+				for _, startRequest := range startRequests {
+					buffered[startRequest.ProcessGuid] = startRequest
+				}
+				return nil
+			}
+			buffer.BufferedStartRequestsStub = func(_ lager.Logger) ([]*auctioneer.LRPStartRequest, error) {
+				startRequests := []*auctioneer.LRPStartRequest{}
+				for _, startRequest := range buffered {
+					startRequests = append(startRequests, startRequest)
+				}
+				return startRequests, nil
+			}
+			buffer.AcknowledgeStartRequestsStub = func(_ lager.Logger, processGuids []string) error {
+				for _, guid := range processGuids {
+					delete(buffered, guid)
+				}
+				return nil
+			}
+			controller.SetStartRequestBuffer(buffer)
+
+			fakeAuctioneerClient.RequestLRPAuctionsReturns(errors.New("auctioneer unavailable"))
+		})
+
+		It("keeps retrying undelivered start requests until they are delivered", func() {
+			Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(1))
+			Expect(buffered).To(HaveKey("to-auction-1"))
+			Expect(buffered).To(HaveKey("to-auction-2"))
+
+			fakeLRPDB.ConvergeLRPsReturns(nil, nil, nil)
+
+			Expect(controller.ConvergeLRPs(logger)).To(Succeed())
+			Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(2))
+			Expect(auctionedGuids(1)).To(ContainElement("to-auction-1"))
+			Expect(buffered).To(HaveKey("to-auction-1"))
+
+			fakeAuctioneerClient.RequestLRPAuctionsReturns(nil)
+
+			Expect(controller.ConvergeLRPs(logger)).To(Succeed())
+			Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(3))
+			Expect(auctionedGuids(2)).To(ContainElement("to-auction-1"))
+			Expect(buffered).To(BeEmpty())
+		})
+
+		It("requests a buffered start request once alongside a new one for the same process guid", func() {
+			Expect(controller.ConvergeLRPs(logger)).To(Succeed())
+
+			_, startRequests := fakeAuctioneerClient.RequestLRPAuctionsArgsForCall(1)
+			Expect(auctionedGuids(1)).To(HaveLen(4))
+			for _, startRequest := range startRequests {
+				if startRequest.ProcessGuid == "to-auction-1" {
+					Expect(startRequest.Indices).To(ConsistOf(1, 2))
+				}
+			}
+		})
+	})
+
 	It("unclaims and auctions the actual lrps with missing cells", func() {
 		Eventually(fakeLRPDB.UnclaimActualLRPCallCount).Should(Equal(2))
 
@@ -228,6 +400,14 @@ var _ = Describe("LRP Convergence Controllers", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err).Should(Equal(models.NewUnrecoverableError(nil)))
 		})
+
+		It("does not report converged", func() {
+			Expect(controller.Converged()).To(BeFalse())
+		})
+
+		It("does not record a convergence summary", func() {
+			Expect(controller.ConvergenceHistory()).To(BeEmpty())
+		})
 	})
 
 	Context("when unclaiming the actual lrp fails", func() {
@@ -249,6 +429,74 @@ var _ = Describe("LRP Convergence Controllers", func() {
 		})
 	})
 
+	Describe("convergence history", func() {
+		convergeWithStartRequests := func(count int) {
+			startRequests := []*auctioneer.LRPStartRequest{}
+			for i := 0; i < count; i++ {
+				startRequest := auctioneer.NewLRPStartRequestFromModel(model_helpers.NewValidDesiredLRP(fmt.Sprintf("history-guid-%d", i)), 0)
+				startRequests = append(startRequests, &startRequest)
+			}
+			fakeLRPDB.ConvergeLRPsReturns(startRequests, nil, nil)
+			Expect(controller.ConvergeLRPs(logger)).To(Succeed())
+		}
+
+		startRequestCounts := func(history []controllers.ConvergenceSummary) []int {
+			counts := []int{}
+			for _, summary := range history {
+				counts = append(counts, summary.StartRequests)
+			}
+			return counts
+		}
+
+		It("records a summary of the convergence", func() {
+			history := controller.ConvergenceHistory()
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].StartRequests).To(Equal(4))
+			Expect(history[0].LRPsWithMissingCells).To(Equal(2))
+			Expect(history[0].LRPsRetired).To(Equal(2))
+			Expect(history[0].UndeliveredStartRequests).To(BeZero())
+			Expect(history[0].CompletedAt).NotTo(BeZero())
+		})
+
+		Context("when more convergences have run than the history holds", func() {
+			BeforeEach(func() {
+				controller.SetConvergenceHistorySize(3)
+			})
+
+			It("returns the most recent summaries newest first", func() {
+				for count := 1; count <= 5; count++ {
+					convergeWithStartRequests(count)
+				}
+
+				Expect(startRequestCounts(controller.ConvergenceHistory())).To(Equal([]int{5, 4, 3}))
+			})
+		})
+
+		Context("when fewer convergences have run than the history holds", func() {
+			BeforeEach(func() {
+				controller.SetConvergenceHistorySize(5)
+			})
+
+			It("returns every summary newest first", func() {
+				convergeWithStartRequests(1)
+				convergeWithStartRequests(2)
+
+				Expect(startRequestCounts(controller.ConvergenceHistory())).To(Equal([]int{2, 1, 4}))
+			})
+		})
+
+		Context("when the history size is not positive", func() {
+			BeforeEach(func() {
+				controller.SetConvergenceHistorySize(0)
+			})
+
+			It("keeps no history", func() {
+				convergeWithStartRequests(1)
+				Expect(controller.ConvergenceHistory()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("stopping extra LRPs", func() {
 		var (
 			cellPresence models.CellPresence
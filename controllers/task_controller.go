@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/auctioneer"
@@ -20,6 +22,7 @@ type TaskController struct {
 	serviceClient        serviceclient.ServiceClient
 	repClientFactory     rep.ClientFactory
 	taskHub              events.Hub
+	knownPlacementTags   map[string]struct{}
 }
 
 func NewTaskController(
@@ -40,6 +43,20 @@ func NewTaskController(
 	}
 }
 
+// SetKnownPlacementTags makes DesireTask reject tasks with placement tags
+// outside the given set of known segments. An empty set disables the check.
+func (h *TaskController) SetKnownPlacementTags(tags []string) {
+	if len(tags) == 0 {
+		h.knownPlacementTags = nil
+		return
+	}
+
+	h.knownPlacementTags = make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		h.knownPlacementTags[tag] = struct{}{}
+	}
+}
+
 func (h *TaskController) Tasks(logger lager.Logger, domain, cellId string) ([]*models.Task, error) {
 	logger = logger.Session("tasks")
 
@@ -60,6 +77,12 @@ func (h *TaskController) DesireTask(logger lager.Logger, taskDefinition *models.
 
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
 
+	err = h.validatePlacementTags(taskDefinition)
+	if err != nil {
+		logger.Error("invalid-placement-tags", err)
+		return err
+	}
+
 	task, err = h.db.DesireTask(logger, taskDefinition, taskGuid, domain)
 	if err != nil {
 		return err
@@ -79,6 +102,27 @@ func (h *TaskController) DesireTask(logger lager.Logger, taskDefinition *models.
 	return nil
 }
 
+func (h *TaskController) validatePlacementTags(taskDefinition *models.TaskDefinition) error {
+	if h.knownPlacementTags == nil {
+		return nil
+	}
+
+	unknown := []string{}
+	for _, tag := range taskDefinition.GetPlacementTags() {
+		if _, ok := h.knownPlacementTags[tag]; !ok {
+			unknown = append(unknown, tag)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return models.NewError(
+			models.Error_InvalidRequest,
+			fmt.Sprintf("placement tags do not match any known segment: %s", strings.Join(unknown, ", ")),
+		)
+	}
+	return nil
+}
+
 func (h *TaskController) StartTask(logger lager.Logger, taskGuid, cellId string) (shouldStart bool, err error) {
 	logger = logger.Session("start-task", lager.Data{"task_guid": taskGuid, "cell_id": cellId})
 	before, after, shouldStart, err := h.db.StartTask(logger, taskGuid, cellId)
@@ -95,6 +139,12 @@ func (h *TaskController) CancelTask(logger lager.Logger, taskGuid string) error
 	if err != nil {
 		return err
 	}
+
+	if before.GetState() == models.Task_Completed {
+		logger.Info("task-already-completed", lager.Data{"task_guid": taskGuid})
+		return nil
+	}
+
 	go h.taskHub.Emit(models.NewTaskChangedEvent(before, after))
 
 	if after.CompletionCallbackUrl != "" {
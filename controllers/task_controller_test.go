@@ -281,6 +281,43 @@ var _ = Describe("Task Controller", func() {
 				Consistently(taskHub.EmitCallCount).Should(Equal(0))
 			})
 		})
+
+		Context("when known placement tags are configured", func() {
+			BeforeEach(func() {
+				controller.SetKnownPlacementTags([]string{"red-tag", "blue-tag"})
+				fakeTaskDB.DesireTaskReturns(&models.Task{TaskGuid: taskGuid}, nil)
+			})
+
+			Context("when every placement tag is known", func() {
+				BeforeEach(func() {
+					taskDef.PlacementTags = []string{"red-tag"}
+				})
+
+				It("desires the task", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeTaskDB.DesireTaskCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when a placement tag is unknown", func() {
+				BeforeEach(func() {
+					taskDef.PlacementTags = []string{"red-tag", "green-tag"}
+				})
+
+				It("responds with an invalid request error naming the unknown tag", func() {
+					modelErr := models.ConvertError(err)
+					Expect(modelErr).NotTo(BeNil())
+					Expect(modelErr.Type).To(Equal(models.Error_InvalidRequest))
+					Expect(modelErr.Message).To(ContainSubstring("green-tag"))
+					Expect(modelErr.Message).NotTo(ContainSubstring("red-tag"))
+				})
+
+				It("does not desire the task or request an auction", func() {
+					Expect(fakeTaskDB.DesireTaskCallCount()).To(Equal(0))
+					Consistently(fakeAuctioneerClient.RequestTaskAuctionsCallCount).Should(Equal(0))
+				})
+			})
+		})
 	})
 
 	Describe("StartTask", func() {
@@ -465,6 +502,32 @@ var _ = Describe("Task Controller", func() {
 					})
 				})
 
+				Context("when the task was already completed", func() {
+					BeforeEach(func() {
+						task := model_helpers.NewValidTask("hi-bob")
+						task.State = models.Task_Completed
+						task.CompletionCallbackUrl = "bogus"
+						fakeTaskDB.CancelTaskReturns(task, task, "", nil)
+					})
+
+					It("does not return an error", func() {
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("does not emit a change to the hub", func() {
+						Consistently(taskHub.EmitCallCount).Should(Equal(0))
+					})
+
+					It("does not complete the task callback again", func() {
+						Consistently(fakeTaskCompletionClient.SubmitCallCount).Should(Equal(0))
+					})
+
+					It("does not make any calls to the rep", func() {
+						Expect(fakeServiceClient.CellByIdCallCount()).To(Equal(0))
+						Expect(fakeRepClient.CancelTaskCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when the task has no cell id", func() {
 					BeforeEach(func() {
 						task := model_helpers.NewValidTask("hi-bob")
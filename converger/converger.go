@@ -34,6 +34,7 @@ type Converger struct {
 	kickTaskDuration            time.Duration
 	expirePendingTaskDuration   time.Duration
 	expireCompletedTaskDuration time.Duration
+	drainTimeout                time.Duration
 	closeOnce                   *sync.Once
 }
 
@@ -46,7 +47,8 @@ func New(
 	convergeRepeatInterval,
 	kickTaskDuration,
 	expirePendingTaskDuration,
-	expireCompletedTaskDuration time.Duration,
+	expireCompletedTaskDuration,
+	drainTimeout time.Duration,
 ) *Converger {
 
 	uuid, err := uuid.NewV4()
@@ -65,6 +67,7 @@ func New(
 		kickTaskDuration:            kickTaskDuration,
 		expirePendingTaskDuration:   expirePendingTaskDuration,
 		expireCompletedTaskDuration: expireCompletedTaskDuration,
+		drainTimeout:                drainTimeout,
 		closeOnce:                   &sync.Once{},
 	}
 }
@@ -96,18 +99,52 @@ func (c *Converger) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 			switch event.EventType() {
 			case models.EventTypeCellDisappeared:
 				logger.Info("received-cell-disappeared-event", lager.Data{"cell-id": event.CellIDs()})
-				c.converge()
+				if c.convergeUnlessSignalled(logger, signals) {
+					return nil
+				}
 			}
 
 		case <-convergeTimer.C():
 			convergeTimer.Stop()
-			c.converge()
+			if c.convergeUnlessSignalled(logger, signals) {
+				return nil
+			}
 		}
 
 		convergeTimer.Reset(c.convergeRepeatInterval)
 	}
 }
 
+// convergeUnlessSignalled runs a convergence. If a signal arrives while it is
+// in progress, the convergence is given up to the drain timeout to finish so
+// that it is not cut off mid-write, and true is returned so that the process
+// exits. A convergence still running after the drain timeout is abandoned.
+func (c *Converger) convergeUnlessSignalled(logger lager.Logger, signals <-chan os.Signal) bool {
+	done := make(chan struct{})
+	go func() {
+		c.converge()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return false
+
+	case <-signals:
+		logger.Info("draining-convergence", lager.Data{"drain-timeout": c.drainTimeout.String()})
+		drainTimer := c.clock.NewTimer(c.drainTimeout)
+		defer drainTimer.Stop()
+
+		select {
+		case <-done:
+			logger.Info("drained-convergence")
+		case <-drainTimer.C():
+			logger.Info("drain-timeout-exceeded")
+		}
+		return true
+	}
+}
+
 func (c *Converger) converge() {
 	logger := c.logger.Session("executing-convergence")
 	wg := sync.WaitGroup{}
@@ -2,8 +2,10 @@ package converger_test
 
 import (
 	"errors"
+	"os"
 	"time"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/ginkgomon"
@@ -13,6 +15,7 @@ import (
 	"code.cloudfoundry.org/clock/fakeclock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 
 	"code.cloudfoundry.org/bbs/converger"
 	"code.cloudfoundry.org/bbs/converger/fake_controllers"
@@ -31,6 +34,7 @@ var _ = Describe("ConvergerProcess", func() {
 		kickTaskDuration             time.Duration
 		expirePendingTaskDuration    time.Duration
 		expireCompletedTaskDuration  time.Duration
+		drainTimeout                 time.Duration
 
 		process ifrit.Process
 
@@ -50,6 +54,7 @@ var _ = Describe("ConvergerProcess", func() {
 		kickTaskDuration = 10 * time.Millisecond
 		expirePendingTaskDuration = 30 * time.Second
 		expireCompletedTaskDuration = 60 * time.Minute
+		drainTimeout = 10 * time.Second
 
 		cellEvents := make(chan models.CellEvent, 100)
 		errs := make(chan error, 100)
@@ -72,6 +77,7 @@ var _ = Describe("ConvergerProcess", func() {
 				kickTaskDuration,
 				expirePendingTaskDuration,
 				expireCompletedTaskDuration,
+				drainTimeout,
 			),
 		)
 	})
@@ -152,4 +158,40 @@ var _ = Describe("ConvergerProcess", func() {
 			Eventually(fakeLrpConvergenceController.ConvergeLRPsCallCount).Should(Equal(2))
 		})
 	})
+
+	Describe("draining on shutdown", func() {
+		var convergeLRPsDone chan struct{}
+
+		BeforeEach(func() {
+			convergeLRPsDone = make(chan struct{})
+			fakeLrpConvergenceController.ConvergeLRPsStub = func(lager.Logger) error {
+				<-convergeLRPsDone
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			fakeClock.WaitForWatcherAndIncrement(convergeRepeatInterval + aBit)
+			Eventually(fakeLrpConvergenceController.ConvergeLRPsCallCount).Should(Equal(1))
+
+			process.Signal(os.Interrupt)
+		})
+
+		It("waits for the in-progress convergence to complete", func() {
+			Consistently(process.Wait()).ShouldNot(Receive())
+
+			close(convergeLRPsDone)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+
+		It("stops waiting once the drain timeout has elapsed", func() {
+			defer close(convergeLRPsDone)
+
+			Consistently(process.Wait()).ShouldNot(Receive())
+
+			fakeClock.WaitForWatcherAndIncrement(drainTimeout)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(logger).To(gbytes.Say("drain-timeout-exceeded"))
+		})
+	})
 })
@@ -38,6 +38,20 @@ type FakeActualLRPDB struct {
 		result1 []*models.ActualLRPGroup
 		result2 error
 	}
+	ActualLRPGroupsByStateStub        func(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error)
+	actualLRPGroupsByStateMutex       sync.RWMutex
+	actualLRPGroupsByStateArgsForCall []struct {
+		logger lager.Logger
+		state  models.ActualLRPState
+	}
+	actualLRPGroupsByStateReturns struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}
+	actualLRPGroupsByStateReturnsOnCall map[int]struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}
 	ActualLRPGroupByProcessGuidAndIndexStub        func(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, error)
 	actualLRPGroupByProcessGuidAndIndexMutex       sync.RWMutex
 	actualLRPGroupByProcessGuidAndIndexArgsForCall []struct {
@@ -119,6 +133,20 @@ type FakeActualLRPDB struct {
 		result2 *models.ActualLRPGroup
 		result3 error
 	}
+	ApplyActualLRPLifecycleOperationsStub        func(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]*models.ActualLRPLifecycleOperationResult, error)
+	applyActualLRPLifecycleOperationsMutex       sync.RWMutex
+	applyActualLRPLifecycleOperationsArgsForCall []struct {
+		logger     lager.Logger
+		operations []*models.ActualLRPLifecycleOperation
+	}
+	applyActualLRPLifecycleOperationsReturns struct {
+		result1 []*models.ActualLRPLifecycleOperationResult
+		result2 error
+	}
+	applyActualLRPLifecycleOperationsReturnsOnCall map[int]struct {
+		result1 []*models.ActualLRPLifecycleOperationResult
+		result2 error
+	}
 	CrashActualLRPStub        func(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, crashReason string) (before *models.ActualLRPGroup, after *models.ActualLRPGroup, shouldRestart bool, err error)
 	crashActualLRPMutex       sync.RWMutex
 	crashActualLRPArgsForCall []struct {
@@ -278,6 +306,58 @@ func (fake *FakeActualLRPDB) ActualLRPGroupsByProcessGuidReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *FakeActualLRPDB) ActualLRPGroupsByState(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error) {
+	fake.actualLRPGroupsByStateMutex.Lock()
+	ret, specificReturn := fake.actualLRPGroupsByStateReturnsOnCall[len(fake.actualLRPGroupsByStateArgsForCall)]
+	fake.actualLRPGroupsByStateArgsForCall = append(fake.actualLRPGroupsByStateArgsForCall, struct {
+		logger lager.Logger
+		state  models.ActualLRPState
+	}{logger, state})
+	fake.recordInvocation("ActualLRPGroupsByState", []interface{}{logger, state})
+	fake.actualLRPGroupsByStateMutex.Unlock()
+	if fake.ActualLRPGroupsByStateStub != nil {
+		return fake.ActualLRPGroupsByStateStub(logger, state)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.actualLRPGroupsByStateReturns.result1, fake.actualLRPGroupsByStateReturns.result2
+}
+
+func (fake *FakeActualLRPDB) ActualLRPGroupsByStateCallCount() int {
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
+	return len(fake.actualLRPGroupsByStateArgsForCall)
+}
+
+func (fake *FakeActualLRPDB) ActualLRPGroupsByStateArgsForCall(i int) (lager.Logger, models.ActualLRPState) {
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
+	return fake.actualLRPGroupsByStateArgsForCall[i].logger, fake.actualLRPGroupsByStateArgsForCall[i].state
+}
+
+func (fake *FakeActualLRPDB) ActualLRPGroupsByStateReturns(result1 []*models.ActualLRPGroup, result2 error) {
+	fake.ActualLRPGroupsByStateStub = nil
+	fake.actualLRPGroupsByStateReturns = struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActualLRPDB) ActualLRPGroupsByStateReturnsOnCall(i int, result1 []*models.ActualLRPGroup, result2 error) {
+	fake.ActualLRPGroupsByStateStub = nil
+	if fake.actualLRPGroupsByStateReturnsOnCall == nil {
+		fake.actualLRPGroupsByStateReturnsOnCall = make(map[int]struct {
+			result1 []*models.ActualLRPGroup
+			result2 error
+		})
+	}
+	fake.actualLRPGroupsByStateReturnsOnCall[i] = struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeActualLRPDB) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, error) {
 	fake.actualLRPGroupByProcessGuidAndIndexMutex.Lock()
 	ret, specificReturn := fake.actualLRPGroupByProcessGuidAndIndexReturnsOnCall[len(fake.actualLRPGroupByProcessGuidAndIndexArgsForCall)]
@@ -552,6 +632,63 @@ func (fake *FakeActualLRPDB) StartActualLRPReturnsOnCall(i int, result1 *models.
 	}{result1, result2, result3}
 }
 
+func (fake *FakeActualLRPDB) ApplyActualLRPLifecycleOperations(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]*models.ActualLRPLifecycleOperationResult, error) {
+	var operationsCopy []*models.ActualLRPLifecycleOperation
+	if operations != nil {
+		operationsCopy = make([]*models.ActualLRPLifecycleOperation, len(operations))
+		copy(operationsCopy, operations)
+	}
+	fake.applyActualLRPLifecycleOperationsMutex.Lock()
+	ret, specificReturn := fake.applyActualLRPLifecycleOperationsReturnsOnCall[len(fake.applyActualLRPLifecycleOperationsArgsForCall)]
+	fake.applyActualLRPLifecycleOperationsArgsForCall = append(fake.applyActualLRPLifecycleOperationsArgsForCall, struct {
+		logger     lager.Logger
+		operations []*models.ActualLRPLifecycleOperation
+	}{logger, operationsCopy})
+	fake.recordInvocation("ApplyActualLRPLifecycleOperations", []interface{}{logger, operationsCopy})
+	fake.applyActualLRPLifecycleOperationsMutex.Unlock()
+	if fake.ApplyActualLRPLifecycleOperationsStub != nil {
+		return fake.ApplyActualLRPLifecycleOperationsStub(logger, operations)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.applyActualLRPLifecycleOperationsReturns.result1, fake.applyActualLRPLifecycleOperationsReturns.result2
+}
+
+func (fake *FakeActualLRPDB) ApplyActualLRPLifecycleOperationsCallCount() int {
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
+	return len(fake.applyActualLRPLifecycleOperationsArgsForCall)
+}
+
+func (fake *FakeActualLRPDB) ApplyActualLRPLifecycleOperationsArgsForCall(i int) (lager.Logger, []*models.ActualLRPLifecycleOperation) {
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
+	return fake.applyActualLRPLifecycleOperationsArgsForCall[i].logger, fake.applyActualLRPLifecycleOperationsArgsForCall[i].operations
+}
+
+func (fake *FakeActualLRPDB) ApplyActualLRPLifecycleOperationsReturns(result1 []*models.ActualLRPLifecycleOperationResult, result2 error) {
+	fake.ApplyActualLRPLifecycleOperationsStub = nil
+	fake.applyActualLRPLifecycleOperationsReturns = struct {
+		result1 []*models.ActualLRPLifecycleOperationResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActualLRPDB) ApplyActualLRPLifecycleOperationsReturnsOnCall(i int, result1 []*models.ActualLRPLifecycleOperationResult, result2 error) {
+	fake.ApplyActualLRPLifecycleOperationsStub = nil
+	if fake.applyActualLRPLifecycleOperationsReturnsOnCall == nil {
+		fake.applyActualLRPLifecycleOperationsReturnsOnCall = make(map[int]struct {
+			result1 []*models.ActualLRPLifecycleOperationResult
+			result2 error
+		})
+	}
+	fake.applyActualLRPLifecycleOperationsReturnsOnCall[i] = struct {
+		result1 []*models.ActualLRPLifecycleOperationResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeActualLRPDB) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, crashReason string) (before *models.ActualLRPGroup, after *models.ActualLRPGroup, shouldRestart bool, err error) {
 	fake.crashActualLRPMutex.Lock()
 	ret, specificReturn := fake.crashActualLRPReturnsOnCall[len(fake.crashActualLRPArgsForCall)]
@@ -726,6 +863,8 @@ func (fake *FakeActualLRPDB) Invocations() map[string][][]interface{} {
 	defer fake.actualLRPGroupsMutex.RUnlock()
 	fake.actualLRPGroupsByProcessGuidMutex.RLock()
 	defer fake.actualLRPGroupsByProcessGuidMutex.RUnlock()
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
 	fake.actualLRPGroupByProcessGuidAndIndexMutex.RLock()
 	defer fake.actualLRPGroupByProcessGuidAndIndexMutex.RUnlock()
 	fake.createUnclaimedActualLRPMutex.RLock()
@@ -736,6 +875,8 @@ func (fake *FakeActualLRPDB) Invocations() map[string][][]interface{} {
 	defer fake.claimActualLRPMutex.RUnlock()
 	fake.startActualLRPMutex.RLock()
 	defer fake.startActualLRPMutex.RUnlock()
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
 	fake.crashActualLRPMutex.RLock()
 	defer fake.crashActualLRPMutex.RUnlock()
 	fake.failActualLRPMutex.RLock()
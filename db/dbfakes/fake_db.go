@@ -132,6 +132,20 @@ type FakeDB struct {
 		result1 []*models.ActualLRPGroup
 		result2 error
 	}
+	ActualLRPGroupsByStateStub        func(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error)
+	actualLRPGroupsByStateMutex       sync.RWMutex
+	actualLRPGroupsByStateArgsForCall []struct {
+		logger lager.Logger
+		state  models.ActualLRPState
+	}
+	actualLRPGroupsByStateReturns struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}
+	actualLRPGroupsByStateReturnsOnCall map[int]struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}
 	ActualLRPGroupByProcessGuidAndIndexStub        func(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, error)
 	actualLRPGroupByProcessGuidAndIndexMutex       sync.RWMutex
 	actualLRPGroupByProcessGuidAndIndexArgsForCall []struct {
@@ -213,6 +227,20 @@ type FakeDB struct {
 		result2 *models.ActualLRPGroup
 		result3 error
 	}
+	ApplyActualLRPLifecycleOperationsStub        func(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]*models.ActualLRPLifecycleOperationResult, error)
+	applyActualLRPLifecycleOperationsMutex       sync.RWMutex
+	applyActualLRPLifecycleOperationsArgsForCall []struct {
+		logger     lager.Logger
+		operations []*models.ActualLRPLifecycleOperation
+	}
+	applyActualLRPLifecycleOperationsReturns struct {
+		result1 []*models.ActualLRPLifecycleOperationResult
+		result2 error
+	}
+	applyActualLRPLifecycleOperationsReturnsOnCall map[int]struct {
+		result1 []*models.ActualLRPLifecycleOperationResult
+		result2 error
+	}
 	CrashActualLRPStub        func(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, crashReason string) (before *models.ActualLRPGroup, after *models.ActualLRPGroup, shouldRestart bool, err error)
 	crashActualLRPMutex       sync.RWMutex
 	crashActualLRPArgsForCall []struct {
@@ -306,6 +334,19 @@ type FakeDB struct {
 		result1 []*models.DesiredLRPSchedulingInfo
 		result2 error
 	}
+	DesiredLRPProcessGuidsStub        func(logger lager.Logger) ([]string, error)
+	desiredLRPProcessGuidsMutex       sync.RWMutex
+	desiredLRPProcessGuidsArgsForCall []struct {
+		logger lager.Logger
+	}
+	desiredLRPProcessGuidsReturns struct {
+		result1 []string
+		result2 error
+	}
+	desiredLRPProcessGuidsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	DesireLRPStub        func(logger lager.Logger, desiredLRP *models.DesiredLRP) error
 	desireLRPMutex       sync.RWMutex
 	desireLRPArgsForCall []struct {
@@ -318,6 +359,18 @@ type FakeDB struct {
 	desireLRPReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DesireLRPsStub        func(logger lager.Logger, desiredLRPs []*models.DesiredLRP) error
+	desireLRPsMutex       sync.RWMutex
+	desireLRPsArgsForCall []struct {
+		logger      lager.Logger
+		desiredLRPs []*models.DesiredLRP
+	}
+	desireLRPsReturns struct {
+		result1 error
+	}
+	desireLRPsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateDesiredLRPStub        func(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) (beforeDesiredLRP *models.DesiredLRP, err error)
 	updateDesiredLRPMutex       sync.RWMutex
 	updateDesiredLRPArgsForCall []struct {
@@ -333,6 +386,23 @@ type FakeDB struct {
 		result1 *models.DesiredLRP
 		result2 error
 	}
+	UpdateDesiredLRPRoutesStub        func(logger lager.Logger, processGuid string, routes *models.Routes) (beforeDesiredLRP, afterDesiredLRP *models.DesiredLRP, err error)
+	updateDesiredLRPRoutesMutex       sync.RWMutex
+	updateDesiredLRPRoutesArgsForCall []struct {
+		logger      lager.Logger
+		processGuid string
+		routes      *models.Routes
+	}
+	updateDesiredLRPRoutesReturns struct {
+		result1 *models.DesiredLRP
+		result2 *models.DesiredLRP
+		result3 error
+	}
+	updateDesiredLRPRoutesReturnsOnCall map[int]struct {
+		result1 *models.DesiredLRP
+		result2 *models.DesiredLRP
+		result3 error
+	}
 	RemoveDesiredLRPStub        func(logger lager.Logger, processGuid string) error
 	removeDesiredLRPMutex       sync.RWMutex
 	removeDesiredLRPArgsForCall []struct {
@@ -345,7 +415,7 @@ type FakeDB struct {
 	removeDesiredLRPReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ConvergeLRPsStub        func(logger lager.Logger, cellSet models.CellSet) (startRequests []*auctioneer.LRPStartRequest, keysWithMissingCells []*models.ActualLRPKeyWithSchedulingInfo, keysToRetire []*models.ActualLRPKey)
+	ConvergeLRPsStub        func(logger lager.Logger, cellSet models.CellSet) (startRequests []*auctioneer.LRPStartRequest, keysWithMissingCells []*models.ActualLRPKeyWithSchedulingInfo, keysToRetire []*models.ActualLRPKeyWithRetireReason)
 	convergeLRPsMutex       sync.RWMutex
 	convergeLRPsArgsForCall []struct {
 		logger  lager.Logger
@@ -354,12 +424,26 @@ type FakeDB struct {
 	convergeLRPsReturns struct {
 		result1 []*auctioneer.LRPStartRequest
 		result2 []*models.ActualLRPKeyWithSchedulingInfo
-		result3 []*models.ActualLRPKey
+		result3 []*models.ActualLRPKeyWithRetireReason
 	}
 	convergeLRPsReturnsOnCall map[int]struct {
 		result1 []*auctioneer.LRPStartRequest
 		result2 []*models.ActualLRPKeyWithSchedulingInfo
-		result3 []*models.ActualLRPKey
+		result3 []*models.ActualLRPKeyWithRetireReason
+	}
+	ConvergenceLRPCandidateCountStub        func(logger lager.Logger, cellSet models.CellSet) (int, error)
+	convergenceLRPCandidateCountMutex       sync.RWMutex
+	convergenceLRPCandidateCountArgsForCall []struct {
+		logger  lager.Logger
+		cellSet models.CellSet
+	}
+	convergenceLRPCandidateCountReturns struct {
+		result1 int
+		result2 error
+	}
+	convergenceLRPCandidateCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
 	}
 	GatherAndPruneLRPsStub        func(logger lager.Logger, cellSet models.CellSet) (*models.ConvergenceInput, error)
 	gatherAndPruneLRPsMutex       sync.RWMutex
@@ -1029,6 +1113,58 @@ func (fake *FakeDB) ActualLRPGroupsByProcessGuidReturnsOnCall(i int, result1 []*
 	}{result1, result2}
 }
 
+func (fake *FakeDB) ActualLRPGroupsByState(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error) {
+	fake.actualLRPGroupsByStateMutex.Lock()
+	ret, specificReturn := fake.actualLRPGroupsByStateReturnsOnCall[len(fake.actualLRPGroupsByStateArgsForCall)]
+	fake.actualLRPGroupsByStateArgsForCall = append(fake.actualLRPGroupsByStateArgsForCall, struct {
+		logger lager.Logger
+		state  models.ActualLRPState
+	}{logger, state})
+	fake.recordInvocation("ActualLRPGroupsByState", []interface{}{logger, state})
+	fake.actualLRPGroupsByStateMutex.Unlock()
+	if fake.ActualLRPGroupsByStateStub != nil {
+		return fake.ActualLRPGroupsByStateStub(logger, state)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.actualLRPGroupsByStateReturns.result1, fake.actualLRPGroupsByStateReturns.result2
+}
+
+func (fake *FakeDB) ActualLRPGroupsByStateCallCount() int {
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
+	return len(fake.actualLRPGroupsByStateArgsForCall)
+}
+
+func (fake *FakeDB) ActualLRPGroupsByStateArgsForCall(i int) (lager.Logger, models.ActualLRPState) {
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
+	return fake.actualLRPGroupsByStateArgsForCall[i].logger, fake.actualLRPGroupsByStateArgsForCall[i].state
+}
+
+func (fake *FakeDB) ActualLRPGroupsByStateReturns(result1 []*models.ActualLRPGroup, result2 error) {
+	fake.ActualLRPGroupsByStateStub = nil
+	fake.actualLRPGroupsByStateReturns = struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ActualLRPGroupsByStateReturnsOnCall(i int, result1 []*models.ActualLRPGroup, result2 error) {
+	fake.ActualLRPGroupsByStateStub = nil
+	if fake.actualLRPGroupsByStateReturnsOnCall == nil {
+		fake.actualLRPGroupsByStateReturnsOnCall = make(map[int]struct {
+			result1 []*models.ActualLRPGroup
+			result2 error
+		})
+	}
+	fake.actualLRPGroupsByStateReturnsOnCall[i] = struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDB) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, error) {
 	fake.actualLRPGroupByProcessGuidAndIndexMutex.Lock()
 	ret, specificReturn := fake.actualLRPGroupByProcessGuidAndIndexReturnsOnCall[len(fake.actualLRPGroupByProcessGuidAndIndexArgsForCall)]
@@ -1303,6 +1439,63 @@ func (fake *FakeDB) StartActualLRPReturnsOnCall(i int, result1 *models.ActualLRP
 	}{result1, result2, result3}
 }
 
+func (fake *FakeDB) ApplyActualLRPLifecycleOperations(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]*models.ActualLRPLifecycleOperationResult, error) {
+	var operationsCopy []*models.ActualLRPLifecycleOperation
+	if operations != nil {
+		operationsCopy = make([]*models.ActualLRPLifecycleOperation, len(operations))
+		copy(operationsCopy, operations)
+	}
+	fake.applyActualLRPLifecycleOperationsMutex.Lock()
+	ret, specificReturn := fake.applyActualLRPLifecycleOperationsReturnsOnCall[len(fake.applyActualLRPLifecycleOperationsArgsForCall)]
+	fake.applyActualLRPLifecycleOperationsArgsForCall = append(fake.applyActualLRPLifecycleOperationsArgsForCall, struct {
+		logger     lager.Logger
+		operations []*models.ActualLRPLifecycleOperation
+	}{logger, operationsCopy})
+	fake.recordInvocation("ApplyActualLRPLifecycleOperations", []interface{}{logger, operationsCopy})
+	fake.applyActualLRPLifecycleOperationsMutex.Unlock()
+	if fake.ApplyActualLRPLifecycleOperationsStub != nil {
+		return fake.ApplyActualLRPLifecycleOperationsStub(logger, operations)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.applyActualLRPLifecycleOperationsReturns.result1, fake.applyActualLRPLifecycleOperationsReturns.result2
+}
+
+func (fake *FakeDB) ApplyActualLRPLifecycleOperationsCallCount() int {
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
+	return len(fake.applyActualLRPLifecycleOperationsArgsForCall)
+}
+
+func (fake *FakeDB) ApplyActualLRPLifecycleOperationsArgsForCall(i int) (lager.Logger, []*models.ActualLRPLifecycleOperation) {
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
+	return fake.applyActualLRPLifecycleOperationsArgsForCall[i].logger, fake.applyActualLRPLifecycleOperationsArgsForCall[i].operations
+}
+
+func (fake *FakeDB) ApplyActualLRPLifecycleOperationsReturns(result1 []*models.ActualLRPLifecycleOperationResult, result2 error) {
+	fake.ApplyActualLRPLifecycleOperationsStub = nil
+	fake.applyActualLRPLifecycleOperationsReturns = struct {
+		result1 []*models.ActualLRPLifecycleOperationResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ApplyActualLRPLifecycleOperationsReturnsOnCall(i int, result1 []*models.ActualLRPLifecycleOperationResult, result2 error) {
+	fake.ApplyActualLRPLifecycleOperationsStub = nil
+	if fake.applyActualLRPLifecycleOperationsReturnsOnCall == nil {
+		fake.applyActualLRPLifecycleOperationsReturnsOnCall = make(map[int]struct {
+			result1 []*models.ActualLRPLifecycleOperationResult
+			result2 error
+		})
+	}
+	fake.applyActualLRPLifecycleOperationsReturnsOnCall[i] = struct {
+		result1 []*models.ActualLRPLifecycleOperationResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDB) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, crashReason string) (before *models.ActualLRPGroup, after *models.ActualLRPGroup, shouldRestart bool, err error) {
 	fake.crashActualLRPMutex.Lock()
 	ret, specificReturn := fake.crashActualLRPReturnsOnCall[len(fake.crashActualLRPArgsForCall)]
@@ -1626,6 +1819,57 @@ func (fake *FakeDB) DesiredLRPSchedulingInfosReturnsOnCall(i int, result1 []*mod
 	}{result1, result2}
 }
 
+func (fake *FakeDB) DesiredLRPProcessGuids(logger lager.Logger) ([]string, error) {
+	fake.desiredLRPProcessGuidsMutex.Lock()
+	ret, specificReturn := fake.desiredLRPProcessGuidsReturnsOnCall[len(fake.desiredLRPProcessGuidsArgsForCall)]
+	fake.desiredLRPProcessGuidsArgsForCall = append(fake.desiredLRPProcessGuidsArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("DesiredLRPProcessGuids", []interface{}{logger})
+	fake.desiredLRPProcessGuidsMutex.Unlock()
+	if fake.DesiredLRPProcessGuidsStub != nil {
+		return fake.DesiredLRPProcessGuidsStub(logger)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.desiredLRPProcessGuidsReturns.result1, fake.desiredLRPProcessGuidsReturns.result2
+}
+
+func (fake *FakeDB) DesiredLRPProcessGuidsCallCount() int {
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
+	return len(fake.desiredLRPProcessGuidsArgsForCall)
+}
+
+func (fake *FakeDB) DesiredLRPProcessGuidsArgsForCall(i int) lager.Logger {
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
+	return fake.desiredLRPProcessGuidsArgsForCall[i].logger
+}
+
+func (fake *FakeDB) DesiredLRPProcessGuidsReturns(result1 []string, result2 error) {
+	fake.DesiredLRPProcessGuidsStub = nil
+	fake.desiredLRPProcessGuidsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) DesiredLRPProcessGuidsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.DesiredLRPProcessGuidsStub = nil
+	if fake.desiredLRPProcessGuidsReturnsOnCall == nil {
+		fake.desiredLRPProcessGuidsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.desiredLRPProcessGuidsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDB) DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP) error {
 	fake.desireLRPMutex.Lock()
 	ret, specificReturn := fake.desireLRPReturnsOnCall[len(fake.desireLRPArgsForCall)]
@@ -1675,6 +1919,60 @@ func (fake *FakeDB) DesireLRPReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDB) DesireLRPs(logger lager.Logger, desiredLRPs []*models.DesiredLRP) error {
+	var desiredLRPsCopy []*models.DesiredLRP
+	if desiredLRPs != nil {
+		desiredLRPsCopy = make([]*models.DesiredLRP, len(desiredLRPs))
+		copy(desiredLRPsCopy, desiredLRPs)
+	}
+	fake.desireLRPsMutex.Lock()
+	ret, specificReturn := fake.desireLRPsReturnsOnCall[len(fake.desireLRPsArgsForCall)]
+	fake.desireLRPsArgsForCall = append(fake.desireLRPsArgsForCall, struct {
+		logger      lager.Logger
+		desiredLRPs []*models.DesiredLRP
+	}{logger, desiredLRPsCopy})
+	fake.recordInvocation("DesireLRPs", []interface{}{logger, desiredLRPsCopy})
+	fake.desireLRPsMutex.Unlock()
+	if fake.DesireLRPsStub != nil {
+		return fake.DesireLRPsStub(logger, desiredLRPs)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.desireLRPsReturns.result1
+}
+
+func (fake *FakeDB) DesireLRPsCallCount() int {
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
+	return len(fake.desireLRPsArgsForCall)
+}
+
+func (fake *FakeDB) DesireLRPsArgsForCall(i int) (lager.Logger, []*models.DesiredLRP) {
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
+	return fake.desireLRPsArgsForCall[i].logger, fake.desireLRPsArgsForCall[i].desiredLRPs
+}
+
+func (fake *FakeDB) DesireLRPsReturns(result1 error) {
+	fake.DesireLRPsStub = nil
+	fake.desireLRPsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DesireLRPsReturnsOnCall(i int, result1 error) {
+	fake.DesireLRPsStub = nil
+	if fake.desireLRPsReturnsOnCall == nil {
+		fake.desireLRPsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.desireLRPsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeDB) UpdateDesiredLRP(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) (beforeDesiredLRP *models.DesiredLRP, err error) {
 	fake.updateDesiredLRPMutex.Lock()
 	ret, specificReturn := fake.updateDesiredLRPReturnsOnCall[len(fake.updateDesiredLRPArgsForCall)]
@@ -1728,6 +2026,62 @@ func (fake *FakeDB) UpdateDesiredLRPReturnsOnCall(i int, result1 *models.Desired
 	}{result1, result2}
 }
 
+func (fake *FakeDB) UpdateDesiredLRPRoutes(logger lager.Logger, processGuid string, routes *models.Routes) (beforeDesiredLRP *models.DesiredLRP, afterDesiredLRP *models.DesiredLRP, err error) {
+	fake.updateDesiredLRPRoutesMutex.Lock()
+	ret, specificReturn := fake.updateDesiredLRPRoutesReturnsOnCall[len(fake.updateDesiredLRPRoutesArgsForCall)]
+	fake.updateDesiredLRPRoutesArgsForCall = append(fake.updateDesiredLRPRoutesArgsForCall, struct {
+		logger      lager.Logger
+		processGuid string
+		routes      *models.Routes
+	}{logger, processGuid, routes})
+	fake.recordInvocation("UpdateDesiredLRPRoutes", []interface{}{logger, processGuid, routes})
+	fake.updateDesiredLRPRoutesMutex.Unlock()
+	if fake.UpdateDesiredLRPRoutesStub != nil {
+		return fake.UpdateDesiredLRPRoutesStub(logger, processGuid, routes)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.updateDesiredLRPRoutesReturns.result1, fake.updateDesiredLRPRoutesReturns.result2, fake.updateDesiredLRPRoutesReturns.result3
+}
+
+func (fake *FakeDB) UpdateDesiredLRPRoutesCallCount() int {
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
+	return len(fake.updateDesiredLRPRoutesArgsForCall)
+}
+
+func (fake *FakeDB) UpdateDesiredLRPRoutesArgsForCall(i int) (lager.Logger, string, *models.Routes) {
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
+	return fake.updateDesiredLRPRoutesArgsForCall[i].logger, fake.updateDesiredLRPRoutesArgsForCall[i].processGuid, fake.updateDesiredLRPRoutesArgsForCall[i].routes
+}
+
+func (fake *FakeDB) UpdateDesiredLRPRoutesReturns(result1 *models.DesiredLRP, result2 *models.DesiredLRP, result3 error) {
+	fake.UpdateDesiredLRPRoutesStub = nil
+	fake.updateDesiredLRPRoutesReturns = struct {
+		result1 *models.DesiredLRP
+		result2 *models.DesiredLRP
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) UpdateDesiredLRPRoutesReturnsOnCall(i int, result1 *models.DesiredLRP, result2 *models.DesiredLRP, result3 error) {
+	fake.UpdateDesiredLRPRoutesStub = nil
+	if fake.updateDesiredLRPRoutesReturnsOnCall == nil {
+		fake.updateDesiredLRPRoutesReturnsOnCall = make(map[int]struct {
+			result1 *models.DesiredLRP
+			result2 *models.DesiredLRP
+			result3 error
+		})
+	}
+	fake.updateDesiredLRPRoutesReturnsOnCall[i] = struct {
+		result1 *models.DesiredLRP
+		result2 *models.DesiredLRP
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeDB) RemoveDesiredLRP(logger lager.Logger, processGuid string) error {
 	fake.removeDesiredLRPMutex.Lock()
 	ret, specificReturn := fake.removeDesiredLRPReturnsOnCall[len(fake.removeDesiredLRPArgsForCall)]
@@ -1777,7 +2131,7 @@ func (fake *FakeDB) RemoveDesiredLRPReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeDB) ConvergeLRPs(logger lager.Logger, cellSet models.CellSet) (startRequests []*auctioneer.LRPStartRequest, keysWithMissingCells []*models.ActualLRPKeyWithSchedulingInfo, keysToRetire []*models.ActualLRPKey) {
+func (fake *FakeDB) ConvergeLRPs(logger lager.Logger, cellSet models.CellSet) (startRequests []*auctioneer.LRPStartRequest, keysWithMissingCells []*models.ActualLRPKeyWithSchedulingInfo, keysToRetire []*models.ActualLRPKeyWithRetireReason) {
 	fake.convergeLRPsMutex.Lock()
 	ret, specificReturn := fake.convergeLRPsReturnsOnCall[len(fake.convergeLRPsArgsForCall)]
 	fake.convergeLRPsArgsForCall = append(fake.convergeLRPsArgsForCall, struct {
@@ -1807,31 +2161,83 @@ func (fake *FakeDB) ConvergeLRPsArgsForCall(i int) (lager.Logger, models.CellSet
 	return fake.convergeLRPsArgsForCall[i].logger, fake.convergeLRPsArgsForCall[i].cellSet
 }
 
-func (fake *FakeDB) ConvergeLRPsReturns(result1 []*auctioneer.LRPStartRequest, result2 []*models.ActualLRPKeyWithSchedulingInfo, result3 []*models.ActualLRPKey) {
+func (fake *FakeDB) ConvergeLRPsReturns(result1 []*auctioneer.LRPStartRequest, result2 []*models.ActualLRPKeyWithSchedulingInfo, result3 []*models.ActualLRPKeyWithRetireReason) {
 	fake.ConvergeLRPsStub = nil
 	fake.convergeLRPsReturns = struct {
 		result1 []*auctioneer.LRPStartRequest
 		result2 []*models.ActualLRPKeyWithSchedulingInfo
-		result3 []*models.ActualLRPKey
+		result3 []*models.ActualLRPKeyWithRetireReason
 	}{result1, result2, result3}
 }
 
-func (fake *FakeDB) ConvergeLRPsReturnsOnCall(i int, result1 []*auctioneer.LRPStartRequest, result2 []*models.ActualLRPKeyWithSchedulingInfo, result3 []*models.ActualLRPKey) {
+func (fake *FakeDB) ConvergeLRPsReturnsOnCall(i int, result1 []*auctioneer.LRPStartRequest, result2 []*models.ActualLRPKeyWithSchedulingInfo, result3 []*models.ActualLRPKeyWithRetireReason) {
 	fake.ConvergeLRPsStub = nil
 	if fake.convergeLRPsReturnsOnCall == nil {
 		fake.convergeLRPsReturnsOnCall = make(map[int]struct {
 			result1 []*auctioneer.LRPStartRequest
 			result2 []*models.ActualLRPKeyWithSchedulingInfo
-			result3 []*models.ActualLRPKey
+			result3 []*models.ActualLRPKeyWithRetireReason
 		})
 	}
 	fake.convergeLRPsReturnsOnCall[i] = struct {
 		result1 []*auctioneer.LRPStartRequest
 		result2 []*models.ActualLRPKeyWithSchedulingInfo
-		result3 []*models.ActualLRPKey
+		result3 []*models.ActualLRPKeyWithRetireReason
 	}{result1, result2, result3}
 }
 
+func (fake *FakeDB) ConvergenceLRPCandidateCount(logger lager.Logger, cellSet models.CellSet) (int, error) {
+	fake.convergenceLRPCandidateCountMutex.Lock()
+	ret, specificReturn := fake.convergenceLRPCandidateCountReturnsOnCall[len(fake.convergenceLRPCandidateCountArgsForCall)]
+	fake.convergenceLRPCandidateCountArgsForCall = append(fake.convergenceLRPCandidateCountArgsForCall, struct {
+		logger  lager.Logger
+		cellSet models.CellSet
+	}{logger, cellSet})
+	fake.recordInvocation("ConvergenceLRPCandidateCount", []interface{}{logger, cellSet})
+	fake.convergenceLRPCandidateCountMutex.Unlock()
+	if fake.ConvergenceLRPCandidateCountStub != nil {
+		return fake.ConvergenceLRPCandidateCountStub(logger, cellSet)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.convergenceLRPCandidateCountReturns.result1, fake.convergenceLRPCandidateCountReturns.result2
+}
+
+func (fake *FakeDB) ConvergenceLRPCandidateCountCallCount() int {
+	fake.convergenceLRPCandidateCountMutex.RLock()
+	defer fake.convergenceLRPCandidateCountMutex.RUnlock()
+	return len(fake.convergenceLRPCandidateCountArgsForCall)
+}
+
+func (fake *FakeDB) ConvergenceLRPCandidateCountArgsForCall(i int) (lager.Logger, models.CellSet) {
+	fake.convergenceLRPCandidateCountMutex.RLock()
+	defer fake.convergenceLRPCandidateCountMutex.RUnlock()
+	return fake.convergenceLRPCandidateCountArgsForCall[i].logger, fake.convergenceLRPCandidateCountArgsForCall[i].cellSet
+}
+
+func (fake *FakeDB) ConvergenceLRPCandidateCountReturns(result1 int, result2 error) {
+	fake.ConvergenceLRPCandidateCountStub = nil
+	fake.convergenceLRPCandidateCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ConvergenceLRPCandidateCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.ConvergenceLRPCandidateCountStub = nil
+	if fake.convergenceLRPCandidateCountReturnsOnCall == nil {
+		fake.convergenceLRPCandidateCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.convergenceLRPCandidateCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDB) GatherAndPruneLRPs(logger lager.Logger, cellSet models.CellSet) (*models.ConvergenceInput, error) {
 	fake.gatherAndPruneLRPsMutex.Lock()
 	ret, specificReturn := fake.gatherAndPruneLRPsReturnsOnCall[len(fake.gatherAndPruneLRPsArgsForCall)]
@@ -2560,6 +2966,8 @@ func (fake *FakeDB) Invocations() map[string][][]interface{} {
 	defer fake.actualLRPGroupsMutex.RUnlock()
 	fake.actualLRPGroupsByProcessGuidMutex.RLock()
 	defer fake.actualLRPGroupsByProcessGuidMutex.RUnlock()
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
 	fake.actualLRPGroupByProcessGuidAndIndexMutex.RLock()
 	defer fake.actualLRPGroupByProcessGuidAndIndexMutex.RUnlock()
 	fake.createUnclaimedActualLRPMutex.RLock()
@@ -2570,6 +2978,8 @@ func (fake *FakeDB) Invocations() map[string][][]interface{} {
 	defer fake.claimActualLRPMutex.RUnlock()
 	fake.startActualLRPMutex.RLock()
 	defer fake.startActualLRPMutex.RUnlock()
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
 	fake.crashActualLRPMutex.RLock()
 	defer fake.crashActualLRPMutex.RUnlock()
 	fake.failActualLRPMutex.RLock()
@@ -2582,14 +2992,22 @@ func (fake *FakeDB) Invocations() map[string][][]interface{} {
 	defer fake.desiredLRPByProcessGuidMutex.RUnlock()
 	fake.desiredLRPSchedulingInfosMutex.RLock()
 	defer fake.desiredLRPSchedulingInfosMutex.RUnlock()
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
 	fake.desireLRPMutex.RLock()
 	defer fake.desireLRPMutex.RUnlock()
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
 	fake.updateDesiredLRPMutex.RLock()
 	defer fake.updateDesiredLRPMutex.RUnlock()
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
 	fake.removeDesiredLRPMutex.RLock()
 	defer fake.removeDesiredLRPMutex.RUnlock()
 	fake.convergeLRPsMutex.RLock()
 	defer fake.convergeLRPsMutex.RUnlock()
+	fake.convergenceLRPCandidateCountMutex.RLock()
+	defer fake.convergenceLRPCandidateCountMutex.RUnlock()
 	fake.gatherAndPruneLRPsMutex.RLock()
 	defer fake.gatherAndPruneLRPsMutex.RUnlock()
 	fake.tasksMutex.RLock()
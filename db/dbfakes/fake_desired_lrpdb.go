@@ -52,6 +52,19 @@ type FakeDesiredLRPDB struct {
 		result1 []*models.DesiredLRPSchedulingInfo
 		result2 error
 	}
+	DesiredLRPProcessGuidsStub        func(logger lager.Logger) ([]string, error)
+	desiredLRPProcessGuidsMutex       sync.RWMutex
+	desiredLRPProcessGuidsArgsForCall []struct {
+		logger lager.Logger
+	}
+	desiredLRPProcessGuidsReturns struct {
+		result1 []string
+		result2 error
+	}
+	desiredLRPProcessGuidsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	DesireLRPStub        func(logger lager.Logger, desiredLRP *models.DesiredLRP) error
 	desireLRPMutex       sync.RWMutex
 	desireLRPArgsForCall []struct {
@@ -64,6 +77,18 @@ type FakeDesiredLRPDB struct {
 	desireLRPReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DesireLRPsStub        func(logger lager.Logger, desiredLRPs []*models.DesiredLRP) error
+	desireLRPsMutex       sync.RWMutex
+	desireLRPsArgsForCall []struct {
+		logger      lager.Logger
+		desiredLRPs []*models.DesiredLRP
+	}
+	desireLRPsReturns struct {
+		result1 error
+	}
+	desireLRPsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateDesiredLRPStub        func(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) (beforeDesiredLRP *models.DesiredLRP, err error)
 	updateDesiredLRPMutex       sync.RWMutex
 	updateDesiredLRPArgsForCall []struct {
@@ -79,6 +104,23 @@ type FakeDesiredLRPDB struct {
 		result1 *models.DesiredLRP
 		result2 error
 	}
+	UpdateDesiredLRPRoutesStub        func(logger lager.Logger, processGuid string, routes *models.Routes) (beforeDesiredLRP, afterDesiredLRP *models.DesiredLRP, err error)
+	updateDesiredLRPRoutesMutex       sync.RWMutex
+	updateDesiredLRPRoutesArgsForCall []struct {
+		logger      lager.Logger
+		processGuid string
+		routes      *models.Routes
+	}
+	updateDesiredLRPRoutesReturns struct {
+		result1 *models.DesiredLRP
+		result2 *models.DesiredLRP
+		result3 error
+	}
+	updateDesiredLRPRoutesReturnsOnCall map[int]struct {
+		result1 *models.DesiredLRP
+		result2 *models.DesiredLRP
+		result3 error
+	}
 	RemoveDesiredLRPStub        func(logger lager.Logger, processGuid string) error
 	removeDesiredLRPMutex       sync.RWMutex
 	removeDesiredLRPArgsForCall []struct {
@@ -251,6 +293,57 @@ func (fake *FakeDesiredLRPDB) DesiredLRPSchedulingInfosReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
+func (fake *FakeDesiredLRPDB) DesiredLRPProcessGuids(logger lager.Logger) ([]string, error) {
+	fake.desiredLRPProcessGuidsMutex.Lock()
+	ret, specificReturn := fake.desiredLRPProcessGuidsReturnsOnCall[len(fake.desiredLRPProcessGuidsArgsForCall)]
+	fake.desiredLRPProcessGuidsArgsForCall = append(fake.desiredLRPProcessGuidsArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("DesiredLRPProcessGuids", []interface{}{logger})
+	fake.desiredLRPProcessGuidsMutex.Unlock()
+	if fake.DesiredLRPProcessGuidsStub != nil {
+		return fake.DesiredLRPProcessGuidsStub(logger)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.desiredLRPProcessGuidsReturns.result1, fake.desiredLRPProcessGuidsReturns.result2
+}
+
+func (fake *FakeDesiredLRPDB) DesiredLRPProcessGuidsCallCount() int {
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
+	return len(fake.desiredLRPProcessGuidsArgsForCall)
+}
+
+func (fake *FakeDesiredLRPDB) DesiredLRPProcessGuidsArgsForCall(i int) lager.Logger {
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
+	return fake.desiredLRPProcessGuidsArgsForCall[i].logger
+}
+
+func (fake *FakeDesiredLRPDB) DesiredLRPProcessGuidsReturns(result1 []string, result2 error) {
+	fake.DesiredLRPProcessGuidsStub = nil
+	fake.desiredLRPProcessGuidsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDesiredLRPDB) DesiredLRPProcessGuidsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.DesiredLRPProcessGuidsStub = nil
+	if fake.desiredLRPProcessGuidsReturnsOnCall == nil {
+		fake.desiredLRPProcessGuidsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.desiredLRPProcessGuidsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDesiredLRPDB) DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP) error {
 	fake.desireLRPMutex.Lock()
 	ret, specificReturn := fake.desireLRPReturnsOnCall[len(fake.desireLRPArgsForCall)]
@@ -300,6 +393,60 @@ func (fake *FakeDesiredLRPDB) DesireLRPReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDesiredLRPDB) DesireLRPs(logger lager.Logger, desiredLRPs []*models.DesiredLRP) error {
+	var desiredLRPsCopy []*models.DesiredLRP
+	if desiredLRPs != nil {
+		desiredLRPsCopy = make([]*models.DesiredLRP, len(desiredLRPs))
+		copy(desiredLRPsCopy, desiredLRPs)
+	}
+	fake.desireLRPsMutex.Lock()
+	ret, specificReturn := fake.desireLRPsReturnsOnCall[len(fake.desireLRPsArgsForCall)]
+	fake.desireLRPsArgsForCall = append(fake.desireLRPsArgsForCall, struct {
+		logger      lager.Logger
+		desiredLRPs []*models.DesiredLRP
+	}{logger, desiredLRPsCopy})
+	fake.recordInvocation("DesireLRPs", []interface{}{logger, desiredLRPsCopy})
+	fake.desireLRPsMutex.Unlock()
+	if fake.DesireLRPsStub != nil {
+		return fake.DesireLRPsStub(logger, desiredLRPs)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.desireLRPsReturns.result1
+}
+
+func (fake *FakeDesiredLRPDB) DesireLRPsCallCount() int {
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
+	return len(fake.desireLRPsArgsForCall)
+}
+
+func (fake *FakeDesiredLRPDB) DesireLRPsArgsForCall(i int) (lager.Logger, []*models.DesiredLRP) {
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
+	return fake.desireLRPsArgsForCall[i].logger, fake.desireLRPsArgsForCall[i].desiredLRPs
+}
+
+func (fake *FakeDesiredLRPDB) DesireLRPsReturns(result1 error) {
+	fake.DesireLRPsStub = nil
+	fake.desireLRPsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDesiredLRPDB) DesireLRPsReturnsOnCall(i int, result1 error) {
+	fake.DesireLRPsStub = nil
+	if fake.desireLRPsReturnsOnCall == nil {
+		fake.desireLRPsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.desireLRPsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeDesiredLRPDB) UpdateDesiredLRP(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) (beforeDesiredLRP *models.DesiredLRP, err error) {
 	fake.updateDesiredLRPMutex.Lock()
 	ret, specificReturn := fake.updateDesiredLRPReturnsOnCall[len(fake.updateDesiredLRPArgsForCall)]
@@ -353,6 +500,62 @@ func (fake *FakeDesiredLRPDB) UpdateDesiredLRPReturnsOnCall(i int, result1 *mode
 	}{result1, result2}
 }
 
+func (fake *FakeDesiredLRPDB) UpdateDesiredLRPRoutes(logger lager.Logger, processGuid string, routes *models.Routes) (beforeDesiredLRP *models.DesiredLRP, afterDesiredLRP *models.DesiredLRP, err error) {
+	fake.updateDesiredLRPRoutesMutex.Lock()
+	ret, specificReturn := fake.updateDesiredLRPRoutesReturnsOnCall[len(fake.updateDesiredLRPRoutesArgsForCall)]
+	fake.updateDesiredLRPRoutesArgsForCall = append(fake.updateDesiredLRPRoutesArgsForCall, struct {
+		logger      lager.Logger
+		processGuid string
+		routes      *models.Routes
+	}{logger, processGuid, routes})
+	fake.recordInvocation("UpdateDesiredLRPRoutes", []interface{}{logger, processGuid, routes})
+	fake.updateDesiredLRPRoutesMutex.Unlock()
+	if fake.UpdateDesiredLRPRoutesStub != nil {
+		return fake.UpdateDesiredLRPRoutesStub(logger, processGuid, routes)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.updateDesiredLRPRoutesReturns.result1, fake.updateDesiredLRPRoutesReturns.result2, fake.updateDesiredLRPRoutesReturns.result3
+}
+
+func (fake *FakeDesiredLRPDB) UpdateDesiredLRPRoutesCallCount() int {
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
+	return len(fake.updateDesiredLRPRoutesArgsForCall)
+}
+
+func (fake *FakeDesiredLRPDB) UpdateDesiredLRPRoutesArgsForCall(i int) (lager.Logger, string, *models.Routes) {
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
+	return fake.updateDesiredLRPRoutesArgsForCall[i].logger, fake.updateDesiredLRPRoutesArgsForCall[i].processGuid, fake.updateDesiredLRPRoutesArgsForCall[i].routes
+}
+
+func (fake *FakeDesiredLRPDB) UpdateDesiredLRPRoutesReturns(result1 *models.DesiredLRP, result2 *models.DesiredLRP, result3 error) {
+	fake.UpdateDesiredLRPRoutesStub = nil
+	fake.updateDesiredLRPRoutesReturns = struct {
+		result1 *models.DesiredLRP
+		result2 *models.DesiredLRP
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDesiredLRPDB) UpdateDesiredLRPRoutesReturnsOnCall(i int, result1 *models.DesiredLRP, result2 *models.DesiredLRP, result3 error) {
+	fake.UpdateDesiredLRPRoutesStub = nil
+	if fake.updateDesiredLRPRoutesReturnsOnCall == nil {
+		fake.updateDesiredLRPRoutesReturnsOnCall = make(map[int]struct {
+			result1 *models.DesiredLRP
+			result2 *models.DesiredLRP
+			result3 error
+		})
+	}
+	fake.updateDesiredLRPRoutesReturnsOnCall[i] = struct {
+		result1 *models.DesiredLRP
+		result2 *models.DesiredLRP
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeDesiredLRPDB) RemoveDesiredLRP(logger lager.Logger, processGuid string) error {
 	fake.removeDesiredLRPMutex.Lock()
 	ret, specificReturn := fake.removeDesiredLRPReturnsOnCall[len(fake.removeDesiredLRPArgsForCall)]
@@ -411,10 +614,16 @@ func (fake *FakeDesiredLRPDB) Invocations() map[string][][]interface{} {
 	defer fake.desiredLRPByProcessGuidMutex.RUnlock()
 	fake.desiredLRPSchedulingInfosMutex.RLock()
 	defer fake.desiredLRPSchedulingInfosMutex.RUnlock()
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
 	fake.desireLRPMutex.RLock()
 	defer fake.desireLRPMutex.RUnlock()
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
 	fake.updateDesiredLRPMutex.RLock()
 	defer fake.updateDesiredLRPMutex.RUnlock()
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
 	fake.removeDesiredLRPMutex.RLock()
 	defer fake.removeDesiredLRPMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -12,8 +12,11 @@ type DesiredLRPDB interface {
 	DesiredLRPByProcessGuid(logger lager.Logger, processGuid string) (*models.DesiredLRP, error)
 
 	DesiredLRPSchedulingInfos(logger lager.Logger, filter models.DesiredLRPFilter) ([]*models.DesiredLRPSchedulingInfo, error)
+	DesiredLRPProcessGuids(logger lager.Logger) ([]string, error)
 
 	DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP) error
+	DesireLRPs(logger lager.Logger, desiredLRPs []*models.DesiredLRP) error
 	UpdateDesiredLRP(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) (beforeDesiredLRP *models.DesiredLRP, err error)
+	UpdateDesiredLRPRoutes(logger lager.Logger, processGuid string, routes *models.Routes) (beforeDesiredLRP, afterDesiredLRP *models.DesiredLRP, err error)
 	RemoveDesiredLRP(logger lager.Logger, processGuid string) error
 }
@@ -88,6 +88,32 @@ func (db *ETCDDB) ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid
 	return db.parseActualLRPGroups(logger, node, models.ActualLRPFilter{})
 }
 
+// ActualLRPGroupsByState filters every actual LRP group, as etcd has no index
+// on the state. Like the SQL backend, a group only holds its actual LRPs that
+// are in the state.
+func (db *ETCDDB) ActualLRPGroupsByState(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error) {
+	groups, err := db.ActualLRPGroups(logger, models.ActualLRPFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []*models.ActualLRPGroup{}
+	for _, group := range groups {
+		filteredGroup := &models.ActualLRPGroup{}
+		if group.Instance != nil && group.Instance.State == string(state) {
+			filteredGroup.Instance = group.Instance
+		}
+		if group.Evacuating != nil && group.Evacuating.State == string(state) {
+			filteredGroup.Evacuating = group.Evacuating
+		}
+		if filteredGroup.Instance != nil || filteredGroup.Evacuating != nil {
+			filtered = append(filtered, filteredGroup)
+		}
+	}
+
+	return filtered, nil
+}
+
 func (db *ETCDDB) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, error) {
 	group, err := db.rawActualLRPGroupByProcessGuidAndIndex(logger, processGuid, index)
 	return group, err
@@ -239,6 +265,50 @@ func (db *ETCDDB) StartActualLRP(logger lager.Logger, key *models.ActualLRPKey,
 	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: lrp}, nil
 }
 
+// ApplyActualLRPLifecycleOperations applies the given claims and starts one
+// after another, as etcd cannot update several actual LRPs atomically. An
+// operation that is invalid, or whose actual LRP is missing or cannot make
+// the transition, fails on its own; any other error stops the batch and is
+// returned instead.
+func (db *ETCDDB) ApplyActualLRPLifecycleOperations(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]*models.ActualLRPLifecycleOperationResult, error) {
+	logger = logger.Session("apply-actual-lrp-lifecycle-operations", lager.Data{"operations": len(operations)})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	results := make([]*models.ActualLRPLifecycleOperationResult, 0, len(operations))
+	for _, operation := range operations {
+		if err := operation.Validate(); err != nil {
+			logger.Error("invalid-operation", err)
+			results = append(results, &models.ActualLRPLifecycleOperationResult{Error: models.NewError(models.Error_InvalidRequest, err.Error())})
+			continue
+		}
+
+		var before, after *models.ActualLRPGroup
+		var err error
+		if claim := operation.Claim; claim != nil {
+			before, after, err = db.ClaimActualLRP(logger, claim.ProcessGuid, claim.Index, claim.ActualLrpInstanceKey)
+		} else {
+			start := operation.Start
+			before, after, err = db.StartActualLRP(logger, start.ActualLrpKey, start.ActualLrpInstanceKey, start.ActualLrpNetInfo)
+		}
+
+		bbsErr := models.ConvertError(err)
+		if bbsErr != nil {
+			switch bbsErr.Type {
+			case models.Error_ResourceNotFound, models.Error_ActualLRPCannotBeClaimed, models.Error_ActualLRPCannotBeStarted:
+				results = append(results, &models.ActualLRPLifecycleOperationResult{Error: bbsErr})
+				continue
+			default:
+				return nil, err
+			}
+		}
+
+		results = append(results, &models.ActualLRPLifecycleOperationResult{Before: before, After: after})
+	}
+
+	return results, nil
+}
+
 func (db *ETCDDB) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, errorMessage string) (*models.ActualLRPGroup, *models.ActualLRPGroup, bool, error) {
 	logger = logger.WithData(lager.Data{"actual_lrp_key": key, "actual_lrp_instance_key": instanceKey})
 	logger.Info("starting")
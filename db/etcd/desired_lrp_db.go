@@ -1,6 +1,7 @@
 package etcd
 
 import (
+	"path"
 	"sync"
 
 	"code.cloudfoundry.org/bbs/models"
@@ -68,6 +69,27 @@ func (db *ETCDDB) DesiredLRPSchedulingInfos(logger lager.Logger, filter models.D
 	return schedulingInfos, nil
 }
 
+func (db *ETCDDB) DesiredLRPProcessGuids(logger lager.Logger) ([]string, error) {
+	logger = logger.Session("desired-lrp-process-guids")
+	logger.Info("start")
+	defer logger.Info("complete")
+
+	root, err := db.fetchRecursiveRaw(logger, DesiredLRPSchedulingInfoSchemaRoot)
+	bbsErr := models.ConvertError(err)
+	if bbsErr != nil {
+		if bbsErr.Type == models.Error_ResourceNotFound {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	guids := make([]string, 0, len(root.Nodes))
+	for _, node := range root.Nodes {
+		guids = append(guids, path.Base(node.Key))
+	}
+	return guids, nil
+}
+
 func (db *ETCDDB) desiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) ([]*models.DesiredLRP, guidSet, error) {
 	root, err := db.fetchRecursiveRaw(logger, DesiredLRPComponentsSchemaRoot)
 	bbsErr := models.ConvertError(err)
@@ -264,6 +286,47 @@ func (db *ETCDDB) DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP)
 	return nil
 }
 
+// DesireLRPs desires each of the given desired LRPs in turn. Etcd has no
+// transactions, so when one of them fails to be written the ones already
+// desired by this call are removed again before the error is returned.
+func (db *ETCDDB) DesireLRPs(logger lager.Logger, desiredLRPs []*models.DesiredLRP) error {
+	logger = logger.Session("desire-lrps", lager.Data{"count": len(desiredLRPs)})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	processGuids := make(map[string]struct{}, len(desiredLRPs))
+	for _, desiredLRP := range desiredLRPs {
+		request := models.DesireLRPRequest{DesiredLrp: desiredLRP}
+		err := request.Validate()
+		if err != nil {
+			logger.Error("invalid-desired-lrp", err, lager.Data{"process_guid": desiredLRP.GetProcessGuid()})
+			return err
+		}
+
+		if _, ok := processGuids[desiredLRP.ProcessGuid]; ok {
+			err := models.ErrDuplicateProcessGuid{ProcessGuid: desiredLRP.ProcessGuid}
+			logger.Error("duplicate-process-guid", err)
+			return err
+		}
+		processGuids[desiredLRP.ProcessGuid] = struct{}{}
+	}
+
+	for i, desiredLRP := range desiredLRPs {
+		err := db.DesireLRP(logger, desiredLRP)
+		if err != nil {
+			for _, desired := range desiredLRPs[:i] {
+				removeErr := db.RemoveDesiredLRP(logger, desired.ProcessGuid)
+				if removeErr != nil {
+					logger.Error("failed-removing-desired-lrp", removeErr, lager.Data{"process_guid": desired.ProcessGuid})
+				}
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (db *ETCDDB) createDesiredLRPSchedulingInfo(logger lager.Logger, schedulingInfo *models.DesiredLRPSchedulingInfo) error {
 	epochGuid, err := uuid.NewV4()
 	if err != nil {
@@ -363,6 +426,65 @@ func (db *ETCDDB) UpdateDesiredLRP(logger lager.Logger, processGuid string, upda
 	return beforeDesiredLRP, nil
 }
 
+// UpdateDesiredLRPRoutes replaces only the routes of the DesiredLRPSchedulingInfo,
+// retrying once if the scheduling info changed underneath it.
+func (db *ETCDDB) UpdateDesiredLRPRoutes(logger lager.Logger, processGuid string, routes *models.Routes) (*models.DesiredLRP, *models.DesiredLRP, error) {
+	logger = logger.WithData(lager.Data{"process_guid": processGuid})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	if routes == nil {
+		return nil, nil, models.NewError(models.Error_InvalidRequest, "routes cannot be nil")
+	}
+
+	update := &models.DesiredLRPUpdate{Routes: routes}
+	err := update.Validate()
+	if err != nil {
+		logger.Error("invalid-routes", err)
+		return nil, nil, models.NewError(models.Error_InvalidRequest, err.Error())
+	}
+
+	var schedulingInfo *models.DesiredLRPSchedulingInfo
+	var beforeDesiredLRP *models.DesiredLRP
+
+	for i := 0; i < 2; i++ {
+		var index uint64
+
+		beforeDesiredLRP, index, err = db.rawDesiredLRPByProcessGuid(logger, processGuid)
+		if err != nil {
+			logger.Error("failed-to-fetch-desired-lrp", err)
+			break
+		}
+
+		schedulingInfoValue := beforeDesiredLRP.DesiredLRPSchedulingInfo()
+		schedulingInfo = &schedulingInfoValue
+		schedulingInfo.ApplyUpdate(update)
+
+		err = db.updateDesiredLRPSchedulingInfo(logger, schedulingInfo, index)
+		if err != nil {
+			logger.Error("update-scheduling-info-failed", err)
+			modelErr := models.ConvertError(err)
+			if modelErr != models.ErrResourceConflict {
+				break
+			}
+			// Retry on CAS fail
+			continue
+		}
+
+		break
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	afterDesiredLRP := beforeDesiredLRP.Copy()
+	afterDesiredLRP.Routes = routes
+	afterDesiredLRP.ModificationTag = &schedulingInfo.ModificationTag
+
+	return beforeDesiredLRP, afterDesiredLRP, nil
+}
+
 // RemoveDesiredLRP deletes the DesiredLRPSchedulingInfo and the DesiredLRPRunInfo
 // from the database. We delete DesiredLRPSchedulingInfo first because the system
 // uses it to determine wheter the lrp is present. In the event that only the
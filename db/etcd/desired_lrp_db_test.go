@@ -233,6 +233,78 @@ var _ = Describe("DesiredLRPDB", func() {
 		})
 	})
 
+	Describe("DesireLRPs", func() {
+		var desiredLRPs []*models.DesiredLRP
+
+		BeforeEach(func() {
+			desiredLRPs = []*models.DesiredLRP{
+				model_helpers.NewValidDesiredLRP("web-guid"),
+				model_helpers.NewValidDesiredLRP("worker-guid"),
+				model_helpers.NewValidDesiredLRP("clock-guid"),
+			}
+		})
+
+		It("persists every lrp", func() {
+			Expect(etcdDB.DesireLRPs(logger, desiredLRPs)).To(Succeed())
+
+			for _, lrp := range desiredLRPs {
+				persisted, err := etcdDB.DesiredLRPByProcessGuid(logger, lrp.ProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(persisted.DesiredLRPKey()).To(Equal(lrp.DesiredLRPKey()))
+				Expect(persisted.Instances).To(Equal(lrp.Instances))
+			}
+		})
+
+		Context("when one of the lrps is invalid", func() {
+			BeforeEach(func() {
+				desiredLRPs[1].Instances = -1
+			})
+
+			It("returns the validation error and persists none of them", func() {
+				err := etcdDB.DesireLRPs(logger, desiredLRPs)
+				Expect(err).To(BeAssignableToTypeOf(models.ValidationError{}))
+
+				persisted, err := etcdDB.DesiredLRPs(logger, models.DesiredLRPFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(persisted).To(BeEmpty())
+			})
+		})
+
+		Context("when two of the lrps share a process guid", func() {
+			BeforeEach(func() {
+				desiredLRPs[2].ProcessGuid = "web-guid"
+			})
+
+			It("returns a duplicate process guid error and persists none of them", func() {
+				err := etcdDB.DesireLRPs(logger, desiredLRPs)
+				Expect(err).To(Equal(models.ErrDuplicateProcessGuid{ProcessGuid: "web-guid"}))
+
+				persisted, err := etcdDB.DesiredLRPs(logger, models.DesiredLRPFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(persisted).To(BeEmpty())
+			})
+		})
+
+		Context("when one of the lrps fails to be written", func() {
+			BeforeEach(func() {
+				Expect(etcdDB.DesireLRP(logger, model_helpers.NewValidDesiredLRP("clock-guid"))).To(Succeed())
+			})
+
+			It("returns the error and removes the lrps it already desired", func() {
+				err := etcdDB.DesireLRPs(logger, desiredLRPs)
+				Expect(err).To(Equal(models.ErrResourceExists))
+
+				_, err = etcdDB.DesiredLRPByProcessGuid(logger, "web-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+				_, err = etcdDB.DesiredLRPByProcessGuid(logger, "worker-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+
+				_, err = etcdDB.DesiredLRPByProcessGuid(logger, "clock-guid")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("DesireLRP", func() {
 		var lrp *models.DesiredLRP
 
@@ -491,4 +563,51 @@ var _ = Describe("DesiredLRPDB", func() {
 			})
 		})
 	})
+
+	Describe("UpdateDesiredLRPRoutes", func() {
+		var (
+			lrp    *models.DesiredLRP
+			routes models.Routes
+		)
+
+		BeforeEach(func() {
+			lrp = model_helpers.NewValidDesiredLRP("some-process-guid")
+			lrp.Instances = 3
+			Expect(etcdDB.DesireLRP(logger, lrp)).To(Succeed())
+
+			rawMessage := json.RawMessage([]byte(`{"port":8080}`))
+			routes = models.Routes{
+				"new-router": &rawMessage,
+			}
+		})
+
+		It("updates only the routes of the lrp and returns it before and after", func() {
+			beforeDesiredLRP, afterDesiredLRP, err := etcdDB.UpdateDesiredLRPRoutes(logger, lrp.ProcessGuid, &routes)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated, err := etcdDB.DesiredLRPByProcessGuid(logger, lrp.ProcessGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Instances).To(Equal(int32(3)))
+			Expect(*updated.Routes).To(HaveKey("new-router"))
+			Expect(updated.ModificationTag.Index).To(Equal(beforeDesiredLRP.ModificationTag.Index + 1))
+
+			Expect(beforeDesiredLRP.Routes).To(Equal(lrp.Routes))
+			Expect(afterDesiredLRP).To(Equal(updated))
+		})
+
+		Context("when the routes are nil", func() {
+			It("returns an invalid request error", func() {
+				_, _, err := etcdDB.UpdateDesiredLRPRoutes(logger, lrp.ProcessGuid, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*models.Error).Type).To(Equal(models.Error_InvalidRequest))
+			})
+		})
+
+		Context("when the LRP does not exist", func() {
+			It("returns an ErrorKeyNotFound", func() {
+				_, _, err := etcdDB.UpdateDesiredLRPRoutes(logger, "garbage-guid", &routes)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+	})
 })
@@ -34,7 +34,51 @@ const (
 	crashingDesiredLRPs = "CrashingDesiredLRPs"
 )
 
-func (db *ETCDDB) ConvergeLRPs(logger lager.Logger, cellSet models.CellSet) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKey) {
+// ConvergenceLRPCandidateCount gathers the convergence input without pruning
+// it and returns the number of changes convergence would make to the actual
+// LRPs, without emitting metrics.
+func (db *ETCDDB) ConvergenceLRPCandidateCount(logger lager.Logger, cellSet models.CellSet) (int, error) {
+	logger = logger.Session("convergence-lrp-candidate-count")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	guids := map[string]struct{}{}
+	lrpMetricCounter := &LRPMetricCounter{}
+
+	// always fetch actualLRPs before desiredLRPs to ensure correctness
+	actuals, err := db.GatherActualLRPs(logger, guids, lrpMetricCounter)
+	if err != nil {
+		logger.Error("failed-gathering-actual-lrps", err)
+		return 0, err
+	}
+
+	desireds, err := db.gatherAndOptionallyPruneDesiredLRPs(logger, guids, false, lrpMetricCounter)
+	if err != nil {
+		logger.Error("failed-gathering-desired-lrps", err)
+		return 0, err
+	}
+
+	domains, err := db.Domains(logger)
+	if err != nil {
+		return 0, err
+	}
+
+	changes, _, _ := calculateConvergence(logger, db.clock, models.NewDefaultRestartCalculator(), &models.ConvergenceInput{
+		AllProcessGuids: guids,
+		DesiredLRPs:     desireds,
+		ActualLRPs:      actuals,
+		Domains:         models.NewDomainSet(domains),
+		Cells:           cellSet,
+	})
+
+	return len(changes.ActualLRPsForExtraIndices) +
+		len(changes.ActualLRPKeysForMissingIndices) +
+		len(changes.ActualLRPsWithMissingCells) +
+		len(changes.RestartableCrashedActualLRPs) +
+		len(changes.StaleUnclaimedActualLRPs), nil
+}
+
+func (db *ETCDDB) ConvergeLRPs(logger lager.Logger, cellSet models.CellSet) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKeyWithRetireReason) {
 	convergeStart := db.clock.Now()
 	db.metronClient.IncrementCounter(convergeLRPRunsCounter)
 	logger = logger.Session("etcd")
@@ -325,6 +369,10 @@ func (db *ETCDDB) deleteLeaves(logger lager.Logger, keys []string) error {
 }
 
 func (db *ETCDDB) GatherAndPruneDesiredLRPs(logger lager.Logger, guids map[string]struct{}, lmc *LRPMetricCounter) (map[string]*models.DesiredLRP, error) {
+	return db.gatherAndOptionallyPruneDesiredLRPs(logger, guids, true, lmc)
+}
+
+func (db *ETCDDB) gatherAndOptionallyPruneDesiredLRPs(logger lager.Logger, guids map[string]struct{}, doPrune bool, lmc *LRPMetricCounter) (map[string]*models.DesiredLRP, error) {
 	desiredLRPsRoot, modelErr := db.fetchRecursiveRaw(logger, DesiredLRPComponentsSchemaRoot)
 
 	if modelErr == models.ErrResourceNotFound {
@@ -402,11 +450,13 @@ func (db *ETCDDB) GatherAndPruneDesiredLRPs(logger lager.Logger, guids map[strin
 
 	throttler.Work()
 
-	db.batchDeleteNodes(malformedSchedulingInfos, logger)
-	db.batchDeleteNodes(malformedRunInfos, logger)
+	if doPrune {
+		db.batchDeleteNodes(malformedSchedulingInfos, logger)
+		db.batchDeleteNodes(malformedRunInfos, logger)
 
-	db.metronClient.IncrementCounterWithDelta(malformedSchedulingInfosMetric, uint64(len(malformedSchedulingInfos)))
-	db.metronClient.IncrementCounterWithDelta(malformedRunInfosMetric, uint64(len(malformedRunInfos)))
+		db.metronClient.IncrementCounterWithDelta(malformedSchedulingInfosMetric, uint64(len(malformedSchedulingInfos)))
+		db.metronClient.IncrementCounterWithDelta(malformedRunInfosMetric, uint64(len(malformedRunInfos)))
+	}
 
 	logger.Debug("done-walking-desired-lrp-tree")
 
@@ -423,6 +473,10 @@ func (db *ETCDDB) GatherAndPruneDesiredLRPs(logger lager.Logger, guids map[strin
 			desireds[guid] = &desiredLRP
 		}
 	}
+	if !doPrune {
+		return desireds, nil
+	}
+
 	db.batchDeleteNodes(schedInfosToDelete, logger)
 
 	// Check to see if we have orphaned RunInfos
@@ -481,14 +535,25 @@ func CalculateConvergence(
 	restartCalculator models.RestartCalculator,
 	input *models.ConvergenceInput,
 ) *models.ConvergenceChanges {
-	sess := logger.Session("calculate-convergence")
+	changes, missingLRPCount, extraLRPCount := calculateConvergence(logger, clock, restartCalculator, input)
+
+	db.metronClient.SendMetric(missingLRPs, missingLRPCount)
+	db.metronClient.SendMetric(extraLRPs, extraLRPCount)
+	return changes
+}
 
-	var extraLRPCount, missingLRPCount int
+func calculateConvergence(
+	logger lager.Logger,
+	clock clock.Clock,
+	restartCalculator models.RestartCalculator,
+	input *models.ConvergenceInput,
+) (changes *models.ConvergenceChanges, missingLRPCount, extraLRPCount int) {
+	sess := logger.Session("calculate-convergence")
 
 	sess.Info("start")
 	defer sess.Info("done")
 
-	changes := &models.ConvergenceChanges{}
+	changes = &models.ConvergenceChanges{}
 
 	now := clock.Now()
 
@@ -554,12 +619,10 @@ func CalculateConvergence(
 		}
 	}
 
-	db.metronClient.SendMetric(missingLRPs, missingLRPCount)
-	db.metronClient.SendMetric(extraLRPs, extraLRPCount)
-	return changes
+	return changes, missingLRPCount, extraLRPCount
 }
 
-func (db *ETCDDB) ResolveConvergence(logger lager.Logger, desiredLRPs map[string]*models.DesiredLRP, changes *models.ConvergenceChanges) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKey) {
+func (db *ETCDDB) ResolveConvergence(logger lager.Logger, desiredLRPs map[string]*models.DesiredLRP, changes *models.ConvergenceChanges) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKeyWithRetireReason) {
 	startRequests := newStartRequests(desiredLRPs)
 	for _, actual := range changes.StaleUnclaimedActualLRPs {
 		startRequests.Add(logger, &actual.ActualLRPKey)
@@ -567,9 +630,13 @@ func (db *ETCDDB) ResolveConvergence(logger lager.Logger, desiredLRPs map[string
 
 	works := []func(){}
 
-	keysToRetire := make([]*models.ActualLRPKey, len(changes.ActualLRPsForExtraIndices))
+	keysToRetire := make([]*models.ActualLRPKeyWithRetireReason, len(changes.ActualLRPsForExtraIndices))
 	for i, actual := range changes.ActualLRPsForExtraIndices {
-		keysToRetire[i] = &actual.ActualLRPKey
+		reason := models.RetireReasonExtraInstance
+		if _, ok := desiredLRPs[actual.ProcessGuid]; !ok {
+			reason = models.RetireReasonNoDesiredLRP
+		}
+		keysToRetire[i] = &models.ActualLRPKeyWithRetireReason{Key: &actual.ActualLRPKey, Reason: reason}
 	}
 
 	keysWithMissingCells := []*models.ActualLRPKeyWithSchedulingInfo{}
@@ -756,6 +756,57 @@ var _ = Describe("LRPConvergence", func() {
 		})
 	})
 
+	Describe("ConvergenceLRPCandidateCount", func() {
+		var desiredLRP *models.DesiredLRP
+
+		BeforeEach(func() {
+			desiredLRP = model_helpers.NewValidDesiredLRP("some-process-guid")
+			desiredLRP.Instances = 2
+			etcdHelper.SetRawDesiredLRP(desiredLRP)
+
+			etcdHelper.SetRawActualLRP(&models.ActualLRP{
+				ActualLRPKey:         models.NewActualLRPKey(desiredLRP.ProcessGuid, 0, desiredLRP.Domain),
+				ActualLRPInstanceKey: models.NewActualLRPInstanceKey("some-instance-guid", "cell-id"),
+				ActualLRPNetInfo:     models.NewActualLRPNetInfo("1.2.3.4", "2.2.2.2", &models.PortMapping{ContainerPort: 1234, HostPort: 5678}),
+				State:                models.ActualLRPStateRunning,
+				Since:                clock.Now().Add(-time.Minute).UnixNano(),
+			})
+		})
+
+		It("counts the changes convergence would make", func() {
+			count, err := etcdDB.ConvergenceLRPCandidateCount(logger, models.CellSet{})
+			Expect(err).NotTo(HaveOccurred())
+			// index 0 is on a missing cell and index 1 is missing
+			Expect(count).To(Equal(2))
+		})
+
+		It("does not converge or emit metrics", func() {
+			_, err := etcdDB.ConvergenceLRPCandidateCount(logger, models.CellSet{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = etcdDB.ActualLRPGroupByProcessGuidAndIndex(logger, desiredLRP.ProcessGuid, 1)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			Expect(fakeMetronClient.SendMetricCallCount()).To(BeZero())
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(BeZero())
+			Expect(fakeMetronClient.IncrementCounterWithDeltaCallCount()).To(BeZero())
+		})
+
+		Context("when a desired LRP is malformed", func() {
+			BeforeEach(func() {
+				etcdHelper.CreateMalformedDesiredLRP("bogus-desired")
+			})
+
+			It("does not prune it", func() {
+				_, err := etcdDB.ConvergenceLRPCandidateCount(logger, models.CellSet{})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = storeClient.Get(etcd.DesiredLRPSchedulingInfoSchemaPath("bogus-desired"), false, false)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("converging missing actual LRPs", func() {
 		var (
 			desiredLRP          *models.DesiredLRP
@@ -1026,13 +1077,19 @@ var _ = Describe("LRPConvergence", func() {
 			Context("when the actual LRP is UNCLAIMED", func() {
 				It("returns the lrp to be retired", func() {
 					_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, models.CellSet{})
-					Expect(keysToRetire).To(ConsistOf(&models.ActualLRPKey{
+					Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ConsistOf(&models.ActualLRPKey{
 						ProcessGuid: processGuid,
 						Index:       index,
 						Domain:      domain,
 					}))
 				})
 
+				It("retires it because no desired LRP exists", func() {
+					_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, models.CellSet{})
+					Expect(keysToRetire).To(HaveLen(1))
+					Expect(keysToRetire[0].Reason).To(Equal(models.RetireReasonNoDesiredLRP))
+				})
+
 				It("logs", func() {
 					etcdDB.ConvergeLRPs(logger, models.CellSet{})
 					Expect(logger.TestSink).To(gbytes.Say("no-longer-desired"))
@@ -1092,7 +1149,7 @@ var _ = Describe("LRPConvergence", func() {
 
 					It("returns the lrp to be retired", func() {
 						_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, cells)
-						Expect(keysToRetire).To(ConsistOf(&models.ActualLRPKey{
+						Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ConsistOf(&models.ActualLRPKey{
 							ProcessGuid: processGuid,
 							Index:       index,
 							Domain:      domain,
@@ -1119,7 +1176,7 @@ var _ = Describe("LRPConvergence", func() {
 				Context("when the cell is missing", func() {
 					It("returns the lrp to be retired", func() {
 						_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, cells)
-						Expect(keysToRetire).To(ConsistOf(&models.ActualLRPKey{
+						Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ConsistOf(&models.ActualLRPKey{
 							ProcessGuid: processGuid,
 							Index:       index,
 							Domain:      domain,
@@ -1184,7 +1241,7 @@ var _ = Describe("LRPConvergence", func() {
 
 				It("returns the correct lrps to retire", func() {
 					_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, cells)
-					Expect(keysToRetire).To(ConsistOf(&models.ActualLRPKey{
+					Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ConsistOf(&models.ActualLRPKey{
 						ProcessGuid: processGuid,
 						Index:       index,
 						Domain:      domain,
@@ -1241,13 +1298,19 @@ var _ = Describe("LRPConvergence", func() {
 
 				It("returns the lrp to be retired", func() {
 					_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, models.CellSet{})
-					Expect(keysToRetire).To(ConsistOf(&models.ActualLRPKey{
+					Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ConsistOf(&models.ActualLRPKey{
 						ProcessGuid: processGuid,
 						Index:       index,
 						Domain:      domain,
 					}))
 				})
 
+				It("retires it as an extra instance", func() {
+					_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, models.CellSet{})
+					Expect(keysToRetire).To(HaveLen(1))
+					Expect(keysToRetire[0].Reason).To(Equal(models.RetireReasonExtraInstance))
+				})
+
 				Context("when the LRP domain is not fresh", func() {
 					BeforeEach(func() {
 						domain = "expired-domain"
@@ -1307,7 +1370,7 @@ var _ = Describe("LRPConvergence", func() {
 
 				It("returns the lrp to be retired", func() {
 					_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, cells)
-					Expect(keysToRetire).To(ConsistOf(&models.ActualLRPKey{
+					Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ConsistOf(&models.ActualLRPKey{
 						ProcessGuid: processGuid,
 						Index:       index,
 						Domain:      domain,
@@ -1383,7 +1446,7 @@ var _ = Describe("LRPConvergence", func() {
 
 				It("sends a stop request to the corresponding cell", func() {
 					_, _, keysToRetire := etcdDB.ConvergeLRPs(logger, cells)
-					Expect(keysToRetire).To(ConsistOf(&models.ActualLRPKey{
+					Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ConsistOf(&models.ActualLRPKey{
 						ProcessGuid: processGuid,
 						Index:       index,
 						Domain:      domain,
@@ -136,7 +136,8 @@ func (db *ETCDDB) StartTask(logger lager.Logger, taskGuid, cellID string) (*mode
 
 // The cell calls this when the user requested to cancel the task
 // stagerTaskBBS will retry this repeatedly if it gets a StoreTimeout error (up to N seconds?)
-// Will fail if the task has already been cancelled or completed normally
+// Cancelling a task that already completed does nothing and returns it as both
+// the task before and after
 func (db *ETCDDB) CancelTask(logger lager.Logger, taskGuid string) (*models.Task, *models.Task, string, error) {
 	logger = logger.WithData(lager.Data{"task_guid": taskGuid})
 
@@ -149,6 +150,12 @@ func (db *ETCDDB) CancelTask(logger lager.Logger, taskGuid string) (*models.Task
 		return nil, nil, "", err
 	}
 
+	if task.State == models.Task_Completed {
+		logger.Info("task-already-completed")
+		return task, task, task.CellId, nil
+	}
+
+	beforeTask := *task
 	if err = task.ValidateTransitionTo(models.Task_Completed); err != nil {
 		if task.State != models.Task_Pending {
 			logger.Error("invalid-state-transition", err)
@@ -165,7 +172,7 @@ func (db *ETCDDB) CancelTask(logger lager.Logger, taskGuid string) (*models.Task
 	}
 
 	logger.Info("succeeded-completing-task")
-	return nil, task, cellID, nil
+	return &beforeTask, task, cellID, nil
 }
 
 // The cell calls this when it has finished running the task (be it success or failure)
@@ -306,14 +306,15 @@ var _ = Describe("TaskDB", func() {
 
 	Describe("CancelTask", func() {
 		var (
-			cancelError     error
-			taskAfterCancel *models.Task
-			taskReturned    *models.Task
-			cellIDReturned  string
+			cancelError        error
+			taskAfterCancel    *models.Task
+			taskBeforeReturned *models.Task
+			taskReturned       *models.Task
+			cellIDReturned     string
 		)
 
 		JustBeforeEach(func() {
-			_, taskReturned, cellIDReturned, cancelError = etcdDB.CancelTask(logger, taskGuid)
+			taskBeforeReturned, taskReturned, cellIDReturned, cancelError = etcdDB.CancelTask(logger, taskGuid)
 			taskAfterCancel, _ = etcdDB.TaskByGuid(logger, taskGuid)
 		})
 
@@ -369,6 +370,10 @@ var _ = Describe("TaskDB", func() {
 
 			itMarksTaskAsCancelled()
 
+			It("returns the task as it was before it was cancelled", func() {
+				Expect(taskBeforeReturned.State).To(Equal(models.Task_Running))
+			})
+
 			It("returns the cellID on which the task was running", func() {
 				Expect(cellIDReturned).To(Equal(cellId))
 			})
@@ -388,9 +393,15 @@ var _ = Describe("TaskDB", func() {
 				Expect(task.TaskGuid).To(Equal(taskGuid))
 			})
 
-			It("returns an error", func() {
-				Expect(cancelError).To(HaveOccurred())
-				Expect(cancelError).To(Equal(models.NewTaskTransitionError(models.Task_Completed, models.Task_Completed)))
+			It("does not error", func() {
+				Expect(cancelError).NotTo(HaveOccurred())
+			})
+
+			It("leaves the task as it was", func() {
+				Expect(taskAfterCancel.State).To(Equal(models.Task_Completed))
+				Expect(taskAfterCancel.Failed).To(BeFalse())
+				Expect(taskReturned).To(Equal(taskAfterCancel))
+				Expect(taskBeforeReturned).To(Equal(taskAfterCancel))
 			})
 		})
 
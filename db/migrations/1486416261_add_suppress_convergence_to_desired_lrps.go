@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"code.cloudfoundry.org/bbs/db/etcd"
+	"code.cloudfoundry.org/bbs/encryption"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+func init() {
+	AppendMigration(NewAddSuppressConvergenceToDesiredLRPs())
+}
+
+type AddSuppressConvergenceToDesiredLRPs struct {
+	serializer  format.Serializer
+	storeClient etcd.StoreClient
+	clock       clock.Clock
+	rawSQLDB    *sql.DB
+	dbFlavor    string
+}
+
+func NewAddSuppressConvergenceToDesiredLRPs() migration.Migration {
+	return &AddSuppressConvergenceToDesiredLRPs{}
+}
+
+func (e *AddSuppressConvergenceToDesiredLRPs) String() string {
+	return "1486416261"
+}
+
+func (e *AddSuppressConvergenceToDesiredLRPs) Version() int64 {
+	return 1486416261
+}
+
+func (e *AddSuppressConvergenceToDesiredLRPs) SetStoreClient(storeClient etcd.StoreClient) {
+	e.storeClient = storeClient
+}
+
+func (e *AddSuppressConvergenceToDesiredLRPs) SetCryptor(cryptor encryption.Cryptor) {
+	e.serializer = format.NewSerializer(cryptor)
+}
+
+func (e *AddSuppressConvergenceToDesiredLRPs) SetRawSQLDB(db *sql.DB) {
+	e.rawSQLDB = db
+}
+
+func (e *AddSuppressConvergenceToDesiredLRPs) RequiresSQL() bool         { return true }
+func (e *AddSuppressConvergenceToDesiredLRPs) SetClock(c clock.Clock)    { e.clock = c }
+func (e *AddSuppressConvergenceToDesiredLRPs) SetDBFlavor(flavor string) { e.dbFlavor = flavor }
+
+func (e *AddSuppressConvergenceToDesiredLRPs) Up(logger lager.Logger) error {
+	logger.Info("altering the table", lager.Data{"query": alterDesiredLRPAddSuppressConvergenceSQL})
+	_, err := e.rawSQLDB.Exec(alterDesiredLRPAddSuppressConvergenceSQL)
+	if err != nil {
+		logger.Error("failed-altering-tables", err)
+		return err
+	}
+	logger.Info("altered the table", lager.Data{"query": alterDesiredLRPAddSuppressConvergenceSQL})
+
+	return nil
+}
+
+const alterDesiredLRPAddSuppressConvergenceSQL = `ALTER TABLE desired_lrps
+	ADD COLUMN suppress_convergence BOOL DEFAULT false;`
+
+func (e *AddSuppressConvergenceToDesiredLRPs) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"code.cloudfoundry.org/bbs/db/etcd"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/encryption"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+func init() {
+	AppendMigration(NewAddStartRequestBuffer())
+}
+
+type AddStartRequestBuffer struct {
+	serializer  format.Serializer
+	storeClient etcd.StoreClient
+	clock       clock.Clock
+	rawSQLDB    *sql.DB
+	dbFlavor    string
+}
+
+func NewAddStartRequestBuffer() migration.Migration {
+	return &AddStartRequestBuffer{}
+}
+
+func (e *AddStartRequestBuffer) String() string {
+	return "1489588466"
+}
+
+func (e *AddStartRequestBuffer) Version() int64 {
+	return 1489588466
+}
+
+func (e *AddStartRequestBuffer) SetStoreClient(storeClient etcd.StoreClient) {
+	e.storeClient = storeClient
+}
+
+func (e *AddStartRequestBuffer) SetCryptor(cryptor encryption.Cryptor) {
+	e.serializer = format.NewSerializer(cryptor)
+}
+
+func (e *AddStartRequestBuffer) SetRawSQLDB(db *sql.DB) {
+	e.rawSQLDB = db
+}
+
+func (e *AddStartRequestBuffer) RequiresSQL() bool         { return true }
+func (e *AddStartRequestBuffer) SetClock(c clock.Clock)    { e.clock = c }
+func (e *AddStartRequestBuffer) SetDBFlavor(flavor string) { e.dbFlavor = flavor }
+
+func (e *AddStartRequestBuffer) Up(logger lager.Logger) error {
+	query := helpers.RebindForFlavor(createStartRequestBufferSQL, e.dbFlavor)
+
+	logger.Info("creating the table", lager.Data{"query": query})
+	_, err := e.rawSQLDB.Exec(query)
+	if err != nil {
+		logger.Error("failed-creating-table", err)
+		return err
+	}
+	logger.Info("created the table", lager.Data{"query": query})
+
+	return nil
+}
+
+const createStartRequestBufferSQL = `CREATE TABLE IF NOT EXISTS start_request_buffer(
+	process_guid VARCHAR(255) PRIMARY KEY,
+	start_request MEDIUMTEXT NOT NULL,
+	updated_at BIGINT DEFAULT 0
+);`
+
+func (e *AddStartRequestBuffer) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
@@ -0,0 +1,96 @@
+package migrations_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/bbs/db/migrations"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add Start Request Buffer", func() {
+	var (
+		mig       migration.Migration
+		migErr    error
+		fakeClock *fakeclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		rawSQLDB.Exec("DROP TABLE domains;")
+		rawSQLDB.Exec("DROP TABLE tasks;")
+		rawSQLDB.Exec("DROP TABLE desired_lrps;")
+		rawSQLDB.Exec("DROP TABLE actual_lrps;")
+		rawSQLDB.Exec("DROP TABLE start_request_buffer;")
+
+		mig = migrations.NewAddStartRequestBuffer()
+	})
+
+	It("appends itself to the migration list", func() {
+		Expect(migrations.Migrations).To(ContainElement(mig))
+	})
+
+	Describe("Version", func() {
+		It("returns the timestamp from which it was created", func() {
+			Expect(mig.Version()).To(BeEquivalentTo(1489588466))
+		})
+	})
+
+	Describe("Up", func() {
+		var initialMigrations migration.Migrations
+
+		BeforeEach(func() {
+			initialMigrations = []migration.Migration{
+				migrations.NewETCDToSQL(),
+				migrations.NewIncreaseRunInfoColumnSize(),
+			}
+
+			for _, m := range initialMigrations {
+				m.SetRawSQLDB(rawSQLDB)
+				m.SetDBFlavor(flavor)
+				m.SetClock(fakeClock)
+				err := m.Up(logger)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			// Can't do this in the Describe BeforeEach
+			// as the test on line 33 will cause ginkgo to panic
+			mig.SetRawSQLDB(rawSQLDB)
+			mig.SetDBFlavor(flavor)
+		})
+
+		JustBeforeEach(func() {
+			migErr = mig.Up(logger)
+		})
+
+		It("does not error out", func() {
+			Expect(migErr).NotTo(HaveOccurred())
+		})
+
+		It("creates a start_request_buffer table", func() {
+			_, err := rawSQLDB.Exec(
+				helpers.RebindForFlavor(
+					`INSERT INTO start_request_buffer (process_guid, start_request, updated_at) VALUES (?, ?, ?)`,
+					flavor,
+				),
+				"guid", "start request", 1,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			var startRequest string
+			query := helpers.RebindForFlavor("SELECT start_request FROM start_request_buffer WHERE process_guid = ?", flavor)
+			row := rawSQLDB.QueryRow(query, "guid")
+			Expect(row.Scan(&startRequest)).NotTo(HaveOccurred())
+			Expect(startRequest).To(Equal("start request"))
+		})
+	})
+
+	Describe("Down", func() {
+		It("returns a not implemented error", func() {
+			Expect(mig.Down(logger)).To(HaveOccurred())
+		})
+	})
+})
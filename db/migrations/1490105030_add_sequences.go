@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"code.cloudfoundry.org/bbs/db/etcd"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/encryption"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+func init() {
+	AppendMigration(NewAddSequences())
+}
+
+type AddSequences struct {
+	serializer  format.Serializer
+	storeClient etcd.StoreClient
+	clock       clock.Clock
+	rawSQLDB    *sql.DB
+	dbFlavor    string
+}
+
+func NewAddSequences() migration.Migration {
+	return &AddSequences{}
+}
+
+func (e *AddSequences) String() string {
+	return "1490105030"
+}
+
+func (e *AddSequences) Version() int64 {
+	return 1490105030
+}
+
+func (e *AddSequences) SetStoreClient(storeClient etcd.StoreClient) {
+	e.storeClient = storeClient
+}
+
+func (e *AddSequences) SetCryptor(cryptor encryption.Cryptor) {
+	e.serializer = format.NewSerializer(cryptor)
+}
+
+func (e *AddSequences) SetRawSQLDB(db *sql.DB) {
+	e.rawSQLDB = db
+}
+
+func (e *AddSequences) RequiresSQL() bool         { return true }
+func (e *AddSequences) SetClock(c clock.Clock)    { e.clock = c }
+func (e *AddSequences) SetDBFlavor(flavor string) { e.dbFlavor = flavor }
+
+func (e *AddSequences) Up(logger lager.Logger) error {
+	query := helpers.RebindForFlavor(createSequencesSQL, e.dbFlavor)
+
+	logger.Info("creating the table", lager.Data{"query": query})
+	_, err := e.rawSQLDB.Exec(query)
+	if err != nil {
+		logger.Error("failed-creating-table", err)
+		return err
+	}
+	logger.Info("created the table", lager.Data{"query": query})
+
+	return nil
+}
+
+const createSequencesSQL = `CREATE TABLE IF NOT EXISTS sequences(
+	name VARCHAR(255) PRIMARY KEY,
+	value BIGINT NOT NULL DEFAULT 0
+);`
+
+func (e *AddSequences) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
@@ -0,0 +1,93 @@
+package migrations_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/bbs/db/migrations"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add Sequences", func() {
+	var (
+		mig       migration.Migration
+		migErr    error
+		fakeClock *fakeclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		rawSQLDB.Exec("DROP TABLE domains;")
+		rawSQLDB.Exec("DROP TABLE tasks;")
+		rawSQLDB.Exec("DROP TABLE desired_lrps;")
+		rawSQLDB.Exec("DROP TABLE actual_lrps;")
+		rawSQLDB.Exec("DROP TABLE sequences;")
+
+		mig = migrations.NewAddSequences()
+	})
+
+	It("appends itself to the migration list", func() {
+		Expect(migrations.Migrations).To(ContainElement(mig))
+	})
+
+	Describe("Version", func() {
+		It("returns the timestamp from which it was created", func() {
+			Expect(mig.Version()).To(BeEquivalentTo(1490105030))
+		})
+	})
+
+	Describe("Up", func() {
+		var initialMigrations migration.Migrations
+
+		BeforeEach(func() {
+			initialMigrations = []migration.Migration{
+				migrations.NewETCDToSQL(),
+				migrations.NewIncreaseRunInfoColumnSize(),
+			}
+
+			for _, m := range initialMigrations {
+				m.SetRawSQLDB(rawSQLDB)
+				m.SetDBFlavor(flavor)
+				m.SetClock(fakeClock)
+				err := m.Up(logger)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			// Can't do this in the Describe BeforeEach
+			// as the test on line 33 will cause ginkgo to panic
+			mig.SetRawSQLDB(rawSQLDB)
+			mig.SetDBFlavor(flavor)
+		})
+
+		JustBeforeEach(func() {
+			migErr = mig.Up(logger)
+		})
+
+		It("does not error out", func() {
+			Expect(migErr).NotTo(HaveOccurred())
+		})
+
+		It("creates a sequences table", func() {
+			_, err := rawSQLDB.Exec(
+				helpers.RebindForFlavor(`INSERT INTO sequences (name, value) VALUES (?, ?)`, flavor),
+				"events", 1,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			var value int64
+			query := helpers.RebindForFlavor("SELECT value FROM sequences WHERE name = ?", flavor)
+			row := rawSQLDB.QueryRow(query, "events")
+			Expect(row.Scan(&value)).NotTo(HaveOccurred())
+			Expect(value).To(BeEquivalentTo(1))
+		})
+	})
+
+	Describe("Down", func() {
+		It("returns a not implemented error", func() {
+			Expect(mig.Down(logger)).To(HaveOccurred())
+		})
+	})
+})
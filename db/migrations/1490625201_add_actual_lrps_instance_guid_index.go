@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"code.cloudfoundry.org/bbs/db/etcd"
+	"code.cloudfoundry.org/bbs/encryption"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+func init() {
+	AppendMigration(NewAddActualLRPsInstanceGuidIndex())
+}
+
+type AddActualLRPsInstanceGuidIndex struct {
+	serializer  format.Serializer
+	storeClient etcd.StoreClient
+	clock       clock.Clock
+	rawSQLDB    *sql.DB
+	dbFlavor    string
+}
+
+func NewAddActualLRPsInstanceGuidIndex() migration.Migration {
+	return &AddActualLRPsInstanceGuidIndex{}
+}
+
+func (e *AddActualLRPsInstanceGuidIndex) String() string {
+	return "1490625201"
+}
+
+func (e *AddActualLRPsInstanceGuidIndex) Version() int64 {
+	return 1490625201
+}
+
+func (e *AddActualLRPsInstanceGuidIndex) SetStoreClient(storeClient etcd.StoreClient) {
+	e.storeClient = storeClient
+}
+
+func (e *AddActualLRPsInstanceGuidIndex) SetCryptor(cryptor encryption.Cryptor) {
+	e.serializer = format.NewSerializer(cryptor)
+}
+
+func (e *AddActualLRPsInstanceGuidIndex) SetRawSQLDB(db *sql.DB) {
+	e.rawSQLDB = db
+}
+
+func (e *AddActualLRPsInstanceGuidIndex) RequiresSQL() bool         { return true }
+func (e *AddActualLRPsInstanceGuidIndex) SetClock(c clock.Clock)    { e.clock = c }
+func (e *AddActualLRPsInstanceGuidIndex) SetDBFlavor(flavor string) { e.dbFlavor = flavor }
+
+func (e *AddActualLRPsInstanceGuidIndex) Up(logger lager.Logger) error {
+	logger.Info("creating the index", lager.Data{"query": createActualLRPsInstanceGuidIndexSQL})
+	_, err := e.rawSQLDB.Exec(createActualLRPsInstanceGuidIndexSQL)
+	if err != nil {
+		logger.Error("failed-creating-index", err)
+		return err
+	}
+	logger.Info("created the index", lager.Data{"query": createActualLRPsInstanceGuidIndexSQL})
+
+	return nil
+}
+
+const createActualLRPsInstanceGuidIndexSQL = `CREATE INDEX actual_lrps_instance_guid_idx ON actual_lrps (instance_guid)`
+
+func (e *AddActualLRPsInstanceGuidIndex) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
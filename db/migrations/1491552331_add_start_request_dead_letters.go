@@ -0,0 +1,90 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"code.cloudfoundry.org/bbs/db/etcd"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/encryption"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+func init() {
+	AppendMigration(NewAddStartRequestDeadLetters())
+}
+
+type AddStartRequestDeadLetters struct {
+	serializer  format.Serializer
+	storeClient etcd.StoreClient
+	clock       clock.Clock
+	rawSQLDB    *sql.DB
+	dbFlavor    string
+}
+
+func NewAddStartRequestDeadLetters() migration.Migration {
+	return &AddStartRequestDeadLetters{}
+}
+
+func (e *AddStartRequestDeadLetters) String() string {
+	return "1491552331"
+}
+
+func (e *AddStartRequestDeadLetters) Version() int64 {
+	return 1491552331
+}
+
+func (e *AddStartRequestDeadLetters) SetStoreClient(storeClient etcd.StoreClient) {
+	e.storeClient = storeClient
+}
+
+func (e *AddStartRequestDeadLetters) SetCryptor(cryptor encryption.Cryptor) {
+	e.serializer = format.NewSerializer(cryptor)
+}
+
+func (e *AddStartRequestDeadLetters) SetRawSQLDB(db *sql.DB) {
+	e.rawSQLDB = db
+}
+
+func (e *AddStartRequestDeadLetters) RequiresSQL() bool         { return true }
+func (e *AddStartRequestDeadLetters) SetClock(c clock.Clock)    { e.clock = c }
+func (e *AddStartRequestDeadLetters) SetDBFlavor(flavor string) { e.dbFlavor = flavor }
+
+func (e *AddStartRequestDeadLetters) Up(logger lager.Logger) error {
+	logger.Info("altering the table", lager.Data{"query": alterStartRequestBufferAddAttemptsSQL})
+	_, err := e.rawSQLDB.Exec(alterStartRequestBufferAddAttemptsSQL)
+	if err != nil {
+		logger.Error("failed-altering-table", err)
+		return err
+	}
+	logger.Info("altered the table", lager.Data{"query": alterStartRequestBufferAddAttemptsSQL})
+
+	query := helpers.RebindForFlavor(createStartRequestDeadLettersSQL, e.dbFlavor)
+
+	logger.Info("creating the table", lager.Data{"query": query})
+	_, err = e.rawSQLDB.Exec(query)
+	if err != nil {
+		logger.Error("failed-creating-table", err)
+		return err
+	}
+	logger.Info("created the table", lager.Data{"query": query})
+
+	return nil
+}
+
+const alterStartRequestBufferAddAttemptsSQL = `ALTER TABLE start_request_buffer
+	ADD COLUMN attempts INT DEFAULT 0;`
+
+const createStartRequestDeadLettersSQL = `CREATE TABLE IF NOT EXISTS start_request_dead_letters(
+	process_guid VARCHAR(255) PRIMARY KEY,
+	start_request MEDIUMTEXT NOT NULL,
+	attempts INT DEFAULT 0,
+	dead_lettered_at BIGINT DEFAULT 0
+);`
+
+func (e *AddStartRequestDeadLetters) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
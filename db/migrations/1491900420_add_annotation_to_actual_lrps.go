@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+
+	"code.cloudfoundry.org/bbs/db/etcd"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/encryption"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+func init() {
+	AppendMigration(NewAddAnnotationToActualLRPs())
+}
+
+type AddAnnotationToActualLRPs struct {
+	serializer  format.Serializer
+	storeClient etcd.StoreClient
+	clock       clock.Clock
+	rawSQLDB    *sql.DB
+	dbFlavor    string
+}
+
+func NewAddAnnotationToActualLRPs() migration.Migration {
+	return &AddAnnotationToActualLRPs{}
+}
+
+func (e *AddAnnotationToActualLRPs) String() string {
+	return "1491900420"
+}
+
+func (e *AddAnnotationToActualLRPs) Version() int64 {
+	return 1491900420
+}
+
+func (e *AddAnnotationToActualLRPs) SetStoreClient(storeClient etcd.StoreClient) {
+	e.storeClient = storeClient
+}
+
+func (e *AddAnnotationToActualLRPs) SetCryptor(cryptor encryption.Cryptor) {
+	e.serializer = format.NewSerializer(cryptor)
+}
+
+func (e *AddAnnotationToActualLRPs) SetRawSQLDB(db *sql.DB) {
+	e.rawSQLDB = db
+}
+
+func (e *AddAnnotationToActualLRPs) RequiresSQL() bool         { return true }
+func (e *AddAnnotationToActualLRPs) SetClock(c clock.Clock)    { e.clock = c }
+func (e *AddAnnotationToActualLRPs) SetDBFlavor(flavor string) { e.dbFlavor = flavor }
+
+func (e *AddAnnotationToActualLRPs) Up(logger lager.Logger) error {
+	query := helpers.RebindForFlavor(alterActualLRPsAddAnnotationSQL, e.dbFlavor)
+
+	logger.Info("altering the table", lager.Data{"query": query})
+	_, err := e.rawSQLDB.Exec(query)
+	if err != nil {
+		logger.Error("failed-altering-tables", err)
+		return err
+	}
+	logger.Info("altered the table", lager.Data{"query": query})
+
+	return nil
+}
+
+const alterActualLRPsAddAnnotationSQL = `ALTER TABLE actual_lrps
+	ADD COLUMN annotation MEDIUMTEXT;`
+
+func (e *AddAnnotationToActualLRPs) Down(logger lager.Logger) error {
+	return errors.New("not implemented")
+}
@@ -0,0 +1,115 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"time"
+
+	"code.cloudfoundry.org/bbs/db/migrations"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/migration"
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add Annotation to Actual LRPs", func() {
+	var (
+		mig       migration.Migration
+		migErr    error
+		fakeClock *fakeclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		rawSQLDB.Exec("DROP TABLE domains;")
+		rawSQLDB.Exec("DROP TABLE tasks;")
+		rawSQLDB.Exec("DROP TABLE desired_lrps;")
+		rawSQLDB.Exec("DROP TABLE actual_lrps;")
+
+		mig = migrations.NewAddAnnotationToActualLRPs()
+	})
+
+	It("appends itself to the migration list", func() {
+		Expect(migrations.Migrations).To(ContainElement(mig))
+	})
+
+	Describe("Version", func() {
+		It("returns the timestamp from which it was created", func() {
+			Expect(mig.Version()).To(BeEquivalentTo(1491900420))
+		})
+	})
+
+	Describe("Up", func() {
+		var initialMigrations migration.Migrations
+
+		BeforeEach(func() {
+			initialMigrations = []migration.Migration{
+				migrations.NewETCDToSQL(),
+				migrations.NewIncreaseRunInfoColumnSize(),
+			}
+
+			for _, m := range initialMigrations {
+				m.SetRawSQLDB(rawSQLDB)
+				m.SetDBFlavor(flavor)
+				m.SetClock(fakeClock)
+				err := m.Up(logger)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			// Can't do this in the Describe BeforeEach
+			// as the test on line 32 will cause ginkgo to panic
+			mig.SetRawSQLDB(rawSQLDB)
+			mig.SetDBFlavor(flavor)
+
+			_, err := rawSQLDB.Exec(
+				helpers.RebindForFlavor(
+					`INSERT INTO actual_lrps (process_guid, instance_index, domain, state, net_info, modification_tag_epoch)
+						VALUES (?, ?, ?, ?, ?, ?)`,
+					flavor,
+				),
+				"existing-guid", 0, "domain", "RUNNING", "net info", "epoch",
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			migErr = mig.Up(logger)
+		})
+
+		It("does not error out", func() {
+			Expect(migErr).NotTo(HaveOccurred())
+		})
+
+		It("adds an annotation column to actual lrps", func() {
+			_, err := rawSQLDB.Exec(
+				helpers.RebindForFlavor(
+					`INSERT INTO actual_lrps (process_guid, instance_index, domain, state, net_info, modification_tag_epoch, annotation)
+						VALUES (?, ?, ?, ?, ?, ?, ?)`,
+					flavor,
+				),
+				"guid", 0, "domain", "RUNNING", "net info", "epoch", "v2",
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			var annotation string
+			query := helpers.RebindForFlavor("SELECT annotation FROM actual_lrps WHERE process_guid = ?", flavor)
+			row := rawSQLDB.QueryRow(query, "guid")
+			Expect(row.Scan(&annotation)).NotTo(HaveOccurred())
+			Expect(annotation).To(Equal("v2"))
+		})
+
+		It("leaves the annotation of existing actual lrps empty", func() {
+			var annotation sql.NullString
+			query := helpers.RebindForFlavor("SELECT annotation FROM actual_lrps WHERE process_guid = ?", flavor)
+			row := rawSQLDB.QueryRow(query, "existing-guid")
+			Expect(row.Scan(&annotation)).NotTo(HaveOccurred())
+			Expect(annotation.String).To(BeEmpty())
+		})
+	})
+
+	Describe("Down", func() {
+		It("returns a not implemented error", func() {
+			Expect(mig.Down(logger)).To(HaveOccurred())
+		})
+	})
+})
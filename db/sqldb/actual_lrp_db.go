@@ -2,6 +2,7 @@ package sqldb
 
 import (
 	"database/sql"
+	"math"
 	"strings"
 	"time"
 
@@ -50,11 +51,106 @@ func (db *SQLDB) ActualLRPGroups(logger lager.Logger, filter models.ActualLRPFil
 }
 
 func (db *SQLDB) ActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string) ([]*models.ActualLRPGroup, error) {
+	groups := []*models.ActualLRPGroup{}
+	err := db.StreamActualLRPGroupsByProcessGuid(logger, processGuid, func(group *models.ActualLRPGroup) error {
+		groups = append(groups, group)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// StreamActualLRPGroupsByProcessGuid calls the callback with every actual LRP
+// group of the given process guid, in index order, as its rows are scanned,
+// rather than first loading them all into memory. Iteration stops at the
+// first error returned by the callback, which is returned.
+func (db *SQLDB) StreamActualLRPGroupsByProcessGuid(logger lager.Logger, processGuid string, callback func(*models.ActualLRPGroup) error) error {
 	logger = logger.WithData(lager.Data{"process_guid": processGuid})
 	logger.Debug("starting")
 	defer logger.Debug("complete")
 
-	return db.getActualLRPS(logger, "process_guid = ?", processGuid)
+	rows, err := db.selectActualLRPsByProcessGuid(logger, db.db, processGuid)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return db.convertSQLError(err)
+	}
+
+	actualsToDelete := []*actualToDelete{}
+	defer func() {
+		db.deleteInvalidActualLRPs(logger, db.db, actualsToDelete)
+	}()
+	defer rows.Close()
+
+	// The instance and evacuating rows of an index are adjacent, so a group is
+	// complete once a row of the next index is scanned.
+	var group *models.ActualLRPGroup
+	var groupIndex int32
+	for rows.Next() {
+		actualLRP, evacuating, err := db.scanToActualLRP(logger, rows)
+		if err == models.ErrDeserialize {
+			actualsToDelete = append(actualsToDelete, &actualToDelete{actualLRP, evacuating})
+			continue
+		}
+
+		if err != nil {
+			logger.Error("failed-scanning-actual-lrp", err)
+			return err
+		}
+
+		if group != nil && groupIndex != actualLRP.Index {
+			err = callback(group)
+			if err != nil {
+				return err
+			}
+			group = nil
+		}
+
+		if group == nil {
+			group = &models.ActualLRPGroup{}
+			groupIndex = actualLRP.Index
+		}
+		if evacuating {
+			group.Evacuating = actualLRP
+		} else {
+			group.Instance = actualLRP
+		}
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return db.convertSQLError(rows.Err())
+	}
+
+	if group != nil {
+		return callback(group)
+	}
+
+	return nil
+}
+
+// ActualLRPGroupsByDomain returns every actual LRP group in the given domain,
+// using the index on the actual_lrps domain column.
+func (db *SQLDB) ActualLRPGroupsByDomain(logger lager.Logger, domain string) ([]*models.ActualLRPGroup, error) {
+	logger = logger.WithData(lager.Data{"domain": domain})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	return db.getActualLRPS(logger, "domain = ?", domain)
+}
+
+// ActualLRPGroupsByState returns the actual LRP groups of every actual LRP in
+// the given state, using the index on the actual_lrps state column. A group
+// only holds its actual LRPs that are in the state, so an evacuating actual
+// LRP in the state is returned without its replacement.
+func (db *SQLDB) ActualLRPGroupsByState(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error) {
+	logger = logger.WithData(lager.Data{"state": state})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	return db.getActualLRPS(logger, "state = ?", string(state))
 }
 
 func (db *SQLDB) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int32) (*models.ActualLRPGroup, error) {
@@ -75,11 +171,96 @@ func (db *SQLDB) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, proces
 	return groups[0], nil
 }
 
+// ActualLRPGroupsByProcessGuidAndIndexRange returns the actual LRP groups of
+// the given process guid whose index is in [startIndex, endIndex).
+func (db *SQLDB) ActualLRPGroupsByProcessGuidAndIndexRange(logger lager.Logger, processGuid string, startIndex, endIndex int32) ([]*models.ActualLRPGroup, error) {
+	logger = logger.WithData(lager.Data{"process_guid": processGuid, "start_index": startIndex, "end_index": endIndex})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	return db.getActualLRPS(logger,
+		"process_guid = ? AND instance_index >= ? AND instance_index < ?",
+		processGuid, startIndex, endIndex,
+	)
+}
+
+// ActualLRPByInstanceGuid returns the actual LRP group holding the actual LRP
+// with the given instance guid, using the index on the actual_lrps
+// instance_guid column. Unclaimed actual LRPs have no instance guid, so an
+// empty instance guid is never found.
+func (db *SQLDB) ActualLRPByInstanceGuid(logger lager.Logger, instanceGuid string) (*models.ActualLRPGroup, error) {
+	logger = logger.WithData(lager.Data{"instance_guid": instanceGuid})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	if instanceGuid == "" {
+		return nil, models.ErrResourceNotFound
+	}
+
+	groups, err := db.getActualLRPS(logger, "instance_guid = ?", instanceGuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		logger.Error("failed-to-find-actual-lrp-group", models.ErrResourceNotFound)
+		return nil, models.ErrResourceNotFound
+	}
+
+	return groups[0], nil
+}
+
+// ActualLRPStateCounts returns the number of non-evacuating actual LRPs of the
+// given desired LRP in each state. States with no actual LRPs are omitted.
+func (db *SQLDB) ActualLRPStateCounts(logger lager.Logger, processGuid string) (map[models.ActualLRPState]int, error) {
+	logger = logger.Session("actual-lrp-state-counts", lager.Data{"process_guid": processGuid})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	rows, err := db.selectActualLRPStateCounts(logger, db.db, processGuid)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	counts := map[models.ActualLRPState]int{}
+	for rows.Next() {
+		var state string
+		var count int
+		err := rows.Scan(&state, &count)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			return nil, db.convertSQLError(err)
+		}
+		counts[models.ActualLRPState(state)] = count
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	return counts, nil
+}
+
+// A desired LRP with as many instances as an index can address, for which
+// IsValidIndex only rejects negative indices.
+var anyInstanceCount = &models.DesiredLRP{Instances: math.MaxInt32}
+
 func (db *SQLDB) CreateUnclaimedActualLRP(logger lager.Logger, key *models.ActualLRPKey) (*models.ActualLRPGroup, error) {
 	logger = logger.WithData(lager.Data{"key": key})
 	logger.Info("starting")
 	defer logger.Info("complete")
 
+	// Whether the index is beyond the desired instances is up to convergence,
+	// which retires such actual LRPs, but a negative index is never valid.
+	if !anyInstanceCount.IsValidIndex(key.Index) {
+		err := models.NewError(models.Error_InvalidRequest, "index cannot be negative")
+		logger.Error("invalid-index", err)
+		return nil, err
+	}
+
 	guid, err := db.guidProvider.NextGUID()
 	if err != nil {
 		logger.Error("failed-to-generate-guid", err)
@@ -154,6 +335,7 @@ func (db *SQLDB) UnclaimActualLRP(logger lager.Logger, key *models.ActualLRPKey)
 		actualLRP.ActualLRPInstanceKey.InstanceGuid = ""
 		actualLRP.Since = now
 		actualLRP.ActualLRPNetInfo = models.ActualLRPNetInfo{}
+		actualLRP.Annotation = ""
 		netInfoData, err := db.serializeModel(logger, &models.ActualLRPNetInfo{})
 		if err != nil {
 			logger.Error("failed-to-serialize-net-info", err)
@@ -168,6 +350,7 @@ func (db *SQLDB) UnclaimActualLRP(logger lager.Logger, key *models.ActualLRPKey)
 				"modification_tag_index": actualLRP.ModificationTag.Index,
 				"since":                  actualLRP.Since,
 				"net_info":               netInfoData,
+				"annotation":             actualLRP.Annotation,
 			},
 			"process_guid = ? AND instance_index = ? AND evacuating = ?",
 			processGuid, index, false,
@@ -192,56 +375,70 @@ func (db *SQLDB) ClaimActualLRP(logger lager.Logger, processGuid string, index i
 	var actualLRP *models.ActualLRP
 	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
 		var err error
-		actualLRP, err = db.fetchActualLRPForUpdate(logger, processGuid, index, false, tx)
-		if err != nil {
-			logger.Error("failed-fetching-actual-lrp-for-share", err)
-			return err
-		}
-		beforeActualLRP = *actualLRP
+		beforeActualLRP, actualLRP, err = db.claimActualLRP(logger, tx, processGuid, index, instanceKey)
+		return err
+	})
 
-		if !actualLRP.AllowsTransitionTo(&actualLRP.ActualLRPKey, instanceKey, models.ActualLRPStateClaimed) {
-			logger.Error("cannot-transition-to-claimed", nil, lager.Data{"from_state": actualLRP.State, "same_instance_key": actualLRP.ActualLRPInstanceKey.Equal(instanceKey)})
-			return models.ErrActualLRPCannotBeClaimed
-		}
+	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, err
+}
 
-		if actualLRP.State == models.ActualLRPStateClaimed && actualLRP.ActualLRPInstanceKey.Equal(instanceKey) {
-			return nil
-		}
+func (db *SQLDB) claimActualLRP(logger lager.Logger, tx *sql.Tx, processGuid string, index int32, instanceKey *models.ActualLRPInstanceKey) (models.ActualLRP, *models.ActualLRP, error) {
+	var beforeActualLRP models.ActualLRP
 
-		actualLRP.ModificationTag.Increment()
-		actualLRP.State = models.ActualLRPStateClaimed
-		actualLRP.ActualLRPInstanceKey = *instanceKey
-		actualLRP.PlacementError = ""
-		actualLRP.ActualLRPNetInfo = models.ActualLRPNetInfo{}
-		actualLRP.Since = db.clock.Now().UnixNano()
-		netInfoData, err := db.serializeModel(logger, &models.ActualLRPNetInfo{})
-		if err != nil {
-			logger.Error("failed-to-serialize-net-info", err)
-			return err
-		}
+	actualLRP, err := db.fetchActualLRPForUpdate(logger, processGuid, index, false, tx)
+	if err != nil {
+		logger.Error("failed-fetching-actual-lrp-for-share", err)
+		return beforeActualLRP, nil, err
+	}
+	beforeActualLRP = *actualLRP
 
-		_, err = db.update(logger, tx, actualLRPsTable,
-			helpers.SQLAttributes{
-				"state":                  actualLRP.State,
-				"cell_id":                actualLRP.CellId,
-				"instance_guid":          actualLRP.InstanceGuid,
-				"modification_tag_index": actualLRP.ModificationTag.Index,
-				"placement_error":        actualLRP.PlacementError,
-				"since":                  actualLRP.Since,
-				"net_info":               netInfoData,
-			},
-			"process_guid = ? AND instance_index = ? AND evacuating = ?",
-			processGuid, index, false,
-		)
-		if err != nil {
-			logger.Error("failed-claiming-actual-lrp", err)
-			return err
-		}
+	if !actualLRP.AllowsTransitionTo(&actualLRP.ActualLRPKey, instanceKey, models.ActualLRPStateClaimed) {
+		logger.Error("cannot-transition-to-claimed", nil, lager.Data{"from_state": actualLRP.State, "same_instance_key": actualLRP.ActualLRPInstanceKey.Equal(instanceKey)})
+		return beforeActualLRP, actualLRP, models.ErrActualLRPCannotBeClaimed
+	}
 
-		return nil
-	})
+	if actualLRP.State == models.ActualLRPStateClaimed && actualLRP.ActualLRPInstanceKey.Equal(instanceKey) {
+		return beforeActualLRP, actualLRP, nil
+	}
 
-	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, err
+	annotation, err := db.desiredLRPAnnotation(logger, tx, processGuid)
+	if err != nil {
+		return beforeActualLRP, actualLRP, err
+	}
+
+	actualLRP.ModificationTag.Increment()
+	actualLRP.State = models.ActualLRPStateClaimed
+	actualLRP.ActualLRPInstanceKey = *instanceKey
+	actualLRP.PlacementError = ""
+	actualLRP.ActualLRPNetInfo = models.ActualLRPNetInfo{}
+	actualLRP.Since = db.clock.Now().UnixNano()
+	actualLRP.Annotation = annotation
+	netInfoData, err := db.serializeModel(logger, &models.ActualLRPNetInfo{})
+	if err != nil {
+		logger.Error("failed-to-serialize-net-info", err)
+		return beforeActualLRP, actualLRP, err
+	}
+
+	_, err = db.update(logger, tx, actualLRPsTable,
+		helpers.SQLAttributes{
+			"state":                  actualLRP.State,
+			"cell_id":                actualLRP.CellId,
+			"instance_guid":          actualLRP.InstanceGuid,
+			"modification_tag_index": actualLRP.ModificationTag.Index,
+			"placement_error":        actualLRP.PlacementError,
+			"since":                  actualLRP.Since,
+			"net_info":               netInfoData,
+			"annotation":             actualLRP.Annotation,
+		},
+		"process_guid = ? AND instance_index = ? AND evacuating = ?",
+		processGuid, index, false,
+	)
+	if err != nil {
+		logger.Error("failed-claiming-actual-lrp", err)
+		return beforeActualLRP, actualLRP, err
+	}
+
+	return beforeActualLRP, actualLRP, nil
 }
 
 func (db *SQLDB) StartActualLRP(logger lager.Logger, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) (*models.ActualLRPGroup, *models.ActualLRPGroup, error) {
@@ -252,73 +449,172 @@ func (db *SQLDB) StartActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 
 	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
 		var err error
-		actualLRP, err = db.fetchActualLRPForUpdate(logger, key.ProcessGuid, key.Index, false, tx)
-		if err == models.ErrResourceNotFound {
-			actualLRP, err = db.createRunningActualLRP(logger, key, instanceKey, netInfo, tx)
-			return err
-		}
+		beforeActualLRP, actualLRP, err = db.startActualLRP(logger, tx, key, instanceKey, netInfo)
+		return err
+	})
 
-		if err != nil {
-			logger.Error("failed-to-get-actual-lrp", err)
-			return err
-		}
+	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, err
+}
 
-		beforeActualLRP = *actualLRP
+func (db *SQLDB) startActualLRP(logger lager.Logger, tx *sql.Tx, key *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey, netInfo *models.ActualLRPNetInfo) (models.ActualLRP, *models.ActualLRP, error) {
+	var beforeActualLRP models.ActualLRP
 
-		if actualLRP.ActualLRPKey.Equal(key) &&
-			actualLRP.ActualLRPInstanceKey.Equal(instanceKey) &&
-			actualLRP.ActualLRPNetInfo.Equal(netInfo) &&
-			actualLRP.State == models.ActualLRPStateRunning {
-			logger.Debug("nothing-to-change")
-			return nil
-		}
+	actualLRP, err := db.fetchActualLRPForUpdate(logger, key.ProcessGuid, key.Index, false, tx)
+	if err == models.ErrResourceNotFound {
+		actualLRP, err = db.createRunningActualLRP(logger, key, instanceKey, netInfo, tx)
+		return beforeActualLRP, actualLRP, err
+	}
 
-		if !actualLRP.AllowsTransitionTo(key, instanceKey, models.ActualLRPStateRunning) {
-			logger.Error("failed-to-transition-actual-lrp-to-started", nil)
-			return models.ErrActualLRPCannotBeStarted
-		}
+	if err != nil {
+		logger.Error("failed-to-get-actual-lrp", err)
+		return beforeActualLRP, actualLRP, err
+	}
 
-		logger.Info("starting")
-		defer logger.Info("completed")
+	beforeActualLRP = *actualLRP
 
-		now := db.clock.Now().UnixNano()
-		evacuating := false
+	if actualLRP.ActualLRPKey.Equal(key) &&
+		actualLRP.ActualLRPInstanceKey.Equal(instanceKey) &&
+		actualLRP.ActualLRPNetInfo.Equal(netInfo) &&
+		actualLRP.State == models.ActualLRPStateRunning {
+		logger.Debug("nothing-to-change")
+		return beforeActualLRP, actualLRP, nil
+	}
 
-		actualLRP.ActualLRPInstanceKey = *instanceKey
-		actualLRP.ActualLRPNetInfo = *netInfo
-		actualLRP.State = models.ActualLRPStateRunning
-		actualLRP.Since = now
-		actualLRP.ModificationTag.Increment()
-		actualLRP.PlacementError = ""
+	if !actualLRP.AllowsTransitionTo(key, instanceKey, models.ActualLRPStateRunning) {
+		logger.Error("failed-to-transition-actual-lrp-to-started", nil)
+		return beforeActualLRP, actualLRP, models.ErrActualLRPCannotBeStarted
+	}
 
-		netInfoData, err := db.serializeModel(logger, &actualLRP.ActualLRPNetInfo)
-		if err != nil {
-			logger.Error("failed-to-serialize-net-info", err)
-			return err
-		}
+	logger.Info("starting")
+	defer logger.Info("completed")
 
-		_, err = db.update(logger, tx, actualLRPsTable,
-			helpers.SQLAttributes{
-				"state":                  actualLRP.State,
-				"cell_id":                actualLRP.CellId,
-				"instance_guid":          actualLRP.InstanceGuid,
-				"modification_tag_index": actualLRP.ModificationTag.Index,
-				"placement_error":        actualLRP.PlacementError,
-				"since":                  actualLRP.Since,
-				"net_info":               netInfoData,
-			},
-			"process_guid = ? AND instance_index = ? AND evacuating = ?",
-			key.ProcessGuid, key.Index, evacuating,
-		)
+	// An instance that was claimed already runs the version it was claimed
+	// for; one that starts without being claimed runs the current version.
+	if !actualLRP.ActualLRPInstanceKey.Equal(instanceKey) {
+		annotation, err := db.desiredLRPAnnotation(logger, tx, key.ProcessGuid)
 		if err != nil {
-			logger.Error("failed-starting-actual-lrp", err)
-			return err
+			return beforeActualLRP, actualLRP, err
 		}
+		actualLRP.Annotation = annotation
+	}
 
+	now := db.clock.Now().UnixNano()
+	evacuating := false
+
+	actualLRP.ActualLRPInstanceKey = *instanceKey
+	actualLRP.ActualLRPNetInfo = *netInfo
+	actualLRP.State = models.ActualLRPStateRunning
+	actualLRP.Since = now
+	actualLRP.ModificationTag.Increment()
+	actualLRP.PlacementError = ""
+
+	netInfoData, err := db.serializeModel(logger, &actualLRP.ActualLRPNetInfo)
+	if err != nil {
+		logger.Error("failed-to-serialize-net-info", err)
+		return beforeActualLRP, actualLRP, err
+	}
+
+	_, err = db.update(logger, tx, actualLRPsTable,
+		helpers.SQLAttributes{
+			"state":                  actualLRP.State,
+			"cell_id":                actualLRP.CellId,
+			"instance_guid":          actualLRP.InstanceGuid,
+			"modification_tag_index": actualLRP.ModificationTag.Index,
+			"placement_error":        actualLRP.PlacementError,
+			"since":                  actualLRP.Since,
+			"net_info":               netInfoData,
+			"annotation":             actualLRP.Annotation,
+		},
+		"process_guid = ? AND instance_index = ? AND evacuating = ?",
+		key.ProcessGuid, key.Index, evacuating,
+	)
+	if err != nil {
+		logger.Error("failed-starting-actual-lrp", err)
+		return beforeActualLRP, actualLRP, err
+	}
+
+	return beforeActualLRP, actualLRP, nil
+}
+
+// ApplyActualLRPLifecycleOperations applies the given claims and starts in
+// order, in a single transaction, and returns the result of each. An
+// operation that is invalid, or whose actual LRP is missing or cannot make
+// the transition, fails on its own; any other error rolls back the whole
+// batch and is returned instead.
+func (db *SQLDB) ApplyActualLRPLifecycleOperations(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]*models.ActualLRPLifecycleOperationResult, error) {
+	logger = logger.Session("apply-actual-lrp-lifecycle-operations", lager.Data{"operations": len(operations)})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	var results []*models.ActualLRPLifecycleOperationResult
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		results = make([]*models.ActualLRPLifecycleOperationResult, 0, len(operations))
+		for _, operation := range operations {
+			result, err := db.applyActualLRPLifecycleOperation(logger, tx, operation)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, err
+	return results, nil
+}
+
+func (db *SQLDB) applyActualLRPLifecycleOperation(logger lager.Logger, tx *sql.Tx, operation *models.ActualLRPLifecycleOperation) (*models.ActualLRPLifecycleOperationResult, error) {
+	if err := operation.Validate(); err != nil {
+		logger.Error("invalid-operation", err)
+		return &models.ActualLRPLifecycleOperationResult{Error: models.NewError(models.Error_InvalidRequest, err.Error())}, nil
+	}
+
+	var beforeActualLRP models.ActualLRP
+	var actualLRP *models.ActualLRP
+	var err error
+	if claim := operation.Claim; claim != nil {
+		claimLogger := logger.WithData(lager.Data{"process_guid": claim.ProcessGuid, "index": claim.Index, "instance_key": claim.ActualLrpInstanceKey})
+		beforeActualLRP, actualLRP, err = db.claimActualLRP(claimLogger, tx, claim.ProcessGuid, claim.Index, claim.ActualLrpInstanceKey)
+	} else {
+		start := operation.Start
+		startLogger := logger.WithData(lager.Data{"actual_lrp_key": start.ActualLrpKey, "actual_lrp_instance_key": start.ActualLrpInstanceKey, "net_info": start.ActualLrpNetInfo})
+		beforeActualLRP, actualLRP, err = db.startActualLRP(startLogger, tx, start.ActualLrpKey, start.ActualLrpInstanceKey, start.ActualLrpNetInfo)
+	}
+
+	switch err {
+	case nil:
+		return &models.ActualLRPLifecycleOperationResult{
+			Before: &models.ActualLRPGroup{Instance: &beforeActualLRP},
+			After:  &models.ActualLRPGroup{Instance: actualLRP},
+		}, nil
+	case models.ErrResourceNotFound, models.ErrActualLRPCannotBeClaimed, models.ErrActualLRPCannotBeStarted:
+		return &models.ActualLRPLifecycleOperationResult{Error: err}, nil
+	default:
+		return nil, err
+	}
+}
+
+// Returns the annotation of the desired LRP, which carries the version that an
+// instance claiming or starting now runs. Actual LRPs without a desired LRP
+// have no version.
+func (db *SQLDB) desiredLRPAnnotation(logger lager.Logger, tx *sql.Tx, processGuid string) (string, error) {
+	row := db.one(logger, tx, desiredLRPsTable,
+		helpers.ColumnList{"annotation"}, helpers.NoLockRow,
+		"process_guid = ?", processGuid,
+	)
+
+	var annotation sql.NullString
+	err := row.Scan(&annotation)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		logger.Error("failed-fetching-desired-lrp-annotation", err)
+		return "", db.convertSQLError(err)
+	}
+	return annotation.String, nil
 }
 
 func truncateString(s string, maxLen int) string {
@@ -335,6 +631,7 @@ func (db *SQLDB) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 	defer logger.Info("complete")
 
 	var immediateRestart = false
+	var firstCrash = false
 	var beforeActualLRP models.ActualLRP
 	var actualLRP *models.ActualLRP
 
@@ -367,6 +664,7 @@ func (db *SQLDB) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 		actualLRP.ActualLRPInstanceKey.InstanceGuid = ""
 		actualLRP.ActualLRPInstanceKey.CellId = ""
 		actualLRP.ActualLRPNetInfo = models.ActualLRPNetInfo{}
+		actualLRP.Annotation = ""
 		actualLRP.CrashCount = newCrashCount
 		actualLRP.CrashReason = crashReason
 		netInfoData, err := db.serializeModel(logger, &actualLRP.ActualLRPNetInfo)
@@ -394,6 +692,7 @@ func (db *SQLDB) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 				"crash_reason":           truncateString(actualLRP.CrashReason, 1024),
 				"since":                  actualLRP.Since,
 				"net_info":               netInfoData,
+				"annotation":             actualLRP.Annotation,
 			},
 			"process_guid = ? AND instance_index = ? AND evacuating = ?",
 			key.ProcessGuid, key.Index, evacuating,
@@ -403,9 +702,14 @@ func (db *SQLDB) CrashActualLRP(logger lager.Logger, key *models.ActualLRPKey, i
 			return err
 		}
 
+		firstCrash = newCrashCount == 1
 		return nil
 	})
 
+	if err == nil && firstCrash {
+		db.recordFirstCrash(key.ProcessGuid)
+	}
+
 	return &models.ActualLRPGroup{Instance: &beforeActualLRP}, &models.ActualLRPGroup{Instance: actualLRP}, immediateRestart, err
 }
 
@@ -510,6 +814,11 @@ func (db *SQLDB) createRunningActualLRP(logger lager.Logger, key *models.ActualL
 	actualLRP.State = models.ActualLRPStateRunning
 	actualLRP.Since = now
 
+	actualLRP.Annotation, err = db.desiredLRPAnnotation(logger, tx, key.ProcessGuid)
+	if err != nil {
+		return nil, err
+	}
+
 	netInfoData, err := db.serializeModel(logger, &actualLRP.ActualLRPNetInfo)
 	if err != nil {
 		return nil, err
@@ -527,6 +836,7 @@ func (db *SQLDB) createRunningActualLRP(logger lager.Logger, key *models.ActualL
 			"since":                  actualLRP.Since,
 			"modification_tag_epoch": actualLRP.ModificationTag.Epoch,
 			"modification_tag_index": actualLRP.ModificationTag.Index,
+			"annotation":             actualLRP.Annotation,
 		},
 	)
 	if err != nil {
@@ -540,6 +850,7 @@ func (db *SQLDB) scanToActualLRP(logger lager.Logger, row RowScanner) (*models.A
 	var netInfoData []byte
 	var actualLRP models.ActualLRP
 	var evacuating bool
+	var annotation sql.NullString
 
 	err := row.Scan(
 		&actualLRP.ProcessGuid,
@@ -556,11 +867,13 @@ func (db *SQLDB) scanToActualLRP(logger lager.Logger, row RowScanner) (*models.A
 		&actualLRP.ModificationTag.Index,
 		&actualLRP.CrashCount,
 		&actualLRP.CrashReason,
+		&annotation,
 	)
 	if err != nil {
 		logger.Error("failed-scanning-actual-lrp", err)
 		return nil, false, err
 	}
+	actualLRP.Annotation = annotation.String
 
 	if len(netInfoData) > 0 {
 		logger.Debug("unmarshalling-net-info-data", lager.Data{"net_info": string(netInfoData)})
@@ -644,6 +957,12 @@ func (db *SQLDB) scanAndCleanupActualLRPs(logger lager.Logger, q Queryable, rows
 		return nil, db.convertSQLError(rows.Err())
 	}
 
+	db.deleteInvalidActualLRPs(logger, q, actualsToDelete)
+
+	return result, nil
+}
+
+func (db *SQLDB) deleteInvalidActualLRPs(logger lager.Logger, q Queryable, actualsToDelete []*actualToDelete) {
 	for _, actual := range actualsToDelete {
 		_, err := db.delete(logger, q, actualLRPsTable,
 			"process_guid = ? AND instance_index = ? AND evacuating = ?",
@@ -653,6 +972,4 @@ func (db *SQLDB) scanAndCleanupActualLRPs(logger lager.Logger, q Queryable, rows
 			logger.Error("failed-cleaning-up-invalid-actual-lrp", err)
 		}
 	}
-
-	return result, nil
 }
@@ -2,6 +2,7 @@ package sqldb_test
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -70,6 +71,25 @@ var _ = Describe("ActualLRPDB", func() {
 				Expect(err).To(Equal(models.ErrResourceExists))
 			})
 		})
+
+		Context("when the index is negative", func() {
+			BeforeEach(func() {
+				key.Index = -1
+			})
+
+			It("returns an invalid request error", func() {
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, key)
+				Expect(err).To(HaveOccurred())
+				Expect(models.ConvertError(err).Type).To(Equal(models.Error_InvalidRequest))
+			})
+
+			It("does not persist the actual lrp", func() {
+				sqlDB.CreateUnclaimedActualLRP(logger, key)
+
+				_, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, key.ProcessGuid, key.Index)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
 	})
 
 	Describe("ActualLRPGroupByProcessGuidAndIndex", func() {
@@ -518,6 +538,339 @@ var _ = Describe("ActualLRPDB", func() {
 				Expect(actualLRPGroups).To(HaveLen(0))
 			})
 		})
+
+		Describe("StreamActualLRPGroupsByProcessGuid", func() {
+			It("calls the callback once with each actual lrp group of the process guid", func() {
+				streamedGroups := []*models.ActualLRPGroup{}
+				err := sqlDB.StreamActualLRPGroupsByProcessGuid(logger, "guid1", func(group *models.ActualLRPGroup) error {
+					streamedGroups = append(streamedGroups, group)
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(streamedGroups).To(Equal(allActualLRPGroups[:2]))
+			})
+
+			Context("when the callback returns an error", func() {
+				It("stops iterating and returns the error", func() {
+					callbackErr := errors.New("boom")
+					calls := 0
+					err := sqlDB.StreamActualLRPGroupsByProcessGuid(logger, "guid1", func(group *models.ActualLRPGroup) error {
+						calls++
+						return callbackErr
+					})
+					Expect(err).To(Equal(callbackErr))
+					Expect(calls).To(Equal(1))
+				})
+			})
+
+			Context("when no actual lrps exist for the process guid", func() {
+				It("never calls the callback", func() {
+					err := sqlDB.StreamActualLRPGroupsByProcessGuid(logger, "guid3", func(group *models.ActualLRPGroup) error {
+						Fail("unexpected actual lrp group")
+						return nil
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+
+	Describe("ActualLRPGroupsByProcessGuidAndIndexRange", func() {
+		BeforeEach(func() {
+			fakeGUIDProvider.NextGUIDReturns("mod-tag-guid", nil)
+
+			for i := int32(0); i < 10; i++ {
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: "guid1", Index: i, Domain: "domain1"})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			_, err := sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: "guid2", Index: 4, Domain: "domain1"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		indices := func(groups []*models.ActualLRPGroup) []int32 {
+			indices := []int32{}
+			for _, group := range groups {
+				actual, _ := group.Resolve()
+				Expect(actual.ProcessGuid).To(Equal("guid1"))
+				indices = append(indices, actual.Index)
+			}
+			return indices
+		}
+
+		It("returns the actual lrp groups with an index from the start index up to the end index", func() {
+			actualLRPGroups, err := sqlDB.ActualLRPGroupsByProcessGuidAndIndexRange(logger, "guid1", 3, 7)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indices(actualLRPGroups)).To(ConsistOf(int32(3), int32(4), int32(5), int32(6)))
+		})
+
+		Context("when the range is empty", func() {
+			It("returns an empty slice", func() {
+				actualLRPGroups, err := sqlDB.ActualLRPGroupsByProcessGuidAndIndexRange(logger, "guid1", 5, 5)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroups).To(BeEmpty())
+			})
+		})
+
+		Context("when the range is past the last index", func() {
+			It("returns an empty slice", func() {
+				actualLRPGroups, err := sqlDB.ActualLRPGroupsByProcessGuidAndIndexRange(logger, "guid1", 10, 20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroups).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ActualLRPGroupsByDomain", func() {
+		BeforeEach(func() {
+			fakeGUIDProvider.NextGUIDReturns("mod-tag-guid", nil)
+
+			keys := []*models.ActualLRPKey{
+				{ProcessGuid: "guid1", Index: 0, Domain: "domain1"},
+				{ProcessGuid: "guid1", Index: 1, Domain: "domain1"},
+				{ProcessGuid: "guid2", Index: 0, Domain: "domain1"},
+				{ProcessGuid: "guid3", Index: 0, Domain: "domain2"},
+				{ProcessGuid: "guid4", Index: 0, Domain: "domain3"},
+			}
+			for _, key := range keys {
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, key)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			queryStr := "UPDATE actual_lrps SET evacuating = ? WHERE process_guid = ? AND instance_index = ? AND evacuating = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			_, err := db.Exec(queryStr, true, "guid1", 1, false)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns only the actual lrp groups in the chosen domain", func() {
+			actualLRPGroups, err := sqlDB.ActualLRPGroupsByDomain(logger, "domain1")
+			Expect(err).NotTo(HaveOccurred())
+
+			keys := []models.ActualLRPKey{}
+			for _, group := range actualLRPGroups {
+				actual, _ := group.Resolve()
+				keys = append(keys, actual.ActualLRPKey)
+			}
+			Expect(keys).To(ConsistOf(
+				models.NewActualLRPKey("guid1", 0, "domain1"),
+				models.NewActualLRPKey("guid1", 1, "domain1"),
+				models.NewActualLRPKey("guid2", 0, "domain1"),
+			))
+
+			actualLRPGroups, err = sqlDB.ActualLRPGroupsByDomain(logger, "domain2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroups).To(HaveLen(1))
+			Expect(actualLRPGroups[0].Instance.ActualLRPKey).To(Equal(models.NewActualLRPKey("guid3", 0, "domain2")))
+		})
+
+		It("returns evacuating actual lrps in the domain", func() {
+			actualLRPGroups, err := sqlDB.ActualLRPGroupsByDomain(logger, "domain1")
+			Expect(err).NotTo(HaveOccurred())
+
+			var evacuating *models.ActualLRP
+			for _, group := range actualLRPGroups {
+				if group.Evacuating != nil {
+					evacuating = group.Evacuating
+				}
+			}
+			Expect(evacuating).NotTo(BeNil())
+			Expect(evacuating.ActualLRPKey).To(Equal(models.NewActualLRPKey("guid1", 1, "domain1")))
+		})
+
+		Context("when no actual lrps exist in the domain", func() {
+			It("returns an empty slice", func() {
+				actualLRPGroups, err := sqlDB.ActualLRPGroupsByDomain(logger, "domain4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroups).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ActualLRPGroupsByState", func() {
+		BeforeEach(func() {
+			fakeGUIDProvider.NextGUIDReturns("mod-tag-guid", nil)
+
+			keys := []*models.ActualLRPKey{
+				{ProcessGuid: "unclaimed-guid", Index: 0, Domain: "domain"},
+				{ProcessGuid: "unclaimed-guid", Index: 1, Domain: "domain"},
+				{ProcessGuid: "claimed-guid", Index: 0, Domain: "domain"},
+				{ProcessGuid: "crashed-guid", Index: 0, Domain: "domain"},
+				{ProcessGuid: "crashed-guid", Index: 1, Domain: "domain"},
+			}
+			for _, key := range keys {
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, key)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, _, err := sqlDB.ClaimActualLRP(logger, "claimed-guid", 0, &models.ActualLRPInstanceKey{InstanceGuid: "instance-guid", CellId: "cell-id"})
+			Expect(err).NotTo(HaveOccurred())
+
+			queryStr := "UPDATE actual_lrps SET state = ? WHERE process_guid = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			_, err = db.Exec(queryStr, models.ActualLRPStateCrashed, "crashed-guid")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		resolvedKeys := func(groups []*models.ActualLRPGroup) []models.ActualLRPKey {
+			keys := []models.ActualLRPKey{}
+			for _, group := range groups {
+				actual, _ := group.Resolve()
+				keys = append(keys, actual.ActualLRPKey)
+			}
+			return keys
+		}
+
+		It("returns only the crashed actual lrp groups", func() {
+			actualLRPGroups, err := sqlDB.ActualLRPGroupsByState(logger, models.ActualLRPStateCrashed)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resolvedKeys(actualLRPGroups)).To(ConsistOf(
+				models.NewActualLRPKey("crashed-guid", 0, "domain"),
+				models.NewActualLRPKey("crashed-guid", 1, "domain"),
+			))
+			for _, group := range actualLRPGroups {
+				Expect(group.Instance.State).To(Equal(models.ActualLRPStateCrashed))
+			}
+		})
+
+		It("returns only the unclaimed actual lrp groups", func() {
+			actualLRPGroups, err := sqlDB.ActualLRPGroupsByState(logger, models.ActualLRPStateUnclaimed)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resolvedKeys(actualLRPGroups)).To(ConsistOf(
+				models.NewActualLRPKey("unclaimed-guid", 0, "domain"),
+				models.NewActualLRPKey("unclaimed-guid", 1, "domain"),
+			))
+		})
+
+		Context("when no actual lrps are in the state", func() {
+			It("returns an empty slice", func() {
+				actualLRPGroups, err := sqlDB.ActualLRPGroupsByState(logger, models.ActualLRPStateRunning)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroups).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ActualLRPByInstanceGuid", func() {
+		var (
+			key         *models.ActualLRPKey
+			instanceKey *models.ActualLRPInstanceKey
+		)
+
+		BeforeEach(func() {
+			fakeGUIDProvider.NextGUIDReturns("mod-tag-guid", nil)
+
+			key = &models.ActualLRPKey{ProcessGuid: "guid1", Index: 1, Domain: "domain1"}
+			instanceKey = &models.ActualLRPInstanceKey{InstanceGuid: "instance-guid-1", CellId: "cell-1"}
+
+			_, err := sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: "guid1", Index: 0, Domain: "domain1"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, key)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = sqlDB.ClaimActualLRP(logger, key.ProcessGuid, key.Index, instanceKey)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the actual lrp group running the instance guid", func() {
+			group, err := sqlDB.ActualLRPByInstanceGuid(logger, "instance-guid-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Instance).NotTo(BeNil())
+			Expect(group.Instance.ActualLRPKey).To(Equal(*key))
+			Expect(group.Instance.ActualLRPInstanceKey).To(Equal(*instanceKey))
+			Expect(group.Evacuating).To(BeNil())
+		})
+
+		Context("when no actual lrp has the instance guid", func() {
+			It("returns a resource not found error", func() {
+				group, err := sqlDB.ActualLRPByInstanceGuid(logger, "missing-instance-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+				Expect(group).To(BeNil())
+			})
+		})
+
+		Context("when the instance guid is empty", func() {
+			It("does not return an unclaimed actual lrp", func() {
+				group, err := sqlDB.ActualLRPByInstanceGuid(logger, "")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+				Expect(group).To(BeNil())
+			})
+		})
+	})
+
+	Describe("ActualLRPStateCounts", func() {
+		const processGuid = "mixed-states-guid"
+
+		BeforeEach(func() {
+			for i := int32(0); i < 5; i++ {
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: processGuid, Index: i, Domain: "domain"})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, _, err := sqlDB.ClaimActualLRP(logger, processGuid, 1, &models.ActualLRPInstanceKey{InstanceGuid: "instance-1", CellId: "cell"})
+			Expect(err).NotTo(HaveOccurred())
+
+			netInfo := models.NewActualLRPNetInfo("1.2.3.4", "2.2.2.2", models.NewPortMapping(5678, 8080))
+			for i := int32(2); i < 4; i++ {
+				_, _, err = sqlDB.StartActualLRP(logger,
+					&models.ActualLRPKey{ProcessGuid: processGuid, Index: i, Domain: "domain"},
+					&models.ActualLRPInstanceKey{InstanceGuid: fmt.Sprintf("instance-%d", i), CellId: "cell"},
+					&netInfo,
+				)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			queryStr := "UPDATE actual_lrps SET state = ? WHERE process_guid = ? AND instance_index = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			_, err = db.Exec(queryStr, models.ActualLRPStateCrashed, processGuid, 4)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: "other-guid", Index: 0, Domain: "domain"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the actual lrps of the desired lrp in each state", func() {
+			counts, err := sqlDB.ActualLRPStateCounts(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[models.ActualLRPState]int{
+				models.ActualLRPStateUnclaimed: 1,
+				models.ActualLRPStateClaimed:   1,
+				models.ActualLRPStateRunning:   2,
+				models.ActualLRPStateCrashed:   1,
+			}))
+		})
+
+		Context("when some of the actual lrps are evacuating", func() {
+			BeforeEach(func() {
+				queryStr := "UPDATE actual_lrps SET evacuating = ? WHERE process_guid = ? AND instance_index = ?"
+				if test_helpers.UsePostgres() {
+					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+				}
+				_, err := db.Exec(queryStr, true, processGuid, 2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not count the evacuating actual lrps", func() {
+				counts, err := sqlDB.ActualLRPStateCounts(logger, processGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts[models.ActualLRPStateRunning]).To(Equal(1))
+			})
+		})
+
+		Context("when the desired lrp has no actual lrps", func() {
+			It("returns an empty map", func() {
+				counts, err := sqlDB.ActualLRPStateCounts(logger, "missing-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("ClaimActualLRP", func() {
@@ -1123,6 +1476,237 @@ var _ = Describe("ActualLRPDB", func() {
 		})
 	})
 
+	Describe("actual LRP annotations", func() {
+		var (
+			instanceKey models.ActualLRPInstanceKey
+			netInfo     models.ActualLRPNetInfo
+		)
+
+		annotationOf := func(index int32) string {
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "the-guid", index)
+			Expect(err).NotTo(HaveOccurred())
+			return actualLRPGroup.Instance.Annotation
+		}
+
+		BeforeEach(func() {
+			instanceKey = models.NewActualLRPInstanceKey("instance-guid", "cell-id")
+			netInfo = models.NewActualLRPNetInfo("1.2.3.4", "2.2.2.2", models.NewPortMapping(5678, 8080))
+
+			desiredLRP := model_helpers.NewValidDesiredLRP("the-guid")
+			desiredLRP.Domain = "the-domain"
+			desiredLRP.Instances = 2
+			desiredLRP.Annotation = "v1"
+			Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+
+			for i := int32(0); i < 2; i++ {
+				key := models.NewActualLRPKey("the-guid", i, "the-domain")
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, &key)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("records the desired annotation when an instance is claimed", func() {
+			_, after, err := sqlDB.ClaimActualLRP(logger, "the-guid", 0, &instanceKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after.Instance.Annotation).To(Equal("v1"))
+			Expect(annotationOf(0)).To(Equal("v1"))
+		})
+
+		It("keeps the annotation of the claim when the claimed instance starts", func() {
+			_, _, err := sqlDB.ClaimActualLRP(logger, "the-guid", 0, &instanceKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			annotation := "v2"
+			_, err = sqlDB.UpdateDesiredLRP(logger, "the-guid", &models.DesiredLRPUpdate{Annotation: &annotation})
+			Expect(err).NotTo(HaveOccurred())
+
+			key := models.NewActualLRPKey("the-guid", 0, "the-domain")
+			_, _, err = sqlDB.StartActualLRP(logger, &key, &instanceKey, &netInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annotationOf(0)).To(Equal("v1"))
+		})
+
+		It("records the desired annotation when an unclaimed instance starts", func() {
+			key := models.NewActualLRPKey("the-guid", 1, "the-domain")
+			_, _, err := sqlDB.StartActualLRP(logger, &key, &instanceKey, &netInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annotationOf(1)).To(Equal("v1"))
+		})
+
+		It("clears the annotation when the instance is unclaimed or crashes", func() {
+			for i := int32(0); i < 2; i++ {
+				key := models.NewActualLRPKey("the-guid", i, "the-domain")
+				_, _, err := sqlDB.StartActualLRP(logger, &key, &instanceKey, &netInfo)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			key := models.NewActualLRPKey("the-guid", 0, "the-domain")
+			_, _, err := sqlDB.UnclaimActualLRP(logger, &key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annotationOf(0)).To(BeEmpty())
+
+			key = models.NewActualLRPKey("the-guid", 1, "the-domain")
+			_, _, _, err = sqlDB.CrashActualLRP(logger, &key, &instanceKey, "crashed")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annotationOf(1)).To(BeEmpty())
+		})
+
+		It("carries the annotation of an evacuating instance", func() {
+			key := models.NewActualLRPKey("the-guid", 0, "the-domain")
+			_, _, err := sqlDB.StartActualLRP(logger, &key, &instanceKey, &netInfo)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.EvacuateActualLRP(logger, &key, &instanceKey, &netInfo, 60)
+			Expect(err).NotTo(HaveOccurred())
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "the-guid", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Evacuating.Annotation).To(Equal("v1"))
+		})
+
+		It("reports the update progress of a desired LRP read from the database", func() {
+			key := models.NewActualLRPKey("the-guid", 0, "the-domain")
+			_, _, err := sqlDB.StartActualLRP(logger, &key, &instanceKey, &netInfo)
+			Expect(err).NotTo(HaveOccurred())
+
+			annotation := "v2"
+			_, err = sqlDB.UpdateDesiredLRP(logger, "the-guid", &models.DesiredLRPUpdate{Annotation: &annotation})
+			Expect(err).NotTo(HaveOccurred())
+
+			key = models.NewActualLRPKey("the-guid", 1, "the-domain")
+			otherInstanceKey := models.NewActualLRPInstanceKey("other-instance-guid", "cell-id")
+			_, _, err = sqlDB.StartActualLRP(logger, &key, &otherInstanceKey, &netInfo)
+			Expect(err).NotTo(HaveOccurred())
+
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, "the-guid")
+			Expect(err).NotTo(HaveOccurred())
+			groups, err := sqlDB.ActualLRPGroupsByProcessGuid(logger, "the-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			current, target := desiredLRP.UpdateProgress(groups)
+			Expect(current).To(Equal(1))
+			Expect(target).To(Equal(2))
+		})
+	})
+
+	Describe("ApplyActualLRPLifecycleOperations", func() {
+		var instanceKeyA, instanceKeyB *models.ActualLRPInstanceKey
+		var netInfo models.ActualLRPNetInfo
+
+		claim := func(processGuid string, index int32, instanceKey *models.ActualLRPInstanceKey) *models.ActualLRPLifecycleOperation {
+			return &models.ActualLRPLifecycleOperation{
+				Claim: &models.ClaimActualLRPRequest{ProcessGuid: processGuid, Index: index, ActualLrpInstanceKey: instanceKey},
+			}
+		}
+
+		start := func(index int32, instanceKey *models.ActualLRPInstanceKey) *models.ActualLRPLifecycleOperation {
+			key := models.NewActualLRPKey("the-guid", index, "the-domain")
+			return &models.ActualLRPLifecycleOperation{
+				Start: &models.StartActualLRPRequest{ActualLrpKey: &key, ActualLrpInstanceKey: instanceKey, ActualLrpNetInfo: &netInfo},
+			}
+		}
+
+		BeforeEach(func() {
+			instanceKeyA = &models.ActualLRPInstanceKey{InstanceGuid: "instance-guid-a", CellId: "cell-a"}
+			instanceKeyB = &models.ActualLRPInstanceKey{InstanceGuid: "instance-guid-b", CellId: "cell-b"}
+			netInfo = models.NewActualLRPNetInfo("1.2.3.4", "2.2.2.2", models.NewPortMapping(5678, 8080))
+
+			for i := int32(0); i < 3; i++ {
+				key := models.NewActualLRPKey("the-guid", i, "the-domain")
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, &key)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			fakeClock.Increment(time.Hour)
+		})
+
+		It("applies the valid operations and fails the others on their own", func() {
+			results, err := sqlDB.ApplyActualLRPLifecycleOperations(logger, []*models.ActualLRPLifecycleOperation{
+				claim("the-guid", 0, instanceKeyA),
+				claim("", 0, instanceKeyA),
+				start(0, instanceKeyA),
+				claim("the-guid", 0, instanceKeyB),
+				claim("the-guid", 5, instanceKeyA),
+				{},
+				start(1, instanceKeyB),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(7))
+
+			Expect(results[0].Error).NotTo(HaveOccurred())
+			Expect(results[0].Before.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			Expect(results[0].After.Instance.State).To(Equal(models.ActualLRPStateClaimed))
+			Expect(models.ConvertError(results[1].Error).Type).To(Equal(models.Error_InvalidRequest))
+			Expect(results[2].Error).NotTo(HaveOccurred())
+			Expect(results[2].Before.Instance.State).To(Equal(models.ActualLRPStateClaimed))
+			Expect(results[2].After.Instance.State).To(Equal(models.ActualLRPStateRunning))
+			Expect(results[3].Error).To(Equal(models.ErrActualLRPCannotBeClaimed))
+			Expect(results[4].Error).To(Equal(models.ErrResourceNotFound))
+			Expect(models.ConvertError(results[5].Error).Type).To(Equal(models.Error_InvalidRequest))
+			Expect(results[6].Error).NotTo(HaveOccurred())
+
+			for _, result := range []*models.ActualLRPLifecycleOperationResult{results[1], results[3], results[4], results[5]} {
+				Expect(result.Before).To(BeNil())
+				Expect(result.After).To(BeNil())
+			}
+
+			group, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "the-guid", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Instance.State).To(Equal(models.ActualLRPStateRunning))
+			Expect(group.Instance.ActualLRPInstanceKey).To(Equal(*instanceKeyA))
+			Expect(group.Instance.ActualLRPNetInfo).To(Equal(netInfo))
+
+			group, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "the-guid", 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Instance.State).To(Equal(models.ActualLRPStateRunning))
+			Expect(group.Instance.ActualLRPInstanceKey).To(Equal(*instanceKeyB))
+
+			group, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "the-guid", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+
+			_, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "the-guid", 5)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+		})
+
+		It("claims fresh indices and treats re-claims by the same instance as no-ops", func() {
+			_, _, err := sqlDB.ClaimActualLRP(logger, "the-guid", 1, instanceKeyA)
+			Expect(err).NotTo(HaveOccurred())
+			reclaimed, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "the-guid", 1)
+			Expect(err).NotTo(HaveOccurred())
+			fakeClock.Increment(time.Hour)
+
+			results, err := sqlDB.ApplyActualLRPLifecycleOperations(logger, []*models.ActualLRPLifecycleOperation{
+				claim("the-guid", 0, instanceKeyA),
+				claim("the-guid", 1, instanceKeyA),
+				claim("the-guid", 2, instanceKeyA),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(3))
+
+			for _, index := range []int{0, 2} {
+				Expect(results[index].Error).NotTo(HaveOccurred())
+				Expect(results[index].Before.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+				Expect(results[index].After.Instance.State).To(Equal(models.ActualLRPStateClaimed))
+				Expect(results[index].After.Instance.ActualLRPInstanceKey).To(Equal(*instanceKeyA))
+				Expect(results[index].After.Instance.Since).To(Equal(fakeClock.Now().UnixNano()))
+			}
+
+			Expect(results[1].Error).NotTo(HaveOccurred())
+			Expect(results[1].Before).To(Equal(reclaimed))
+			Expect(results[1].After).To(Equal(reclaimed))
+
+			group, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "the-guid", 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group).To(Equal(reclaimed))
+		})
+
+		It("returns no results for an empty batch", func() {
+			results, err := sqlDB.ApplyActualLRPLifecycleOperations(logger, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(BeEmpty())
+		})
+	})
+
 	Describe("CrashActualLRP", func() {
 		Context("when the actual lrp exists", func() {
 			var (
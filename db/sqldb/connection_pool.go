@@ -0,0 +1,55 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ConfigureConnectionPool applies the pool limits to conn. Connections that
+// have been idle for longer than maxIdleTime are closed instead of being
+// reused, so that connections killed server side (e.g. by MySQL's
+// wait_timeout) do not surface as "invalid connection" errors. A maxIdleTime
+// of zero keeps idle connections forever.
+func ConfigureConnectionPool(conn *sql.DB, maxOpenConns, maxIdleConns int, maxIdleTime time.Duration) {
+	conn.SetMaxOpenConns(maxOpenConns)
+	conn.SetMaxIdleConns(maxIdleConns)
+	conn.SetConnMaxIdleTime(maxIdleTime)
+}
+
+// PrewarmConnections opens and pings n connections of the pool at once, so
+// that the first requests after startup do not have to establish them. The
+// connections stay open as idle connections, up to the pool's max idle
+// connections. n is clamped to the pool's max open connections, since asking
+// for more would block forever waiting for a connection to be released, and
+// prewarming gives up with an error once timeout has elapsed.
+func PrewarmConnections(conn *sql.DB, n int, timeout time.Duration) error {
+	if maxOpen := conn.Stats().MaxOpenConnections; maxOpen > 0 && n > maxOpen {
+		n = maxOpen
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conns := make([]*sql.Conn, 0, n)
+	defer func() {
+		for _, c := range conns {
+			c.Close()
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		c, err := conn.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		conns = append(conns, c)
+
+		err = c.PingContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
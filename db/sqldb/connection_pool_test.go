@@ -0,0 +1,101 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/bbs/db/sqldb"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigureConnectionPool", func() {
+	var conn *sql.DB
+
+	BeforeEach(func() {
+		var err error
+		conn, err = sql.Open(dbDriverName, fmt.Sprintf("%sdiego_%d", dbBaseConnectionString, GinkgoParallelNode()))
+		Expect(err).NotTo(HaveOccurred())
+
+		sqldb.ConfigureConnectionPool(conn, 1, 1, 100*time.Millisecond)
+		Expect(conn.Ping()).To(Succeed())
+		Expect(conn.Stats().Idle).To(Equal(1))
+	})
+
+	AfterEach(func() {
+		Expect(conn.Close()).To(Succeed())
+	})
+
+	It("does not reuse a connection that has been idle for longer than the max idle time", func() {
+		Eventually(func() int64 { return conn.Stats().MaxIdleTimeClosed }).Should(BeEquivalentTo(1))
+		Expect(conn.Stats().Idle).To(Equal(0))
+
+		Expect(conn.Ping()).To(Succeed())
+		Expect(conn.Stats().OpenConnections).To(Equal(1))
+	})
+
+	It("reuses a connection that has not been idle for the max idle time", func() {
+		Expect(conn.Ping()).To(Succeed())
+		Expect(conn.Stats().MaxIdleTimeClosed).To(BeZero())
+	})
+})
+
+var _ = Describe("PrewarmConnections", func() {
+	var conn *sql.DB
+
+	BeforeEach(func() {
+		var err error
+		conn, err = sql.Open(dbDriverName, fmt.Sprintf("%sdiego_%d", dbBaseConnectionString, GinkgoParallelNode()))
+		Expect(err).NotTo(HaveOccurred())
+
+		sqldb.ConfigureConnectionPool(conn, 10, 5, 0)
+	})
+
+	AfterEach(func() {
+		Expect(conn.Close()).To(Succeed())
+	})
+
+	It("opens the connections and keeps them idle in the pool", func() {
+		Expect(conn.Stats().OpenConnections).To(BeZero())
+
+		Expect(sqldb.PrewarmConnections(conn, 3, time.Second)).To(Succeed())
+		Expect(conn.Stats().OpenConnections).To(Equal(3))
+		Expect(conn.Stats().Idle).To(Equal(3))
+	})
+
+	It("keeps no more connections open than the pool keeps idle", func() {
+		Expect(sqldb.PrewarmConnections(conn, 7, time.Second)).To(Succeed())
+		Expect(conn.Stats().OpenConnections).To(Equal(5))
+	})
+
+	It("opens no more connections than the pool allows", func() {
+		Expect(sqldb.PrewarmConnections(conn, 15, time.Second)).To(Succeed())
+		Expect(conn.Stats().OpenConnections).To(Equal(5))
+	})
+
+	Context("when the pool has no connections to spare", func() {
+		var held []*sql.Conn
+
+		BeforeEach(func() {
+			held = nil
+			for i := 0; i < 10; i++ {
+				c, err := conn.Conn(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				held = append(held, c)
+			}
+		})
+
+		AfterEach(func() {
+			for _, c := range held {
+				Expect(c.Close()).To(Succeed())
+			}
+		})
+
+		It("gives up once the timeout has elapsed", func() {
+			err := sqldb.PrewarmConnections(conn, 1, 100*time.Millisecond)
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+		})
+	})
+})
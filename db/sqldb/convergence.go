@@ -0,0 +1,53 @@
+package sqldb
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/auctioneer"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager"
+)
+
+const convergeDuration = "ConvergenceDuration"
+
+// ConvergenceResult holds what ConvergeLRPs and ConvergeTasks returned during
+// a coordinated convergence pass.
+type ConvergenceResult struct {
+	LRPStartRequests     []*auctioneer.LRPStartRequest
+	KeysWithMissingCells []*models.ActualLRPKeyWithSchedulingInfo
+	KeysToRetire         []*models.ActualLRPKeyWithRetireReason
+
+	TaskStartRequests []*auctioneer.TaskStartRequest
+	TasksToComplete   []*models.Task
+	TaskEvents        []models.Event
+}
+
+// Converge runs LRP convergence and then task convergence against the same
+// cell set, so that both never hit the database at the same time. Like
+// ConvergeLRPs and ConvergeTasks, it does not run concurrently with either of
+// them. The duration of the whole pass is emitted
+// in addition to the LRP and task convergence durations.
+func (db *SQLDB) Converge(logger lager.Logger, cellSet models.CellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) *ConvergenceResult {
+	logger = logger.Session("converge")
+	logger.Info("starting")
+	defer logger.Info("completed")
+
+	db.convergenceLock.Lock()
+	defer db.convergenceLock.Unlock()
+
+	convergeStart := db.clock.Now()
+	defer func() {
+		err := db.metronClient.SendDuration(convergeDuration, time.Since(convergeStart))
+		if err != nil {
+			logger.Error("failed-sending-converge-duration-metric", err)
+		}
+	}()
+
+	result := &ConvergenceResult{}
+	result.LRPStartRequests, result.KeysWithMissingCells, result.KeysToRetire = db.convergeLRPs(logger.Session("lrps"), cellSet)
+	result.TaskStartRequests, result.TasksToComplete, result.TaskEvents = db.convergeTasks(logger.Session("tasks"), cellSet,
+		kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration,
+	)
+
+	return result
+}
@@ -0,0 +1,179 @@
+package sqldb
+
+import (
+	"database/sql"
+	"time"
+
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager"
+)
+
+const ConvergenceLockID = "convergence_lock"
+
+type convergenceLockHolder struct {
+	HolderID   string `json:"holder_id"`
+	AcquiredAt int64  `json:"acquired_at"`
+}
+
+func (*convergenceLockHolder) Version() format.Version {
+	return format.V0
+}
+
+func (*convergenceLockHolder) Validate() error {
+	return nil
+}
+
+// AcquireConvergenceLock records holderID as the holder of the convergence
+// lock unless another holder acquired it less than ttl ago, and reports
+// whether the lock is now held by holderID. Re-acquiring a lock that is
+// already held by holderID renews it. When the lock has never been acquired,
+// concurrent callers race to create it and only the one that creates it
+// acquires it. The holder metadata is serialized like every other record.
+func (db *SQLDB) AcquireConvergenceLock(logger lager.Logger, holderID string, ttl time.Duration) (bool, error) {
+	logger = logger.Session("acquire-convergence-lock", lager.Data{"holder_id": holderID})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	var acquired bool
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		acquired = false
+		now := db.clock.Now()
+
+		var value string
+		err := db.one(logger, tx, configurationsTable,
+			helpers.ColumnList{"value"}, helpers.LockRow,
+			"id = ?", ConvergenceLockID,
+		).Scan(&value)
+		if err != nil && err != sql.ErrNoRows {
+			logger.Error("failed-fetching-convergence-lock", err)
+			return err
+		}
+		exists := err == nil
+
+		if exists {
+			holder, err := db.decodeConvergenceLockHolder(logger, value)
+			if err != nil {
+				return err
+			}
+			if holder.HolderID != holderID && now.Sub(time.Unix(0, holder.AcquiredAt)) < ttl {
+				logger.Debug("convergence-lock-held", lager.Data{"holder": holder.HolderID})
+				return nil
+			}
+		}
+
+		encodedHolder, err := db.encodeConvergenceLockHolder(logger, &convergenceLockHolder{
+			HolderID:   holderID,
+			AcquiredAt: now.UnixNano(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			_, err = db.update(logger, tx, configurationsTable,
+				helpers.SQLAttributes{"value": string(encodedHolder)},
+				"id = ?", ConvergenceLockID,
+			)
+		} else {
+			// relies on the primary key for only one of several first holders
+			// to create the lock
+			_, err = db.insert(logger, tx, configurationsTable,
+				helpers.SQLAttributes{"value": string(encodedHolder), "id": ConvergenceLockID},
+			)
+		}
+		if err != nil {
+			logger.Error("failed-setting-convergence-lock", err)
+			return err
+		}
+
+		acquired = true
+		return nil
+	})
+
+	if err == models.ErrResourceExists {
+		logger.Debug("convergence-lock-created-by-another-holder")
+		return false, nil
+	}
+	return acquired, err
+}
+
+// ConvergenceLockHolder returns the holder of the convergence lock and the
+// time it was acquired at.
+func (db *SQLDB) ConvergenceLockHolder(logger lager.Logger) (string, time.Time, error) {
+	logger = logger.Session("convergence-lock-holder")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	value, err := db.getConfigurationValue(logger, ConvergenceLockID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	holder, err := db.decodeConvergenceLockHolder(logger, value)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return holder.HolderID, time.Unix(0, holder.AcquiredAt), nil
+}
+
+func (db *SQLDB) encodeConvergenceLockHolder(logger lager.Logger, holder *convergenceLockHolder) ([]byte, error) {
+	encodedData, err := db.serializer.Marshal(logger, db.convergenceLockFormat(), holder)
+	if err != nil {
+		logger.Error("failed-serializing-convergence-lock-holder", err)
+		return nil, models.ErrBadRequest
+	}
+	return encodedData, nil
+}
+
+func (db *SQLDB) decodeConvergenceLockHolder(logger lager.Logger, value string) (*convergenceLockHolder, error) {
+	var holder convergenceLockHolder
+	err := db.serializer.Unmarshal(logger, []byte(value), &holder)
+	if err != nil {
+		logger.Error("failed-deserializing-convergence-lock-holder", err)
+		return nil, models.ErrDeserialize
+	}
+	return &holder, nil
+}
+
+// The holder is not a protobuf model, so it is enveloped as JSON and encoded
+// with the encoding of the SQLDB's format.
+func (db *SQLDB) convergenceLockFormat() *format.Format {
+	return format.NewFormat(db.format.Encoding, format.JSON)
+}
+
+func (db *SQLDB) reEncryptConvergenceLock(logger lager.Logger) error {
+	logger = logger.Session("re-encrypt-convergence-lock")
+
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		var value string
+		err := db.one(logger, tx, configurationsTable,
+			helpers.ColumnList{"value"}, helpers.LockRow,
+			"id = ?", ConvergenceLockID,
+		).Scan(&value)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			logger.Error("failed-fetching-convergence-lock", err)
+			return err
+		}
+
+		reEncrypted, err := db.encoder.Reencrypt([]byte(value))
+		if err != nil {
+			logger.Error("failed-re-encrypting-convergence-lock", err)
+			return nil
+		}
+
+		_, err = db.update(logger, tx, configurationsTable,
+			helpers.SQLAttributes{"value": string(reEncrypted)},
+			"id = ?", ConvergenceLockID,
+		)
+		if err != nil {
+			logger.Error("failed-updating-convergence-lock", err)
+		}
+		return err
+	})
+}
@@ -0,0 +1,132 @@
+package sqldb_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/bbs/db/sqldb"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/bbs/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConvergenceLock", func() {
+	Describe("ConvergenceLockHolder", func() {
+		Context("when the lock has been acquired", func() {
+			var acquiredAt time.Time
+
+			BeforeEach(func() {
+				acquiredAt = fakeClock.Now()
+				acquired, err := sqlDB.AcquireConvergenceLock(logger, "bbs-0", time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("returns the holder and the time it was acquired", func() {
+				holder, since, err := sqlDB.ConvergenceLockHolder(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holder).To(Equal("bbs-0"))
+				Expect(since.UnixNano()).To(Equal(acquiredAt.UnixNano()))
+			})
+
+			It("stores the holder metadata encrypted", func() {
+				queryStr := "SELECT value FROM configurations WHERE id = ?"
+				if test_helpers.UsePostgres() {
+					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+				}
+
+				var value string
+				err := db.QueryRow(queryStr, sqldb.ConvergenceLockID).Scan(&value)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(format.PayloadEncoding([]byte(value))).To(Equal(format.BASE64_ENCRYPTED))
+				Expect(strings.Contains(value, "bbs-0")).To(BeFalse())
+			})
+
+			It("does not let another holder acquire the lock before it expires", func() {
+				fakeClock.Increment(30 * time.Second)
+
+				acquired, err := sqlDB.AcquireConvergenceLock(logger, "bbs-1", time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+
+				holder, _, err := sqlDB.ConvergenceLockHolder(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holder).To(Equal("bbs-0"))
+			})
+
+			It("lets another holder acquire the lock once it has expired", func() {
+				fakeClock.Increment(time.Minute)
+
+				acquired, err := sqlDB.AcquireConvergenceLock(logger, "bbs-1", time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				holder, since, err := sqlDB.ConvergenceLockHolder(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holder).To(Equal("bbs-1"))
+				Expect(since.UnixNano()).To(Equal(fakeClock.Now().UnixNano()))
+			})
+
+			Context("when the holder cannot be decoded", func() {
+				BeforeEach(func() {
+					queryStr := "UPDATE configurations SET value = ? WHERE id = ?"
+					if test_helpers.UsePostgres() {
+						queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+					}
+					_, err := db.Exec(queryStr, "garbage", sqldb.ConvergenceLockID)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an error instead of acquiring the lock", func() {
+					acquired, err := sqlDB.AcquireConvergenceLock(logger, "bbs-1", time.Minute)
+					Expect(err).To(Equal(models.ErrDeserialize))
+					Expect(acquired).To(BeFalse())
+				})
+			})
+
+			It("renews the lock when the holder acquires it again", func() {
+				fakeClock.Increment(30 * time.Second)
+
+				acquired, err := sqlDB.AcquireConvergenceLock(logger, "bbs-0", time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+
+				_, since, err := sqlDB.ConvergenceLockHolder(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(since.UnixNano()).To(Equal(fakeClock.Now().UnixNano()))
+			})
+		})
+
+		Context("when the lock has never been acquired", func() {
+			It("returns a resource not found error", func() {
+				_, _, err := sqlDB.ConvergenceLockHolder(logger)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+
+			It("lets only one of several concurrent holders acquire it", func() {
+				const holders = 3
+				results := make(chan bool, holders)
+				for i := 0; i < holders; i++ {
+					holderID := fmt.Sprintf("bbs-%d", i)
+					go func() {
+						defer GinkgoRecover()
+						acquired, err := sqlDB.AcquireConvergenceLock(logger, holderID, time.Minute)
+						Expect(err).NotTo(HaveOccurred())
+						results <- acquired
+					}()
+				}
+
+				acquiredCount := 0
+				for i := 0; i < holders; i++ {
+					if <-results {
+						acquiredCount++
+					}
+				}
+				Expect(acquiredCount).To(Equal(1))
+			})
+		})
+	})
+})
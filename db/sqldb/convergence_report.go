@@ -0,0 +1,310 @@
+package sqldb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/auctioneer"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager"
+)
+
+// LRPConvergenceActions lists the instance indices of a single process guid
+// that the next convergence would act on.
+type LRPConvergenceActions struct {
+	ProcessGuid string
+
+	// Missing actual LRPs that would be created as UNCLAIMED.
+	CreateIndices []int32
+	// Crashed actual LRPs, and running ones without net info, that would be
+	// transitioned to UNCLAIMED.
+	UnclaimIndices []int32
+	// Instances that would be sent to the auctioneer.
+	StartIndices []int32
+	// Instances whose start would be deferred to a later convergence, as the
+	// start requests exceed the number of instances started per convergence.
+	DeferredIndices []int32
+	// Actual LRPs on cells missing from the cell set.
+	MissingCellIndices []int32
+	// Extra and orphaned actual LRPs that would be retired.
+	RetireIndices []int32
+}
+
+func (a *LRPConvergenceActions) String() string {
+	actions := []string{}
+	for _, action := range []struct {
+		name    string
+		indices []int32
+	}{
+		{"create", a.CreateIndices},
+		{"unclaim", a.UnclaimIndices},
+		{"start", a.StartIndices},
+		{"deferred", a.DeferredIndices},
+		{"missing cell", a.MissingCellIndices},
+		{"retire", a.RetireIndices},
+	} {
+		if len(action.indices) > 0 {
+			actions = append(actions, fmt.Sprintf("%s %v", action.name, action.indices))
+		}
+	}
+	return a.ProcessGuid + ": " + strings.Join(actions, ", ")
+}
+
+// ConvergenceReport is the set of actions the next convergence would take,
+// sorted by process guid.
+type ConvergenceReport []*LRPConvergenceActions
+
+func (r ConvergenceReport) Len() int           { return len(r) }
+func (r ConvergenceReport) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r ConvergenceReport) Less(i, j int) bool { return r[i].ProcessGuid < r[j].ProcessGuid }
+
+func (r ConvergenceReport) String() string {
+	lines := make([]string, 0, len(r))
+	for _, actions := range r {
+		lines = append(lines, actions.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ConvergenceReport runs LRP convergence against the given cell set as a dry
+// run and reports what it would do per process guid, with the start requests
+// capped like ConvergeLRPs caps them. Nothing is written to the database, no
+// start requests are deferred and no metrics are emitted.
+func (db *SQLDB) ConvergenceReport(logger lager.Logger, cellSet models.CellSet) (ConvergenceReport, error) {
+	logger = logger.Session("convergence-report")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	converge, err := db.dryRunConvergence(logger, cellSet)
+	if err != nil {
+		return nil, err
+	}
+
+	converge.poolWg.Wait()
+	converge.pool.Stop()
+
+	return converge.report(logger), nil
+}
+
+// ConvergenceLRPCandidateCount returns the number of actual LRP instances the
+// next convergence against the given cell set would act on: stale unclaimed,
+// on a missing cell, missing, extra, orphaned, crashed and either restartable
+// or retired, and running for too long, plus the indices of the deferred start
+// requests. It counts with aggregate queries over the rows the convergence
+// steps select rather than running them, so it neither writes to the database
+// nor emits metrics. An instance is counted once per step that acts on it, so
+// a missing instance, which is created and started, is counted once, while an
+// instance both on a missing cell and running for too long is counted twice.
+// Running instances without net info are not counted, as telling them apart
+// needs the net info of every running instance decrypted.
+func (db *SQLDB) ConvergenceLRPCandidateCount(logger lager.Logger, cellSet models.CellSet) (int, error) {
+	logger = logger.Session("convergence-lrp-candidate-count")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	return db.convergenceLRPCandidateCount(logger, cellSet, db.clock.Now())
+}
+
+func (db *SQLDB) convergenceLRPCandidateCount(logger lager.Logger, cellSet models.CellSet, now time.Time) (int, error) {
+	count, err := db.countConvergenceCandidates(logger, db.db, cellSet, now)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := db.selectCrashedActualLRPCrashes(logger, db.db)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var since int64
+		var crashCount int32
+		err := rows.Scan(&since, &crashCount)
+		if err != nil {
+			logger.Error("failed-scanning", err)
+			return 0, err
+		}
+
+		if db.restartPolicy.ShouldRestart(now.UnixNano(), since, crashCount) ||
+			(db.retireNonRestartableCrashes && db.restartPolicy.GivesUp(crashCount)) {
+			count++
+		}
+	}
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return 0, rows.Err()
+	}
+
+	db.deferredStartRequestsMutex.Lock()
+	for _, startRequest := range db.deferredStartRequests {
+		count += len(startRequest.Indices)
+	}
+	db.deferredStartRequestsMutex.Unlock()
+
+	return count, nil
+}
+
+// ConvergeLRPsDryRun returns the start requests, capped like ConvergeLRPs caps
+// them, actual LRPs on missing cells and keys to retire that ConvergeLRPs
+// would return for the given cell set, without creating or unclaiming any
+// actual LRP, pruning expired domains or evacuating actual LRPs, deferring
+// start requests, or emitting metrics.
+func (db *SQLDB) ConvergeLRPsDryRun(logger lager.Logger, cellSet models.CellSet) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKeyWithRetireReason) {
+	logger = logger.Session("converge-lrps-dry-run")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	converge, err := db.dryRunConvergence(logger, cellSet)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	startRequests, keysWithMissingCells, keysToRetire := converge.result(logger)
+	startRequests, _ = db.previewCapStartRequests(logger, startRequests)
+	return startRequests, keysWithMissingCells, keysToRetire
+}
+
+// Runs every convergence step of ConvergeLRPs as a dry run. Expired domains
+// and evacuating actual LRPs need not be pruned first: the steps ignore them
+// like the pruned tables would.
+func (db *SQLDB) dryRunConvergence(logger lager.Logger, cellSet models.CellSet) (*convergence, error) {
+	now := db.clock.Now()
+
+	domainSet, err := db.domainSet(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	converge := newConvergence(db, cellSet)
+	converge.dryRun = true
+	converge.staleUnclaimedActualLRPs(logger, now)
+	if len(cellSet) != 0 || !db.skipMissingCellsWhenCellSetEmpty {
+		converge.actualLRPsWithMissingCells(logger, cellSet)
+	}
+	converge.lrpInstanceCounts(logger, domainSet, now)
+	converge.orphanedActualLRPs(logger, now)
+	converge.crashedActualLRPs(logger, now)
+	converge.runningActualLRPsWithMissingNetInfo(logger)
+	if db.maxInstanceLifetime > 0 {
+		converge.agedRunningActualLRPs(logger, now)
+	}
+
+	return converge, nil
+}
+
+func (c *convergence) report(logger lager.Logger) ConvergenceReport {
+	c.startRequestsMutex.Lock()
+	startRequests := c.startRequestsToSend(logger)
+	c.startRequestsMutex.Unlock()
+
+	return c.reportWithStartRequests(c.previewCapStartRequests(logger, startRequests))
+}
+
+// Reports the actions convergence took with the given start requests and
+// deferred start requests in place of the ones it collected, which
+// ConvergeLRPs caps and adds previously deferred ones to.
+func (c *convergence) reportWithStartRequests(startRequests, deferredStartRequests []*auctioneer.LRPStartRequest) ConvergenceReport {
+	c.keysMutex.Lock()
+	defer c.keysMutex.Unlock()
+
+	actionsByGuid := map[string]*LRPConvergenceActions{}
+	actionsFor := func(processGuid string) *LRPConvergenceActions {
+		actions, ok := actionsByGuid[processGuid]
+		if !ok {
+			actions = &LRPConvergenceActions{ProcessGuid: processGuid}
+			actionsByGuid[processGuid] = actions
+		}
+		return actions
+	}
+
+	for _, key := range c.keysToCreate {
+		actions := actionsFor(key.ProcessGuid)
+		actions.CreateIndices = append(actions.CreateIndices, key.Index)
+	}
+	for _, key := range c.keysToUnclaim {
+		actions := actionsFor(key.ProcessGuid)
+		actions.UnclaimIndices = append(actions.UnclaimIndices, key.Index)
+	}
+	for _, startRequest := range startRequests {
+		actions := actionsFor(startRequest.ProcessGuid)
+		for _, index := range startRequest.Indices {
+			actions.StartIndices = append(actions.StartIndices, int32(index))
+		}
+	}
+	for _, startRequest := range deferredStartRequests {
+		actions := actionsFor(startRequest.ProcessGuid)
+		for _, index := range startRequest.Indices {
+			actions.DeferredIndices = append(actions.DeferredIndices, int32(index))
+		}
+	}
+	for _, keyWithSchedulingInfo := range c.keysWithMissingCells {
+		actions := actionsFor(keyWithSchedulingInfo.Key.ProcessGuid)
+		actions.MissingCellIndices = append(actions.MissingCellIndices, keyWithSchedulingInfo.Key.Index)
+	}
+	for _, key := range c.keysToRetire {
+		actions := actionsFor(key.Key.ProcessGuid)
+		actions.RetireIndices = append(actions.RetireIndices, key.Key.Index)
+	}
+
+	report := make(ConvergenceReport, 0, len(actionsByGuid))
+	for _, actions := range actionsByGuid {
+		for _, indices := range [][]int32{actions.CreateIndices, actions.UnclaimIndices, actions.StartIndices, actions.DeferredIndices, actions.MissingCellIndices, actions.RetireIndices} {
+			sort.Sort(int32Slice(indices))
+		}
+		report = append(report, actions)
+	}
+	sort.Sort(report)
+
+	return report
+}
+
+type int32Slice []int32
+
+func (s int32Slice) Len() int           { return len(s) }
+func (s int32Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s int32Slice) Less(i, j int) bool { return s[i] < s[j] }
+
+// Logs one line per process guid that convergence acted on, listing the
+// indices of the start requests it returns and defers rather than of the ones
+// it collected.
+func (c *convergence) logActions(logger lager.Logger, startRequests, deferredStartRequests []*auctioneer.LRPStartRequest) {
+	for _, actions := range c.reportWithStartRequests(startRequests, deferredStartRequests) {
+		data := lager.Data{"process_guid": actions.ProcessGuid}
+		for _, action := range []struct {
+			name    string
+			indices []int32
+		}{
+			{"create", actions.CreateIndices},
+			{"unclaim", actions.UnclaimIndices},
+			{"start", actions.StartIndices},
+			{"deferred", actions.DeferredIndices},
+			{"missing_cell", actions.MissingCellIndices},
+			{"retire", actions.RetireIndices},
+		} {
+			if len(action.indices) > 0 {
+				data[action.name] = action.indices
+			}
+		}
+		logger.Info("converged-lrp", data)
+	}
+}
+
+// Drops the debug and info logs of the wrapped logger, and of its sessions.
+type errorsOnlyLogger struct {
+	lager.Logger
+}
+
+func (l errorsOnlyLogger) Debug(action string, data ...lager.Data) {}
+func (l errorsOnlyLogger) Info(action string, data ...lager.Data)  {}
+
+func (l errorsOnlyLogger) Session(task string, data ...lager.Data) lager.Logger {
+	return errorsOnlyLogger{l.Logger.Session(task, data...)}
+}
+
+func (l errorsOnlyLogger) WithData(data lager.Data) lager.Logger {
+	return errorsOnlyLogger{l.Logger.WithData(data)}
+}
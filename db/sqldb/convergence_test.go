@@ -0,0 +1,76 @@
+package sqldb_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/auctioneer"
+	"code.cloudfoundry.org/bbs/db/sqldb"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/bbs/models/test/model_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Converge", func() {
+	var (
+		cellSet models.CellSet
+		taskDef *models.TaskDefinition
+		result  *sqldb.ConvergenceResult
+
+		kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration
+	)
+
+	BeforeEach(func() {
+		cellSet = models.NewCellSetFromList([]*models.CellPresence{
+			{CellId: "existing-cell"},
+		})
+		kickTasksDuration = 10 * time.Second
+		expirePendingTaskDuration = 30 * time.Second
+		expireCompletedTaskDuration = time.Hour
+
+		Expect(sqlDB.UpsertDomain(logger, "some-domain", 100)).To(Succeed())
+
+		desiredLRP := model_helpers.NewValidDesiredLRP("desired-with-missing-actual")
+		desiredLRP.Domain = "some-domain"
+		desiredLRP.Instances = 1
+		Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+
+		taskDef = model_helpers.NewValidTaskDefinition()
+		fakeClock.Increment(-kickTasksDuration)
+		_, err := sqlDB.DesireTask(logger, taskDef, "pending-kickable-task", "some-domain")
+		Expect(err).NotTo(HaveOccurred())
+		fakeClock.Increment(kickTasksDuration)
+	})
+
+	JustBeforeEach(func() {
+		result = sqlDB.Converge(logger, cellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+	})
+
+	It("converges the lrps", func() {
+		Expect(result.LRPStartRequests).To(HaveLen(1))
+		Expect(result.LRPStartRequests[0].ProcessGuid).To(Equal("desired-with-missing-actual"))
+		Expect(result.LRPStartRequests[0].Indices).To(ConsistOf(0))
+
+		actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "desired-with-missing-actual", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+	})
+
+	It("converges the tasks", func() {
+		taskRequest := auctioneer.NewTaskStartRequestFromModel("pending-kickable-task", "some-domain", taskDef)
+		Expect(result.TaskStartRequests).To(ConsistOf(&taskRequest))
+	})
+
+	It("emits the duration of the lrp, task and combined convergence", func() {
+		durations := map[string]time.Duration{}
+		for i := 0; i < fakeMetronClient.SendDurationCallCount(); i++ {
+			name, value := fakeMetronClient.SendDurationArgsForCall(i)
+			durations[name] = value
+		}
+
+		Expect(durations).To(HaveKey("ConvergenceLRPDuration"))
+		Expect(durations).To(HaveKey("ConvergenceTaskDuration"))
+		Expect(durations).To(HaveKey("ConvergenceDuration"))
+		Expect(durations["ConvergenceDuration"]).NotTo(BeZero())
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
 	"code.cloudfoundry.org/bbs/format"
@@ -17,71 +18,113 @@ func (db *SQLDB) DesireLRP(logger lager.Logger, desiredLRP *models.DesiredLRP) e
 	defer logger.Info("complete")
 
 	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
-		routesData, err := db.encodeRouteData(logger, desiredLRP.Routes)
-		if err != nil {
-			logger.Error("failed-encoding-route-data", err)
-			return err
-		}
+		return db.insertDesiredLRP(logger, tx, desiredLRP)
+	})
+}
 
-		runInfo := desiredLRP.DesiredLRPRunInfo(db.clock.Now())
+// DesireLRPs inserts all of the given desired LRPs in a single transaction, so
+// that either all of them are desired or, on any validation or write failure,
+// none are. Each desired LRP is validated as a desire LRP request would be, and
+// process guids must be unique within the batch.
+func (db *SQLDB) DesireLRPs(logger lager.Logger, desiredLRPs []*models.DesiredLRP) error {
+	logger = logger.Session("desire-lrps", lager.Data{"count": len(desiredLRPs)})
+	logger.Info("starting")
+	defer logger.Info("complete")
 
-		runInfoData, err := db.serializeModel(logger, &runInfo)
+	processGuids := make(map[string]struct{}, len(desiredLRPs))
+	for _, desiredLRP := range desiredLRPs {
+		request := models.DesireLRPRequest{DesiredLrp: desiredLRP}
+		err := request.Validate()
 		if err != nil {
-			logger.Error("failed-to-serialize-model", err)
+			logger.Error("invalid-desired-lrp", err, lager.Data{"process_guid": desiredLRP.GetProcessGuid()})
 			return err
 		}
 
-		volumePlacement := &models.VolumePlacement{}
-		volumePlacement.DriverNames = []string{}
-		for _, mount := range desiredLRP.VolumeMounts {
-			volumePlacement.DriverNames = append(volumePlacement.DriverNames, mount.Driver)
-		}
-
-		volumePlacementData, err := db.serializeModel(logger, volumePlacement)
-		if err != nil {
-			logger.Error("failed-to-serialize-model", err)
+		if _, ok := processGuids[desiredLRP.ProcessGuid]; ok {
+			err := models.ErrDuplicateProcessGuid{ProcessGuid: desiredLRP.ProcessGuid}
+			logger.Error("duplicate-process-guid", err)
 			return err
 		}
+		processGuids[desiredLRP.ProcessGuid] = struct{}{}
+	}
 
-		guid, err := db.guidProvider.NextGUID()
-		if err != nil {
-			logger.Error("failed-to-generate-guid", err)
-			return models.ErrGUIDGeneration
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		for _, desiredLRP := range desiredLRPs {
+			err := db.insertDesiredLRP(logger.WithData(lager.Data{"process_guid": desiredLRP.ProcessGuid}), tx, desiredLRP)
+			if err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+}
 
-		placementTagData, err := json.Marshal(desiredLRP.PlacementTags)
-		if err != nil {
-			logger.Error("failed-to-serialize-model", err)
-			return err
-		}
+func (db *SQLDB) insertDesiredLRP(logger lager.Logger, tx *sql.Tx, desiredLRP *models.DesiredLRP) error {
+	routesData, err := db.encodeRouteData(logger, desiredLRP.Routes)
+	if err != nil {
+		logger.Error("failed-encoding-route-data", err)
+		return err
+	}
 
-		desiredLRP.ModificationTag = &models.ModificationTag{Epoch: guid, Index: 0}
+	runInfo := desiredLRP.DesiredLRPRunInfo(db.clock.Now())
 
-		_, err = db.insert(logger, tx, desiredLRPsTable,
-			helpers.SQLAttributes{
-				"process_guid":           desiredLRP.ProcessGuid,
-				"domain":                 desiredLRP.Domain,
-				"log_guid":               desiredLRP.LogGuid,
-				"annotation":             desiredLRP.Annotation,
-				"instances":              desiredLRP.Instances,
-				"memory_mb":              desiredLRP.MemoryMb,
-				"disk_mb":                desiredLRP.DiskMb,
-				"max_pids":               desiredLRP.MaxPids,
-				"rootfs":                 desiredLRP.RootFs,
-				"volume_placement":       volumePlacementData,
-				"modification_tag_epoch": desiredLRP.ModificationTag.Epoch,
-				"modification_tag_index": desiredLRP.ModificationTag.Index,
-				"routes":                 routesData,
-				"run_info":               runInfoData,
-				"placement_tags":         placementTagData,
-			},
-		)
-		if err != nil {
-			logger.Error("failed-inserting-desired", err)
-			return err
-		}
-		return nil
-	})
+	runInfoData, err := db.serializeModel(logger, &runInfo)
+	if err != nil {
+		logger.Error("failed-to-serialize-model", err)
+		return err
+	}
+
+	volumePlacement := &models.VolumePlacement{}
+	volumePlacement.DriverNames = []string{}
+	for _, mount := range desiredLRP.VolumeMounts {
+		volumePlacement.DriverNames = append(volumePlacement.DriverNames, mount.Driver)
+	}
+
+	volumePlacementData, err := db.serializeModel(logger, volumePlacement)
+	if err != nil {
+		logger.Error("failed-to-serialize-model", err)
+		return err
+	}
+
+	guid, err := db.guidProvider.NextGUID()
+	if err != nil {
+		logger.Error("failed-to-generate-guid", err)
+		return models.ErrGUIDGeneration
+	}
+
+	placementTagData, err := json.Marshal(desiredLRP.PlacementTags)
+	if err != nil {
+		logger.Error("failed-to-serialize-model", err)
+		return err
+	}
+
+	desiredLRP.ModificationTag = &models.ModificationTag{Epoch: guid, Index: 0}
+
+	_, err = db.insert(logger, tx, desiredLRPsTable,
+		helpers.SQLAttributes{
+			"process_guid":           desiredLRP.ProcessGuid,
+			"domain":                 desiredLRP.Domain,
+			"log_guid":               desiredLRP.LogGuid,
+			"annotation":             desiredLRP.Annotation,
+			"instances":              desiredLRP.Instances,
+			"memory_mb":              desiredLRP.MemoryMb,
+			"disk_mb":                desiredLRP.DiskMb,
+			"max_pids":               desiredLRP.MaxPids,
+			"rootfs":                 desiredLRP.RootFs,
+			"volume_placement":       volumePlacementData,
+			"modification_tag_epoch": desiredLRP.ModificationTag.Epoch,
+			"modification_tag_index": desiredLRP.ModificationTag.Index,
+			"routes":                 routesData,
+			"run_info":               runInfoData,
+			"placement_tags":         placementTagData,
+			"suppress_convergence":   desiredLRP.SuppressConvergence,
+		},
+	)
+	if err != nil {
+		logger.Error("failed-inserting-desired", err)
+		return err
+	}
+	return nil
 }
 
 func (db *SQLDB) DesiredLRPByProcessGuid(logger lager.Logger, processGuid string) (*models.DesiredLRP, error) {
@@ -105,6 +148,50 @@ func (db *SQLDB) DesiredLRPByProcessGuid(logger lager.Logger, processGuid string
 	return desiredLRP, err
 }
 
+// DesiredLRPState returns the desired LRP together with its actual LRP groups.
+// Both are read in one transaction while holding the desired LRP's row lock,
+// so they reflect the same point in any update that locks the desired LRP.
+func (db *SQLDB) DesiredLRPState(logger lager.Logger, processGuid string) (*models.DesiredLRP, []*models.ActualLRPGroup, error) {
+	logger = logger.WithData(lager.Data{"process_guid": processGuid})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	var desiredLRP *models.DesiredLRP
+	var groups []*models.ActualLRPGroup
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		var err error
+		row := db.one(logger, tx, desiredLRPsTable,
+			desiredLRPColumns, helpers.LockRow,
+			"process_guid = ?", processGuid,
+		)
+
+		desiredLRP, err = db.fetchDesiredLRP(logger, row, tx)
+		if err != nil {
+			logger.Error("failed-fetching-desired", err)
+			return err
+		}
+
+		rows, err := db.all(logger, tx, actualLRPsTable,
+			actualLRPColumns, helpers.NoLockRow,
+			"process_guid = ?", processGuid,
+		)
+		if err != nil {
+			logger.Error("failed-query", err)
+			return err
+		}
+		defer rows.Close()
+
+		groups, err = db.scanAndCleanupActualLRPs(logger, tx, rows)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return desiredLRP, groups, nil
+}
+
 func (db *SQLDB) DesiredLRPs(logger lager.Logger, filter models.DesiredLRPFilter) ([]*models.DesiredLRP, error) {
 	logger = logger.WithData(lager.Data{"filter": filter})
 	logger.Debug("start")
@@ -205,6 +292,212 @@ func (db *SQLDB) DesiredLRPSchedulingInfos(logger lager.Logger, filter models.De
 	return results, err
 }
 
+// DesiredLRPProcessGuids returns the process guids of all desired LRPs. Only
+// the process_guid column is read, so no run info is fetched or decrypted.
+func (db *SQLDB) DesiredLRPProcessGuids(logger lager.Logger) ([]string, error) {
+	logger = logger.Session("desired-lrp-process-guids")
+	logger.Debug("start")
+	defer logger.Debug("complete")
+
+	guids := []string{}
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		rows, err := db.all(logger, tx, desiredLRPsTable,
+			helpers.ColumnList{desiredLRPsTable + ".process_guid"}, helpers.NoLockRow,
+			"",
+		)
+		if err != nil {
+			logger.Error("failed-query", err)
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var guid string
+			err := rows.Scan(&guid)
+			if err != nil {
+				logger.Error("failed-scanning-row", err)
+				return db.convertSQLError(err)
+			}
+			guids = append(guids, guid)
+		}
+
+		if rows.Err() != nil {
+			logger.Error("failed-fetching-row", rows.Err())
+			return db.convertSQLError(rows.Err())
+		}
+
+		return nil
+	})
+
+	return guids, err
+}
+
+// DesiredLRPsByAnnotation returns the desired LRPs whose annotation is a JSON
+// object mapping key to value. Annotations that are not JSON objects of
+// strings never match.
+func (db *SQLDB) DesiredLRPsByAnnotation(logger lager.Logger, key, value string) ([]*models.DesiredLRP, error) {
+	logger = logger.Session("desired-lrps-by-annotation", lager.Data{"key": key, "value": value})
+	logger.Debug("start")
+	defer logger.Debug("complete")
+
+	results := []*models.DesiredLRP{}
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		rows, err := db.all(logger, tx, desiredLRPsTable,
+			helpers.ColumnList{desiredLRPsTable + ".process_guid", desiredLRPsTable + ".annotation"}, helpers.NoLockRow,
+			"annotation <> ''",
+		)
+		if err != nil {
+			logger.Error("failed-query", err)
+			return err
+		}
+		defer rows.Close()
+
+		var guids []interface{}
+		for rows.Next() {
+			var guid, annotation string
+			err := rows.Scan(&guid, &annotation)
+			if err != nil {
+				logger.Error("failed-scanning-row", err)
+				return db.convertSQLError(err)
+			}
+			if annotationMatches(annotation, key, value) {
+				guids = append(guids, guid)
+			}
+		}
+
+		if rows.Err() != nil {
+			logger.Error("failed-fetching-row", rows.Err())
+			return db.convertSQLError(rows.Err())
+		}
+		rows.Close()
+
+		if len(guids) == 0 {
+			return nil
+		}
+
+		rows, err = db.all(logger, tx, desiredLRPsTable,
+			desiredLRPColumns, helpers.NoLockRow,
+			whereClauseForProcessGuids(make([]string, len(guids))), guids...,
+		)
+		if err != nil {
+			logger.Error("failed-query", err)
+			return err
+		}
+		defer rows.Close()
+
+		results, err = db.fetchDesiredLRPs(logger, rows, tx)
+		if err != nil {
+			logger.Error("failed-fetching-row", rows.Err())
+			return db.convertSQLError(rows.Err())
+		}
+
+		return nil
+	})
+
+	return results, err
+}
+
+func annotationMatches(annotation, key, value string) bool {
+	var annotations map[string]string
+	if err := json.Unmarshal([]byte(annotation), &annotations); err != nil {
+		return false
+	}
+
+	actual, ok := annotations[key]
+	return ok && actual == value
+}
+
+// DesiredLRPsWithoutActuals returns the process guids of desired LRPs that
+// want instances but have no actual LRPs at all, and were desired more than
+// olderThan ago. These are typically LRPs whose instances never got created or
+// auctioned.
+func (db *SQLDB) DesiredLRPsWithoutActuals(logger lager.Logger, olderThan time.Duration) ([]string, error) {
+	logger = logger.WithData(lager.Data{"older_than": olderThan})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	cutoff := db.clock.Now().Add(-olderThan).UnixNano()
+	guids := []string{}
+
+	err := db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		rows, err := db.selectDesiredLRPsWithoutActuals(logger, tx)
+		if err != nil {
+			logger.Error("failed-query", err)
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var guid string
+			var runInfoData []byte
+			err := rows.Scan(&guid, &runInfoData)
+			if err != nil {
+				logger.Error("failed-scanning-row", err)
+				continue
+			}
+
+			var runInfo models.DesiredLRPRunInfo
+			err = db.deserializeModel(logger, runInfoData, &runInfo)
+			if err != nil {
+				logger.Error("failed-deserializing-run-info", err, lager.Data{"process_guid": guid})
+				continue
+			}
+
+			if runInfo.CreatedAt < cutoff {
+				guids = append(guids, guid)
+			}
+		}
+
+		if rows.Err() != nil {
+			logger.Error("failed-fetching-row", rows.Err())
+			return db.convertSQLError(rows.Err())
+		}
+
+		return nil
+	})
+
+	return guids, err
+}
+
+// TopCrashingDesiredLRPs returns the n desired LRPs whose actual LRPs have
+// the highest total crash count, most crashed first.
+func (db *SQLDB) TopCrashingDesiredLRPs(logger lager.Logger, n int) ([]models.CrashingLRPInfo, error) {
+	logger = logger.Session("top-crashing-desired-lrps", lager.Data{"n": n})
+	logger.Debug("start")
+	defer logger.Debug("complete")
+
+	results := []models.CrashingLRPInfo{}
+	if n <= 0 {
+		return results, nil
+	}
+
+	rows, err := db.selectTopCrashingDesiredLRPs(logger, db.db, n)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var info models.CrashingLRPInfo
+		err := rows.Scan(&info.ProcessGuid, &info.Domain, &info.CrashCount, &info.CrashingInstances)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			return nil, db.convertSQLError(err)
+		}
+		results = append(results, info)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	return results, nil
+}
+
 func (db *SQLDB) UpdateDesiredLRP(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) (*models.DesiredLRP, error) {
 	logger = logger.WithData(lager.Data{"process_guid": processGuid})
 	logger.Info("starting")
@@ -254,8 +547,71 @@ func (db *SQLDB) UpdateDesiredLRP(logger lager.Logger, processGuid string, updat
 	return beforeDesiredLRP, err
 }
 
+// UpdateDesiredLRPRoutes replaces only the routes of the desired LRP, leaving
+// its instances, annotation and run info as they are in the database rather
+// than as the caller last read them.
+func (db *SQLDB) UpdateDesiredLRPRoutes(logger lager.Logger, processGuid string, routes *models.Routes) (*models.DesiredLRP, *models.DesiredLRP, error) {
+	logger = logger.WithData(lager.Data{"process_guid": processGuid})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	if routes == nil {
+		return nil, nil, models.NewError(models.Error_InvalidRequest, "routes cannot be nil")
+	}
+
+	err := (&models.DesiredLRPUpdate{Routes: routes}).Validate()
+	if err != nil {
+		logger.Error("invalid-routes", err)
+		return nil, nil, models.NewError(models.Error_InvalidRequest, err.Error())
+	}
+
+	var beforeDesiredLRP, afterDesiredLRP *models.DesiredLRP
+	err = db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		var err error
+		row := db.one(logger, tx, desiredLRPsTable,
+			desiredLRPColumns, helpers.LockRow,
+			"process_guid = ?", processGuid,
+		)
+		beforeDesiredLRP, err = db.fetchDesiredLRP(logger, row, tx)
+		if err != nil {
+			logger.Error("failed-lock-desired", err)
+			return err
+		}
+
+		routesData, err := db.encodeRouteData(logger, routes)
+		if err != nil {
+			return err
+		}
+
+		modificationTag := *beforeDesiredLRP.ModificationTag
+		modificationTag.Increment()
+
+		_, err = db.update(logger, tx, desiredLRPsTable,
+			helpers.SQLAttributes{
+				"routes":                 routesData,
+				"modification_tag_index": modificationTag.Index,
+			},
+			"process_guid = ?", processGuid,
+		)
+		if err != nil {
+			logger.Error("failed-updating-desired-routes", err)
+			return err
+		}
+
+		afterDesiredLRP = beforeDesiredLRP.Copy()
+		afterDesiredLRP.Routes = routes
+		afterDesiredLRP.ModificationTag = &modificationTag
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return beforeDesiredLRP, afterDesiredLRP, nil
+}
+
 func (db *SQLDB) encodeRouteData(logger lager.Logger, routes *models.Routes) ([]byte, error) {
-	routeData, err := json.Marshal(routes)
+	routeData, err := json.Marshal(routes.Normalized())
 	if err != nil {
 		logger.Error("failed-marshalling-routes", err)
 		return nil, models.ErrBadRequest
@@ -290,6 +646,55 @@ func (db *SQLDB) RemoveDesiredLRP(logger lager.Logger, processGuid string) error
 	})
 }
 
+// ReassignDesiredLRPDomain moves the desired LRP and all of its actual LRPs
+// to newDomain in a single transaction, so the app keeps running instances.
+func (db *SQLDB) ReassignDesiredLRPDomain(logger lager.Logger, processGuid, newDomain string) error {
+	logger = logger.WithData(lager.Data{"process_guid": processGuid, "new_domain": newDomain})
+	logger.Info("starting")
+	defer logger.Info("complete")
+
+	if newDomain == "" {
+		return models.NewError(models.Error_InvalidRequest, "domain cannot be empty")
+	}
+
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		row := db.one(logger, tx, desiredLRPsTable,
+			helpers.ColumnList{"modification_tag_index"}, helpers.LockRow,
+			"process_guid = ?", processGuid,
+		)
+
+		var modificationTagIndex uint32
+		err := row.Scan(&modificationTagIndex)
+		if err != nil {
+			logger.Error("failed-lock-desired", err)
+			return db.convertSQLError(err)
+		}
+
+		_, err = db.update(logger, tx, desiredLRPsTable,
+			helpers.SQLAttributes{
+				"domain":                 newDomain,
+				"modification_tag_index": modificationTagIndex + 1,
+			},
+			"process_guid = ?", processGuid,
+		)
+		if err != nil {
+			logger.Error("failed-updating-desired-domain", err)
+			return err
+		}
+
+		_, err = db.update(logger, tx, actualLRPsTable,
+			helpers.SQLAttributes{"domain": newDomain},
+			"process_guid = ?", processGuid,
+		)
+		if err != nil {
+			logger.Error("failed-updating-actual-domains", err)
+			return err
+		}
+
+		return nil
+	})
+}
+
 // "rows" needs to have the columns defined in the schedulingInfoColumns constant
 func (db *SQLDB) fetchDesiredLRPSchedulingInfoAndMore(logger lager.Logger, scanner RowScanner, dest ...interface{}) (*models.DesiredLRPSchedulingInfo, error) {
 	schedulingInfo := &models.DesiredLRPSchedulingInfo{}
@@ -309,6 +714,7 @@ func (db *SQLDB) fetchDesiredLRPSchedulingInfoAndMore(logger lager.Logger, scann
 		&schedulingInfo.ModificationTag.Epoch,
 		&schedulingInfo.ModificationTag.Index,
 		&placementTagData,
+		&schedulingInfo.SuppressConvergence,
 	}
 	values = append(values, dest...)
 
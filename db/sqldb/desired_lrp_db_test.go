@@ -3,6 +3,8 @@ package sqldb_test
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/bbs/models"
 	"code.cloudfoundry.org/bbs/models/test/model_helpers"
@@ -28,6 +30,16 @@ var _ = Describe("DesiredLRPDB", func() {
 			Expect(desiredLRP).To(Equal(expectedDesiredLRP))
 		})
 
+		It("saves convergence suppression", func() {
+			expectedDesiredLRP.SuppressConvergence = true
+			err := sqlDB.DesireLRP(logger, expectedDesiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, "the-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desiredLRP.SuppressConvergence).To(BeTrue())
+		})
+
 		Context("when the process_guid is already taken", func() {
 			BeforeEach(func() {
 				err := sqlDB.DesireLRP(logger, expectedDesiredLRP)
@@ -41,6 +53,110 @@ var _ = Describe("DesiredLRPDB", func() {
 		})
 	})
 
+	Describe("DesireLRPs", func() {
+		var desiredLRPs []*models.DesiredLRP
+
+		BeforeEach(func() {
+			desiredLRPs = []*models.DesiredLRP{
+				model_helpers.NewValidDesiredLRP("web-guid"),
+				model_helpers.NewValidDesiredLRP("worker-guid"),
+				model_helpers.NewValidDesiredLRP("clock-guid"),
+			}
+		})
+
+		It("saves every lrp in the database", func() {
+			Expect(sqlDB.DesireLRPs(logger, desiredLRPs)).To(Succeed())
+
+			for _, expectedDesiredLRP := range desiredLRPs {
+				desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRP).To(Equal(expectedDesiredLRP))
+			}
+		})
+
+		Context("when one of the lrps is invalid", func() {
+			BeforeEach(func() {
+				desiredLRPs[1].Instances = -1
+			})
+
+			It("returns the validation error and saves none of them", func() {
+				err := sqlDB.DesireLRPs(logger, desiredLRPs)
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(BeAssignableToTypeOf(models.ValidationError{}))
+
+				desiredLRPs, err := sqlDB.DesiredLRPs(logger, models.DesiredLRPFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRPs).To(BeEmpty())
+			})
+		})
+
+		Context("when one of the lrps is nil", func() {
+			BeforeEach(func() {
+				desiredLRPs[1] = nil
+			})
+
+			It("returns a validation error and saves none of them", func() {
+				err := sqlDB.DesireLRPs(logger, desiredLRPs)
+				Expect(err).To(ConsistOf(models.ErrInvalidField{"desired_lrp"}))
+
+				desiredLRPs, err := sqlDB.DesiredLRPs(logger, models.DesiredLRPFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRPs).To(BeEmpty())
+			})
+		})
+
+		Context("when the rootfs of one of the lrps is not allowed", func() {
+			BeforeEach(func() {
+				models.SetImageSourceAllowlist([]string{"docker"}, nil)
+			})
+
+			AfterEach(func() {
+				models.SetImageSourceAllowlist(nil, nil)
+			})
+
+			It("returns the validation error and saves none of them", func() {
+				err := sqlDB.DesireLRPs(logger, desiredLRPs)
+				Expect(err).To(ConsistOf(BeAssignableToTypeOf(models.ErrDisallowedImageSource{})))
+
+				desiredLRPs, err := sqlDB.DesiredLRPs(logger, models.DesiredLRPFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRPs).To(BeEmpty())
+			})
+		})
+
+		Context("when one of the lrps fails to be written", func() {
+			BeforeEach(func() {
+				Expect(sqlDB.DesireLRP(logger, model_helpers.NewValidDesiredLRP("clock-guid"))).To(Succeed())
+			})
+
+			It("returns the error and rolls back the rest of the batch", func() {
+				err := sqlDB.DesireLRPs(logger, desiredLRPs)
+				Expect(err).To(Equal(models.ErrResourceExists))
+
+				_, err = sqlDB.DesiredLRPByProcessGuid(logger, "web-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+				_, err = sqlDB.DesiredLRPByProcessGuid(logger, "worker-guid")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+
+		Context("when a process guid appears more than once", func() {
+			BeforeEach(func() {
+				desiredLRPs = append(desiredLRPs, model_helpers.NewValidDesiredLRP("worker-guid"))
+			})
+
+			It("returns an error naming the guid and saves none of them", func() {
+				err := sqlDB.DesireLRPs(logger, desiredLRPs)
+				Expect(err).To(Equal(models.ErrDuplicateProcessGuid{ProcessGuid: "worker-guid"}))
+				Expect(err.Error()).To(ContainSubstring("worker-guid"))
+
+				desiredLRPs, err := sqlDB.DesiredLRPs(logger, models.DesiredLRPFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRPs).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("DesiredLRPByProcessGuid", func() {
 		var expectedDesiredLRP *models.DesiredLRP
 
@@ -106,6 +222,74 @@ var _ = Describe("DesiredLRPDB", func() {
 		})
 	})
 
+	Describe("DesiredLRPState", func() {
+		var expectedDesiredLRP *models.DesiredLRP
+
+		BeforeEach(func() {
+			expectedDesiredLRP = model_helpers.NewValidDesiredLRP("desired-lrp-guid")
+			expectedDesiredLRP.Instances = 2
+			Expect(sqlDB.DesireLRP(logger, expectedDesiredLRP)).To(Succeed())
+
+			for i := int32(0); i < 2; i++ {
+				key := models.NewActualLRPKey(expectedDesiredLRP.ProcessGuid, i, expectedDesiredLRP.Domain)
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, &key)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns the desired lrp and its actual lrp groups", func() {
+			desiredLRP, groups, err := sqlDB.DesiredLRPState(logger, expectedDesiredLRP.ProcessGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desiredLRP).To(BeEquivalentTo(expectedDesiredLRP))
+
+			expectedGroups, err := sqlDB.ActualLRPGroupsByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(ConsistOf(expectedGroups))
+		})
+
+		It("returns a consistent view while the domain is concurrently reassigned", func() {
+			done := make(chan struct{})
+			stopped := make(chan struct{})
+
+			go func() {
+				defer GinkgoRecover()
+				defer close(stopped)
+
+				domains := []string{"domain-a", "domain-b"}
+				for i := 0; ; i++ {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					err := sqlDB.ReassignDesiredLRPDomain(logger, expectedDesiredLRP.ProcessGuid, domains[i%2])
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}()
+
+			for i := 0; i < 50; i++ {
+				desiredLRP, groups, err := sqlDB.DesiredLRPState(logger, expectedDesiredLRP.ProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groups).To(HaveLen(2))
+				for _, group := range groups {
+					Expect(group.Instance.Domain).To(Equal(desiredLRP.Domain))
+				}
+			}
+
+			close(done)
+			Eventually(stopped).Should(BeClosed())
+		})
+
+		Context("when the desired lrp does not exist", func() {
+			It("returns a ResourceNotFound error", func() {
+				desiredLRP, groups, err := sqlDB.DesiredLRPState(logger, "does-not-exist")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+				Expect(desiredLRP).To(BeNil())
+				Expect(groups).To(BeNil())
+			})
+		})
+	})
+
 	Describe("DesiredLRPs", func() {
 		var expectedDesiredLRPs []*models.DesiredLRP
 
@@ -288,6 +472,128 @@ var _ = Describe("DesiredLRPDB", func() {
 		})
 	})
 
+	Describe("DesiredLRPsWithoutActuals", func() {
+		BeforeEach(func() {
+			staleWithoutActuals := model_helpers.NewValidDesiredLRP("stale-without-actuals")
+			Expect(sqlDB.DesireLRP(logger, staleWithoutActuals)).To(Succeed())
+
+			staleWithActuals := model_helpers.NewValidDesiredLRP("stale-with-actuals")
+			Expect(sqlDB.DesireLRP(logger, staleWithActuals)).To(Succeed())
+			key := models.NewActualLRPKey(staleWithActuals.ProcessGuid, 0, staleWithActuals.Domain)
+			_, err := sqlDB.CreateUnclaimedActualLRP(logger, &key)
+			Expect(err).NotTo(HaveOccurred())
+
+			staleWithoutInstances := model_helpers.NewValidDesiredLRP("stale-without-instances")
+			staleWithoutInstances.Instances = 0
+			Expect(sqlDB.DesireLRP(logger, staleWithoutInstances)).To(Succeed())
+
+			fakeClock.Increment(10 * time.Minute)
+
+			fresh := model_helpers.NewValidDesiredLRP("fresh-without-actuals")
+			Expect(sqlDB.DesireLRP(logger, fresh)).To(Succeed())
+
+			fakeClock.Increment(time.Minute)
+		})
+
+		It("returns the desired lrps without actuals that are older than the grace period", func() {
+			guids, err := sqlDB.DesiredLRPsWithoutActuals(logger, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(guids).To(ConsistOf("stale-without-actuals"))
+		})
+
+		Context("when the grace period covers every desired lrp", func() {
+			It("returns no guids", func() {
+				guids, err := sqlDB.DesiredLRPsWithoutActuals(logger, time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(guids).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DesiredLRPProcessGuids", func() {
+		BeforeEach(func() {
+			for _, guid := range []string{"d-1", "d-2", "d-3"} {
+				Expect(sqlDB.DesireLRP(logger, model_helpers.NewValidDesiredLRP(guid))).To(Succeed())
+			}
+		})
+
+		It("returns the process guids of all desired lrps", func() {
+			guids, err := sqlDB.DesiredLRPProcessGuids(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(guids).To(ConsistOf("d-1", "d-2", "d-3"))
+		})
+
+		Context("when the run info and routes are invalid", func() {
+			BeforeEach(func() {
+				queryStr := "UPDATE desired_lrps SET run_info = ?, routes = ? WHERE process_guid = ?"
+				if test_helpers.UsePostgres() {
+					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+				}
+				result, err := db.Exec(queryStr, "{{", "{{", "d-1")
+				Expect(err).NotTo(HaveOccurred())
+				rowsAffected, err := result.RowsAffected()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rowsAffected).To(BeEquivalentTo(1))
+			})
+
+			It("still returns every process guid without reading the payloads", func() {
+				guids, err := sqlDB.DesiredLRPProcessGuids(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(guids).To(ConsistOf("d-1", "d-2", "d-3"))
+			})
+		})
+
+		Context("when there are no desired lrps", func() {
+			BeforeEach(func() {
+				for _, guid := range []string{"d-1", "d-2", "d-3"} {
+					Expect(sqlDB.RemoveDesiredLRP(logger, guid)).To(Succeed())
+				}
+			})
+
+			It("returns an empty list", func() {
+				guids, err := sqlDB.DesiredLRPProcessGuids(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(guids).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DesiredLRPsByAnnotation", func() {
+		BeforeEach(func() {
+			for guid, annotation := range map[string]string{
+				"d-1": `{"space":"space-1"}`,
+				"d-2": `{"space":"space-2","org":"org-1"}`,
+				"d-3": `{"org":"space-1"}`,
+				"d-4": "space-1",
+				"d-5": "",
+			} {
+				desiredLRP := model_helpers.NewValidDesiredLRP(guid)
+				desiredLRP.Annotation = annotation
+				Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+			}
+		})
+
+		It("returns the desired lrps whose annotation has the matching value", func() {
+			desiredLRPs, err := sqlDB.DesiredLRPsByAnnotation(logger, "space", "space-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desiredLRPs).To(HaveLen(1))
+			Expect(desiredLRPs[0].ProcessGuid).To(Equal("d-1"))
+			Expect(desiredLRPs[0].Annotation).To(Equal(`{"space":"space-1"}`))
+		})
+
+		It("does not return desired lrps with a non-matching value", func() {
+			desiredLRPs, err := sqlDB.DesiredLRPsByAnnotation(logger, "space", "space-3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desiredLRPs).To(BeEmpty())
+		})
+
+		It("does not return desired lrps that do not have the key", func() {
+			desiredLRPs, err := sqlDB.DesiredLRPsByAnnotation(logger, "team", "space-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desiredLRPs).To(BeEmpty())
+		})
+	})
+
 	Describe("UpdateDesiredLRP", func() {
 		var expectedDesiredLRP *models.DesiredLRP
 		var update *models.DesiredLRPUpdate
@@ -391,6 +697,62 @@ var _ = Describe("DesiredLRPDB", func() {
 		})
 	})
 
+	Describe("TopCrashingDesiredLRPs", func() {
+		BeforeEach(func() {
+			crashCounts := map[string][]int32{
+				"crashy":          {5, 3},
+				"less-crashy":     {4},
+				"a-little-crashy": {0, 1},
+				"not-crashing":    {0},
+			}
+
+			for processGuid, counts := range crashCounts {
+				desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+				desiredLRP.Instances = int32(len(counts))
+				Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+
+				for i, count := range counts {
+					key := &models.ActualLRPKey{ProcessGuid: processGuid, Index: int32(i), Domain: desiredLRP.Domain}
+					_, err := sqlDB.CreateUnclaimedActualLRP(logger, key)
+					Expect(err).NotTo(HaveOccurred())
+
+					queryStr := `UPDATE actual_lrps SET crash_count = ? WHERE process_guid = ? AND instance_index = ?`
+					if test_helpers.UsePostgres() {
+						queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+					}
+					_, err = db.Exec(queryStr, count, processGuid, i)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+		})
+
+		It("returns the most crashed desired lrps first, limited to n", func() {
+			infos, err := sqlDB.TopCrashingDesiredLRPs(logger, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infos).To(Equal([]models.CrashingLRPInfo{
+				{ProcessGuid: "crashy", Domain: "some-domain", CrashCount: 8, CrashingInstances: 2},
+				{ProcessGuid: "less-crashy", Domain: "some-domain", CrashCount: 4, CrashingInstances: 1},
+			}))
+		})
+
+		It("does not return desired lrps that are not crashing", func() {
+			infos, err := sqlDB.TopCrashingDesiredLRPs(logger, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infos).To(HaveLen(3))
+			Expect(infos[2]).To(Equal(models.CrashingLRPInfo{
+				ProcessGuid: "a-little-crashy", Domain: "some-domain", CrashCount: 1, CrashingInstances: 1,
+			}))
+		})
+
+		Context("when n is not positive", func() {
+			It("returns no results", func() {
+				infos, err := sqlDB.TopCrashingDesiredLRPs(logger, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(infos).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("RemoveDesiredLRP", func() {
 		var expectedDesiredLRP *models.DesiredLRP
 
@@ -416,4 +778,161 @@ var _ = Describe("DesiredLRPDB", func() {
 			})
 		})
 	})
+
+	Describe("UpdateDesiredLRPRoutes", func() {
+		var (
+			expectedDesiredLRP *models.DesiredLRP
+			routes             models.Routes
+		)
+
+		BeforeEach(func() {
+			expectedDesiredLRP = model_helpers.NewValidDesiredLRP("desired-lrp-guid")
+			expectedDesiredLRP.Instances = 3
+			Expect(sqlDB.DesireLRP(logger, expectedDesiredLRP)).To(Succeed())
+
+			routeContent := []byte(`{"port":8080}`)
+			routes = models.Routes{
+				"new-router": (*json.RawMessage)(&routeContent),
+			}
+		})
+
+		It("updates only the routes of the lrp", func() {
+			_, _, err := sqlDB.UpdateDesiredLRPRoutes(logger, expectedDesiredLRP.ProcessGuid, &routes)
+			Expect(err).NotTo(HaveOccurred())
+
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desiredLRP.Instances).To(Equal(int32(3)))
+
+			expectedDesiredLRP.Routes = &routes
+			expectedDesiredLRP.ModificationTag.Increment()
+			Expect(desiredLRP).To(BeEquivalentTo(expectedDesiredLRP))
+		})
+
+		It("returns the lrp before and after the update", func() {
+			originalRoutes := expectedDesiredLRP.Routes
+
+			beforeDesiredLRP, afterDesiredLRP, err := sqlDB.UpdateDesiredLRPRoutes(logger, expectedDesiredLRP.ProcessGuid, &routes)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(beforeDesiredLRP).To(BeEquivalentTo(expectedDesiredLRP))
+			Expect(beforeDesiredLRP.Routes).To(Equal(originalRoutes))
+
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(afterDesiredLRP).To(BeEquivalentTo(desiredLRP))
+		})
+
+		Context("when the desired lrp does not exist", func() {
+			It("returns a ResourceNotFound error", func() {
+				_, _, err := sqlDB.UpdateDesiredLRPRoutes(logger, "does-not-exist", &routes)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+
+		Context("when the routes are nil", func() {
+			It("returns an invalid request error and leaves the routes unchanged", func() {
+				_, _, err := sqlDB.UpdateDesiredLRPRoutes(logger, expectedDesiredLRP.ProcessGuid, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*models.Error).Type).To(Equal(models.Error_InvalidRequest))
+
+				desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRP.Routes).To(Equal(expectedDesiredLRP.Routes))
+			})
+		})
+
+		Context("when the routes are too long", func() {
+			BeforeEach(func() {
+				routeContent := []byte(`"` + strings.Repeat("x", 128*1024) + `"`)
+				routes = models.Routes{
+					"new-router": (*json.RawMessage)(&routeContent),
+				}
+			})
+
+			It("returns an invalid request error and leaves the routes unchanged", func() {
+				_, _, err := sqlDB.UpdateDesiredLRPRoutes(logger, expectedDesiredLRP.ProcessGuid, &routes)
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*models.Error).Type).To(Equal(models.Error_InvalidRequest))
+
+				desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRP.Routes).To(Equal(expectedDesiredLRP.Routes))
+			})
+		})
+
+		Context("when there are more routes than allowed", func() {
+			BeforeEach(func() {
+				models.SetMaximumRouteCount(1)
+
+				otherRouteContent := []byte(`{"port":9090}`)
+				routes["other-router"] = (*json.RawMessage)(&otherRouteContent)
+			})
+
+			AfterEach(func() {
+				models.SetMaximumRouteCount(0)
+			})
+
+			It("returns an invalid request error and leaves the routes unchanged", func() {
+				_, _, err := sqlDB.UpdateDesiredLRPRoutes(logger, expectedDesiredLRP.ProcessGuid, &routes)
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*models.Error).Type).To(Equal(models.Error_InvalidRequest))
+
+				desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRP.Routes).To(Equal(expectedDesiredLRP.Routes))
+			})
+		})
+	})
+
+	Describe("ReassignDesiredLRPDomain", func() {
+		var expectedDesiredLRP *models.DesiredLRP
+
+		BeforeEach(func() {
+			expectedDesiredLRP = model_helpers.NewValidDesiredLRP("desired-lrp-guid")
+			Expect(sqlDB.DesireLRP(logger, expectedDesiredLRP)).To(Succeed())
+
+			for i := int32(0); i < 2; i++ {
+				key := models.NewActualLRPKey(expectedDesiredLRP.ProcessGuid, i, expectedDesiredLRP.Domain)
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, &key)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("moves the desired lrp and its actual lrps to the new domain", func() {
+			err := sqlDB.ReassignDesiredLRPDomain(logger, expectedDesiredLRP.ProcessGuid, "new-domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(desiredLRP.Domain).To(Equal("new-domain"))
+			Expect(desiredLRP.ModificationTag.Index).To(Equal(expectedDesiredLRP.ModificationTag.Index + 1))
+
+			groups, err := sqlDB.ActualLRPGroupsByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(HaveLen(2))
+			for _, group := range groups {
+				Expect(group.Instance.Domain).To(Equal("new-domain"))
+			}
+		})
+
+		Context("when the desired lrp does not exist", func() {
+			It("returns a ResourceNotFound error", func() {
+				err := sqlDB.ReassignDesiredLRPDomain(logger, "does-not-exist", "new-domain")
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+		})
+
+		Context("when the new domain is empty", func() {
+			It("returns an invalid request error and leaves the domain unchanged", func() {
+				err := sqlDB.ReassignDesiredLRPDomain(logger, expectedDesiredLRP.ProcessGuid, "")
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*models.Error).Type).To(Equal(models.Error_InvalidRequest))
+
+				desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, expectedDesiredLRP.ProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(desiredLRP.Domain).To(Equal(expectedDesiredLRP.Domain))
+			})
+		})
+	})
 })
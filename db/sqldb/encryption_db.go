@@ -3,14 +3,18 @@ package sqldb
 import (
 	"database/sql"
 	"fmt"
+	"sync"
 
 	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
-	"code.cloudfoundry.org/bbs/format"
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/workpool"
 )
 
 const EncryptionKeyID = "encryption_key_label"
 
+// The number of rows re-encrypted, and committed, per transaction.
+const reEncryptionBatchSize = 100
+
 func (db *SQLDB) SetEncryptionKeyLabel(logger lager.Logger, label string) error {
 	logger = logger.Session("set-encrption-key-label", lager.Data{"label": label})
 	logger.Debug("starting")
@@ -27,6 +31,13 @@ func (db *SQLDB) EncryptionKeyLabel(logger lager.Logger) (string, error) {
 	return db.getConfigurationValue(logger, EncryptionKeyID)
 }
 
+// SetReEncryptionWorkers bounds the number of batches of rows each table
+// re-encrypts concurrently during PerformEncryption. Values below 1 re-encrypt
+// the batches of a table one at a time.
+func (db *SQLDB) SetReEncryptionWorkers(workers int) {
+	db.reEncryptionWorkers = workers
+}
+
 func (db *SQLDB) PerformEncryption(logger lager.Logger) error {
 	errCh := make(chan error)
 
@@ -40,6 +51,15 @@ func (db *SQLDB) PerformEncryption(logger lager.Logger) error {
 		func() {
 			errCh <- db.reEncrypt(logger, actualLRPsTable, "process_guid", false, "net_info")
 		},
+		func() {
+			errCh <- db.reEncrypt(logger, startRequestBufferTable, "process_guid", true, "start_request")
+		},
+		func() {
+			errCh <- db.reEncrypt(logger, startRequestDeadLettersTable, "process_guid", true, "start_request")
+		},
+		func() {
+			errCh <- db.reEncryptConvergenceLock(logger)
+		},
 	}
 
 	for _, f := range funcs {
@@ -66,6 +86,7 @@ func (db *SQLDB) reEncrypt(logger lager.Logger, tableName, primaryKey string, en
 	defer rows.Close()
 
 	guids := []string{}
+	seen := map[string]struct{}{}
 	for rows.Next() {
 		var guid string
 		err := rows.Scan(&guid)
@@ -73,67 +94,124 @@ func (db *SQLDB) reEncrypt(logger lager.Logger, tableName, primaryKey string, en
 			logger.Error("failed-to-scan-primary-key", err)
 			continue
 		}
+		if _, ok := seen[guid]; ok {
+			continue
+		}
+		seen[guid] = struct{}{}
 		guids = append(guids, guid)
 	}
 
-	where := fmt.Sprintf("%s = ?", primaryKey)
-	for _, guid := range guids {
-		err = db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
-			blobs := make([]interface{}, len(blobColumns))
-
-			row := db.one(logger, tx, tableName, blobColumns, helpers.LockRow, where, guid)
-			for i := range blobColumns {
-				var blob []byte
-				blobs[i] = &blob
-			}
-
-			err := row.Scan(blobs...)
+	works := []func(){}
+	errLock := &sync.Mutex{}
+	var batchErr error
+	for start := 0; start < len(guids); start += reEncryptionBatchSize {
+		end := start + reEncryptionBatchSize
+		if end > len(guids) {
+			end = len(guids)
+		}
+		batch := guids[start:end]
+		works = append(works, func() {
+			err := db.reEncryptBatch(logger, tableName, primaryKey, encryptIfEmpty, batch, blobColumns)
 			if err != nil {
-				logger.Error("failed-to-scan-blob", err)
-				return nil
+				errLock.Lock()
+				if batchErr == nil {
+					batchErr = err
+				}
+				errLock.Unlock()
 			}
+		})
+	}
 
-			updatedColumnValues := map[string]interface{}{}
+	if len(works) == 0 {
+		return nil
+	}
 
-			for columnIdx := range blobs {
-				// This type assertion should not fail because we set the value to be a pointer to a byte array above
-				blobPtr := blobs[columnIdx].(*[]byte)
-				blob := *blobPtr
+	workers := db.reEncryptionWorkers
+	if workers < 1 {
+		workers = 1
+	}
 
-				// don't encrypt column if it doesn't contain any data, see #132626553 for more info
-				if !encryptIfEmpty && len(blob) == 0 {
-					return nil
-				}
+	throttler, err := workpool.NewThrottler(workers, works)
+	if err != nil {
+		logger.Error("failed-constructing-throttler", err, lager.Data{"max_workers": workers, "num_works": len(works)})
+		return err
+	}
+	throttler.Work()
 
-				encoder := format.NewEncoder(db.cryptor)
-				payload, err := encoder.Decode(blob)
-				if err != nil {
-					logger.Error("failed-to-decode-blob", err)
-					return nil
-				}
-				encryptedPayload, err := encoder.Encode(format.BASE64_ENCRYPTED, payload)
-				if err != nil {
-					logger.Error("failed-to-encode-blob", err)
-					return err
-				}
+	return batchErr
+}
 
-				columnName := blobColumns[columnIdx]
-				updatedColumnValues[columnName] = encryptedPayload
-			}
-			_, err = db.update(logger, tx, tableName,
-				updatedColumnValues,
-				where, guid,
-			)
+// Re-encrypts the rows with the given primary keys in a single transaction.
+func (db *SQLDB) reEncryptBatch(logger lager.Logger, tableName, primaryKey string, encryptIfEmpty bool, guids []string, blobColumns []string) error {
+	where := fmt.Sprintf("%s = ?", primaryKey)
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		for _, guid := range guids {
+			err := db.reEncryptRow(logger, tx, tableName, where, guid, encryptIfEmpty, blobColumns)
 			if err != nil {
-				logger.Error("failed-to-update-blob", err)
 				return err
 			}
+		}
+		return nil
+	})
+}
+
+// Rows that cannot be read or decoded are skipped rather than failing the
+// batch they belong to.
+func (db *SQLDB) reEncryptRow(logger lager.Logger, tx *sql.Tx, tableName, where, guid string, encryptIfEmpty bool, blobColumns []string) error {
+	blobs := make([]interface{}, len(blobColumns))
+
+	row := db.one(logger, tx, tableName, blobColumns, helpers.LockRow, where, guid)
+	for i := range blobColumns {
+		var blob []byte
+		blobs[i] = &blob
+	}
+
+	err := row.Scan(blobs...)
+	if err != nil {
+		logger.Error("failed-to-scan-blob", err)
+		return nil
+	}
+
+	updatedColumnValues := map[string]interface{}{}
+
+	for columnIdx := range blobs {
+		// This type assertion should not fail because we set the value to be a pointer to a byte array above
+		blobPtr := blobs[columnIdx].(*[]byte)
+		blob := *blobPtr
+
+		// don't encrypt column if it doesn't contain any data, see #132626553 for more info
+		if !encryptIfEmpty && len(blob) == 0 {
 			return nil
-		})
+		}
 
+		payload, err := db.encoder.Decode(blob)
 		if err != nil {
+			logger.Error("failed-to-decode-blob", err)
+			return nil
+		}
+		columnName := blobColumns[columnIdx]
+		encoding := db.encryptionScheme
+		if model, ok := blobColumnModels[columnName]; ok {
+			if _, ok := db.encodingPolicy[model]; ok {
+				encoding = db.writeEncoding(model)
+			}
+		}
+
+		encryptedPayload, err := db.encoder.Encode(encoding, payload)
+		if err != nil {
+			logger.Error("failed-to-encode-blob", err)
 			return err
 		}
+
+		updatedColumnValues[columnName] = encryptedPayload
+	}
+	_, err = db.update(logger, tx, tableName,
+		updatedColumnValues,
+		where, guid,
+	)
+	if err != nil {
+		logger.Error("failed-to-update-blob", err)
+		return err
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package sqldb_test
 import (
 	"crypto/rand"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -11,6 +12,7 @@ import (
 	"code.cloudfoundry.org/bbs/encryption"
 	"code.cloudfoundry.org/bbs/format"
 	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/bbs/models/test/model_helpers"
 	"code.cloudfoundry.org/bbs/test_helpers"
 )
 
@@ -107,7 +109,7 @@ var _ = Describe("Encryption", func() {
 		})
 	})
 
-	makeCryptor := func(activeLabel string, decryptionLabels ...string) encryption.Cryptor {
+	makeKeyManager := func(activeLabel string, decryptionLabels ...string) encryption.KeyManager {
 		activeKey, err := encryption.NewKey(activeLabel, fmt.Sprintf("%s-passphrase", activeLabel))
 		Expect(err).NotTo(HaveOccurred())
 
@@ -123,9 +125,151 @@ var _ = Describe("Encryption", func() {
 
 		keyManager, err := encryption.NewKeyManager(activeKey, decryptionKeys)
 		Expect(err).NotTo(HaveOccurred())
-		return encryption.NewCryptor(keyManager, rand.Reader)
+		return keyManager
+	}
+
+	makeCryptor := func(activeLabel string, decryptionLabels ...string) encryption.Cryptor {
+		return encryption.NewCryptor(makeKeyManager(activeLabel, decryptionLabels...), rand.Reader)
 	}
 
+	Describe("SetEncryptionScheme", func() {
+		var keyManager encryption.KeyManager
+
+		BeforeEach(func() {
+			keyManager = makeKeyManager("label")
+		})
+
+		It("writes records with the ChaCha20 scheme and reads them back", func() {
+			sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, encryption.NewCryptor(keyManager, rand.Reader), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+			Expect(sqlDB.SetEncryptionScheme(encryption.NewChaCha20Cryptor(keyManager, rand.Reader), format.CHACHA20_ENCRYPTED)).To(Succeed())
+
+			taskDef := model_helpers.NewValidTaskDefinition()
+			_, err := sqlDB.DesireTask(logger, taskDef, "task-guid", "domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			queryStr := "SELECT task_definition FROM tasks WHERE guid = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			var stored []byte
+			Expect(db.QueryRow(queryStr, "task-guid").Scan(&stored)).To(Succeed())
+			Expect(format.PayloadEncoding(stored)).To(Equal(format.CHACHA20_ENCRYPTED))
+
+			task, err := sqlDB.TaskByGuid(logger, "task-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.TaskDefinition).To(Equal(taskDef))
+		})
+
+		It("keeps the ChaCha20 scheme when nonce reuse detection is set afterwards", func() {
+			sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, encryption.NewCryptor(keyManager, rand.Reader), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+			Expect(sqlDB.SetEncryptionScheme(encryption.NewChaCha20Cryptor(keyManager, rand.Reader), format.CHACHA20_ENCRYPTED)).To(Succeed())
+			sqlDB.SetNonceReuseDetection(logger, 10)
+
+			_, err := sqlDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), "task-guid", "domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			queryStr := "SELECT task_definition FROM tasks WHERE guid = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			var stored []byte
+			Expect(db.QueryRow(queryStr, "task-guid").Scan(&stored)).To(Succeed())
+			Expect(format.PayloadEncoding(stored)).To(Equal(format.CHACHA20_ENCRYPTED))
+
+			_, err = sqlDB.TaskByGuid(logger, "task-guid")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects an unencrypted scheme", func() {
+			Expect(sqlDB.SetEncryptionScheme(encryption.NewChaCha20Cryptor(keyManager, rand.Reader), format.BASE64)).NotTo(Succeed())
+		})
+
+		It("re-encrypts existing records with the ChaCha20 scheme", func() {
+			encoded, err := format.NewEncoder(encryption.NewCryptor(keyManager, rand.Reader)).Encode(format.BASE64_ENCRYPTED, []byte("some text"))
+			Expect(err).NotTo(HaveOccurred())
+
+			queryStr := "INSERT INTO tasks (guid, domain, task_definition) VALUES (?, ?, ?)"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			_, err = db.Exec(queryStr, "task-guid", "fake-domain", encoded)
+			Expect(err).NotTo(HaveOccurred())
+
+			chacha20Cryptor := encryption.NewChaCha20Cryptor(keyManager, rand.Reader)
+			sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, encryption.NewCryptor(keyManager, rand.Reader), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+			Expect(sqlDB.SetEncryptionScheme(chacha20Cryptor, format.CHACHA20_ENCRYPTED)).To(Succeed())
+			Expect(sqlDB.PerformEncryption(logger)).To(Succeed())
+
+			queryStr = "SELECT task_definition FROM tasks WHERE guid = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			var stored []byte
+			Expect(db.QueryRow(queryStr, "task-guid").Scan(&stored)).To(Succeed())
+			Expect(format.PayloadEncoding(stored)).To(Equal(format.CHACHA20_ENCRYPTED))
+
+			encoder, err := format.NewEncoderWithEncryptionScheme(nil, chacha20Cryptor, format.CHACHA20_ENCRYPTED, nil)
+			Expect(err).NotTo(HaveOccurred())
+			decoded, err := encoder.Decode(stored)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(Equal([]byte("some text")))
+		})
+	})
+
+	Describe("SetEncodingPolicy", func() {
+		var sqlDB *sqldb.SQLDB
+
+		BeforeEach(func() {
+			sqlDB = sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, makeCryptor("label"), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+			Expect(sqlDB.SetEncodingPolicy(format.EncodingPolicy{"TaskDefinition": format.BASE64})).To(Succeed())
+		})
+
+		storedTaskDefinition := func() []byte {
+			queryStr := "SELECT task_definition FROM tasks WHERE guid = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			var stored []byte
+			Expect(db.QueryRow(queryStr, "task-guid").Scan(&stored)).To(Succeed())
+			return stored
+		}
+
+		It("writes the records named in the policy with its encoding and reads them back", func() {
+			taskDef := model_helpers.NewValidTaskDefinition()
+			_, err := sqlDB.DesireTask(logger, taskDef, "task-guid", "domain")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.PayloadEncoding(storedTaskDefinition())).To(Equal(format.BASE64))
+
+			task, err := sqlDB.TaskByGuid(logger, "task-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.TaskDefinition).To(Equal(taskDef))
+		})
+
+		It("writes the records missing from the policy with the encoding of the format", func() {
+			Expect(sqlDB.DesireLRP(logger, model_helpers.NewValidDesiredLRP("process-guid"))).To(Succeed())
+
+			queryStr := "SELECT run_info FROM desired_lrps WHERE process_guid = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			var stored []byte
+			Expect(db.QueryRow(queryStr, "process-guid").Scan(&stored)).To(Succeed())
+			Expect(format.PayloadEncoding(stored)).To(Equal(format.BASE64_ENCRYPTED))
+		})
+
+		It("keeps the encoding of the policy when re-encrypting", func() {
+			_, err := sqlDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), "task-guid", "domain")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sqlDB.PerformEncryption(logger)).To(Succeed())
+			Expect(format.PayloadEncoding(storedTaskDefinition())).To(Equal(format.BASE64))
+		})
+
+		It("rejects records it does not store", func() {
+			Expect(sqlDB.SetEncodingPolicy(format.EncodingPolicy{"Task": format.BASE64})).NotTo(Succeed())
+		})
+	})
+
 	Describe("PerformEncryption", func() {
 		It("recursively re-encrypts all existing records", func() {
 			var cryptor encryption.Cryptor
@@ -173,7 +317,7 @@ var _ = Describe("Encryption", func() {
 			Expect(err).NotTo(HaveOccurred())
 			cryptor = makeCryptor("new", "old")
 
-			sqlDB := sqldb.NewSQLDB(db, 5, 5, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+			sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
 			err = sqlDB.PerformEncryption(logger)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -214,6 +358,69 @@ var _ = Describe("Encryption", func() {
 			Expect(decryptedVolumePlacement).To(Equal(unencodedVolumePlacement))
 		})
 
+		Context("start request encryption", func() {
+			var unencodedStartRequest []byte
+
+			BeforeEach(func() {
+				unencodedStartRequest = []byte(`{"process_guid":"some-guid","indices":[0]}`)
+				encoder := format.NewEncoder(makeCryptor("old"))
+
+				for _, table := range []string{"start_request_buffer", "start_request_dead_letters"} {
+					encodedStartRequest, err := encoder.Encode(format.BASE64_ENCRYPTED, unencodedStartRequest)
+					Expect(err).NotTo(HaveOccurred())
+
+					queryStr := fmt.Sprintf("INSERT INTO %s (process_guid, start_request) VALUES (?, ?)", table)
+					if test_helpers.UsePostgres() {
+						queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+					}
+					_, err = db.Exec(queryStr, "some-guid", encodedStartRequest)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("re-encrypts buffered and dead-lettered start requests with the new key", func() {
+				sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, makeCryptor("new", "old"), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+				err := sqlDB.PerformEncryption(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				encoder := format.NewEncoder(makeCryptor("new"))
+				for _, table := range []string{"start_request_buffer", "start_request_dead_letters"} {
+					queryStr := fmt.Sprintf("SELECT start_request FROM %s WHERE process_guid = ?", table)
+					if test_helpers.UsePostgres() {
+						queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+					}
+					var result []byte
+					err = db.QueryRow(queryStr, "some-guid").Scan(&result)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(result).NotTo(ContainSubstring("some-guid"))
+					decrypted, err := encoder.Decode(result)
+					Expect(err).NotTo(HaveOccurred(), table)
+					Expect(decrypted).To(Equal(unencodedStartRequest))
+				}
+			})
+		})
+
+		Context("convergence lock encryption", func() {
+			BeforeEach(func() {
+				sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, makeCryptor("old"), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+				acquired, err := sqlDB.AcquireConvergenceLock(logger, "bbs-0", time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("re-encrypts the convergence lock holder with the new key", func() {
+				sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, makeCryptor("new", "old"), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+				err := sqlDB.PerformEncryption(logger)
+				Expect(err).NotTo(HaveOccurred())
+
+				sqlDB = sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, makeCryptor("new"), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+				holder, _, err := sqlDB.ConvergenceLockHolder(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holder).To(Equal("bbs-0"))
+			})
+		})
+
 		Context("net_info encryption", func() {
 			var (
 				processGuid = "uniqueprocessguid"
@@ -240,7 +447,7 @@ var _ = Describe("Encryption", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				cryptor = makeCryptor("new", "old")
-				sqlDB := sqldb.NewSQLDB(db, 5, 5, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+				sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
 				err = sqlDB.PerformEncryption(logger)
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -312,9 +519,54 @@ var _ = Describe("Encryption", func() {
 
 			cryptor = makeCryptor("new", "old")
 
-			sqlDB := sqldb.NewSQLDB(db, 5, 5, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+			sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
 			err = sqlDB.PerformEncryption(logger)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		for _, workers := range []int{0, 1, 4, 16} {
+			workers := workers
+
+			Context(fmt.Sprintf("with %d re-encryption workers", workers), func() {
+				const taskCount = 250
+
+				BeforeEach(func() {
+					encoder := format.NewEncoder(makeCryptor("old"))
+
+					queryStr := "INSERT INTO tasks (guid, domain, task_definition) VALUES (?, ?, ?)"
+					if test_helpers.UsePostgres() {
+						queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+					}
+					for i := 0; i < taskCount; i++ {
+						encoded, err := encoder.Encode(format.BASE64_ENCRYPTED, []byte(fmt.Sprintf("task-definition-%d", i)))
+						Expect(err).NotTo(HaveOccurred())
+						_, err = db.Exec(queryStr, fmt.Sprintf("task-guid-%d", i), "fake-domain", encoded)
+						Expect(err).NotTo(HaveOccurred())
+					}
+				})
+
+				It("re-encrypts every record with the new key", func() {
+					sqlDB := sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, makeCryptor("new", "old"), fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+					sqlDB.SetReEncryptionWorkers(workers)
+					Expect(sqlDB.PerformEncryption(logger)).To(Succeed())
+
+					encoder := format.NewEncoder(makeCryptor("new"))
+
+					queryStr := "SELECT task_definition FROM tasks WHERE guid = ?"
+					if test_helpers.UsePostgres() {
+						queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+					}
+					for i := 0; i < taskCount; i++ {
+						var result []byte
+						err := db.QueryRow(queryStr, fmt.Sprintf("task-guid-%d", i)).Scan(&result)
+						Expect(err).NotTo(HaveOccurred())
+
+						decrypted, err := encoder.Decode(result)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(decrypted).To(Equal([]byte(fmt.Sprintf("task-definition-%d", i))))
+					}
+				})
+			})
+		}
 	})
 })
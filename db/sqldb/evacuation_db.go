@@ -47,12 +47,18 @@ func (db *SQLDB) EvacuateActualLRP(
 			return nil
 		}
 
+		annotation, err := db.evacuatingInstanceAnnotation(logger, tx, lrpKey, instanceKey)
+		if err != nil {
+			return err
+		}
+
 		now := db.clock.Now().UnixNano()
 		actualLRP.ModificationTag.Increment()
 		actualLRP.ActualLRPKey = *lrpKey
 		actualLRP.ActualLRPInstanceKey = *instanceKey
 		actualLRP.Since = now
 		actualLRP.ActualLRPNetInfo = *netInfo
+		actualLRP.Annotation = annotation
 
 		netInfoData, err := db.serializeModel(logger, netInfo)
 		if err != nil {
@@ -69,6 +75,7 @@ func (db *SQLDB) EvacuateActualLRP(
 				"state":                  actualLRP.State,
 				"since":                  actualLRP.Since,
 				"modification_tag_index": actualLRP.ModificationTag.Index,
+				"annotation":             actualLRP.Annotation,
 			},
 			"process_guid = ? AND instance_index = ? AND evacuating = ?",
 			actualLRP.ProcessGuid, actualLRP.Index, true,
@@ -141,6 +148,11 @@ func (db *SQLDB) createEvacuatingActualLRP(logger lager.Logger,
 		return nil, models.ErrGUIDGeneration
 	}
 
+	annotation, err := db.evacuatingInstanceAnnotation(logger, tx, lrpKey, instanceKey)
+	if err != nil {
+		return nil, err
+	}
+
 	expireTime := now.Add(time.Duration(ttl) * time.Second)
 	actualLRP := &models.ActualLRP{
 		ActualLRPKey:         *lrpKey,
@@ -149,6 +161,7 @@ func (db *SQLDB) createEvacuatingActualLRP(logger lager.Logger,
 		State:                models.ActualLRPStateRunning,
 		Since:                now.UnixNano(),
 		ModificationTag:      models.ModificationTag{Epoch: guid, Index: 0},
+		Annotation:           annotation,
 	}
 
 	sqlAttributes := helpers.SQLAttributes{
@@ -164,6 +177,7 @@ func (db *SQLDB) createEvacuatingActualLRP(logger lager.Logger,
 		"modification_tag_epoch": actualLRP.ModificationTag.Epoch,
 		"modification_tag_index": actualLRP.ModificationTag.Index,
 		"expire_time":            expireTime.UnixNano(),
+		"annotation":             actualLRP.Annotation,
 	}
 
 	_, err = db.upsert(logger, tx, "actual_lrps",
@@ -178,3 +192,20 @@ func (db *SQLDB) createEvacuatingActualLRP(logger lager.Logger,
 
 	return actualLRP, nil
 }
+
+// The evacuating instance keeps running the version it was started with,
+// which the instance row still records until it is unclaimed.
+func (db *SQLDB) evacuatingInstanceAnnotation(logger lager.Logger, tx *sql.Tx, lrpKey *models.ActualLRPKey, instanceKey *models.ActualLRPInstanceKey) (string, error) {
+	var annotation sql.NullString
+	row := db.one(logger, tx, actualLRPsTable,
+		helpers.ColumnList{"annotation"}, helpers.NoLockRow,
+		"process_guid = ? AND instance_index = ? AND evacuating = ? AND instance_guid = ?",
+		lrpKey.ProcessGuid, lrpKey.Index, false, instanceKey.InstanceGuid,
+	)
+	err := row.Scan(&annotation)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("failed-fetching-instance-annotation", err)
+		return "", db.convertSQLError(err)
+	}
+	return annotation.String, nil
+}
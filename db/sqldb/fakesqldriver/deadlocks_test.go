@@ -3,12 +3,14 @@ package fakesqldriver_test
 import (
 	"database/sql/driver"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/bbs/db/sqldb/fakesqldriver/fakesqldriverfakes"
 	"code.cloudfoundry.org/bbs/models"
 	"github.com/go-sql-driver/mysql"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("Deadlocks", func() {
@@ -285,4 +287,37 @@ var _ = Describe("Deadlocks", func() {
 			Expect(fakeConn.BeginCallCount()).To(Equal(3))
 		})
 	})
+
+	Context("when a transaction retry budget is set", func() {
+		BeforeEach(func() {
+			sqlDB.SetTransactionRetryBudget(2)
+		})
+
+		It("fails fast with the original error once the budget is spent", func() {
+			_, err := sqlDB.Domains(logger)
+			Expect(err).To(Equal(models.ErrDeadlock))
+			Expect(fakeConn.BeginCallCount()).To(Equal(3))
+
+			_, err = sqlDB.Domains(logger)
+			Expect(err).To(Equal(models.ErrDeadlock))
+			Expect(fakeConn.BeginCallCount()).To(Equal(4))
+
+			_, err = sqlDB.Domains(logger)
+			Expect(err).To(Equal(models.ErrDeadlock))
+			Expect(fakeConn.BeginCallCount()).To(Equal(5))
+			Expect(logger).To(gbytes.Say("retry-budget-exhausted"))
+		})
+
+		It("allows retries again once the budget refills", func() {
+			_, err := sqlDB.Domains(logger)
+			Expect(err).To(HaveOccurred())
+			Expect(fakeConn.BeginCallCount()).To(Equal(3))
+
+			fakeClock.Increment(time.Second)
+
+			_, err = sqlDB.Domains(logger)
+			Expect(err).To(HaveOccurred())
+			Expect(fakeConn.BeginCallCount()).To(Equal(6))
+		})
+	})
 })
@@ -76,5 +76,5 @@ var _ = BeforeEach(func() {
 	cryptor = encryption.NewCryptor(keyManager, rand.Reader)
 	serializer = format.NewSerializer(cryptor)
 
-	sqlDB = sqldb.NewSQLDB(db, 5, 5, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, helpers.MySQL, fakeMetronClient)
+	sqlDB = sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, helpers.MySQL, fakeMetronClient)
 })
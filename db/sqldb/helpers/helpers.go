@@ -28,10 +28,12 @@ type SQLHelper interface {
 
 	ConvertSQLError(err error) error
 	Rebind(query string) string
+	SetRetryBudget(budget *RetryBudget)
 }
 
 type sqlHelper struct {
-	flavor string
+	flavor      string
+	retryBudget *RetryBudget
 }
 
 func NewSQLHelper(flavor string) *sqlHelper {
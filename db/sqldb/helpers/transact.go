@@ -3,11 +3,56 @@ package helpers
 import (
 	"database/sql"
 	"database/sql/driver"
+	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 )
 
+// RetryBudget is a token bucket capping the number of transaction retries per
+// second across every goroutine sharing it.
+type RetryBudget struct {
+	clock            clock.Clock
+	retriesPerSecond float64
+
+	lock       sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+}
+
+func NewRetryBudget(clock clock.Clock, retriesPerSecond int) *RetryBudget {
+	return &RetryBudget{
+		clock:            clock,
+		retriesPerSecond: float64(retriesPerSecond),
+		tokens:           float64(retriesPerSecond),
+		lastRefill:       clock.Now(),
+	}
+}
+
+// Take consumes a retry from the budget, returning false if none are left.
+func (b *RetryBudget) Take() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Now()
+	b.tokens += now.Sub(b.lastRefill).Seconds() * b.retriesPerSecond
+	if b.tokens > b.retriesPerSecond {
+		b.tokens = b.retriesPerSecond
+	}
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (h *sqlHelper) SetRetryBudget(budget *RetryBudget) {
+	h.retryBudget = budget
+}
+
 // BEGIN TRANSACTION; f ... ; COMMIT; or
 // BEGIN TRANSACTION; f ... ; ROLLBACK; if f returns an error.
 func (h *sqlHelper) Transact(logger lager.Logger, db *sql.DB, f func(logger lager.Logger, tx *sql.Tx) error) error {
@@ -42,6 +87,9 @@ func (h *sqlHelper) Transact(logger lager.Logger, db *sql.DB, f func(logger lage
 		// caller to initiate a retry
 		if attempts >= 2 || (convertedErr != ErrDeadlock && convertedErr != driver.ErrBadConn) {
 			break
+		} else if h.retryBudget != nil && !h.retryBudget.Take() {
+			logger.Error("retry-budget-exhausted", err, lager.Data{"attempts": attempts})
+			break
 		} else {
 			logger.Error("deadlock-transaction", err, lager.Data{"attempts": attempts})
 			time.Sleep(500 * time.Millisecond)
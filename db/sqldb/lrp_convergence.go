@@ -2,13 +2,16 @@ package sqldb
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"code.cloudfoundry.org/auctioneer"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
 	"code.cloudfoundry.org/bbs/models"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/workpool"
@@ -18,22 +21,66 @@ const (
 	convergeLRPRunsCounter = "ConvergenceLRPRuns"
 	convergeLRPDuration    = "ConvergenceLRPDuration"
 
-	domainMetricPrefix = "Domain."
+	convergeCellSetEmptySkippedCounter = "ConvergenceCellSetEmptySkipped"
+
+	domainMetricPrefix      = "Domain."
+	staleDomainMetricPrefix = "DomainStale."
 
 	instanceLRPs  = "LRPsDesired" // this is the number of desired instances
 	claimedLRPs   = "LRPsClaimed"
 	unclaimedLRPs = "LRPsUnclaimed"
 	runningLRPs   = "LRPsRunning"
 
-	missingLRPs = "LRPsMissing"
-	extraLRPs   = "LRPsExtra"
+	missingLRPs      = "LRPsMissing"
+	extraLRPs        = "LRPsExtra"
+	inconsistentLRPs = "LRPsInconsistentState"
+	pendingLRPs      = "LRPsPendingPlacement"
+	suppressedLRPs   = "LRPsConvergenceSuppressed"
+	recycledLRPs     = "LRPsRecycledForAge"
+
+	placementFairness = "LRPPlacementFairness"
+
+	invalidDomainLRPs = "LRPsInvalidDomain"
 
 	crashedActualLRPs   = "CrashedActualLRPs"
 	crashingDesiredLRPs = "CrashingDesiredLRPs"
+
+	unclaimedAgeMetricPrefix = "LRPsUnclaimedAge."
+
+	firstCrashCounterPrefix = "LRPFirstCrash."
 )
 
-func (db *SQLDB) ConvergeLRPs(logger lager.Logger, cellSet models.CellSet) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKey) {
-	convergeStart := db.clock.Now()
+// The upper bounds of the LRPsUnclaimedAge buckets, in increasing order.
+// Unclaimed actual LRPs at least as old as the last bound are reported in an
+// overflow bucket.
+var unclaimedAgeBuckets = []struct {
+	name  string
+	bound time.Duration
+}{
+	{"lt1s", time.Second},
+	{"lt10s", 10 * time.Second},
+	{"lt60s", 60 * time.Second},
+}
+
+const unclaimedAgeOverflowBucket = "gte60s"
+
+// ConvergeLRPs never runs at the same time as ConvergeTasks or Converge on the
+// same SQLDB, so that LRP and task convergence do not load the database at
+// once.
+func (db *SQLDB) ConvergeLRPs(logger lager.Logger, cellSet models.CellSet) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKeyWithRetireReason) {
+	db.convergenceLock.Lock()
+	defer db.convergenceLock.Unlock()
+
+	return db.convergeLRPs(logger, cellSet)
+}
+
+func (db *SQLDB) convergeLRPs(logger lager.Logger, cellSet models.CellSet) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKeyWithRetireReason) {
+	actionLogger := logger
+	if db.compactConvergenceLogs {
+		logger = errorsOnlyLogger{logger}
+	}
+
+	convergeStart := time.Now()
 	db.metronClient.IncrementCounter(convergeLRPRunsCounter)
 	logger.Info("starting")
 	defer logger.Info("completed")
@@ -56,33 +103,194 @@ func (db *SQLDB) ConvergeLRPs(logger lager.Logger, cellSet models.CellSet) ([]*a
 	}
 
 	db.emitDomainMetrics(logger, domainSet)
+	db.emitStaleDomainMetrics(logger, now)
+	db.emitFirstCrashEvents(logger)
+
+	converge := newConvergence(db, cellSet)
+	converge.recordActions = db.compactConvergenceLogs
+	skipMissingCells := len(cellSet) == 0 && db.skipMissingCellsWhenCellSetEmpty
+	if skipMissingCells {
+		logger.Error("skipping-missing-cells-for-empty-cell-set", nil)
+		db.metronClient.IncrementCounter(convergeCellSetEmptySkippedCounter)
+	}
+
+	if db.skipIdleConvergence && !db.hasConvergenceCandidates(logger, cellSet, now) {
+		logger.Info("skipping-convergence-steps-without-candidates")
+		converge.skippedSteps(logger, now)
+		converge.runningActualLRPsWithMissingNetInfo(logger)
+	} else {
+		converge.staleUnclaimedActualLRPs(logger, now)
+		if !skipMissingCells {
+			converge.actualLRPsWithMissingCells(logger, cellSet)
+		}
+		converge.lrpInstanceCounts(logger, domainSet, now)
+		converge.orphanedActualLRPs(logger, now)
+		converge.crashedActualLRPs(logger, now)
+		converge.runningActualLRPsWithMissingNetInfo(logger)
+		if db.maxInstanceLifetime > 0 {
+			converge.agedRunningActualLRPs(logger, now)
+		}
+	}
+
+	startRequests, keysWithMissingCells, keysToRetire := converge.result(logger)
+	startRequests, deferredStartRequests := db.capStartRequests(logger, startRequests)
+	if db.compactConvergenceLogs {
+		converge.logActions(actionLogger, startRequests, deferredStartRequests)
+	}
+
+	db.emitInvalidDomainMetrics(logger)
+	converge.emitDomainLRPMetrics(logger, domainSet)
+	if db.placementFairnessMetric {
+		db.emitPlacementFairness(logger, cellSet)
+	}
+
+	return startRequests, keysWithMissingCells, keysToRetire
+}
 
-	converge := newConvergence(db)
-	converge.staleUnclaimedActualLRPs(logger, now)
-	converge.actualLRPsWithMissingCells(logger, cellSet)
-	converge.lrpInstanceCounts(logger, domainSet)
-	converge.orphanedActualLRPs(logger)
-	converge.crashedActualLRPs(logger, now)
+// SetSkipMissingCellsWhenCellSetEmpty makes convergence treat an empty cell
+// set as "cells unknown" and leave actual LRPs on their cells, rather than
+// reporting every actual LRP as being on a missing cell.
+func (db *SQLDB) SetSkipMissingCellsWhenCellSetEmpty(skip bool) {
+	db.skipMissingCellsWhenCellSetEmpty = skip
+}
+
+// SetSkipIdleConvergence makes ConvergeLRPs count its candidates, as
+// ConvergenceLRPCandidateCount does, before looking for start requests and
+// keys to retire, and skip looking when there are none. Domains and evacuating
+// actual LRPs are still pruned, first crashes and metrics still emitted, and
+// running actual LRPs without net info still restarted, as the candidates do
+// not cover them.
+func (db *SQLDB) SetSkipIdleConvergence(skip bool) {
+	db.skipIdleConvergence = skip
+}
+
+// SetFirstCrashEvents makes convergence count LRPFirstCrash.<process guid> once
+// when CrashActualLRP crashed an instance of the desired LRP for the first
+// time since the previous convergence: an instance that had not crashed
+// before, or that had run for longer than models.CrashResetTimeout. This
+// covers the instances that are restarted right away after crashing. Later
+// crashes of an instance that keeps crashing do not count, and neither do
+// crashes from before it was enabled.
+func (db *SQLDB) SetFirstCrashEvents(enabled bool) {
+	db.firstCrashEvents = enabled
+}
+
+// SetPlacementFairnessMetric makes convergence emit LRPPlacementFairness, the
+// placement fairness of the actual LRPs across the cells of the cell set.
+func (db *SQLDB) SetPlacementFairnessMetric(enabled bool) {
+	db.placementFairnessMetric = enabled
+}
 
-	return converge.result(logger)
+// SetDomainStaleWindow makes convergence emit DomainStale.<domain> for every
+// domain that will expire within the given window unless it is upserted
+// again. A non-positive window disables the warning.
+func (db *SQLDB) SetDomainStaleWindow(window time.Duration) {
+	db.domainStaleWindow = window
+}
+
+// SetCompactConvergenceLogs makes ConvergeLRPs log a single line for every
+// process guid it acted on, listing the indices per action, instead of its
+// verbose per-step logs. Errors are still logged.
+func (db *SQLDB) SetCompactConvergenceLogs(enabled bool) {
+	db.compactConvergenceLogs = enabled
+}
+
+// SetMaxStartRequestsPerTick caps the number of instances the start requests
+// ConvergeLRPs returns ask to start, splitting a start request at the cap. The
+// instances over the cap are remembered and returned ahead of any new ones by
+// the following convergences, so that they are not starved, as long as they
+// are still desired and unclaimed. Actual LRPs on missing cells and keys to
+// retire are never capped. A non-positive cap returns every start request.
+func (db *SQLDB) SetMaxStartRequestsPerTick(max int) {
+	db.deferredStartRequestsMutex.Lock()
+	defer db.deferredStartRequestsMutex.Unlock()
+
+	db.maxStartRequestsPerTick = max
+}
+
+// SetRestartPolicy replaces the default restart policy convergence uses to
+// decide which crashed actual LRPs to restart. Crashed actual LRPs still inside
+// their backoff window are left crashed.
+func (db *SQLDB) SetRestartPolicy(policy models.RestartPolicy) {
+	db.restartPolicy = policy
+}
+
+// SetRetireNonRestartableCrashes makes convergence retire crashed actual LRPs
+// that crashed too often to ever be restarted, so that their index is freed
+// and started afresh. By default they are kept crashed, to be inspected.
+//
+// The crash count lives on the actual LRP, so once a retired instance is
+// removed the next convergence recreates its index with a crash count of
+// zero. The restart policy then bounds restarts per retirement rather than
+// over the lifetime of the index.
+func (db *SQLDB) SetRetireNonRestartableCrashes(retire bool) {
+	db.retireNonRestartableCrashes = retire
+}
+
+// SetExtraLRPRetirementMinAge gives extra actual LRPs that changed state less
+// than minAge ago a grace period: convergence only retires them once they are
+// older. A non-positive age retires extras immediately.
+func (db *SQLDB) SetExtraLRPRetirementMinAge(minAge time.Duration) {
+	db.extraLRPRetirementMinAge = minAge
+}
+
+// SetMaxInstanceLifetime makes convergence retire running actual LRPs that
+// have been running for longer than maxLifetime, so that they are replaced
+// with fresh instances, and emit their number as LRPsRecycledForAge. To keep
+// the replacement graceful, at most the oldest instance of a desired LRP is
+// retired per convergence, and none while convergence retires other instances
+// of it. A non-positive lifetime keeps instances running forever.
+func (db *SQLDB) SetMaxInstanceLifetime(maxLifetime time.Duration) {
+	db.maxInstanceLifetime = maxLifetime
+}
+
+var errConvergenceBatchUnreadable = errors.New("no row of a full convergence batch could be read")
+
+// The process guid and index of the last row read by a batched convergence
+// query. Queries grouped by desired LRP ignore the index.
+type convergenceCursor struct {
+	processGuid string
+	index       int32
 }
 
 type convergence struct {
 	*SQLDB
 
+	cellSet models.CellSet
+
 	guidsToStartRequests map[string]*auctioneer.LRPStartRequest
+	guidsToPlacementTags map[string][]string
 	startRequestsMutex   sync.Mutex
 
 	keysWithMissingCells []*models.ActualLRPKeyWithSchedulingInfo
 
-	keysToRetire []*models.ActualLRPKey
+	keysToRetire []*models.ActualLRPKeyWithRetireReason
 	keysMutex    sync.Mutex
 
+	missingLRPsByDomain map[string]int
+
+	// When dryRun is set, no actual LRPs are created or unclaimed and no
+	// metrics are emitted; the keys that would have been created or unclaimed
+	// are recorded instead.
+	dryRun        bool
+	keysToCreate  []*models.ActualLRPKey
+	keysToUnclaim []*models.ActualLRPKey
+
+	// When recordActions is set, the keys that were created or unclaimed are
+	// recorded as well, for the compact convergence logs.
+	recordActions bool
+
+	suppressedGuids      map[string]struct{}
+	suppressedGuidsMutex sync.Mutex
+
+	domainDurations      map[string]time.Duration
+	domainDurationsMutex sync.Mutex
+
 	pool   *workpool.WorkPool
 	poolWg sync.WaitGroup
 }
 
-func newConvergence(db *SQLDB) *convergence {
+func newConvergence(db *SQLDB, cellSet models.CellSet) *convergence {
 	pool, err := workpool.NewWorkPool(db.convergenceWorkersSize)
 	if err != nil {
 		panic(fmt.Sprintf("failing to create workpool is irrecoverable %v", err))
@@ -90,49 +298,101 @@ func newConvergence(db *SQLDB) *convergence {
 
 	return &convergence{
 		SQLDB:                db,
+		cellSet:              cellSet,
 		guidsToStartRequests: map[string]*auctioneer.LRPStartRequest{},
-		keysToRetire:         []*models.ActualLRPKey{},
+		guidsToPlacementTags: map[string][]string{},
+		keysToRetire:         []*models.ActualLRPKeyWithRetireReason{},
+		missingLRPsByDomain:  map[string]int{},
+		suppressedGuids:      map[string]struct{}{},
+		domainDurations:      map[string]time.Duration{},
 		pool:                 pool,
 	}
 }
 
-// Adds stale UNCLAIMED Actual LRPs to the list of start requests.
-func (c *convergence) staleUnclaimedActualLRPs(logger lager.Logger, now time.Time) {
-	logger = logger.Session("stale-unclaimed-actual-lrps")
+// Reports whether the convergence candidates could not be counted or there
+// are any.
+func (db *SQLDB) hasConvergenceCandidates(logger lager.Logger, cellSet models.CellSet, now time.Time) bool {
+	count, err := db.convergenceLRPCandidateCount(logger, cellSet, now)
+	if err != nil {
+		return true
+	}
+	return count > 0
+}
 
-	rows, err := c.selectStaleUnclaimedLRPs(logger, c.db, now)
+// Stands in for the steps looking for start requests and keys to retire when
+// there are no candidates: records the desired LRPs with suppressed
+// convergence they would have come across, and reports that no instances are
+// missing or recycled.
+func (c *convergence) skippedSteps(logger lager.Logger, now time.Time) {
+	logger = logger.Session("skipped-steps")
+
+	rows, err := c.selectSuppressedConvergenceProcessGuids(logger, c.db, c.cellSet, now)
 	if err != nil {
 		logger.Error("failed-query", err)
-		return
-	}
+	} else {
+		defer rows.Close()
 
-	for rows.Next() {
-		var index int
-		schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &index)
-		if err == nil {
-			c.addStartRequestFromSchedulingInfo(logger, schedulingInfo, index)
+		c.suppressedGuidsMutex.Lock()
+		for rows.Next() {
+			var processGuid string
+			err := rows.Scan(&processGuid)
+			if err != nil {
+				logger.Error("failed-scanning", err)
+				continue
+			}
+			c.suppressedGuids[processGuid] = struct{}{}
+		}
+		c.suppressedGuidsMutex.Unlock()
+
+		if rows.Err() != nil {
+			logger.Error("failed-getting-next-row", rows.Err())
 		}
 	}
 
-	if rows.Err() != nil {
-		logger.Error("failed-getting-next-row", rows.Err())
+	c.metronClient.SendMetric(missingLRPs, 0)
+	if c.maxInstanceLifetime > 0 {
+		err = c.metronClient.SendMetric(recycledLRPs, 0)
+		if err != nil {
+			logger.Error("failed-sending-recycled-lrps-metric", err)
+		}
 	}
+}
+
+// Adds stale UNCLAIMED Actual LRPs to the list of start requests.
+func (c *convergence) staleUnclaimedActualLRPs(logger lager.Logger, now time.Time) {
+	logger = logger.Session("stale-unclaimed-actual-lrps")
 
-	return
+	c.eachBatch(logger,
+		func(after *convergenceCursor) (*sql.Rows, error) {
+			return c.selectStaleUnclaimedLRPs(logger, c.db, now, after)
+		},
+		func(rows *sql.Rows) (*convergenceCursor, error) {
+			rowStart := time.Now()
+			var index int
+			schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &index)
+			if err != nil {
+				return nil, nil
+			}
+			cursor := &convergenceCursor{processGuid: schedulingInfo.ProcessGuid, index: int32(index)}
+
+			if c.isSuppressed(schedulingInfo) {
+				c.addDomainDuration(schedulingInfo.Domain, rowStart)
+				return cursor, nil
+			}
+
+			c.addStartRequestFromSchedulingInfo(logger, schedulingInfo, index)
+			c.addDomainDuration(schedulingInfo.Domain, rowStart)
+			return cursor, nil
+		},
+		func() {},
+	)
 }
 
 // Adds CRASHED Actual LRPs that can be restarted to the list of start requests
-// and transitions them to UNCLAIMED.
+// and transitions them to UNCLAIMED. Those that will never be restarted are
+// retired if configured to.
 func (c *convergence) crashedActualLRPs(logger lager.Logger, now time.Time) {
 	logger = logger.Session("crashed-actual-lrps")
-	restartCalculator := models.NewDefaultRestartCalculator()
-
-	rows, err := c.selectCrashedLRPs(logger, c.db)
-	if err != nil {
-		logger.Error("failed-query", err)
-		return
-	}
-
 	type crashedActualLRP struct {
 		lrpKey         models.ActualLRPKey
 		schedulingInfo *models.DesiredLRPSchedulingInfo
@@ -140,61 +400,165 @@ func (c *convergence) crashedActualLRPs(logger lager.Logger, now time.Time) {
 	}
 	lrps := []crashedActualLRP{}
 
-	for rows.Next() {
-		var index int
-		actual := &models.ActualLRP{}
+	c.eachBatch(logger,
+		func(after *convergenceCursor) (*sql.Rows, error) {
+			return c.selectCrashedLRPs(logger, c.db, after)
+		},
+		func(rows *sql.Rows) (*convergenceCursor, error) {
+			rowStart := time.Now()
+			var index int
+			actual := &models.ActualLRP{}
 
-		schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &index, &actual.Since, &actual.CrashCount)
-		if err != nil {
-			continue
-		}
+			schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &index, &actual.Since, &actual.CrashCount)
+			if err != nil {
+				return nil, nil
+			}
+			cursor := &convergenceCursor{processGuid: schedulingInfo.ProcessGuid, index: int32(index)}
+
+			if c.isSuppressed(schedulingInfo) {
+				c.addDomainDuration(schedulingInfo.Domain, rowStart)
+				return cursor, nil
+			}
 
-		actual.ActualLRPKey = models.NewActualLRPKey(schedulingInfo.ProcessGuid, int32(index), schedulingInfo.Domain)
-		actual.State = models.ActualLRPStateCrashed
+			actual.ActualLRPKey = models.NewActualLRPKey(schedulingInfo.ProcessGuid, int32(index), schedulingInfo.Domain)
 
-		if actual.ShouldRestartCrash(now, restartCalculator) {
-			lrps = append(lrps, crashedActualLRP{
-				lrpKey:         actual.ActualLRPKey,
-				schedulingInfo: schedulingInfo,
-				index:          index,
-			})
-		}
+			if c.restartPolicy.ShouldRestart(now.UnixNano(), actual.Since, actual.CrashCount) {
+				lrps = append(lrps, crashedActualLRP{
+					lrpKey:         actual.ActualLRPKey,
+					schedulingInfo: schedulingInfo,
+					index:          index,
+				})
+			} else if c.retireNonRestartableCrashes && c.restartPolicy.GivesUp(actual.CrashCount) {
+				key := actual.ActualLRPKey
+				c.addKeyToRetire(logger, &key, models.RetireReasonNotRestartable)
+			}
+			c.addDomainDuration(schedulingInfo.Domain, rowStart)
+			return cursor, nil
+		},
+		func() {
+			for _, lrp := range lrps {
+				key := lrp.lrpKey
+				schedulingInfo := lrp.schedulingInfo
+				index := lrp.index
+				c.submit(func() {
+					defer c.addDomainDuration(key.Domain, time.Now())
+
+					if !c.unclaimActualLRP(logger, &key) {
+						return
+					}
+
+					c.addStartRequestFromSchedulingInfo(logger, schedulingInfo, index)
+				})
+			}
+			lrps = lrps[:0]
+		},
+	)
+}
+
+// Unclaims RUNNING Actual LRPs that have no net info and adds them to the
+// list of start requests. Such instances cannot be routed to. Actual LRPs on
+// missing cells are skipped, they are already auctioned again for their
+// missing cell.
+func (c *convergence) runningActualLRPsWithMissingNetInfo(logger lager.Logger) {
+	logger = logger.Session("running-actual-lrps-with-missing-net-info")
+
+	onMissingCells := make(map[models.ActualLRPKey]struct{}, len(c.keysWithMissingCells))
+	for _, key := range c.keysWithMissingCells {
+		onMissingCells[*key.Key] = struct{}{}
 	}
 
-	for _, lrp := range lrps {
-		key := lrp.lrpKey
-		schedulingInfo := lrp.schedulingInfo
-		index := lrp.index
-		c.submit(func() {
-			_, _, err := c.UnclaimActualLRP(logger, &key)
+	type inconsistentActualLRP struct {
+		lrpKey         models.ActualLRPKey
+		schedulingInfo *models.DesiredLRPSchedulingInfo
+		index          int
+	}
+	lrps := []inconsistentActualLRP{}
+	inconsistentLRPCount := 0
+
+	c.eachBatch(logger,
+		func(after *convergenceCursor) (*sql.Rows, error) {
+			return c.selectRunningLRPsWithNetInfo(logger, c.db, after)
+		},
+		func(rows *sql.Rows) (*convergenceCursor, error) {
+			rowStart := time.Now()
+			var index int
+			var netInfoData []byte
+
+			schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &index, &netInfoData)
 			if err != nil {
-				logger.Error("failed-unclaiming-actual-lrp", err)
-				return
+				return nil, nil
 			}
+			cursor := &convergenceCursor{processGuid: schedulingInfo.ProcessGuid, index: int32(index)}
 
-			c.addStartRequestFromSchedulingInfo(logger, schedulingInfo, index)
-		})
-	}
+			netInfo := models.ActualLRPNetInfo{}
+			if len(netInfoData) > 0 {
+				err = c.deserializeModel(logger, netInfoData, &netInfo)
+				if err != nil {
+					logger.Error("failed-unmarshaling-net-info-data", err)
+					return cursor, nil
+				}
+			}
 
-	if rows.Err() != nil {
-		logger.Error("failed-getting-next-row", rows.Err())
+			lrpKey := models.NewActualLRPKey(schedulingInfo.ProcessGuid, int32(index), schedulingInfo.Domain)
+			if _, ok := onMissingCells[lrpKey]; ok {
+				c.addDomainDuration(schedulingInfo.Domain, rowStart)
+				return cursor, nil
+			}
+
+			if netInfo.Address == "" {
+				lrps = append(lrps, inconsistentActualLRP{
+					lrpKey:         lrpKey,
+					schedulingInfo: schedulingInfo,
+					index:          index,
+				})
+			}
+			c.addDomainDuration(schedulingInfo.Domain, rowStart)
+			return cursor, nil
+		},
+		func() {
+			for _, lrp := range lrps {
+				key := lrp.lrpKey
+				schedulingInfo := lrp.schedulingInfo
+				index := lrp.index
+				logger.Info("found-running-actual-lrp-without-net-info", lager.Data{"actual_lrp_key": key})
+				c.submit(func() {
+					defer c.addDomainDuration(key.Domain, time.Now())
+
+					if !c.unclaimActualLRP(logger, &key) {
+						return
+					}
+
+					c.addStartRequestFromSchedulingInfo(logger, schedulingInfo, index)
+				})
+			}
+			inconsistentLRPCount += len(lrps)
+			lrps = lrps[:0]
+		},
+	)
+
+	if c.dryRun {
+		return
 	}
 
-	return
+	err := c.metronClient.SendMetric(inconsistentLRPs, inconsistentLRPCount)
+	if err != nil {
+		logger.Error("failed-sending-inconsistent-lrps-metric", err)
+	}
 }
 
-// Adds orphaned Actual LRPs (ones with no corresponding Desired LRP) to the
-// list of keys to retire.
-func (c *convergence) orphanedActualLRPs(logger lager.Logger) {
+// Adds orphaned Actual LRPs (ones with no corresponding Desired LRP) in fresh
+// domains to the list of keys to retire.
+func (c *convergence) orphanedActualLRPs(logger lager.Logger, now time.Time) {
 	logger = logger.Session("orphaned-actual-lrps")
 
-	rows, err := c.selectOrphanedActualLRPs(logger, c.db)
+	rows, err := c.selectOrphanedActualLRPs(logger, c.db, now)
 	if err != nil {
 		logger.Error("failed-query", err)
 		return
 	}
 
 	for rows.Next() {
+		rowStart := time.Now()
 		actualLRPKey := &models.ActualLRPKey{}
 
 		err := rows.Scan(
@@ -207,7 +571,8 @@ func (c *convergence) orphanedActualLRPs(logger lager.Logger) {
 			continue
 		}
 
-		c.addKeyToRetire(logger, actualLRPKey)
+		c.addKeyToRetire(logger, actualLRPKey, models.RetireReasonNoDesiredLRP)
+		c.addDomainDuration(actualLRPKey.Domain, rowStart)
 	}
 
 	if rows.Err() != nil {
@@ -215,93 +580,220 @@ func (c *convergence) orphanedActualLRPs(logger lager.Logger) {
 	}
 }
 
-// Creates and adds missing Actual LRPs to the list of start requests.
-// Adds extra Actual LRPs  to the list of keys to retire.
-func (c *convergence) lrpInstanceCounts(logger lager.Logger, domainSet map[string]struct{}) {
-	logger = logger.Session("lrp-instance-counts")
+// Adds the oldest running Actual LRP of every Desired LRP that has instances
+// running for longer than the max instance lifetime to the list of keys to
+// retire. Desired LRPs with a single instance, or with any instance that is
+// not running, being started or on a missing cell, are left alone, so that
+// recycling never takes the last running instance of a desired LRP down.
+func (c *convergence) agedRunningActualLRPs(logger lager.Logger, now time.Time) {
+	logger = logger.Session("aged-running-actual-lrps")
 
-	rows, err := c.selectLRPInstanceCounts(logger, c.db)
+	rows, err := c.selectRunningActualLRPsSince(logger, c.db, now.Add(-c.maxInstanceLifetime))
 	if err != nil {
 		logger.Error("failed-query", err)
 		return
 	}
+	defer rows.Close()
 
-	keys := []models.ActualLRPKey{}
+	c.keysMutex.Lock()
+	retiringGuids := map[string]struct{}{}
+	for _, key := range c.keysToRetire {
+		retiringGuids[key.Key.ProcessGuid] = struct{}{}
+	}
+	for _, key := range c.keysWithMissingCells {
+		retiringGuids[key.Key.ProcessGuid] = struct{}{}
+	}
+	c.keysMutex.Unlock()
 
-	missingLRPCount := 0
+	c.startRequestsMutex.Lock()
+	for guid := range c.guidsToStartRequests {
+		retiringGuids[guid] = struct{}{}
+	}
+	c.startRequestsMutex.Unlock()
+
+	c.suppressedGuidsMutex.Lock()
+	for guid := range c.suppressedGuids {
+		retiringGuids[guid] = struct{}{}
+	}
+	c.suppressedGuidsMutex.Unlock()
+
+	recycled := 0
 	for rows.Next() {
-		var existingIndicesStr sql.NullString
-		var actualInstances int
+		actualLRPKey := &models.ActualLRPKey{}
 
-		schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &actualInstances, &existingIndicesStr)
+		err := rows.Scan(
+			&actualLRPKey.ProcessGuid,
+			&actualLRPKey.Index,
+			&actualLRPKey.Domain,
+		)
 		if err != nil {
+			logger.Error("failed-scanning", err)
 			continue
 		}
 
-		indices := []int{}
-		existingIndices := make(map[int]struct{})
-		if existingIndicesStr.String != "" {
-			for _, indexStr := range strings.Split(existingIndicesStr.String, ",") {
-				index, err := strconv.Atoi(indexStr)
-				if err != nil {
-					logger.Error("cannot-parse-index", err, lager.Data{
-						"index":                indexStr,
-						"existing-indeces-str": existingIndicesStr,
-					})
-					return
-				}
-				existingIndices[index] = struct{}{}
-			}
+		if _, ok := retiringGuids[actualLRPKey.ProcessGuid]; ok {
+			continue
 		}
+		retiringGuids[actualLRPKey.ProcessGuid] = struct{}{}
 
-		for i := 0; i < int(schedulingInfo.Instances); i++ {
-			_, found := existingIndices[i]
-			if found {
-				continue
-			}
+		logger.Info("recycling-aged-actual-lrp", lager.Data{"actual_lrp_key": actualLRPKey})
+		c.addKeyToRetire(logger, actualLRPKey, models.RetireReasonMaxLifetime)
+		recycled++
+	}
 
-			missingLRPCount++
-			indices = append(indices, i)
-			index := int32(i)
-			keys = append(keys, models.ActualLRPKey{
-				ProcessGuid: schedulingInfo.ProcessGuid,
-				Domain:      schedulingInfo.Domain,
-				Index:       index,
-			})
-		}
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+	}
 
-		c.addStartRequestFromSchedulingInfo(logger, schedulingInfo, indices...)
+	if c.dryRun {
+		return
+	}
 
-		for index := range existingIndices {
-			if index < int(schedulingInfo.Instances) {
-				continue
+	err = c.metronClient.SendMetric(recycledLRPs, recycled)
+	if err != nil {
+		logger.Error("failed-sending-recycled-lrps-metric", err)
+	}
+}
+
+// Creates and adds missing Actual LRPs to the list of start requests.
+// Adds extra Actual LRPs  to the list of keys to retire.
+func (c *convergence) lrpInstanceCounts(logger lager.Logger, domainSet map[string]struct{}, now time.Time) {
+	logger = logger.Session("lrp-instance-counts")
+
+	keys := []models.ActualLRPKey{}
+
+	missingLRPCount := 0
+	deferredExtraCount := 0
+	completed := c.eachBatch(logger,
+		func(after *convergenceCursor) (*sql.Rows, error) {
+			return c.selectLRPInstanceCounts(logger, c.db, after)
+		},
+		func(rows *sql.Rows) (*convergenceCursor, error) {
+			rowStart := time.Now()
+			var existingIndicesStr, extraSincesStr sql.NullString
+			var actualInstances int
+
+			schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &actualInstances, &existingIndicesStr, &extraSincesStr)
+			if err != nil {
+				return nil, nil
 			}
+			cursor := &convergenceCursor{processGuid: schedulingInfo.ProcessGuid}
 
-			if _, ok := domainSet[schedulingInfo.Domain]; ok {
-				c.addKeyToRetire(logger, &models.ActualLRPKey{
+			if c.isSuppressed(schedulingInfo) {
+				c.addDomainDuration(schedulingInfo.Domain, rowStart)
+				return cursor, nil
+			}
+
+			indices := []int{}
+			existingIndices := make(map[int]struct{})
+			if existingIndicesStr.String != "" {
+				for _, indexStr := range strings.Split(existingIndicesStr.String, ",") {
+					index, err := strconv.Atoi(indexStr)
+					if err != nil {
+						logger.Error("cannot-parse-index", err, lager.Data{
+							"index":                indexStr,
+							"existing-indeces-str": existingIndicesStr,
+						})
+						return nil, err
+					}
+					existingIndices[index] = struct{}{}
+				}
+			}
+
+			for i := 0; i < int(schedulingInfo.Instances); i++ {
+				_, found := existingIndices[i]
+				if found {
+					continue
+				}
+
+				missingLRPCount++
+				c.missingLRPsByDomain[schedulingInfo.Domain]++
+				indices = append(indices, i)
+				index := int32(i)
+				keys = append(keys, models.ActualLRPKey{
 					ProcessGuid: schedulingInfo.ProcessGuid,
-					Index:       int32(index),
 					Domain:      schedulingInfo.Domain,
+					Index:       index,
 				})
 			}
-		}
-	}
 
-	for _, key := range keys {
-		lrpKey := key
-		c.submit(func() {
-			_, err := c.CreateUnclaimedActualLRP(logger, &lrpKey)
-			if err != nil {
-				logger.Error("failed-creating-missing-actual-lrp", err)
+			c.addStartRequestFromSchedulingInfo(logger, schedulingInfo, indices...)
+
+			extraSinces := map[int]int64{}
+			if extraSincesStr.String != "" {
+				for _, extraSinceStr := range strings.Split(extraSincesStr.String, ",") {
+					var index int
+					var since int64
+					_, err := fmt.Sscanf(extraSinceStr, "%d:%d", &index, &since)
+					if err != nil {
+						logger.Error("cannot-parse-extra-since", err, lager.Data{"extra_since": extraSinceStr})
+						continue
+					}
+					extraSinces[index] = since
+				}
 			}
-		})
-	}
 
-	if rows.Err() != nil {
-		logger.Error("failed-getting-next-row", rows.Err())
+			for index := range existingIndices {
+				if index < int(schedulingInfo.Instances) {
+					continue
+				}
+
+				if _, ok := domainSet[schedulingInfo.Domain]; ok {
+					key := &models.ActualLRPKey{
+						ProcessGuid: schedulingInfo.ProcessGuid,
+						Index:       int32(index),
+						Domain:      schedulingInfo.Domain,
+					}
+					if since, ok := extraSinces[index]; ok && c.extraWithinRetirementGracePeriod(since, now) {
+						logger.Debug("deferring-extra-actual-lrp-retirement", lager.Data{"process_guid": key.ProcessGuid, "index": key.Index})
+						deferredExtraCount++
+						continue
+					}
+					c.addKeyToRetire(logger, key, models.RetireReasonExtraInstance)
+				}
+			}
+			c.addDomainDuration(schedulingInfo.Domain, rowStart)
+			return cursor, nil
+		},
+		func() {
+			for _, key := range keys {
+				lrpKey := key
+				if c.dryRun {
+					c.keysMutex.Lock()
+					c.keysToCreate = append(c.keysToCreate, &lrpKey)
+					c.keysMutex.Unlock()
+					continue
+				}
+
+				c.submit(func() {
+					defer c.addDomainDuration(lrpKey.Domain, time.Now())
+
+					_, err := c.CreateUnclaimedActualLRP(logger, &lrpKey)
+					if err != nil {
+						logger.Error("failed-creating-missing-actual-lrp", err)
+						return
+					}
+
+					if c.recordActions {
+						c.keysMutex.Lock()
+						c.keysToCreate = append(c.keysToCreate, &lrpKey)
+						c.keysMutex.Unlock()
+					}
+				})
+			}
+			keys = keys[:0]
+		},
+	)
+	if deferredExtraCount > 0 {
+		logger.Info("deferred-extra-actual-lrp-retirements", lager.Data{"count": deferredExtraCount})
+	}
+	if !completed {
+		return
 	}
 
-	c.metronClient.SendMetric(missingLRPs, missingLRPCount)
+	if !c.dryRun {
+		c.metronClient.SendMetric(missingLRPs, missingLRPCount)
+	}
 }
 
 // Unclaim Actual LRPs that have missing cells (not in the cell set passed to
@@ -311,16 +803,24 @@ func (c *convergence) actualLRPsWithMissingCells(logger lager.Logger, cellSet mo
 
 	keysWithMissingCells := make([]*models.ActualLRPKeyWithSchedulingInfo, 0)
 
-	rows, err := c.selectLRPsWithMissingCells(logger, c.db, cellSet)
-	if err != nil {
-		logger.Error("failed-query", err)
-		return
-	}
+	c.eachBatch(logger,
+		func(after *convergenceCursor) (*sql.Rows, error) {
+			return c.selectLRPsWithMissingCells(logger, c.db, cellSet, after)
+		},
+		func(rows *sql.Rows) (*convergenceCursor, error) {
+			rowStart := time.Now()
+			var index int32
+			schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &index)
+			if err != nil {
+				return nil, nil
+			}
+			cursor := &convergenceCursor{processGuid: schedulingInfo.ProcessGuid, index: index}
+
+			if c.isSuppressed(schedulingInfo) {
+				c.addDomainDuration(schedulingInfo.Domain, rowStart)
+				return cursor, nil
+			}
 
-	for rows.Next() {
-		var index int32
-		schedulingInfo, err := c.fetchDesiredLRPSchedulingInfoAndMore(logger, rows, &index)
-		if err == nil {
 			keysWithMissingCells = append(keysWithMissingCells, &models.ActualLRPKeyWithSchedulingInfo{
 				Key: &models.ActualLRPKey{
 					ProcessGuid: schedulingInfo.ProcessGuid,
@@ -329,14 +829,67 @@ func (c *convergence) actualLRPsWithMissingCells(logger lager.Logger, cellSet mo
 				},
 				SchedulingInfo: schedulingInfo,
 			})
+			c.addDomainDuration(schedulingInfo.Domain, rowStart)
+			return cursor, nil
+		},
+		func() {},
+	)
+
+	c.keysWithMissingCells = keysWithMissingCells
+}
+
+// Runs a convergence query once per batch of at most convergenceBatchSize
+// rows, resuming after the cursor of the last row handled, until a short batch
+// is returned. Without a batch size the query is run once. handleRow returns
+// the cursor of the row, or nil if it could not be read, and aborts the query
+// by returning an error, in which case false is returned. endBatch is called
+// after the rows of each batch have been read so that the work collected from
+// them can be handed off before the next batch is fetched.
+func (c *convergence) eachBatch(
+	logger lager.Logger,
+	query func(after *convergenceCursor) (*sql.Rows, error),
+	handleRow func(rows *sql.Rows) (*convergenceCursor, error),
+	endBatch func(),
+) bool {
+	var after *convergenceCursor
+	for {
+		rows, err := query(after)
+		if err != nil {
+			logger.Error("failed-query", err)
+			return true
 		}
-	}
 
-	if rows.Err() != nil {
-		logger.Error("failed-getting-next-row", rows.Err())
-	}
+		rowCount := 0
+		var last *convergenceCursor
+		for rows.Next() {
+			rowCount++
+			cursor, err := handleRow(rows)
+			if err != nil {
+				rows.Close()
+				return false
+			}
+			if cursor != nil {
+				last = cursor
+			}
+		}
 
-	c.keysWithMissingCells = keysWithMissingCells
+		rowsErr := rows.Err()
+		if rowsErr != nil {
+			logger.Error("failed-getting-next-row", rowsErr)
+		}
+		rows.Close()
+		endBatch()
+
+		if rowsErr != nil || c.convergenceBatchSize <= 0 || rowCount < c.convergenceBatchSize {
+			return true
+		}
+
+		if last == nil {
+			logger.Error("failed-advancing-batch", errConvergenceBatchUnreadable)
+			return true
+		}
+		after = last
+	}
 }
 
 func (c *convergence) addStartRequestFromSchedulingInfo(logger lager.Logger, schedulingInfo *models.DesiredLRPSchedulingInfo, indices ...int) {
@@ -354,13 +907,97 @@ func (c *convergence) addStartRequestFromSchedulingInfo(logger lager.Logger, sch
 
 	startRequest := auctioneer.NewLRPStartRequestFromSchedulingInfo(schedulingInfo, indices...)
 	c.guidsToStartRequests[schedulingInfo.ProcessGuid] = &startRequest
+	c.guidsToPlacementTags[schedulingInfo.ProcessGuid] = schedulingInfo.PlacementTags
 }
 
-func (c *convergence) addKeyToRetire(logger lager.Logger, key *models.ActualLRPKey) {
+// Records and reports whether convergence is suppressed for the desired LRP,
+// in which case its missing, extra, crashed and stale unclaimed instances, and
+// those on missing cells, are left alone.
+func (c *convergence) isSuppressed(schedulingInfo *models.DesiredLRPSchedulingInfo) bool {
+	if !schedulingInfo.SuppressConvergence {
+		return false
+	}
+
+	c.suppressedGuidsMutex.Lock()
+	defer c.suppressedGuidsMutex.Unlock()
+
+	c.suppressedGuids[schedulingInfo.ProcessGuid] = struct{}{}
+	return true
+}
+
+// Reports whether the extra actual LRP, which changed state at since, did so
+// too recently to be retired.
+func (c *convergence) extraWithinRetirementGracePeriod(since int64, now time.Time) bool {
+	if c.extraLRPRetirementMinAge <= 0 {
+		return false
+	}
+	return since > now.Add(-c.extraLRPRetirementMinAge).UnixNano()
+}
+
+func (c *convergence) addKeyToRetire(logger lager.Logger, key *models.ActualLRPKey, reason models.ActualLRPRetireReason) {
 	c.keysMutex.Lock()
 	defer c.keysMutex.Unlock()
 
-	c.keysToRetire = append(c.keysToRetire, key)
+	c.keysToRetire = append(c.keysToRetire, &models.ActualLRPKeyWithRetireReason{Key: key, Reason: reason})
+}
+
+// Counts the keys to retire that are extra, rather than retired for their age
+// or for crashing too often. The caller must hold keysMutex.
+func (c *convergence) extraLRPCount() int {
+	count := 0
+	for _, key := range c.keysToRetire {
+		if retiredAsExtra(key.Reason) {
+			count++
+		}
+	}
+	return count
+}
+
+func retiredAsExtra(reason models.ActualLRPRetireReason) bool {
+	return reason == models.RetireReasonExtraInstance || reason == models.RetireReasonNoDesiredLRP
+}
+
+type keysToRetireByOrderKey []*models.ActualLRPKeyWithRetireReason
+
+func (keys keysToRetireByOrderKey) Len() int      { return len(keys) }
+func (keys keysToRetireByOrderKey) Swap(i, j int) { keys[i], keys[j] = keys[j], keys[i] }
+func (keys keysToRetireByOrderKey) Less(i, j int) bool {
+	return keys[i].Key.OrderKey() < keys[j].Key.OrderKey()
+}
+
+// Transitions the actual LRP to UNCLAIMED, or only records the key when doing a
+// dry run. Reports whether the actual LRP should be started.
+func (c *convergence) unclaimActualLRP(logger lager.Logger, key *models.ActualLRPKey) bool {
+	if c.dryRun {
+		c.keysMutex.Lock()
+		defer c.keysMutex.Unlock()
+
+		c.keysToUnclaim = append(c.keysToUnclaim, key)
+		return true
+	}
+
+	_, _, err := c.UnclaimActualLRP(logger, key)
+	if err != nil {
+		logger.Error("failed-unclaiming-actual-lrp", err)
+		return false
+	}
+
+	if c.recordActions {
+		c.keysMutex.Lock()
+		c.keysToUnclaim = append(c.keysToUnclaim, key)
+		c.keysMutex.Unlock()
+	}
+	return true
+}
+
+// Attributes the time elapsed since start to the given domain.
+func (c *convergence) addDomainDuration(domain string, start time.Time) {
+	elapsed := time.Since(start)
+
+	c.domainDurationsMutex.Lock()
+	defer c.domainDurationsMutex.Unlock()
+
+	c.domainDurations[domain] += elapsed
 }
 
 func (c *convergence) submit(work func()) {
@@ -371,7 +1008,24 @@ func (c *convergence) submit(work func()) {
 	})
 }
 
-func (c *convergence) result(logger lager.Logger) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKey) {
+// Returns the collected start requests, except for those of desired LRPs that
+// only evacuating cells could place, as they would target an evacuating cell.
+// Their actual LRPs stay UNCLAIMED and are started once they are stale, and
+// are counted as pending placement meanwhile. Must be called with the start
+// requests mutex held.
+func (c *convergence) startRequestsToSend(logger lager.Logger) []*auctioneer.LRPStartRequest {
+	startRequests := make([]*auctioneer.LRPStartRequest, 0, len(c.guidsToStartRequests))
+	for guid, startRequest := range c.guidsToStartRequests {
+		if c.onlyPlaceableOnEvacuatingCells(c.guidsToPlacementTags[guid]) {
+			logger.Info("holding-start-request-for-evacuating-cells", lager.Data{"process_guid": guid, "indices": startRequest.Indices})
+			continue
+		}
+		startRequests = append(startRequests, startRequest)
+	}
+	return startRequests
+}
+
+func (c *convergence) result(logger lager.Logger) ([]*auctioneer.LRPStartRequest, []*models.ActualLRPKeyWithSchedulingInfo, []*models.ActualLRPKeyWithRetireReason) {
 	c.poolWg.Wait()
 	c.pool.Stop()
 
@@ -381,17 +1035,371 @@ func (c *convergence) result(logger lager.Logger) ([]*auctioneer.LRPStartRequest
 	c.keysMutex.Lock()
 	defer c.keysMutex.Unlock()
 
-	startRequests := make([]*auctioneer.LRPStartRequest, 0, len(c.guidsToStartRequests))
-	for _, startRequest := range c.guidsToStartRequests {
-		startRequests = append(startRequests, startRequest)
+	startRequests := c.startRequestsToSend(logger)
+
+	sort.Sort(keysToRetireByOrderKey(c.keysToRetire))
+
+	if c.dryRun {
+		return startRequests, c.keysWithMissingCells, c.keysToRetire
+	}
+
+	c.metronClient.SendMetric(extraLRPs, c.extraLRPCount())
+
+	err := c.metronClient.SendMetric(pendingLRPs, c.pendingPlacementCount())
+	if err != nil {
+		logger.Error("failed-sending-pending-placement-lrps-metric", err)
+	}
+
+	c.suppressedGuidsMutex.Lock()
+	err = c.metronClient.SendMetric(suppressedLRPs, len(c.suppressedGuids))
+	c.suppressedGuidsMutex.Unlock()
+	if err != nil {
+		logger.Error("failed-sending-convergence-suppressed-lrps-metric", err)
 	}
 
-	c.metronClient.SendMetric(extraLRPs, len(c.keysToRetire))
 	c.emitLRPMetrics(logger)
+	c.emitDomainDurations(logger)
 
 	return startRequests, c.keysWithMissingCells, c.keysToRetire
 }
 
+// Returns the start requests deferred by the previous convergence, followed by
+// the new ones sorted by process guid, for up to the configured number of
+// instances, and defers the rest to the next convergence. New start requests
+// for a process guid that was deferred are merged into the deferred start
+// request. The deferred start requests are returned as well.
+func (db *SQLDB) capStartRequests(logger lager.Logger, startRequests []*auctioneer.LRPStartRequest) ([]*auctioneer.LRPStartRequest, []*auctioneer.LRPStartRequest) {
+	db.deferredStartRequestsMutex.Lock()
+	defer db.deferredStartRequestsMutex.Unlock()
+
+	capped, deferred := db.splitStartRequests(logger, startRequests, db.deferredStartRequests)
+	db.deferredStartRequests = deferred
+	return capped, deferred
+}
+
+// Like capStartRequests, but leaves the start requests deferred by the
+// previous convergence as they are, for dry runs.
+func (db *SQLDB) previewCapStartRequests(logger lager.Logger, startRequests []*auctioneer.LRPStartRequest) ([]*auctioneer.LRPStartRequest, []*auctioneer.LRPStartRequest) {
+	db.deferredStartRequestsMutex.Lock()
+	defer db.deferredStartRequestsMutex.Unlock()
+
+	return db.splitStartRequests(logger, startRequests, db.deferredStartRequests)
+}
+
+// Splits the previously deferred and the new start requests into the ones to
+// send now and the ones to defer, without changing either.
+func (db *SQLDB) splitStartRequests(logger lager.Logger, startRequests, previouslyDeferred []*auctioneer.LRPStartRequest) ([]*auctioneer.LRPStartRequest, []*auctioneer.LRPStartRequest) {
+	if db.maxStartRequestsPerTick <= 0 && len(previouslyDeferred) == 0 {
+		return startRequests, nil
+	}
+
+	previouslyDeferred = db.refreshDeferredStartRequests(logger, previouslyDeferred)
+	startRequests = append([]*auctioneer.LRPStartRequest{}, startRequests...)
+	sort.Sort(startRequestsByProcessGuid(startRequests))
+
+	candidates := make([]*auctioneer.LRPStartRequest, 0, len(previouslyDeferred)+len(startRequests))
+	candidates = append(candidates, previouslyDeferred...)
+	candidates = append(candidates, startRequests...)
+
+	merged := []*auctioneer.LRPStartRequest{}
+	byGuid := map[string]*auctioneer.LRPStartRequest{}
+	instanceCount := 0
+	for _, startRequest := range candidates {
+		existing, ok := byGuid[startRequest.ProcessGuid]
+		if !ok {
+			copied := *startRequest
+			copied.Indices = append([]int{}, startRequest.Indices...)
+			byGuid[startRequest.ProcessGuid] = &copied
+			merged = append(merged, &copied)
+			instanceCount += len(copied.Indices)
+			continue
+		}
+
+		for _, index := range startRequest.Indices {
+			if !containsIndex(existing.Indices, index) {
+				existing.Indices = append(existing.Indices, index)
+				instanceCount++
+			}
+		}
+	}
+
+	if db.maxStartRequestsPerTick <= 0 || instanceCount <= db.maxStartRequestsPerTick {
+		return merged, nil
+	}
+
+	capped := []*auctioneer.LRPStartRequest{}
+	deferred := []*auctioneer.LRPStartRequest{}
+	remaining := db.maxStartRequestsPerTick
+	for _, startRequest := range merged {
+		switch {
+		case remaining >= len(startRequest.Indices):
+			capped = append(capped, startRequest)
+			remaining -= len(startRequest.Indices)
+		case remaining > 0:
+			head := *startRequest
+			head.Indices = append([]int{}, startRequest.Indices[:remaining]...)
+			tail := *startRequest
+			tail.Indices = append([]int{}, startRequest.Indices[remaining:]...)
+			capped = append(capped, &head)
+			deferred = append(deferred, &tail)
+			remaining = 0
+		default:
+			deferred = append(deferred, startRequest)
+		}
+	}
+
+	logger.Info("deferring-start-requests", lager.Data{
+		"start_requests_count":          len(capped),
+		"instances_count":               db.maxStartRequestsPerTick,
+		"deferred_start_requests_count": len(deferred),
+		"deferred_instances_count":      instanceCount - db.maxStartRequestsPerTick,
+	})
+
+	return capped, deferred
+}
+
+// Drops the indices of the deferred start requests that are no longer to be
+// started: those of desired LRPs that were deleted or scaled down, and those
+// whose actual LRP is not unclaimed anymore. The remaining ones are rebuilt
+// from the current scheduling info of their desired LRP, which may have been
+// updated. If the current state cannot be read they are kept as they are.
+func (db *SQLDB) refreshDeferredStartRequests(logger lager.Logger, deferred []*auctioneer.LRPStartRequest) []*auctioneer.LRPStartRequest {
+	if len(deferred) == 0 {
+		return deferred
+	}
+
+	logger = logger.Session("refresh-deferred-start-requests")
+
+	processGuids := make([]string, 0, len(deferred))
+	for _, startRequest := range deferred {
+		processGuids = append(processGuids, startRequest.ProcessGuid)
+	}
+
+	schedulingInfos, err := db.DesiredLRPSchedulingInfos(logger, models.DesiredLRPFilter{ProcessGuids: processGuids})
+	if err != nil {
+		logger.Error("failed-fetching-scheduling-infos", err)
+		return deferred
+	}
+	schedulingInfosByGuid := make(map[string]*models.DesiredLRPSchedulingInfo, len(schedulingInfos))
+	for _, schedulingInfo := range schedulingInfos {
+		schedulingInfosByGuid[schedulingInfo.ProcessGuid] = schedulingInfo
+	}
+
+	unclaimedIndices, err := db.unclaimedActualLRPIndices(logger, processGuids)
+	if err != nil {
+		return deferred
+	}
+
+	refreshed := make([]*auctioneer.LRPStartRequest, 0, len(deferred))
+	droppedCount := 0
+	for _, startRequest := range deferred {
+		schedulingInfo, ok := schedulingInfosByGuid[startRequest.ProcessGuid]
+		if !ok {
+			droppedCount += len(startRequest.Indices)
+			continue
+		}
+
+		indices := []int{}
+		for _, index := range startRequest.Indices {
+			if _, ok := unclaimedIndices[startRequest.ProcessGuid][index]; !ok || index >= int(schedulingInfo.Instances) {
+				droppedCount++
+				continue
+			}
+			indices = append(indices, index)
+		}
+		if len(indices) == 0 {
+			continue
+		}
+
+		refreshedStartRequest := auctioneer.NewLRPStartRequestFromSchedulingInfo(schedulingInfo, indices...)
+		refreshed = append(refreshed, &refreshedStartRequest)
+	}
+
+	if droppedCount > 0 {
+		logger.Info("dropped-deferred-instances", lager.Data{"count": droppedCount})
+	}
+	return refreshed
+}
+
+// Returns the indices of the non-evacuating unclaimed actual LRPs of the given
+// process guids, by process guid.
+func (db *SQLDB) unclaimedActualLRPIndices(logger lager.Logger, processGuids []string) (map[string]map[int]struct{}, error) {
+	rows, err := db.selectUnclaimedActualLRPIndices(logger, db.db, processGuids)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	indices := map[string]map[int]struct{}{}
+	for rows.Next() {
+		var processGuid string
+		var index int
+		err := rows.Scan(&processGuid, &index)
+		if err != nil {
+			logger.Error("failed-scanning", err)
+			return nil, err
+		}
+		if indices[processGuid] == nil {
+			indices[processGuid] = map[int]struct{}{}
+		}
+		indices[processGuid][index] = struct{}{}
+	}
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return nil, rows.Err()
+	}
+
+	return indices, nil
+}
+
+func containsIndex(indices []int, index int) bool {
+	for _, i := range indices {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+type startRequestsByProcessGuid []*auctioneer.LRPStartRequest
+
+func (s startRequestsByProcessGuid) Len() int           { return len(s) }
+func (s startRequestsByProcessGuid) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s startRequestsByProcessGuid) Less(i, j int) bool { return s[i].ProcessGuid < s[j].ProcessGuid }
+
+// Counts the instances that need to be placed (start requests and instances
+// on missing cells) for which no cell in the cell set satisfies the placement
+// tags. Must be called with the start requests and keys mutexes held.
+func (c *convergence) pendingPlacementCount() int {
+	type instance struct {
+		processGuid string
+		index       int
+	}
+	pending := map[instance]struct{}{}
+
+	for guid, startRequest := range c.guidsToStartRequests {
+		if c.canBePlaced(c.guidsToPlacementTags[guid]) {
+			continue
+		}
+		for _, index := range startRequest.Indices {
+			pending[instance{guid, index}] = struct{}{}
+		}
+	}
+
+	for _, key := range c.keysWithMissingCells {
+		if c.canBePlaced(key.SchedulingInfo.PlacementTags) {
+			continue
+		}
+		pending[instance{key.Key.ProcessGuid, int(key.Key.Index)}] = struct{}{}
+	}
+
+	return len(pending)
+}
+
+// A cell can be targeted if it is not evacuating, offers every requested tag
+// and the request asks for every tag the cell requires.
+func (c *convergence) canBePlaced(placementTags []string) bool {
+	return c.canBePlacedOn(placementTags, false)
+}
+
+// Reports whether only evacuating cells satisfy the placement tags, in which
+// case placing the instance now would target an evacuating cell.
+func (c *convergence) onlyPlaceableOnEvacuatingCells(placementTags []string) bool {
+	return !c.canBePlacedOn(placementTags, false) && c.canBePlacedOn(placementTags, true)
+}
+
+func (c *convergence) canBePlacedOn(placementTags []string, includeEvacuating bool) bool {
+	requested := make(map[string]struct{}, len(placementTags))
+	for _, tag := range placementTags {
+		requested[tag] = struct{}{}
+	}
+
+	for _, cell := range c.cellSet {
+		if cell.Evacuating && !includeEvacuating {
+			continue
+		}
+
+		offered := make(map[string]struct{}, len(cell.PlacementTags)+len(cell.OptionalPlacementTags))
+		for _, tag := range cell.OptionalPlacementTags {
+			offered[tag] = struct{}{}
+		}
+
+		matches := true
+		for _, tag := range cell.PlacementTags {
+			offered[tag] = struct{}{}
+			if _, ok := requested[tag]; !ok {
+				matches = false
+			}
+		}
+		for tag := range requested {
+			if _, ok := offered[tag]; !ok {
+				matches = false
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Breaks LRPsMissing and LRPsExtra down by domain. Every fresh domain is
+// reported, as well as any other domain with missing or extra actual LRPs, so
+// that the per-domain values sum to the global ones.
+func (c *convergence) emitDomainLRPMetrics(logger lager.Logger, domainSet map[string]struct{}) {
+	c.keysMutex.Lock()
+	extraLRPsByDomain := map[string]int{}
+	for _, key := range c.keysToRetire {
+		if retiredAsExtra(key.Reason) {
+			extraLRPsByDomain[key.Key.Domain]++
+		}
+	}
+	c.keysMutex.Unlock()
+
+	reported := map[string]struct{}{}
+	for domain := range domainSet {
+		reported[domain] = struct{}{}
+	}
+	for domain := range c.missingLRPsByDomain {
+		reported[domain] = struct{}{}
+	}
+	for domain := range extraLRPsByDomain {
+		reported[domain] = struct{}{}
+	}
+
+	domains := make([]string, 0, len(reported))
+	for domain := range reported {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+
+	for _, domain := range domains {
+		err := c.metronClient.SendMetric(missingLRPs+"."+domain, c.missingLRPsByDomain[domain])
+		if err != nil {
+			logger.Error("failed-sending-domain-missing-lrps-metric", err, lager.Data{"domain": domain})
+		}
+		err = c.metronClient.SendMetric(extraLRPs+"."+domain, extraLRPsByDomain[domain])
+		if err != nil {
+			logger.Error("failed-sending-domain-extra-lrps-metric", err, lager.Data{"domain": domain})
+		}
+	}
+}
+
+func (c *convergence) emitDomainDurations(logger lager.Logger) {
+	c.domainDurationsMutex.Lock()
+	defer c.domainDurationsMutex.Unlock()
+
+	for domain, duration := range c.domainDurations {
+		err := c.metronClient.SendDuration(convergeLRPDuration+"."+domain, duration)
+		if err != nil {
+			logger.Error("failed-sending-domain-converge-lrp-duration-metric", err, lager.Data{"domain": domain})
+		}
+	}
+}
+
 func (db *SQLDB) pruneDomains(logger lager.Logger, now time.Time) {
 	logger = logger.Session("prune-domains")
 
@@ -431,6 +1439,110 @@ func (db *SQLDB) emitDomainMetrics(logger lager.Logger, domainSet map[string]str
 	}
 }
 
+func (db *SQLDB) emitStaleDomainMetrics(logger lager.Logger, now time.Time) {
+	if db.domainStaleWindow <= 0 {
+		return
+	}
+
+	logger = logger.Session("emit-stale-domain-metrics")
+
+	rows, err := db.all(logger, db.db, domainsTable,
+		domainColumns, helpers.NoLockRow,
+		"expire_time > ? AND expire_time <= ?", now.UnixNano(), now.Add(db.domainStaleWindow).UnixNano(),
+	)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return
+	}
+	defer rows.Close()
+
+	var domain string
+	for rows.Next() {
+		err = rows.Scan(&domain)
+		if err != nil {
+			logger.Error("failed-scan-row", err)
+			return
+		}
+		logger.Error("domain-stale", nil, lager.Data{"domain": domain})
+		err = db.metronClient.SendMetric(staleDomainMetricPrefix+domain, 1)
+		if err != nil {
+			logger.Error("failed-sending-stale-domain-metric", err, lager.Data{"domain": domain})
+		}
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-fetching-row", rows.Err())
+	}
+}
+
+// Actual LRPs whose domain is neither fresh nor the domain of any desired LRP
+// were created against a domain that does not exist. They are never retired
+// as orphans, so they are only reported here for an operator to clean up.
+func (db *SQLDB) emitInvalidDomainMetrics(logger lager.Logger) {
+	logger = logger.Session("emit-invalid-domain-metrics")
+
+	rows, err := db.selectActualLRPsWithInvalidDomains(logger, db.db)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var key models.ActualLRPKey
+		err = rows.Scan(&key.ProcessGuid, &key.Index, &key.Domain)
+		if err != nil {
+			logger.Error("failed-scan-row", err)
+			return
+		}
+		logger.Info("actual-lrp-with-invalid-domain", lager.Data{"process_guid": key.ProcessGuid, "index": key.Index, "domain": key.Domain})
+		count++
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-fetching-row", rows.Err())
+		return
+	}
+
+	db.metronClient.SendMetric(invalidDomainLRPs, count)
+}
+
+// Records that an instance of the desired LRP crashed for the first time,
+// either since it was started or since it last ran long enough to reset its
+// crash count, for the next convergence to count.
+func (db *SQLDB) recordFirstCrash(processGuid string) {
+	if !db.firstCrashEvents {
+		return
+	}
+
+	db.firstCrashGuidsMutex.Lock()
+	defer db.firstCrashGuidsMutex.Unlock()
+
+	if db.firstCrashGuids == nil {
+		db.firstCrashGuids = map[string]struct{}{}
+	}
+	db.firstCrashGuids[processGuid] = struct{}{}
+}
+
+func (db *SQLDB) emitFirstCrashEvents(logger lager.Logger) {
+	if !db.firstCrashEvents {
+		return
+	}
+
+	logger = logger.Session("emit-first-crash-events")
+
+	db.firstCrashGuidsMutex.Lock()
+	firstCrashGuids := db.firstCrashGuids
+	db.firstCrashGuids = nil
+	db.firstCrashGuidsMutex.Unlock()
+
+	for processGuid := range firstCrashGuids {
+		logger.Info("lrp-first-crash", lager.Data{"process_guid": processGuid})
+		db.metronClient.IncrementCounter(firstCrashCounterPrefix + processGuid)
+	}
+}
+
 func (db *SQLDB) emitLRPMetrics(logger lager.Logger) {
 	var err error
 	logger = logger.Session("emit-lrp-metrics")
@@ -467,6 +1579,70 @@ func (db *SQLDB) emitLRPMetrics(logger lager.Logger) {
 	if err != nil {
 		logger.Error("failed-sending-desired-lrps-metric", err)
 	}
+
+	db.emitUnclaimedAgeMetrics(logger, db.clock.Now())
+}
+
+// Emits the models.PlacementFairnessAcrossCellSet of the non-evacuating actual
+// LRPs as LRPPlacementFairness, in percent as metric
+// values are integers.
+func (db *SQLDB) emitPlacementFairness(logger lager.Logger, cellSet models.CellSet) {
+	rows, err := db.selectActualLRPCellCounts(logger, db.db)
+	if err != nil {
+		logger.Error("failed-counting-actual-lrps-by-cell", err)
+		return
+	}
+	defer rows.Close()
+
+	instancesByCell := map[string]int{}
+	for rows.Next() {
+		var cellID string
+		var count int
+		err := rows.Scan(&cellID, &count)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			return
+		}
+		instancesByCell[cellID] = count
+	}
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return
+	}
+
+	fairness := models.PlacementFairnessByCell(instancesByCell, cellSet)
+	err = db.metronClient.SendMetric(placementFairness, int(fairness*100+0.5))
+	if err != nil {
+		logger.Error("failed-sending-placement-fairness-metric", err)
+	}
+}
+
+// Emits a histogram of how long the unclaimed actual LRPs have been waiting to
+// be placed, one LRPsUnclaimedAge.<bucket> gauge per bucket.
+func (db *SQLDB) emitUnclaimedAgeMetrics(logger lager.Logger, now time.Time) {
+	ages := make([]time.Duration, len(unclaimedAgeBuckets))
+	for i, bucket := range unclaimedAgeBuckets {
+		ages[i] = bucket.bound
+	}
+
+	younger, total, err := db.countUnclaimedActualLRPsYoungerThan(logger, db.db, now, ages)
+	if err != nil {
+		return
+	}
+
+	previous := 0
+	for i, bucket := range unclaimedAgeBuckets {
+		err = db.metronClient.SendMetric(unclaimedAgeMetricPrefix+bucket.name, younger[i]-previous)
+		if err != nil {
+			logger.Error("failed-sending-unclaimed-age-metric", err, lager.Data{"bucket": bucket.name})
+		}
+		previous = younger[i]
+	}
+
+	err = db.metronClient.SendMetric(unclaimedAgeMetricPrefix+unclaimedAgeOverflowBucket, total-previous)
+	if err != nil {
+		logger.Error("failed-sending-unclaimed-age-metric", err, lager.Data{"bucket": unclaimedAgeOverflowBucket})
+	}
 }
 
 func (db *SQLDB) GatherAndPruneLRPs(logger lager.Logger, cellSet models.CellSet) (*models.ConvergenceInput, error) {
@@ -1,8 +1,11 @@
 package sqldb_test
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/auctioneer"
@@ -11,6 +14,7 @@ import (
 	"code.cloudfoundry.org/bbs/models"
 	"code.cloudfoundry.org/bbs/models/test/model_helpers"
 	"code.cloudfoundry.org/bbs/test_helpers"
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 
 	mfakes "code.cloudfoundry.org/go-loggregator/testhelpers/fakes/v1"
@@ -33,7 +37,7 @@ var _ = Describe("LRPConvergence", func() {
 	BeforeEach(func() {
 		fakeMetronClient = new(mfakes.FakeIngressClient)
 
-		sqlDB = sqldb.NewSQLDB(db, 5, 5, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+		sqlDB = sqldb.NewSQLDB(db, 5, 0, 5, 0, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
 		var err error
 		freshDomain = "fresh-domain"
 		expiredDomain = "expired-domain"
@@ -201,6 +205,7 @@ var _ = Describe("LRPConvergence", func() {
 		// 1. a desired lrp with 2 instances and 2 claimed actual lrp
 		// 2. a desired lrp with 1 instance and 1 unclaimed actual lrp
 		// 3. a desired lrp with 2 instances and 2 crashed and non-restartable actual lrps
+		// 4. a desired lrp with 1 instance and a running actual lrp with no net info
 
 		domain := freshDomain
 
@@ -280,6 +285,21 @@ var _ = Describe("LRPConvergence", func() {
 		_, err = db.Exec(queryStr, models.DefaultMaxRestarts+1, models.ActualLRPStateCrashed, processGuid, 1, false)
 		Expect(err).NotTo(HaveOccurred())
 
+		processGuid = "desired-with-running-actual-missing-net-info" + "-" + domain
+		desiredLRPWithMissingNetInfo := model_helpers.NewValidDesiredLRP(processGuid)
+		desiredLRPWithMissingNetInfo.Domain = domain
+		desiredLRPWithMissingNetInfo.Instances = 1
+		err = sqlDB.DesireLRP(logger, desiredLRPWithMissingNetInfo)
+		Expect(err).NotTo(HaveOccurred())
+		missingNetInfoKey := &models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: domain}
+		_, err = sqlDB.CreateUnclaimedActualLRP(logger, missingNetInfoKey)
+		Expect(err).NotTo(HaveOccurred())
+		instanceKey := &models.ActualLRPInstanceKey{InstanceGuid: "running-actual-missing-net-info" + "-" + domain, CellId: "existing-cell"}
+		_, _, err = sqlDB.ClaimActualLRP(logger, processGuid, 0, instanceKey)
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = sqlDB.StartActualLRP(logger, missingNetInfoKey, instanceKey, &models.ActualLRPNetInfo{})
+		Expect(err).NotTo(HaveOccurred())
+
 		processGuid = "expired-evacuating-actual-lrp"
 		_, err = sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: domain})
 		Expect(err).NotTo(HaveOccurred())
@@ -298,7 +318,7 @@ var _ = Describe("LRPConvergence", func() {
 			sqlDB.ConvergeLRPs(logger, cellSet)
 
 			domainMap := map[string]int{}
-			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(10))
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
 			name, value := fakeMetronClient.SendMetricArgsForCall(0)
 			domainMap[name] = value
 
@@ -312,245 +332,1720 @@ var _ = Describe("LRPConvergence", func() {
 		It("emits missing LRP metrics", func() {
 			sqlDB.ConvergeLRPs(logger, cellSet)
 
-			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(10))
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
 			name, value := fakeMetronClient.SendMetricArgsForCall(2)
 			Expect(name).To(Equal("LRPsMissing"))
 			Expect(value).To(BeNumerically("==", 17))
 		})
 
-		It("emits extra LRP metrics", func() {
+		It("emits inconsistent state LRP metrics", func() {
 			sqlDB.ConvergeLRPs(logger, cellSet)
-			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(10))
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
 			name, value := fakeMetronClient.SendMetricArgsForCall(3)
+			Expect(name).To(Equal("LRPsInconsistentState"))
+			Expect(value).To(BeNumerically("==", 1))
+		})
+
+		placementFairnessMetrics := func() []int {
+			values := []int{}
+			for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+				name, value := fakeMetronClient.SendMetricArgsForCall(i)
+				if name == "LRPPlacementFairness" {
+					values = append(values, value)
+				}
+			}
+			return values
+		}
+
+		It("does not emit the placement fairness by default", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(placementFairnessMetrics()).To(BeEmpty())
+		})
+
+		Context("when the placement fairness metric is enabled", func() {
+			BeforeEach(func() {
+				sqlDB.SetPlacementFairnessMetric(true)
+			})
+
+			It("emits the placement fairness of the actual LRPs across cells, in percent", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+
+				groups, err := sqlDB.ActualLRPGroups(logger, models.ActualLRPFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				nonEvacuating := []*models.ActualLRPGroup{}
+				for _, group := range groups {
+					if group.Instance != nil {
+						nonEvacuating = append(nonEvacuating, &models.ActualLRPGroup{Instance: group.Instance})
+					}
+				}
+				fairness := models.PlacementFairnessAcrossCellSet(nonEvacuating, cellSet)
+				Expect(placementFairnessMetrics()).To(ConsistOf(int(fairness*100 + 0.5)))
+			})
+		})
+
+		It("emits extra LRP metrics", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+			name, value := fakeMetronClient.SendMetricArgsForCall(4)
 			Expect(name).To(Equal("LRPsExtra"))
 			Expect(value).To(BeNumerically("==", 2))
 		})
 
+		It("breaks the missing and extra LRP metrics down by domain", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+
+			metrics := map[string]int{}
+			for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+				name, value := fakeMetronClient.SendMetricArgsForCall(i)
+				metrics[name] = value
+			}
+
+			missingByDomain := map[string]int{}
+			extraByDomain := map[string]int{}
+			for name, value := range metrics {
+				if strings.HasPrefix(name, "LRPsMissing.") {
+					missingByDomain[strings.TrimPrefix(name, "LRPsMissing.")] = value
+				}
+				if strings.HasPrefix(name, "LRPsExtra.") {
+					extraByDomain[strings.TrimPrefix(name, "LRPsExtra.")] = value
+				}
+			}
+
+			Expect(missingByDomain).To(HaveKey(freshDomain))
+			Expect(missingByDomain).To(HaveKey(expiredDomain))
+			Expect(missingByDomain).To(HaveKey(evacuatingDomain))
+			Expect(extraByDomain).To(HaveKeyWithValue(freshDomain, 2))
+			Expect(extraByDomain).To(HaveKeyWithValue(expiredDomain, 0))
+
+			missingTotal := 0
+			for _, value := range missingByDomain {
+				missingTotal += value
+			}
+			Expect(missingTotal).To(Equal(metrics["LRPsMissing"]))
+
+			extraTotal := 0
+			for _, value := range extraByDomain {
+				extraTotal += value
+			}
+			Expect(extraTotal).To(Equal(metrics["LRPsExtra"]))
+		})
+
+		It("emits pending placement metrics for instances that no cell can target", func() {
+			startRequests, keysWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+			name, value := fakeMetronClient.SendMetricArgsForCall(5)
+			Expect(name).To(Equal("LRPsPendingPlacement"))
+			Expect(value).To(Equal(countInstancesToPlace(startRequests, keysWithMissingCells)))
+		})
+
+		Context("when a cell satisfies the placement tags", func() {
+			BeforeEach(func() {
+				cellSet = models.NewCellSetFromList([]*models.CellPresence{
+					{CellId: "existing-cell", PlacementTags: []string{"red-tag"}, OptionalPlacementTags: []string{"blue-tag"}},
+				})
+			})
+
+			It("does not count any instances as pending placement", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+				name, value := fakeMetronClient.SendMetricArgsForCall(5)
+				Expect(name).To(Equal("LRPsPendingPlacement"))
+				Expect(value).To(Equal(0))
+			})
+		})
+
+		Context("when the only cell satisfying the placement tags is evacuating", func() {
+			startRequestGuids := func(startRequests []*auctioneer.LRPStartRequest) []string {
+				guids := []string{}
+				for _, startRequest := range startRequests {
+					guids = append(guids, startRequest.ProcessGuid)
+				}
+				return guids
+			}
+
+			BeforeEach(func() {
+				cellSet = models.NewCellSetFromList([]*models.CellPresence{
+					{CellId: "existing-cell", PlacementTags: []string{"red-tag"}, OptionalPlacementTags: []string{"blue-tag"}, Evacuating: true},
+				})
+
+				taggedLRP := model_helpers.NewValidDesiredLRP("tagged-desired-lrp")
+				taggedLRP.Domain = freshDomain
+				Expect(sqlDB.DesireLRP(logger, taggedLRP)).To(Succeed())
+
+				untaggedLRP := model_helpers.NewValidDesiredLRP("untagged-desired-lrp")
+				untaggedLRP.Domain = freshDomain
+				untaggedLRP.PlacementTags = nil
+				Expect(sqlDB.DesireLRP(logger, untaggedLRP)).To(Succeed())
+			})
+
+			It("does not return start requests that only it could place", func() {
+				placeableCellSet := models.NewCellSetFromList([]*models.CellPresence{
+					{CellId: "existing-cell", PlacementTags: []string{"red-tag"}, OptionalPlacementTags: []string{"blue-tag"}},
+				})
+				placeableStartRequests, _, _ := sqlDB.ConvergeLRPsDryRun(logger, placeableCellSet)
+				Expect(startRequestGuids(placeableStartRequests)).To(ContainElement("tagged-desired-lrp"))
+
+				startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(startRequestGuids(startRequests)).NotTo(ContainElement("tagged-desired-lrp"))
+			})
+
+			It("still returns start requests that no cell could place", func() {
+				startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(startRequestGuids(startRequests)).To(ContainElement("untagged-desired-lrp"))
+			})
+
+			It("counts the instances it holds back as pending placement", func() {
+				startRequests, keysWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+				name, value := fakeMetronClient.SendMetricArgsForCall(5)
+				Expect(name).To(Equal("LRPsPendingPlacement"))
+				Expect(value).To(BeNumerically(">", countInstancesToPlace(startRequests, keysWithMissingCells)))
+			})
+
+			It("still counts the instances on it", func() {
+				startRequests, keysWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+				processGuid := "normal-desired-lrp" + "-" + freshDomain
+				for _, key := range keysWithMissingCells {
+					Expect(key.Key.ProcessGuid).NotTo(Equal(processGuid))
+				}
+				for _, startRequest := range startRequests {
+					Expect(startRequest.ProcessGuid).NotTo(Equal(processGuid))
+				}
+			})
+		})
+
+		It("emits convergence suppressed LRP metrics", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+			name, value := fakeMetronClient.SendMetricArgsForCall(6)
+			Expect(name).To(Equal("LRPsConvergenceSuppressed"))
+			Expect(value).To(Equal(0))
+		})
+
 		It("emits metrics for lrps", func() {
 			convergenceLogger := lagertest.NewTestLogger("convergence")
 			sqlDB.ConvergeLRPs(convergenceLogger, cellSet)
-			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(10))
-			name, value := fakeMetronClient.SendMetricArgsForCall(4)
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+			name, value := fakeMetronClient.SendMetricArgsForCall(7)
 			Expect(name).To(Equal("LRPsUnclaimed"))
-			Expect(value).To(Equal(32)) // 16 fresh + 5 expired + 11 evac
-			name, value = fakeMetronClient.SendMetricArgsForCall(5)
+			Expect(value).To(Equal(33)) // 17 fresh + 5 expired + 11 evac
+			name, value = fakeMetronClient.SendMetricArgsForCall(8)
 			Expect(name).To(Equal("LRPsClaimed"))
 			Expect(value).To(Equal(7))
-			name, value = fakeMetronClient.SendMetricArgsForCall(6)
+			name, value = fakeMetronClient.SendMetricArgsForCall(9)
 			Expect(name).To(Equal("LRPsRunning"))
 			Expect(value).To(Equal(1))
-			name, value = fakeMetronClient.SendMetricArgsForCall(7)
+			name, value = fakeMetronClient.SendMetricArgsForCall(10)
 			Expect(name).To(Equal("CrashedActualLRPs"))
 			Expect(value).To(Equal(2))
-			name, value = fakeMetronClient.SendMetricArgsForCall(8)
+			name, value = fakeMetronClient.SendMetricArgsForCall(11)
 			Expect(name).To(Equal("CrashingDesiredLRPs"))
 			Expect(value).To(Equal(1))
-			name, value = fakeMetronClient.SendMetricArgsForCall(9)
+			name, value = fakeMetronClient.SendMetricArgsForCall(12)
 			Expect(name).To(Equal("LRPsDesired"))
-			Expect(value).To(Equal(38))
+			Expect(value).To(Equal(39))
 			Consistently(convergenceLogger).ShouldNot(gbytes.Say("failed-.*"))
 		})
+
+		It("emits invalid domain LRP metrics", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+			name, value := fakeMetronClient.SendMetricArgsForCall(17)
+			Expect(name).To(Equal("LRPsInvalidDomain"))
+			Expect(value).To(Equal(0))
+		})
+
+		Context("when an actual lrp is in a domain that does not exist", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: "actual-in-nonexistent-domain", Index: 0, Domain: "nonexistent-domain"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("flags it as being in an invalid domain", func() {
+				convergenceLogger := lagertest.NewTestLogger("convergence")
+				sqlDB.ConvergeLRPs(convergenceLogger, cellSet)
+				Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+				name, value := fakeMetronClient.SendMetricArgsForCall(17)
+				Expect(name).To(Equal("LRPsInvalidDomain"))
+				Expect(value).To(Equal(1))
+				Expect(convergenceLogger).To(gbytes.Say("actual-lrp-with-invalid-domain.*actual-in-nonexistent-domain"))
+			})
+
+			It("does not retire it", func() {
+				_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+				for _, key := range keysToRetire {
+					Expect(key.Key.ProcessGuid).NotTo(Equal("actual-in-nonexistent-domain"))
+				}
+			})
+		})
+
+		Context("when unclaimed actual lrps have been waiting for different amounts of time", func() {
+			BeforeEach(func() {
+				_, err := db.Exec("DELETE FROM actual_lrps")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = db.Exec("DELETE FROM desired_lrps")
+				Expect(err).NotTo(HaveOccurred())
+
+				ages := []time.Duration{0, 500 * time.Millisecond, 5 * time.Second, 30 * time.Second, 2 * time.Minute, time.Hour}
+				for i, age := range ages {
+					key := &models.ActualLRPKey{ProcessGuid: "aged-unclaimed", Index: int32(i), Domain: freshDomain}
+					_, err = sqlDB.CreateUnclaimedActualLRP(logger, key)
+					Expect(err).NotTo(HaveOccurred())
+
+					queryStr := `UPDATE actual_lrps SET since = ? WHERE process_guid = ? AND instance_index = ?`
+					if test_helpers.UsePostgres() {
+						queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+					}
+					_, err = db.Exec(queryStr, fakeClock.Now().Add(-age).UnixNano(), key.ProcessGuid, key.Index)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("emits the number of unclaimed lrps in each age bucket", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+
+				metrics := map[string]int{}
+				for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+					name, value := fakeMetronClient.SendMetricArgsForCall(i)
+					metrics[name] = value
+				}
+
+				Expect(metrics).To(HaveKeyWithValue("LRPsUnclaimedAge.lt1s", 2))
+				Expect(metrics).To(HaveKeyWithValue("LRPsUnclaimedAge.lt10s", 1))
+				Expect(metrics).To(HaveKeyWithValue("LRPsUnclaimedAge.lt60s", 1))
+				Expect(metrics).To(HaveKeyWithValue("LRPsUnclaimedAge.gte60s", 2))
+			})
+		})
 	})
 
-	Describe("convergence counters", func() {
-		It("bumps the convergence counter", func() {
-			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(0))
-			sqlDB.ConvergeLRPs(logger, models.CellSet{})
-			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
-			Expect(fakeMetronClient.IncrementCounterArgsForCall(0)).To(Equal("ConvergenceLRPRuns"))
-			sqlDB.ConvergeLRPs(logger, models.CellSet{})
-			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(2))
-			Expect(fakeMetronClient.IncrementCounterArgsForCall(1)).To(Equal("ConvergenceLRPRuns"))
+	Describe("first crash events", func() {
+		var (
+			processGuid string
+			key         *models.ActualLRPKey
+			instanceKey *models.ActualLRPInstanceKey
+		)
+
+		firstCrashCount := func() int {
+			count := 0
+			for i := 0; i < fakeMetronClient.IncrementCounterCallCount(); i++ {
+				if fakeMetronClient.IncrementCounterArgsForCall(i) == "LRPFirstCrash."+processGuid {
+					count++
+				}
+			}
+			return count
+		}
+
+		restart := func() {
+			_, _, err := sqlDB.ClaimActualLRP(logger, processGuid, 0, instanceKey)
+			Expect(err).NotTo(HaveOccurred())
+			netInfo := models.NewActualLRPNetInfo("some-address", "container-address", models.NewPortMapping(2222, 4444))
+			_, _, err = sqlDB.StartActualLRP(logger, key, instanceKey, &netInfo)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		crash := func() {
+			_, _, _, err := sqlDB.CrashActualLRP(logger, key, instanceKey, "boom")
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			processGuid = "healthy-desired-lrp"
+			desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+			desiredLRP.Domain = freshDomain
+			desiredLRP.Instances = 1
+			err := sqlDB.DesireLRP(logger, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			key = &models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain}
+			instanceKey = &models.ActualLRPInstanceKey{InstanceGuid: "healthy-instance", CellId: "existing-cell"}
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, key)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = sqlDB.ClaimActualLRP(logger, processGuid, 0, instanceKey)
+			Expect(err).NotTo(HaveOccurred())
+			netInfo := models.NewActualLRPNetInfo("some-address", "container-address", models.NewPortMapping(2222, 4444))
+			_, _, err = sqlDB.StartActualLRP(logger, key, instanceKey, &netInfo)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not emit first crash events by default", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+			_, _, _, err := sqlDB.CrashActualLRP(logger, key, instanceKey, "boom")
+			Expect(err).NotTo(HaveOccurred())
+			sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for i := 0; i < fakeMetronClient.IncrementCounterCallCount(); i++ {
+				Expect(fakeMetronClient.IncrementCounterArgsForCall(i)).NotTo(HavePrefix("LRPFirstCrash."))
+			}
+		})
+
+		Context("when first crash events are enabled", func() {
+			BeforeEach(func() {
+				sqlDB.SetFirstCrashEvents(true)
+			})
+
+			It("emits the event once when a healthy app's instance crashes", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(firstCrashCount()).To(Equal(0))
+
+				crash()
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(firstCrashCount()).To(Equal(1))
+
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(firstCrashCount()).To(Equal(1))
+			})
+
+			It("emits the event for an instance restarted right after crashing", func() {
+				crash()
+				actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(firstCrashCount()).To(Equal(1))
+			})
+
+			It("does not emit the event again while the instance keeps crashing", func() {
+				crash()
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(firstCrashCount()).To(Equal(1))
+
+				restart()
+				crash()
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(firstCrashCount()).To(Equal(1))
+			})
+
+			It("emits the event again when a recovered app crashes again", func() {
+				crash()
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(firstCrashCount()).To(Equal(1))
+
+				restart()
+				fakeClock.Increment(models.CrashResetTimeout + time.Second)
+				crash()
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(firstCrashCount()).To(Equal(2))
+			})
+
+			It("does not emit events for apps that were already crashing at the first convergence", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				sqlDB.ConvergeLRPs(logger, cellSet)
+
+				for i := 0; i < fakeMetronClient.IncrementCounterCallCount(); i++ {
+					Expect(fakeMetronClient.IncrementCounterArgsForCall(i)).NotTo(HavePrefix("LRPFirstCrash."))
+				}
+			})
+		})
+	})
+
+	Describe("stale domain metrics", func() {
+		BeforeEach(func() {
+			sqlDB.UpsertDomain(logger, "near-expiry-domain", 20)
+		})
+
+		It("does not emit stale domain metrics by default", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+				name, _ := fakeMetronClient.SendMetricArgsForCall(i)
+				Expect(name).NotTo(HavePrefix("DomainStale."))
+			}
+		})
+
+		Context("when a stale window is set", func() {
+			BeforeEach(func() {
+				sqlDB.SetDomainStaleWindow(30 * time.Second)
+			})
+
+			It("emits a stale metric only for domains about to expire", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+
+				metrics := map[string]int{}
+				for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+					name, value := fakeMetronClient.SendMetricArgsForCall(i)
+					metrics[name] = value
+				}
+
+				Expect(metrics).To(HaveKeyWithValue("DomainStale.near-expiry-domain", 1))
+				Expect(metrics).To(HaveKeyWithValue("Domain.near-expiry-domain", 1))
+				Expect(metrics).NotTo(HaveKey("DomainStale." + freshDomain))
+				Expect(metrics).NotTo(HaveKey("DomainStale." + evacuatingDomain))
+				Expect(metrics).NotTo(HaveKey("DomainStale." + expiredDomain))
+			})
+
+			It("logs an error for domains about to expire", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+
+				staleDomains := []interface{}{}
+				for _, log := range logger.Logs() {
+					if strings.HasSuffix(log.Message, "domain-stale") {
+						Expect(log.LogLevel).To(Equal(lager.ERROR))
+						staleDomains = append(staleDomains, log.Data["domain"])
+					}
+				}
+				Expect(staleDomains).To(ConsistOf("near-expiry-domain"))
+			})
+
+			It("logs failures to send the stale metric", func() {
+				fakeMetronClient.SendMetricReturns(errors.New("boom"))
+				sqlDB.ConvergeLRPs(logger, cellSet)
+
+				Expect(logger).To(gbytes.Say("failed-sending-stale-domain-metric.*near-expiry-domain"))
+			})
+		})
+	})
+
+	Describe("ConvergenceReport", func() {
+		var report sqldb.ConvergenceReport
+
+		actionsFor := func(processGuid string) *sqldb.LRPConvergenceActions {
+			for _, actions := range report {
+				if actions.ProcessGuid == processGuid {
+					return actions
+				}
+			}
+			return nil
+		}
+
+		BeforeEach(func() {
+			var err error
+			report, err = sqlDB.ConvergenceReport(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the actions convergence would take for each process guid", func() {
+			Expect(actionsFor("desired-with-stale-actuals-" + freshDomain)).To(Equal(&sqldb.LRPConvergenceActions{
+				ProcessGuid:  "desired-with-stale-actuals-" + freshDomain,
+				StartIndices: []int32{0, 1},
+			}))
+			Expect(actionsFor("desired-with-missing-cell-actuals-" + freshDomain)).To(Equal(&sqldb.LRPConvergenceActions{
+				ProcessGuid:        "desired-with-missing-cell-actuals-" + freshDomain,
+				MissingCellIndices: []int32{0},
+			}))
+			Expect(actionsFor("desired-with-extra-actuals-" + freshDomain)).To(Equal(&sqldb.LRPConvergenceActions{
+				ProcessGuid:   "desired-with-extra-actuals-" + freshDomain,
+				RetireIndices: []int32{4},
+			}))
+			Expect(actionsFor("desired-with-missing-some-actuals-" + freshDomain)).To(Equal(&sqldb.LRPConvergenceActions{
+				ProcessGuid:   "desired-with-missing-some-actuals-" + freshDomain,
+				CreateIndices: []int32{1, 3},
+				StartIndices:  []int32{1, 3},
+			}))
+			Expect(actionsFor("desired-with-restartable-crashed-actuals-" + freshDomain)).To(Equal(&sqldb.LRPConvergenceActions{
+				ProcessGuid:    "desired-with-restartable-crashed-actuals-" + freshDomain,
+				UnclaimIndices: []int32{0, 1},
+				StartIndices:   []int32{0, 1},
+			}))
+			Expect(actionsFor("desired-with-running-actual-missing-net-info-" + freshDomain)).To(Equal(&sqldb.LRPConvergenceActions{
+				ProcessGuid:    "desired-with-running-actual-missing-net-info-" + freshDomain,
+				UnclaimIndices: []int32{0},
+				StartIndices:   []int32{0},
+			}))
+			Expect(actionsFor("actual-with-no-desired-" + freshDomain)).To(Equal(&sqldb.LRPConvergenceActions{
+				ProcessGuid:   "actual-with-no-desired-" + freshDomain,
+				RetireIndices: []int32{0},
+			}))
+		})
+
+		It("does not retire extra actual lrps in expired domains", func() {
+			Expect(actionsFor("desired-with-extra-actuals-" + expiredDomain)).To(BeNil())
+		})
+
+		It("renders a human readable report", func() {
+			Expect(report.String()).To(ContainSubstring("desired-with-missing-some-actuals-" + freshDomain + ": create [1 3], start [1 3]\n"))
+			Expect(report.String()).To(ContainSubstring("desired-with-restartable-crashed-actuals-" + freshDomain + ": unclaim [0 1], start [0 1]\n"))
+		})
+
+		It("does not modify the actual lrps", func() {
+			_, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "desired-with-missing-all-actuals-"+freshDomain, 0)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "desired-with-restartable-crashed-actuals-"+freshDomain, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateCrashed))
+
+			actualLRPGroup, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "desired-with-running-actual-missing-net-info-"+freshDomain, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateRunning))
+		})
+
+		It("does not emit metrics", func() {
+			Expect(fakeMetronClient.SendMetricCallCount()).To(BeZero())
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("ConvergenceLRPCandidateCount", func() {
+		It("counts the instances the fixtures need convergence to act on", func() {
+			// fresh domain: 2 stale unclaimed, 1 on a missing cell, 1 extra,
+			// 3 missing, 2 restartable crashed and 1 orphaned
+			// expired domain: the same but for the extra and the orphaned
+			// evacuating domain: 11 missing, as evacuating actuals do not count
+			count, err := sqlDB.ConvergenceLRPCandidateCount(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(10 + 8 + 11))
+		})
+
+		It("counts a missing index, which is both created and started, once", func() {
+			queryStr := "DELETE FROM desired_lrps WHERE process_guid <> ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+			_, err := db.Exec(queryStr, "desired-with-missing-some-actuals-"+freshDomain)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec("DELETE FROM actual_lrps")
+			Expect(err).NotTo(HaveOccurred())
+
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, "desired-with-missing-some-actuals-"+freshDomain)
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := sqlDB.ConvergenceLRPCandidateCount(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int(desiredLRP.Instances)))
+		})
+
+		It("is zero when nothing needs converging", func() {
+			_, err := db.Exec("DELETE FROM actual_lrps")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec("DELETE FROM desired_lrps")
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := sqlDB.ConvergenceLRPCandidateCount(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		It("does not modify the actual lrps or emit metrics", func() {
+			_, err := sqlDB.ConvergenceLRPCandidateCount(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "desired-with-missing-all-actuals-"+freshDomain, 0)
+			Expect(err).To(Equal(models.ErrResourceNotFound))
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "desired-with-restartable-crashed-actuals-"+freshDomain, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateCrashed))
+
+			Expect(fakeMetronClient.SendMetricCallCount()).To(BeZero())
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("skipping idle convergence", func() {
+		var missingNetInfoProcessGuid string
+
+		BeforeEach(func() {
+			sqlDB.SetSkipIdleConvergence(true)
+			missingNetInfoProcessGuid = "desired-with-running-actual-missing-net-info-" + freshDomain
+		})
+
+		Context("when there are no convergence candidates", func() {
+			BeforeEach(func() {
+				queryStr := "DELETE FROM desired_lrps WHERE process_guid <> ?"
+				if test_helpers.UsePostgres() {
+					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+				}
+				_, err := db.Exec(queryStr, missingNetInfoProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+
+				queryStr = "DELETE FROM actual_lrps WHERE process_guid NOT IN (?, ?)"
+				if test_helpers.UsePostgres() {
+					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+				}
+				_, err = db.Exec(queryStr, missingNetInfoProcessGuid, "expired-evacuating-actual-lrp")
+				Expect(err).NotTo(HaveOccurred())
+
+				count, err := sqlDB.ConvergenceLRPCandidateCount(logger, cellSet)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+
+			It("skips the convergence steps", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(logger).To(gbytes.Say("skipping-convergence-steps-without-candidates"))
+			})
+
+			It("still unclaims running actual LRPs that are missing net info and returns them to be started", func() {
+				startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+				desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, missingNetInfoProcessGuid)
+				Expect(err).NotTo(HaveOccurred())
+				lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0)
+				Expect(startRequests).To(ConsistOf(&lrpStartRequest))
+
+				actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, missingNetInfoProcessGuid, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			})
+
+			It("still prunes the expired evacuating actual LRPs", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+
+				_, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "expired-evacuating-actual-lrp", 0)
+				Expect(err).To(Equal(models.ErrResourceNotFound))
+			})
+
+			It("still emits the LRP metrics", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+
+				metrics := map[string]int{}
+				for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+					name, value := fakeMetronClient.SendMetricArgsForCall(i)
+					metrics[name] = value
+				}
+				Expect(metrics).To(HaveKeyWithValue("Domain."+freshDomain, 1))
+				Expect(metrics).To(HaveKeyWithValue("LRPsMissing", 0))
+				Expect(metrics).To(HaveKeyWithValue("LRPsExtra", 0))
+				Expect(metrics).To(HaveKeyWithValue("LRPsInconsistentState", 1))
+				Expect(metrics).To(HaveKeyWithValue("LRPsConvergenceSuppressed", 0))
+				Expect(metrics).To(HaveKeyWithValue("LRPsDesired", 1))
+				Expect(metrics).To(HaveKeyWithValue("LRPsUnclaimed", 1))
+			})
+
+			Context("when a desired LRP whose convergence is suppressed is missing instances", func() {
+				BeforeEach(func() {
+					desiredLRP := model_helpers.NewValidDesiredLRP("suppressed-desired-with-missing-actuals")
+					desiredLRP.Domain = freshDomain
+					desiredLRP.SuppressConvergence = true
+					Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+				})
+
+				It("still reports it as suppressed without creating its instances", func() {
+					sqlDB.ConvergeLRPs(logger, cellSet)
+					Expect(logger).To(gbytes.Say("skipping-convergence-steps-without-candidates"))
+
+					found := false
+					for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+						name, value := fakeMetronClient.SendMetricArgsForCall(i)
+						if name == "LRPsConvergenceSuppressed" {
+							found = true
+							Expect(value).To(Equal(1))
+						}
+					}
+					Expect(found).To(BeTrue())
+
+					_, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "suppressed-desired-with-missing-actuals", 0)
+					Expect(err).To(Equal(models.ErrResourceNotFound))
+				})
+			})
+		})
+
+		Context("when there are convergence candidates", func() {
+			It("runs the convergence steps", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(logger).NotTo(gbytes.Say("skipping-convergence-steps-without-candidates"))
+
+				actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, "desired-with-missing-all-actuals-"+freshDomain, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			})
+		})
+	})
+
+	Describe("compact convergence logs", func() {
+		var (
+			report            sqldb.ConvergenceReport
+			convergenceLogger *lagertest.TestLogger
+		)
+
+		BeforeEach(func() {
+			var err error
+			report, err = sqlDB.ConvergenceReport(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report).NotTo(BeEmpty())
+
+			sqlDB.SetCompactConvergenceLogs(true)
+			convergenceLogger = lagertest.NewTestLogger("convergence")
+			sqlDB.ConvergeLRPs(convergenceLogger, cellSet)
+		})
+
+		It("logs one line for every process guid that was acted on", func() {
+			loggedGuids := []interface{}{}
+			for _, log := range convergenceLogger.Logs() {
+				if log.Message == "convergence.converged-lrp" {
+					loggedGuids = append(loggedGuids, log.Data["process_guid"])
+				}
+			}
+
+			expectedGuids := []interface{}{}
+			for _, actions := range report {
+				expectedGuids = append(expectedGuids, actions.ProcessGuid)
+			}
+			Expect(loggedGuids).To(ConsistOf(expectedGuids...))
+		})
+
+		It("lists the indices of every action taken", func() {
+			logged := 0
+			for _, log := range convergenceLogger.Logs() {
+				if log.Data["process_guid"] == "desired-with-missing-some-actuals-"+freshDomain {
+					logged++
+					Expect(log.Data["create"]).To(ConsistOf(BeNumerically("==", 1), BeNumerically("==", 3)))
+					Expect(log.Data["start"]).To(ConsistOf(BeNumerically("==", 1), BeNumerically("==", 3)))
+					Expect(log.Data).NotTo(HaveKey("retire"))
+				}
+			}
+			Expect(logged).To(Equal(1))
+		})
+
+		Context("when the start requests are capped", func() {
+			loggedStartIndices := func() int {
+				count := 0
+				for _, log := range convergenceLogger.Logs() {
+					if log.Message == "convergence.converged-lrp" {
+						if indices, ok := log.Data["start"].([]interface{}); ok {
+							count += len(indices)
+						}
+					}
+				}
+				return count
+			}
+
+			BeforeEach(func() {
+				sqlDB.SetMaxStartRequestsPerTick(2)
+				convergenceLogger = lagertest.NewTestLogger("convergence")
+			})
+
+			It("lists only the start indices returned", func() {
+				sqlDB.ConvergeLRPs(convergenceLogger, cellSet)
+				Expect(loggedStartIndices()).To(Equal(2))
+			})
+
+			It("lists the deferred start indices when they are returned", func() {
+				sqlDB.ConvergeLRPs(logger, cellSet)
+				sqlDB.ConvergeLRPs(convergenceLogger, cellSet)
+				Expect(loggedStartIndices()).To(Equal(2))
+			})
+		})
+
+		It("suppresses the verbose convergence logs", func() {
+			Expect(convergenceLogger.LogMessages()).NotTo(ContainElement("convergence.starting"))
+			Expect(convergenceLogger.LogMessages()).NotTo(ContainElement(HaveSuffix("found-running-actual-lrp-without-net-info")))
+		})
+	})
+
+	Describe("ConvergeLRPsDryRun", func() {
+		tableContents := func(query string) [][]string {
+			rows, err := db.Query(query)
+			Expect(err).NotTo(HaveOccurred())
+			defer rows.Close()
+
+			columns, err := rows.Columns()
+			Expect(err).NotTo(HaveOccurred())
+
+			contents := [][]string{}
+			for rows.Next() {
+				values := make([]sql.RawBytes, len(columns))
+				dest := make([]interface{}, len(columns))
+				for i := range values {
+					dest[i] = &values[i]
+				}
+				Expect(rows.Scan(dest...)).To(Succeed())
+
+				row := make([]string, len(columns))
+				for i, value := range values {
+					row[i] = string(value)
+				}
+				contents = append(contents, row)
+			}
+			Expect(rows.Err()).NotTo(HaveOccurred())
+			return contents
+		}
+
+		actualLRPsQuery := "SELECT * FROM actual_lrps ORDER BY process_guid, instance_index, evacuating"
+		domainsQuery := "SELECT * FROM domains ORDER BY domain"
+
+		normalize := func(startRequests []*auctioneer.LRPStartRequest) []*auctioneer.LRPStartRequest {
+			for _, startRequest := range startRequests {
+				sort.Ints(startRequest.Indices)
+			}
+			return startRequests
+		}
+
+		It("does not modify the actual lrps or domains", func() {
+			beforeActuals := tableContents(actualLRPsQuery)
+			beforeDomains := tableContents(domainsQuery)
+
+			sqlDB.ConvergeLRPsDryRun(logger, cellSet)
+
+			Expect(tableContents(actualLRPsQuery)).To(Equal(beforeActuals))
+			Expect(tableContents(domainsQuery)).To(Equal(beforeDomains))
+		})
+
+		It("returns what a real convergence would return", func() {
+			dryStartRequests, dryKeysWithMissingCells, dryKeysToRetire := sqlDB.ConvergeLRPsDryRun(logger, cellSet)
+			startRequests, keysWithMissingCells, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			Expect(normalize(dryStartRequests)).To(ConsistOf(normalize(startRequests)))
+			Expect(dryKeysWithMissingCells).To(ConsistOf(keysWithMissingCells))
+			Expect(dryKeysToRetire).To(Equal(keysToRetire))
+		})
+
+		It("does not retire orphaned actual lrps in expired domains", func() {
+			_, _, keysToRetire := sqlDB.ConvergeLRPsDryRun(logger, cellSet)
+			for _, key := range keysToRetire {
+				Expect(key.Key.Domain).NotTo(Equal(expiredDomain))
+			}
+		})
+
+		It("does not emit metrics", func() {
+			sqlDB.ConvergeLRPsDryRun(logger, cellSet)
+			Expect(fakeMetronClient.SendMetricCallCount()).To(BeZero())
+			Expect(fakeMetronClient.SendDurationCallCount()).To(BeZero())
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(BeZero())
+		})
+
+		Context("when the start requests are capped", func() {
+			instanceCount := func(startRequests []*auctioneer.LRPStartRequest) int {
+				count := 0
+				for _, startRequest := range startRequests {
+					count += len(startRequest.Indices)
+				}
+				return count
+			}
+
+			BeforeEach(func() {
+				sqlDB.SetMaxStartRequestsPerTick(2)
+			})
+
+			It("returns the capped start requests without deferring the rest", func() {
+				dryStartRequests, _, _ := sqlDB.ConvergeLRPsDryRun(logger, cellSet)
+				Expect(instanceCount(dryStartRequests)).To(Equal(2))
+
+				startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(normalize(dryStartRequests)).To(ConsistOf(normalize(startRequests)))
+			})
+
+			It("reports the deferred start indices", func() {
+				report, err := sqlDB.ConvergenceReport(logger, cellSet)
+				Expect(err).NotTo(HaveOccurred())
+
+				startIndices, deferredIndices := 0, 0
+				for _, actions := range report {
+					startIndices += len(actions.StartIndices)
+					deferredIndices += len(actions.DeferredIndices)
+				}
+				Expect(startIndices).To(Equal(2))
+				Expect(deferredIndices).NotTo(BeZero())
+
+				startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(instanceCount(startRequests)).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("batched convergence", func() {
+		var batchedDB *sqldb.SQLDB
+
+		BeforeEach(func() {
+			batchedDB = sqldb.NewSQLDB(db, 5, 1, 5, 0, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+		})
+
+		It("takes the same actions as unbatched convergence", func() {
+			unbatchedReport, err := sqlDB.ConvergenceReport(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+
+			batchedReport, err := batchedDB.ConvergenceReport(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(batchedReport).To(Equal(unbatchedReport))
+		})
+
+		It("does not drop start requests when a batch boundary falls within a process guid", func() {
+			startRequests, _, _ := batchedDB.ConvergeLRPs(logger, cellSet)
+
+			for _, processGuid := range []string{
+				"desired-with-stale-actuals-" + freshDomain,
+				"desired-with-restartable-crashed-actuals-" + freshDomain,
+			} {
+				desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+				Expect(err).NotTo(HaveOccurred())
+
+				lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+
+				for _, startRequest := range startRequests {
+					sort.Ints(startRequest.Indices)
+				}
+
+				Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+			}
+		})
+
+		It("emits metric totals across all batches", func() {
+			batchedDB.ConvergeLRPs(logger, cellSet)
+
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+			name, value := fakeMetronClient.SendMetricArgsForCall(2)
+			Expect(name).To(Equal("LRPsMissing"))
+			Expect(value).To(Equal(17))
+			name, value = fakeMetronClient.SendMetricArgsForCall(3)
+			Expect(name).To(Equal("LRPsInconsistentState"))
+			Expect(value).To(Equal(1))
+			name, value = fakeMetronClient.SendMetricArgsForCall(4)
+			Expect(name).To(Equal("LRPsExtra"))
+			Expect(value).To(Equal(2))
+		})
+	})
+
+	Describe("convergence counters", func() {
+		It("bumps the convergence counter", func() {
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(0))
+			sqlDB.ConvergeLRPs(logger, models.CellSet{})
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+			Expect(fakeMetronClient.IncrementCounterArgsForCall(0)).To(Equal("ConvergenceLRPRuns"))
+			sqlDB.ConvergeLRPs(logger, models.CellSet{})
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(2))
+			Expect(fakeMetronClient.IncrementCounterArgsForCall(1)).To(Equal("ConvergenceLRPRuns"))
+		})
+
+		It("reports the duration that it took to converge", func() {
+			sqlDB.ConvergeLRPs(logger, models.CellSet{})
+
+			durations := map[string]time.Duration{}
+			for i := 0; i < fakeMetronClient.SendDurationCallCount(); i++ {
+				name, value := fakeMetronClient.SendDurationArgsForCall(i)
+				durations[name] = value
+			}
+
+			Expect(durations).To(HaveKey("ConvergenceLRPDuration"))
+			Expect(durations["ConvergenceLRPDuration"]).NotTo(BeZero())
+		})
+
+		It("reports the duration spent converging each domain", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+
+			durations := map[string]time.Duration{}
+			for i := 0; i < fakeMetronClient.SendDurationCallCount(); i++ {
+				name, value := fakeMetronClient.SendDurationArgsForCall(i)
+				durations[name] = value
+			}
+
+			Expect(durations).To(HaveKey("ConvergenceLRPDuration." + freshDomain))
+			Expect(durations).To(HaveKey("ConvergenceLRPDuration." + evacuatingDomain))
+			Expect(durations["ConvergenceLRPDuration."+freshDomain]).To(BeNumerically(">", 0))
+			Expect(durations["ConvergenceLRPDuration."+evacuatingDomain]).To(BeNumerically(">", 0))
+		})
+	})
+
+	It("returns start requests for stale unclaimed actual LRPs", func() {
+		startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+		By("fresh domain", func() {
+			Expect(startRequests).NotTo(BeEmpty())
+
+			processGuid := "desired-with-stale-actuals" + "-" + freshDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+
+			for _, startRequest := range startRequests {
+				sort.Ints(startRequest.Indices)
+			}
+
+			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+		})
+
+		By("expired domain", func() {
+			Expect(startRequests).NotTo(BeEmpty())
+
+			processGuid := "desired-with-stale-actuals" + "-" + expiredDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+
+			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+		})
+	})
+
+	Context("when the stale unclaimed duration is longer than the unclaimed actual LRPs' age", func() {
+		BeforeEach(func() {
+			sqlDB = sqldb.NewSQLDB(db, 5, 0, 5, 2*models.StaleUnclaimedActualLRPDuration, format.ENCRYPTED_PROTO, cryptor, fakeGUIDProvider, fakeClock, dbFlavor, fakeMetronClient)
+		})
+
+		It("does not return start requests for the recently created unclaimed actual LRPs", func() {
+			startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for _, startRequest := range startRequests {
+				Expect(startRequest.ProcessGuid).NotTo(HavePrefix("desired-with-stale-actuals"))
+			}
+		})
+	})
+
+	It("returns the start requests and actual lrp keys for actuals with missing cells", func() {
+		_, keysWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+		By("fresh domain", func() {
+			processGuid := "desired-with-missing-cell-actuals" + "-" + freshDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+			Expect(err).NotTo(HaveOccurred())
+			expectedSched := desiredLRP.DesiredLRPSchedulingInfo()
+			Expect(keysWithMissingCells).To(ContainElement(&models.ActualLRPKeyWithSchedulingInfo{
+				Key:            &actualLRPGroup.Instance.ActualLRPKey,
+				SchedulingInfo: &expectedSched,
+			}))
+		})
+
+		By("expired domain", func() {
+			processGuid := "desired-with-missing-cell-actuals" + "-" + expiredDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+			Expect(err).NotTo(HaveOccurred())
+			expectedSched := desiredLRP.DesiredLRPSchedulingInfo()
+			Expect(keysWithMissingCells).To(ContainElement(&models.ActualLRPKeyWithSchedulingInfo{
+				Key:            &actualLRPGroup.Instance.ActualLRPKey,
+				SchedulingInfo: &expectedSched,
+			}))
+		})
+	})
+
+	It("creates actual LRPs with missing indices, and returns it to be started", func() {
+		startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+		Expect(startRequests).NotTo(BeEmpty())
+
+		By("missing all actuals, fresh domain", func() {
+			processGuid := "desired-with-missing-all-actuals" + "-" + freshDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0)
+
+			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+		})
+
+		By("missing some actuals, fresh domain", func() {
+			processGuid := "desired-with-missing-some-actuals" + "-" + freshDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 1, 3)
+
+			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+
+			actualLRPGroup, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+		})
+
+		By("missing all actuals, expired domain", func() {
+			processGuid := "desired-with-missing-all-actuals" + "-" + expiredDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0)
+
+			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+		})
+
+		By("missing some actuals, expired domain", func() {
+			processGuid := "desired-with-missing-some-actuals" + "-" + expiredDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 1, 3)
+
+			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+
+			actualLRPGroup, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+		})
+	})
+
+	It("unclaims actual LRPs that are crashed and restartable, and returns it to be started", func() {
+		startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+		Expect(startRequests).NotTo(BeEmpty())
+
+		By("fresh domain", func() {
+			processGuid := "desired-with-restartable-crashed-actuals" + "-" + freshDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+
+			for i := 0; i < 2; i++ {
+				actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, int32(i))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			}
+		})
+
+		By("expired domain", func() {
+			processGuid := "desired-with-restartable-crashed-actuals" + "-" + expiredDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+
+			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+		})
+	})
+
+	Context("when the restart policy backs off crashed actual LRPs", func() {
+		BeforeEach(func() {
+			sqlDB.SetRestartPolicy(models.RestartPolicy{
+				MaxRestarts: 10,
+				Backoff: func(crashCount int32) time.Duration {
+					return time.Duration(crashCount) * time.Hour
+				},
+			})
+		})
+
+		It("leaves crashed actual LRPs inside their backoff window crashed", func() {
+			processGuid := "desired-with-restartable-crashed-actuals" + "-" + freshDomain
+			for i := 0; i < 2; i++ {
+				actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, int32(i))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroup.Instance.CrashCount).To(BeNumerically("<", 10))
+			}
+
+			startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for _, startRequest := range startRequests {
+				Expect(startRequest.ProcessGuid).NotTo(Equal(processGuid))
+			}
+
+			for i := 0; i < 2; i++ {
+				actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, int32(i))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateCrashed))
+			}
+		})
+
+		It("unclaims crashed actual LRPs once their backoff window has elapsed", func() {
+			fakeClock.Increment(2 * time.Hour)
+
+			startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			processGuid := "desired-with-restartable-crashed-actuals" + "-" + freshDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+		})
+	})
+
+	It("unclaims running actual LRPs that are missing net info, and returns it to be started", func() {
+		startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+		Expect(startRequests).NotTo(BeEmpty())
+
+		processGuid := "desired-with-running-actual-missing-net-info" + "-" + freshDomain
+		desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+		Expect(err).NotTo(HaveOccurred())
+
+		lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0)
+		Expect(startRequests).To(ContainElement(&lrpStartRequest))
+
+		actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+	})
+
+	Context("when a running actual LRP missing net info is on a missing cell", func() {
+		var processGuid string
+
+		BeforeEach(func() {
+			processGuid = "desired-with-running-actual-missing-net-info-on-missing-cell"
+			desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+			desiredLRP.Domain = freshDomain
+			desiredLRP.Instances = 1
+			Expect(sqlDB.DesireLRP(logger, desiredLRP)).To(Succeed())
+			key := &models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain}
+			_, err := sqlDB.CreateUnclaimedActualLRP(logger, key)
+			Expect(err).NotTo(HaveOccurred())
+			instanceKey := &models.ActualLRPInstanceKey{InstanceGuid: "running-actual-missing-net-info-on-missing-cell", CellId: "missing-cell"}
+			_, _, err = sqlDB.ClaimActualLRP(logger, processGuid, 0, instanceKey)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = sqlDB.StartActualLRP(logger, key, instanceKey, &models.ActualLRPNetInfo{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("only returns it as an actual LRP on a missing cell", func() {
+			startRequests, keysWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			missingCellKeys := []*models.ActualLRPKey{}
+			for _, keyWithSchedulingInfo := range keysWithMissingCells {
+				missingCellKeys = append(missingCellKeys, keyWithSchedulingInfo.Key)
+			}
+			Expect(missingCellKeys).To(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain}))
+			for _, startRequest := range startRequests {
+				Expect(startRequest.ProcessGuid).NotTo(Equal(processGuid))
+			}
+
+			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateRunning))
+		})
+	})
+
+	It("returns extra actual LRPs to be retired", func() {
+		_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+		Expect(keysToRetire).NotTo(BeEmpty())
+
+		processGuid := "desired-with-extra-actuals" + "-" + freshDomain
+		actualLRPKey := models.ActualLRPKey{ProcessGuid: processGuid, Index: 4, Domain: freshDomain}
+		Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ContainElement(&actualLRPKey))
+
+		processGuid = "actual-with-no-desired" + "-" + freshDomain
+		actualLRPKey = models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain}
+		Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ContainElement(&actualLRPKey))
+	})
+
+	It("attaches the reason each actual LRP is retired", func() {
+		_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+
+		processGuid := "desired-with-extra-actuals" + "-" + freshDomain
+		Expect(keysToRetire).To(ContainElement(&models.ActualLRPKeyWithRetireReason{
+			Key:    &models.ActualLRPKey{ProcessGuid: processGuid, Index: 4, Domain: freshDomain},
+			Reason: models.RetireReasonExtraInstance,
+		}))
+
+		processGuid = "actual-with-no-desired" + "-" + freshDomain
+		Expect(keysToRetire).To(ContainElement(&models.ActualLRPKeyWithRetireReason{
+			Key:    &models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain},
+			Reason: models.RetireReasonNoDesiredLRP,
+		}))
+	})
+
+	Context("when the number of start requests per tick is capped", func() {
+		var processGuids []string
+
+		BeforeEach(func() {
+			_, err := db.Exec("DELETE FROM actual_lrps")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = db.Exec("DELETE FROM desired_lrps")
+			Expect(err).NotTo(HaveOccurred())
+
+			processGuids = []string{"capped-guid-1", "capped-guid-2", "capped-guid-3", "capped-guid-4", "capped-guid-5"}
+			for _, processGuid := range processGuids {
+				desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+				desiredLRP.Domain = freshDomain
+				desiredLRP.Instances = 1
+				err = sqlDB.DesireLRP(logger, desiredLRP)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			sqlDB.SetMaxStartRequestsPerTick(2)
 		})
 
-		It("reports the duration that it took to converge", func() {
-			sqlDB.ConvergeLRPs(logger, models.CellSet{})
+		startRequestGuids := func(startRequests []*auctioneer.LRPStartRequest) []string {
+			guids := []string{}
+			for _, startRequest := range startRequests {
+				guids = append(guids, startRequest.ProcessGuid)
+			}
+			return guids
+		}
 
-			Eventually(fakeMetronClient.SendDurationCallCount).Should(Equal(1))
-			name, value := fakeMetronClient.SendDurationArgsForCall(0)
-			Expect(name).To(Equal("ConvergenceLRPDuration"))
-			Expect(value).NotTo(BeZero())
-		})
-	})
+		It("returns at most the cap and defers the rest to the following ticks", func() {
+			firstStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(firstStartRequests).To(HaveLen(2))
 
-	It("returns start requests for stale unclaimed actual LRPs", func() {
-		startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			secondStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(secondStartRequests).To(HaveLen(2))
 
-		By("fresh domain", func() {
-			Expect(startRequests).NotTo(BeEmpty())
+			thirdStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(thirdStartRequests).To(HaveLen(1))
 
-			processGuid := "desired-with-stale-actuals" + "-" + freshDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
-			Expect(err).NotTo(HaveOccurred())
+			guids := startRequestGuids(firstStartRequests)
+			guids = append(guids, startRequestGuids(secondStartRequests)...)
+			guids = append(guids, startRequestGuids(thirdStartRequests)...)
+			Expect(guids).To(ConsistOf(processGuids))
 
-			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+			fourthStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(fourthStartRequests).To(BeEmpty())
+		})
 
-			for _, startRequest := range startRequests {
-				sort.Ints(startRequest.Indices)
+		It("creates every missing actual LRP on the first tick", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for _, processGuid := range processGuids {
+				actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
 			}
+		})
 
-			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+		It("returns the deferred start requests ahead of new ones", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+
+			desiredLRP := model_helpers.NewValidDesiredLRP("capped-guid-0")
+			desiredLRP.Domain = freshDomain
+			desiredLRP.Instances = 1
+			err := sqlDB.DesireLRP(logger, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(startRequestGuids(startRequests)).NotTo(ContainElement("capped-guid-0"))
 		})
 
-		By("expired domain", func() {
-			Expect(startRequests).NotTo(BeEmpty())
+		It("caps the instances to start, splitting a start request at the cap", func() {
+			_, err := db.Exec("DELETE FROM desired_lrps")
+			Expect(err).NotTo(HaveOccurred())
 
-			processGuid := "desired-with-stale-actuals" + "-" + expiredDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			desiredLRP := model_helpers.NewValidDesiredLRP("capped-guid-with-instances")
+			desiredLRP.Domain = freshDomain
+			desiredLRP.Instances = 3
+			err = sqlDB.DesireLRP(logger, desiredLRP)
 			Expect(err).NotTo(HaveOccurred())
 
-			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+			firstStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(firstStartRequests).To(HaveLen(1))
+			Expect(firstStartRequests[0].Indices).To(HaveLen(2))
 
-			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+			secondStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(secondStartRequests).To(HaveLen(1))
+			Expect(secondStartRequests[0].Indices).To(HaveLen(1))
+
+			indices := append(firstStartRequests[0].Indices, secondStartRequests[0].Indices...)
+			Expect(indices).To(ConsistOf(0, 1, 2))
 		})
-	})
 
-	It("returns the start requests and actual lrp keys for actuals with missing cells", func() {
-		_, keysWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+		It("drops the deferred instances of desired LRPs that were removed", func() {
+			firstStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(startRequestGuids(firstStartRequests)).NotTo(ContainElement("capped-guid-3"))
 
-		By("fresh domain", func() {
-			processGuid := "desired-with-missing-cell-actuals" + "-" + freshDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			err := sqlDB.RemoveDesiredLRP(logger, "capped-guid-3")
 			Expect(err).NotTo(HaveOccurred())
 
-			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
-			Expect(err).NotTo(HaveOccurred())
-			expectedSched := desiredLRP.DesiredLRPSchedulingInfo()
-			Expect(keysWithMissingCells).To(ContainElement(&models.ActualLRPKeyWithSchedulingInfo{
-				Key:            &actualLRPGroup.Instance.ActualLRPKey,
-				SchedulingInfo: &expectedSched,
-			}))
+			secondStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(startRequestGuids(secondStartRequests)).To(ConsistOf("capped-guid-4", "capped-guid-5"))
 		})
 
-		By("expired domain", func() {
-			processGuid := "desired-with-missing-cell-actuals" + "-" + expiredDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
-			Expect(err).NotTo(HaveOccurred())
+		It("drops the deferred instances that are no longer unclaimed", func() {
+			firstStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(startRequestGuids(firstStartRequests)).NotTo(ContainElement("capped-guid-3"))
 
-			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+			_, _, err := sqlDB.ClaimActualLRP(logger, "capped-guid-3", 0, &models.ActualLRPInstanceKey{InstanceGuid: "capped-instance", CellId: "existing-cell"})
 			Expect(err).NotTo(HaveOccurred())
-			expectedSched := desiredLRP.DesiredLRPSchedulingInfo()
-			Expect(keysWithMissingCells).To(ContainElement(&models.ActualLRPKeyWithSchedulingInfo{
-				Key:            &actualLRPGroup.Instance.ActualLRPKey,
-				SchedulingInfo: &expectedSched,
-			}))
+
+			secondStartRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(startRequestGuids(secondStartRequests)).To(ConsistOf("capped-guid-4", "capped-guid-5"))
+		})
+
+		It("does not cap the actual LRPs to retire", func() {
+			for i := int32(1); i <= 3; i++ {
+				_, err := sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: "capped-guid-1", Index: i, Domain: freshDomain})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(keysToRetire).To(HaveLen(3))
 		})
 	})
 
-	It("creates actual LRPs with missing indices, and returns it to be started", func() {
-		startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
-		Expect(startRequests).NotTo(BeEmpty())
+	Context("when a minimum age for retiring extra actual LRPs is set", func() {
+		var processGuid string
 
-		By("missing all actuals, fresh domain", func() {
-			processGuid := "desired-with-missing-all-actuals" + "-" + freshDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+		BeforeEach(func() {
+			processGuid = "desired-with-old-and-new-extras"
+			desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+			desiredLRP.Domain = freshDomain
+			desiredLRP.Instances = 1
+			err := sqlDB.DesireLRP(logger, desiredLRP)
 			Expect(err).NotTo(HaveOccurred())
 
-			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0)
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain})
+			Expect(err).NotTo(HaveOccurred())
 
-			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+			fakeClock.Increment(-time.Minute)
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: processGuid, Index: 1, Domain: freshDomain})
+			Expect(err).NotTo(HaveOccurred())
+			fakeClock.Increment(time.Minute)
 
-			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: processGuid, Index: 2, Domain: freshDomain})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+
+			sqlDB.SetExtraLRPRetirementMinAge(30 * time.Second)
 		})
 
-		By("missing some actuals, fresh domain", func() {
-			processGuid := "desired-with-missing-some-actuals" + "-" + freshDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
-			Expect(err).NotTo(HaveOccurred())
+		It("retires only the extras older than the minimum age", func() {
+			_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
 
-			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 1, 3)
+			retiredKeys := models.ActualLRPKeysToRetire(keysToRetire)
+			Expect(retiredKeys).To(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 1, Domain: freshDomain}))
+			Expect(retiredKeys).NotTo(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 2, Domain: freshDomain}))
+		})
 
-			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+		It("logs the deferred retirements in a single line", func() {
+			convergenceLogger := lagertest.NewTestLogger("convergence")
+			sqlDB.ConvergeLRPs(convergenceLogger, cellSet)
 
-			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 1)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			deferred := 0
+			for _, log := range convergenceLogger.Logs() {
+				if strings.HasSuffix(log.Message, "deferred-extra-actual-lrp-retirements") {
+					deferred++
+					Expect(log.Data).To(HaveKeyWithValue("count", BeNumerically("==", 1)))
+				}
+				if strings.HasSuffix(log.Message, "deferring-extra-actual-lrp-retirement") {
+					Expect(log.LogLevel).To(Equal(lager.DEBUG))
+				}
+			}
+			Expect(deferred).To(Equal(1))
+		})
 
-			actualLRPGroup, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 3)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+		It("retires the freshly-started extra once it is old enough", func() {
+			fakeClock.Increment(time.Minute)
+
+			_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 2, Domain: freshDomain}))
 		})
 
-		By("missing all actuals, expired domain", func() {
-			processGuid := "desired-with-missing-all-actuals" + "-" + expiredDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
-			Expect(err).NotTo(HaveOccurred())
+		It("still retires orphaned actual LRPs immediately", func() {
+			_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
 
-			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0)
+			orphanedKey := models.ActualLRPKey{ProcessGuid: "actual-with-no-desired" + "-" + freshDomain, Index: 0, Domain: freshDomain}
+			Expect(models.ActualLRPKeysToRetire(keysToRetire)).To(ContainElement(&orphanedKey))
+		})
+	})
 
-			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+	Describe("non-restartable crashed actual LRPs", func() {
+		var processGuid string
 
-			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+		nonRestartableKeys := func() []*models.ActualLRPKey {
+			return []*models.ActualLRPKey{
+				{ProcessGuid: processGuid, Index: 0, Domain: freshDomain},
+				{ProcessGuid: processGuid, Index: 1, Domain: freshDomain},
+			}
+		}
+
+		BeforeEach(func() {
+			processGuid = "desired-with-non-restartable-crashed-actuals" + "-" + freshDomain
 		})
 
-		By("missing some actuals, expired domain", func() {
-			processGuid := "desired-with-missing-some-actuals" + "-" + expiredDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
-			Expect(err).NotTo(HaveOccurred())
+		It("keeps them crashed by default", func() {
+			_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
 
-			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 1, 3)
+			retiredKeys := models.ActualLRPKeysToRetire(keysToRetire)
+			for _, key := range nonRestartableKeys() {
+				Expect(retiredKeys).NotTo(ContainElement(key))
+			}
+		})
 
-			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+		Context("when RetireNonRestartableCrashes is set", func() {
+			BeforeEach(func() {
+				sqlDB.SetRetireNonRestartableCrashes(true)
+			})
 
-			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 1)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			It("returns their keys to retire", func() {
+				_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
 
-			actualLRPGroup, err = sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 3)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+				for _, key := range nonRestartableKeys() {
+					Expect(keysToRetire).To(ContainElement(&models.ActualLRPKeyWithRetireReason{
+						Key:    key,
+						Reason: models.RetireReasonNotRestartable,
+					}))
+				}
+			})
+
+			It("does not start them", func() {
+				startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+				for _, startRequest := range startRequests {
+					Expect(startRequest.ProcessGuid).NotTo(Equal(processGuid))
+				}
+			})
+
+			It("recreates their indices with a fresh crash count once they are removed", func() {
+				_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+				for _, key := range nonRestartableKeys() {
+					Expect(keysToRetire).To(ContainElement(&models.ActualLRPKeyWithRetireReason{
+						Key:    key,
+						Reason: models.RetireReasonNotRestartable,
+					}))
+					Expect(sqlDB.RemoveActualLRP(logger, key.ProcessGuid, key.Index, nil)).To(Succeed())
+				}
+
+				startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+				var indices []int
+				for _, startRequest := range startRequests {
+					if startRequest.ProcessGuid == processGuid {
+						indices = append(indices, startRequest.Indices...)
+					}
+				}
+				Expect(indices).To(ConsistOf(0, 1))
+
+				for _, key := range nonRestartableKeys() {
+					actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, key.ProcessGuid, key.Index)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+					Expect(actualLRPGroup.Instance.CrashCount).To(BeZero())
+				}
+			})
 		})
 	})
 
-	It("unclaims actual LRPs that are crashed and restartable, and returns it to be started", func() {
-		startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
-		Expect(startRequests).NotTo(BeEmpty())
+	Context("when a max instance lifetime is set", func() {
+		var processGuid string
 
-		By("fresh domain", func() {
-			processGuid := "desired-with-restartable-crashed-actuals" + "-" + freshDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+		startActualLRP := func(index int32) {
+			key := &models.ActualLRPKey{ProcessGuid: processGuid, Index: index, Domain: freshDomain}
+			_, err := sqlDB.CreateUnclaimedActualLRP(logger, key)
+			Expect(err).NotTo(HaveOccurred())
+			instanceKey := &models.ActualLRPInstanceKey{InstanceGuid: fmt.Sprintf("aged-instance-%d", index), CellId: "existing-cell"}
+			netInfo := models.NewActualLRPNetInfo("127.0.0.1", "10.0.0.1", models.NewPortMapping(8080, 80))
+			_, _, err = sqlDB.StartActualLRP(logger, key, instanceKey, &netInfo)
 			Expect(err).NotTo(HaveOccurred())
+		}
 
-			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
-			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+		recycledMetric := func() (int, bool) {
+			for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+				name, value := fakeMetronClient.SendMetricArgsForCall(i)
+				if name == "LRPsRecycledForAge" {
+					return value, true
+				}
+			}
+			return 0, false
+		}
 
-			for i := 0; i < 2; i++ {
-				actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, int32(i))
+		BeforeEach(func() {
+			processGuid = "desired-with-old-and-young-instances"
+			desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+			desiredLRP.Domain = freshDomain
+			desiredLRP.Instances = 3
+			err := sqlDB.DesireLRP(logger, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeClock.Increment(-3 * time.Hour)
+			startActualLRP(1)
+			fakeClock.Increment(time.Hour)
+			startActualLRP(0)
+			fakeClock.Increment(2 * time.Hour)
+			startActualLRP(2)
+
+			sqlDB.SetMaxInstanceLifetime(time.Hour)
+		})
+
+		It("retires the oldest running instance that outlived the lifetime and leaves young ones alone", func() {
+			_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			Expect(keysToRetire).To(ContainElement(&models.ActualLRPKeyWithRetireReason{
+				Key:    &models.ActualLRPKey{ProcessGuid: processGuid, Index: 1, Domain: freshDomain},
+				Reason: models.RetireReasonMaxLifetime,
+			}))
+
+			retiredKeys := models.ActualLRPKeysToRetire(keysToRetire)
+			Expect(retiredKeys).NotTo(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain}))
+			Expect(retiredKeys).NotTo(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 2, Domain: freshDomain}))
+
+			value, ok := recycledMetric()
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(1))
+		})
+
+		Context("when the desired LRP has a single instance", func() {
+			BeforeEach(func() {
+				processGuid = "desired-with-a-single-old-instance"
+				desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+				desiredLRP.Domain = freshDomain
+				desiredLRP.Instances = 1
+				err := sqlDB.DesireLRP(logger, desiredLRP)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
-			}
+
+				fakeClock.Increment(-2 * time.Hour)
+				startActualLRP(0)
+				fakeClock.Increment(2 * time.Hour)
+			})
+
+			It("does not retire its only instance", func() {
+				_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+
+				retiredKeys := models.ActualLRPKeysToRetire(keysToRetire)
+				Expect(retiredKeys).NotTo(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain}))
+			})
 		})
 
-		By("expired domain", func() {
-			processGuid := "desired-with-restartable-crashed-actuals" + "-" + expiredDomain
-			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
-			Expect(err).NotTo(HaveOccurred())
+		Context("when the desired LRP is partly down", func() {
+			var desiredLRP *models.DesiredLRP
 
-			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0, 1)
+			BeforeEach(func() {
+				processGuid = "desired-with-old-instances-partly-down"
+				desiredLRP = model_helpers.NewValidDesiredLRP(processGuid)
+				desiredLRP.Domain = freshDomain
+				desiredLRP.Instances = 3
+				err := sqlDB.DesireLRP(logger, desiredLRP)
+				Expect(err).NotTo(HaveOccurred())
 
-			Expect(startRequests).To(ContainElement(BeActualLRPStartRequest(lrpStartRequest)))
+				fakeClock.Increment(-2 * time.Hour)
+				startActualLRP(0)
+				startActualLRP(1)
+				fakeClock.Increment(2 * time.Hour)
+			})
 
-			actualLRPGroup, err := sqlDB.ActualLRPGroupByProcessGuidAndIndex(logger, processGuid, 0)
-			Expect(err).NotTo(HaveOccurred())
-			Expect(actualLRPGroup.Instance.State).To(Equal(models.ActualLRPStateUnclaimed))
+			assertNothingRetired := func() {
+				_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+
+				retiredKeys := models.ActualLRPKeysToRetire(keysToRetire)
+				Expect(retiredKeys).NotTo(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain}))
+				Expect(retiredKeys).NotTo(ContainElement(&models.ActualLRPKey{ProcessGuid: processGuid, Index: 1, Domain: freshDomain}))
+			}
+
+			Context("because an instance is missing", func() {
+				It("starts the missing instance", func() {
+					startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+					startRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 2)
+					Expect(startRequests).To(ContainElement(&startRequest))
+				})
+
+				It("does not retire any of its running instances", assertNothingRetired)
+			})
+
+			Context("because an instance is not running", func() {
+				BeforeEach(func() {
+					key := &models.ActualLRPKey{ProcessGuid: processGuid, Index: 2, Domain: freshDomain}
+					_, err := sqlDB.CreateUnclaimedActualLRP(logger, key)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not retire any of its running instances", assertNothingRetired)
+			})
+		})
+
+		It("does not emit LRPsRecycledForAge without a max instance lifetime", func() {
+			sqlDB.SetMaxInstanceLifetime(0)
+			sqlDB.ConvergeLRPs(logger, cellSet)
+
+			_, ok := recycledMetric()
+			Expect(ok).To(BeFalse())
 		})
 	})
 
-	It("returns extra actual LRPs to be retired", func() {
+	It("returns the actual LRPs to be retired in order", func() {
 		_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
 		Expect(keysToRetire).NotTo(BeEmpty())
 
-		processGuid := "desired-with-extra-actuals" + "-" + freshDomain
-		actualLRPKey := models.ActualLRPKey{ProcessGuid: processGuid, Index: 4, Domain: freshDomain}
-		Expect(keysToRetire).To(ContainElement(&actualLRPKey))
-
-		processGuid = "actual-with-no-desired" + "-" + freshDomain
-		actualLRPKey = models.ActualLRPKey{ProcessGuid: processGuid, Index: 0, Domain: freshDomain}
-		Expect(keysToRetire).To(ContainElement(&actualLRPKey))
+		for i := 1; i < len(keysToRetire); i++ {
+			Expect(keysToRetire[i-1].Key.OrderKey() < keysToRetire[i].Key.OrderKey()).To(BeTrue())
+		}
 	})
 
 	It("creates unclaimed for evacuating instances that are missing the running record", func() {
@@ -670,7 +2165,7 @@ var _ = Describe("LRPConvergence", func() {
 
 		retiredGuids := make([]string, 0, len(keysToRetire))
 		for _, keyToRetire := range keysToRetire {
-			retiredGuids = append(retiredGuids, keyToRetire.ProcessGuid)
+			retiredGuids = append(retiredGuids, keyToRetire.Key.ProcessGuid)
 		}
 		for _, processGuid := range processGuids {
 			Expect(retiredGuids).NotTo(ContainElement(processGuid))
@@ -696,6 +2191,116 @@ var _ = Describe("LRPConvergence", func() {
 		Expect(beforeActuals).To(Equal(afterActuals))
 	})
 
+	Context("when convergence is suppressed for desired lrps", func() {
+		var missingProcessGuid, extraProcessGuid, staleProcessGuid, missingCellProcessGuid string
+
+		BeforeEach(func() {
+			missingProcessGuid = "suppressed-desired-with-missing-actuals"
+			missingDesiredLRP := model_helpers.NewValidDesiredLRP(missingProcessGuid)
+			missingDesiredLRP.Domain = freshDomain
+			missingDesiredLRP.Instances = 2
+			missingDesiredLRP.SuppressConvergence = true
+			Expect(sqlDB.DesireLRP(logger, missingDesiredLRP)).To(Succeed())
+
+			extraProcessGuid = "suppressed-desired-with-extra-actuals"
+			extraDesiredLRP := model_helpers.NewValidDesiredLRP(extraProcessGuid)
+			extraDesiredLRP.Domain = freshDomain
+			extraDesiredLRP.Instances = 1
+			extraDesiredLRP.SuppressConvergence = true
+			Expect(sqlDB.DesireLRP(logger, extraDesiredLRP)).To(Succeed())
+			_, err := sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: extraProcessGuid, Index: 0, Domain: freshDomain})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: extraProcessGuid, Index: 3, Domain: freshDomain})
+			Expect(err).NotTo(HaveOccurred())
+
+			staleProcessGuid = "suppressed-desired-with-stale-actuals"
+			staleDesiredLRP := model_helpers.NewValidDesiredLRP(staleProcessGuid)
+			staleDesiredLRP.Domain = freshDomain
+			staleDesiredLRP.Instances = 1
+			staleDesiredLRP.SuppressConvergence = true
+			Expect(sqlDB.DesireLRP(logger, staleDesiredLRP)).To(Succeed())
+			fakeClock.Increment(-models.StaleUnclaimedActualLRPDuration)
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: staleProcessGuid, Index: 0, Domain: freshDomain})
+			Expect(err).NotTo(HaveOccurred())
+			fakeClock.Increment(models.StaleUnclaimedActualLRPDuration)
+
+			missingCellProcessGuid = "suppressed-desired-with-missing-cell-actuals"
+			missingCellDesiredLRP := model_helpers.NewValidDesiredLRP(missingCellProcessGuid)
+			missingCellDesiredLRP.Domain = freshDomain
+			missingCellDesiredLRP.Instances = 1
+			missingCellDesiredLRP.SuppressConvergence = true
+			Expect(sqlDB.DesireLRP(logger, missingCellDesiredLRP)).To(Succeed())
+			_, err = sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: missingCellProcessGuid, Index: 0, Domain: freshDomain})
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = sqlDB.ClaimActualLRP(logger, missingCellProcessGuid, 0, &models.ActualLRPInstanceKey{InstanceGuid: "suppressed-actual-with-missing-cell", CellId: "missing-cell"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not create or start the missing actual lrps", func() {
+			startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for _, startRequest := range startRequests {
+				Expect(startRequest.ProcessGuid).NotTo(Equal(missingProcessGuid))
+			}
+
+			actualLRPGroups, err := sqlDB.ActualLRPGroupsByProcessGuid(logger, missingProcessGuid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualLRPGroups).To(BeEmpty())
+		})
+
+		It("does not retire the extra actual lrps", func() {
+			_, _, keysToRetire := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for _, key := range keysToRetire {
+				Expect(key.Key.ProcessGuid).NotTo(Equal(extraProcessGuid))
+			}
+		})
+
+		It("does not start the stale unclaimed actual lrps", func() {
+			startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for _, startRequest := range startRequests {
+				Expect(startRequest.ProcessGuid).NotTo(Equal(staleProcessGuid))
+			}
+		})
+
+		It("does not unclaim the actual lrps on missing cells", func() {
+			startRequests, keysWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			for _, key := range keysWithMissingCells {
+				Expect(key.Key.ProcessGuid).NotTo(Equal(missingCellProcessGuid))
+			}
+			for _, startRequest := range startRequests {
+				Expect(startRequest.ProcessGuid).NotTo(Equal(missingCellProcessGuid))
+			}
+		})
+
+		It("does not count the suppressed instances as convergence candidates", func() {
+			count, err := sqlDB.ConvergenceLRPCandidateCount(logger, cellSet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(10 + 8 + 11))
+		})
+
+		It("still converges the desired lrps that are not suppressed", func() {
+			startRequests, _, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+
+			processGuid := "desired-with-missing-all-actuals" + "-" + freshDomain
+			desiredLRP, err := sqlDB.DesiredLRPByProcessGuid(logger, processGuid)
+			Expect(err).NotTo(HaveOccurred())
+
+			lrpStartRequest := auctioneer.NewLRPStartRequestFromModel(desiredLRP, 0)
+			Expect(startRequests).To(ContainElement(&lrpStartRequest))
+		})
+
+		It("emits the number of suppressed desired lrps", func() {
+			sqlDB.ConvergeLRPs(logger, cellSet)
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+			name, value := fakeMetronClient.SendMetricArgsForCall(6)
+			Expect(name).To(Equal("LRPsConvergenceSuppressed"))
+			Expect(value).To(Equal(4))
+		})
+	})
+
 	Context("when the cell set is empty", func() {
 		BeforeEach(func() {
 			cellSet = models.NewCellSetFromList([]*models.CellPresence{})
@@ -703,7 +2308,66 @@ var _ = Describe("LRPConvergence", func() {
 
 		It("reports all actual lrps as missing cells", func() {
 			_, actualsWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, models.CellSet{})
-			Expect(len(actualsWithMissingCells)).To(Equal(23))
+			Expect(len(actualsWithMissingCells)).To(Equal(24))
+		})
+
+		Context("and skipping missing cells for an empty cell set is enabled", func() {
+			BeforeEach(func() {
+				sqlDB.SetSkipMissingCellsWhenCellSetEmpty(true)
+			})
+
+			It("does not report any actual lrps as missing cells", func() {
+				_, actualsWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, models.CellSet{})
+				Expect(actualsWithMissingCells).To(BeEmpty())
+			})
+
+			It("bumps the skipped counter", func() {
+				sqlDB.ConvergeLRPs(logger, models.CellSet{})
+				Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(2))
+				Expect(fakeMetronClient.IncrementCounterArgsForCall(1)).To(Equal("ConvergenceCellSetEmptySkipped"))
+			})
+
+			It("logs the skip as an error", func() {
+				sqlDB.ConvergeLRPs(logger, models.CellSet{})
+
+				levels := []lager.LogLevel{}
+				for _, log := range logger.Logs() {
+					if strings.HasSuffix(log.Message, "skipping-missing-cells-for-empty-cell-set") {
+						levels = append(levels, log.LogLevel)
+					}
+				}
+				Expect(levels).To(ConsistOf(lager.ERROR))
+			})
+
+			It("still reports actual lrps on missing cells when the cell set is not empty", func() {
+				cellSet = models.NewCellSetFromList([]*models.CellPresence{
+					{CellId: "existing-cell"},
+				})
+				_, actualsWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, cellSet)
+				Expect(actualsWithMissingCells).NotTo(BeEmpty())
+			})
+		})
+
+		It("counts all missing instances as pending placement", func() {
+			startRequests, actualsWithMissingCells, _ := sqlDB.ConvergeLRPs(logger, models.CellSet{})
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(24))
+			name, value := fakeMetronClient.SendMetricArgsForCall(5)
+			Expect(name).To(Equal("LRPsPendingPlacement"))
+			Expect(value).To(BeNumerically(">", 0))
+			Expect(value).To(Equal(countInstancesToPlace(startRequests, actualsWithMissingCells)))
 		})
 	})
 })
+
+func countInstancesToPlace(startRequests []*auctioneer.LRPStartRequest, keysWithMissingCells []*models.ActualLRPKeyWithSchedulingInfo) int {
+	instances := map[string]struct{}{}
+	for _, startRequest := range startRequests {
+		for _, index := range startRequest.Indices {
+			instances[fmt.Sprintf("%s-%d", startRequest.ProcessGuid, index)] = struct{}{}
+		}
+	}
+	for _, key := range keysWithMissingCells {
+		instances[fmt.Sprintf("%s-%d", key.Key.ProcessGuid, key.Key.Index)] = struct{}{}
+	}
+	return len(instances)
+}
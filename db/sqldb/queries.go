@@ -34,6 +34,7 @@ var (
 		desiredLRPsTable + ".modification_tag_epoch",
 		desiredLRPsTable + ".modification_tag_index",
 		desiredLRPsTable + ".placement_tags",
+		desiredLRPsTable + ".suppress_convergence",
 	}
 
 	desiredLRPColumns = append(schedulingInfoColumns,
@@ -69,6 +70,7 @@ var (
 		actualLRPsTable + ".modification_tag_index",
 		actualLRPsTable + ".crash_count",
 		actualLRPsTable + ".crash_reason",
+		actualLRPsTable + ".annotation",
 	}
 
 	domainColumns = helpers.ColumnList{
@@ -90,102 +92,331 @@ func (db *SQLDB) CreateConfigurationsTable(logger lager.Logger) error {
 	return nil
 }
 
-func (db *SQLDB) selectLRPInstanceCounts(logger lager.Logger, q Queryable) (*sql.Rows, error) {
-	var query string
+// A convergencePredicate is the FROM, WHERE, GROUP BY and HAVING clauses, with
+// their bindings in order, that select the rows a convergence step acts on.
+// The queries of a step and the queries counting its candidates are built from
+// the same predicate, so that they select the same rows.
+type convergencePredicate struct {
+	from     string
+	wheres   []string
+	groupBy  string
+	having   string
+	bindings []interface{}
+}
+
+func (p convergencePredicate) and(where string, bindings ...interface{}) convergencePredicate {
+	p.wheres = append(append([]string{}, p.wheres...), where)
+	p.bindings = append(append([]interface{}{}, p.bindings...), bindings...)
+	return p
+}
+
+func (p convergencePredicate) notSuppressed() convergencePredicate {
+	return p.and("desired_lrps.suppress_convergence = ?", false)
+}
+
+func (p convergencePredicate) suppressed() convergencePredicate {
+	return p.and("desired_lrps.suppress_convergence = ?", true)
+}
+
+func (p convergencePredicate) query(columns ...string) (string, []interface{}) {
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), p.from)
+	if len(p.wheres) > 0 {
+		query += " WHERE " + strings.Join(p.wheres, " AND ")
+	}
+	if p.groupBy != "" {
+		query += " GROUP BY " + p.groupBy
+	}
+	if p.having != "" {
+		query += " HAVING " + p.having
+	}
+	return query, append([]interface{}{}, p.bindings...)
+}
+
+const desiredJoinActualLRPs = "desired_lrps JOIN actual_lrps ON desired_lrps.process_guid = actual_lrps.process_guid"
+
+// Desired LRPs whose number of non-evacuating actual LRPs differs from their
+// number of instances.
+func (db *SQLDB) lrpInstanceCountsPredicate() convergencePredicate {
+	return convergencePredicate{
+		from:    "desired_lrps LEFT OUTER JOIN actual_lrps ON desired_lrps.process_guid = actual_lrps.process_guid AND actual_lrps.evacuating = false",
+		groupBy: "desired_lrps.process_guid",
+		having:  "COUNT(actual_lrps.instance_index) <> desired_lrps.instances",
+	}
+}
+
+// Non-evacuating actual LRPs in fresh domains without a desired LRP.
+func (db *SQLDB) orphanedActualLRPsPredicate(now time.Time) convergencePredicate {
+	return convergencePredicate{
+		from: `actual_lrps
+			JOIN domains ON actual_lrps.domain = domains.domain AND domains.expire_time > ?
+			LEFT JOIN desired_lrps ON actual_lrps.process_guid = desired_lrps.process_guid`,
+		wheres:   []string{"actual_lrps.evacuating = false", "desired_lrps.process_guid IS NULL"},
+		bindings: []interface{}{now.UnixNano()},
+	}
+}
+
+// Running actual LRPs that have been running since before the cutoff, of
+// desired LRPs with more than one instance all of which are running.
+func (db *SQLDB) agedRunningActualLRPsPredicate(cutoff time.Time) convergencePredicate {
+	return convergencePredicate{
+		from: "actual_lrps JOIN desired_lrps ON actual_lrps.process_guid = desired_lrps.process_guid",
+		wheres: []string{
+			"actual_lrps.state = ?", "actual_lrps.evacuating = ?", "actual_lrps.since < ?",
+			"desired_lrps.instances > ?",
+			`NOT EXISTS (
+				SELECT 1 FROM actual_lrps AS other_lrps
+					WHERE other_lrps.process_guid = actual_lrps.process_guid
+						AND other_lrps.evacuating = ? AND other_lrps.state <> ?
+			)`,
+		},
+		bindings: []interface{}{
+			models.ActualLRPStateRunning, false, cutoff.UnixNano(),
+			1,
+			false, models.ActualLRPStateRunning,
+		},
+	}
+}
+
+// Non-evacuating actual LRPs whose cell is not in the cell set. When the cell
+// set is empty, every non-evacuating actual LRP is on a missing cell.
+func (db *SQLDB) actualLRPsWithMissingCellsPredicate(cellSet models.CellSet) convergencePredicate {
+	p := convergencePredicate{
+		from:   desiredJoinActualLRPs,
+		wheres: []string{"actual_lrps.evacuating = false"},
+	}
+
+	if len(cellSet) > 0 {
+		cellIDs := make([]interface{}, 0, len(cellSet))
+		for cellID := range cellSet {
+			cellIDs = append(cellIDs, cellID)
+		}
+		p = p.and(fmt.Sprintf("actual_lrps.cell_id NOT IN (%s)", helpers.QuestionMarks(len(cellSet))), cellIDs...)
+		p = p.and("actual_lrps.cell_id <> ''")
+	}
+	return p
+}
+
+// Non-evacuating crashed actual LRPs of desired LRPs.
+func (db *SQLDB) crashedActualLRPsPredicate() convergencePredicate {
+	return convergencePredicate{
+		from:     desiredJoinActualLRPs,
+		wheres:   []string{"actual_lrps.state = ?", "actual_lrps.evacuating = ?"},
+		bindings: []interface{}{models.ActualLRPStateCrashed, false},
+	}
+}
+
+// Non-evacuating running actual LRPs of desired LRPs.
+func (db *SQLDB) runningActualLRPsPredicate() convergencePredicate {
+	return convergencePredicate{
+		from:     desiredJoinActualLRPs,
+		wheres:   []string{"actual_lrps.state = ?", "actual_lrps.evacuating = ?"},
+		bindings: []interface{}{models.ActualLRPStateRunning, false},
+	}
+}
+
+// Non-evacuating actual LRPs of desired LRPs that have been unclaimed for
+// longer than the stale unclaimed duration.
+func (db *SQLDB) staleUnclaimedActualLRPsPredicate(now time.Time) convergencePredicate {
+	return convergencePredicate{
+		from:     desiredJoinActualLRPs,
+		wheres:   []string{"actual_lrps.state = ?", "actual_lrps.since < ?", "actual_lrps.evacuating = ?"},
+		bindings: []interface{}{models.ActualLRPStateUnclaimed, now.Add(-db.staleUnclaimedDuration).UnixNano(), false},
+	}
+}
+
+func (db *SQLDB) selectLRPInstanceCounts(logger lager.Logger, q Queryable, after *convergenceCursor) (*sql.Rows, error) {
 	columns := schedulingInfoColumns
 	columns = append(columns, "COUNT(actual_lrps.instance_index) AS actual_instances")
 
 	switch db.flavor {
 	case helpers.Postgres:
-		columns = append(columns, "STRING_AGG(actual_lrps.instance_index::text, ',') AS existing_indices")
+		columns = append(columns,
+			"STRING_AGG(actual_lrps.instance_index::text, ',') AS existing_indices",
+			`STRING_AGG(CASE WHEN actual_lrps.instance_index >= desired_lrps.instances
+				THEN actual_lrps.instance_index::text || ':' || actual_lrps.since::text END, ',') AS extra_sinces`,
+		)
 	case helpers.MySQL:
-		columns = append(columns, "GROUP_CONCAT(actual_lrps.instance_index) AS existing_indices")
+		columns = append(columns,
+			"GROUP_CONCAT(actual_lrps.instance_index) AS existing_indices",
+			`GROUP_CONCAT(CASE WHEN actual_lrps.instance_index >= desired_lrps.instances
+				THEN CONCAT(actual_lrps.instance_index, ':', actual_lrps.since) END) AS extra_sinces`,
+		)
 	default:
 		// totally shouldn't happen
 		panic("database flavor not implemented: " + db.flavor)
 	}
 
-	query = fmt.Sprintf(`
-		SELECT %s
-			FROM desired_lrps
-			LEFT OUTER JOIN actual_lrps ON desired_lrps.process_guid = actual_lrps.process_guid AND actual_lrps.evacuating = false
-			GROUP BY desired_lrps.process_guid
-			HAVING COUNT(actual_lrps.instance_index) <> desired_lrps.instances
-		`,
-		strings.Join(columns, ", "),
-	)
+	p := db.lrpInstanceCountsPredicate()
+	if after != nil {
+		p = p.and("desired_lrps.process_guid > ?", after.processGuid)
+	}
+
+	query, bindings := p.query(columns...)
+	if db.convergenceBatchSize > 0 {
+		query += " ORDER BY desired_lrps.process_guid LIMIT ?"
+		bindings = append(bindings, db.convergenceBatchSize)
+	}
+
+	return q.Query(db.helper.Rebind(query), bindings...)
+}
+
+func (db *SQLDB) selectDesiredLRPsWithoutActuals(logger lager.Logger, q Queryable) (*sql.Rows, error) {
+	query := `
+    SELECT desired_lrps.process_guid, desired_lrps.run_info
+      FROM desired_lrps
+      LEFT JOIN actual_lrps ON desired_lrps.process_guid = actual_lrps.process_guid
+      WHERE desired_lrps.instances > 0 AND actual_lrps.process_guid IS NULL
+		`
 
 	return q.Query(query)
 }
 
-func (db *SQLDB) selectOrphanedActualLRPs(logger lager.Logger, q Queryable) (*sql.Rows, error) {
+func (db *SQLDB) selectOrphanedActualLRPs(logger lager.Logger, q Queryable, now time.Time) (*sql.Rows, error) {
+	query, bindings := db.orphanedActualLRPsPredicate(now).query(
+		"actual_lrps.process_guid", "actual_lrps.instance_index", "actual_lrps.domain",
+	)
+
+	return q.Query(db.helper.Rebind(query), bindings...)
+}
+
+// Selects the running actual LRPs of desired LRPs that have been running
+// since before the cutoff, oldest first per process guid.
+func (db *SQLDB) selectRunningActualLRPsSince(logger lager.Logger, q Queryable, cutoff time.Time) (*sql.Rows, error) {
+	query, bindings := db.agedRunningActualLRPsPredicate(cutoff).query(
+		"actual_lrps.process_guid", "actual_lrps.instance_index", "actual_lrps.domain",
+	)
+	query += " ORDER BY actual_lrps.process_guid, actual_lrps.since, actual_lrps.instance_index"
+
+	return q.Query(db.helper.Rebind(query), bindings...)
+}
+
+func (db *SQLDB) selectActualLRPsWithInvalidDomains(logger lager.Logger, q Queryable) (*sql.Rows, error) {
 	query := `
     SELECT actual_lrps.process_guid, actual_lrps.instance_index, actual_lrps.domain
       FROM actual_lrps
-      JOIN domains ON actual_lrps.domain = domains.domain
-      LEFT JOIN desired_lrps ON actual_lrps.process_guid = desired_lrps.process_guid
-      WHERE actual_lrps.evacuating = false AND desired_lrps.process_guid IS NULL
+      WHERE actual_lrps.evacuating = ?
+        AND actual_lrps.domain NOT IN (SELECT domains.domain FROM domains)
+        AND actual_lrps.domain NOT IN (SELECT DISTINCT desired_lrps.domain FROM desired_lrps)
 		`
 
-	return q.Query(query)
+	return q.Query(db.helper.Rebind(query), false)
+}
+
+func (db *SQLDB) selectLRPsWithMissingCells(logger lager.Logger, q Queryable, cellSet models.CellSet, after *convergenceCursor) (*sql.Rows, error) {
+	query, bindings := db.pageActualLRPs(db.actualLRPsWithMissingCellsPredicate(cellSet), after,
+		append(schedulingInfoColumns, "actual_lrps.instance_index")...,
+	)
+	return q.Query(db.helper.Rebind(query), bindings...)
 }
 
-func (db *SQLDB) selectLRPsWithMissingCells(logger lager.Logger, q Queryable, cellSet models.CellSet) (*sql.Rows, error) {
-	wheres := []string{"actual_lrps.evacuating = false"}
-	bindings := make([]interface{}, 0, len(cellSet))
+func (db *SQLDB) selectCrashedLRPs(logger lager.Logger, q Queryable, after *convergenceCursor) (*sql.Rows, error) {
+	query, bindings := db.pageActualLRPs(db.crashedActualLRPsPredicate(), after,
+		append(schedulingInfoColumns, "actual_lrps.instance_index", "actual_lrps.since", "actual_lrps.crash_count")...,
+	)
+	return q.Query(db.helper.Rebind(query), bindings...)
+}
 
-	if len(cellSet) > 0 {
-		wheres = append(wheres, fmt.Sprintf("actual_lrps.cell_id NOT IN (%s)", helpers.QuestionMarks(len(cellSet))))
-		wheres = append(wheres, "actual_lrps.cell_id <> ''")
-		for cellID := range cellSet {
-			bindings = append(bindings, cellID)
-		}
+func (db *SQLDB) selectRunningLRPsWithNetInfo(logger lager.Logger, q Queryable, after *convergenceCursor) (*sql.Rows, error) {
+	query, bindings := db.pageActualLRPs(db.runningActualLRPsPredicate(), after,
+		append(schedulingInfoColumns, "actual_lrps.instance_index", "actual_lrps.net_info")...,
+	)
+	return q.Query(db.helper.Rebind(query), bindings...)
+}
+
+func (db *SQLDB) selectStaleUnclaimedLRPs(logger lager.Logger, q Queryable, now time.Time, after *convergenceCursor) (*sql.Rows, error) {
+	query, bindings := db.pageActualLRPs(db.staleUnclaimedActualLRPsPredicate(now), after,
+		append(schedulingInfoColumns, "actual_lrps.instance_index")...,
+	)
+	return q.Query(db.helper.Rebind(query), bindings...)
+}
+
+// pageActualLRPs selects the columns of the actual LRPs matching the predicate,
+// restricted to the batch following the cursor and ordered by process guid
+// and index when convergence is batched.
+func (db *SQLDB) pageActualLRPs(p convergencePredicate, after *convergenceCursor, columns ...string) (string, []interface{}) {
+	if db.convergenceBatchSize <= 0 {
+		return p.query(columns...)
 	}
 
-	query := fmt.Sprintf(`
-		SELECT %s
+	if after != nil {
+		p = p.and("(actual_lrps.process_guid > ? OR (actual_lrps.process_guid = ? AND actual_lrps.instance_index > ?))",
+			after.processGuid, after.processGuid, after.index)
+	}
+	query, bindings := p.query(columns...)
+	query += " ORDER BY actual_lrps.process_guid, actual_lrps.instance_index LIMIT ?"
+	bindings = append(bindings, db.convergenceBatchSize)
+
+	return query, bindings
+}
+
+func (db *SQLDB) selectTopCrashingDesiredLRPs(logger lager.Logger, q Queryable, n int) (*sql.Rows, error) {
+	query := `
+		SELECT desired_lrps.process_guid, desired_lrps.domain,
+			SUM(actual_lrps.crash_count) AS total_crash_count,
+			SUM(CASE WHEN actual_lrps.crash_count > 0 THEN 1 ELSE 0 END) AS crashing_instances
 			FROM desired_lrps
 			JOIN actual_lrps ON desired_lrps.process_guid = actual_lrps.process_guid
-			WHERE %s
-		`,
-		strings.Join(append(schedulingInfoColumns, "actual_lrps.instance_index"), ", "),
-		strings.Join(wheres, " AND "),
-	)
+			WHERE actual_lrps.evacuating = ?
+			GROUP BY desired_lrps.process_guid, desired_lrps.domain
+			HAVING SUM(actual_lrps.crash_count) > 0
+			ORDER BY total_crash_count DESC, desired_lrps.process_guid
+			LIMIT ?
+	`
 
-	return q.Query(db.helper.Rebind(query), bindings...)
+	return q.Query(db.helper.Rebind(query), false, n)
 }
 
-func (db *SQLDB) selectCrashedLRPs(logger lager.Logger, q Queryable) (*sql.Rows, error) {
+func (db *SQLDB) selectActualLRPsByProcessGuid(logger lager.Logger, q Queryable, processGuid string) (*sql.Rows, error) {
 	query := fmt.Sprintf(`
 		SELECT %s
-			FROM desired_lrps
-			JOIN actual_lrps ON desired_lrps.process_guid = actual_lrps.process_guid
-			WHERE actual_lrps.state = ? AND actual_lrps.evacuating = ?
-		`,
-		strings.Join(
-			append(schedulingInfoColumns, "actual_lrps.instance_index", "actual_lrps.since", "actual_lrps.crash_count"),
-			", ",
-		),
+			FROM actual_lrps
+			WHERE actual_lrps.process_guid = ?
+			ORDER BY actual_lrps.instance_index
+	`,
+		strings.Join(actualLRPColumns, ", "),
 	)
 
-	return q.Query(db.helper.Rebind(query), models.ActualLRPStateCrashed, false)
+	return q.Query(db.helper.Rebind(query), processGuid)
 }
 
-func (db *SQLDB) selectStaleUnclaimedLRPs(logger lager.Logger, q Queryable, now time.Time) (*sql.Rows, error) {
+func (db *SQLDB) selectUnclaimedActualLRPIndices(logger lager.Logger, q Queryable, processGuids []string) (*sql.Rows, error) {
 	query := fmt.Sprintf(`
-		SELECT %s
-			FROM desired_lrps
-			JOIN actual_lrps ON desired_lrps.process_guid = actual_lrps.process_guid
-			WHERE actual_lrps.state = ? AND actual_lrps.since < ? AND actual_lrps.evacuating = ?
+		SELECT actual_lrps.process_guid, actual_lrps.instance_index
+			FROM actual_lrps
+			WHERE actual_lrps.state = ? AND actual_lrps.evacuating = ? AND actual_lrps.process_guid IN (%s)
 		`,
-		strings.Join(append(schedulingInfoColumns, "actual_lrps.instance_index"), ", "),
+		helpers.QuestionMarks(len(processGuids)),
 	)
 
-	return q.Query(db.helper.Rebind(query),
-		models.ActualLRPStateUnclaimed,
-		now.Add(-models.StaleUnclaimedActualLRPDuration).UnixNano(),
-		false,
-	)
+	bindings := make([]interface{}, 0, len(processGuids)+2)
+	bindings = append(bindings, models.ActualLRPStateUnclaimed, false)
+	for _, processGuid := range processGuids {
+		bindings = append(bindings, processGuid)
+	}
+
+	return q.Query(db.helper.Rebind(query), bindings...)
+}
+
+func (db *SQLDB) selectActualLRPStateCounts(logger lager.Logger, q Queryable, processGuid string) (*sql.Rows, error) {
+	query := `
+		SELECT actual_lrps.state, COUNT(*) AS state_count
+			FROM actual_lrps
+			WHERE actual_lrps.process_guid = ? AND actual_lrps.evacuating = ?
+			GROUP BY actual_lrps.state
+	`
+
+	return q.Query(db.helper.Rebind(query), processGuid, false)
+}
+
+func (db *SQLDB) selectActualLRPCellCounts(logger lager.Logger, q Queryable) (*sql.Rows, error) {
+	query := `
+		SELECT actual_lrps.cell_id, COUNT(*) AS cell_count
+			FROM actual_lrps
+			WHERE actual_lrps.cell_id <> '' AND actual_lrps.evacuating = ?
+			GROUP BY actual_lrps.cell_id
+	`
+
+	return q.Query(db.helper.Rebind(query), false)
 }
 
 func (db *SQLDB) countDesiredInstances(logger lager.Logger, q Queryable) int {
@@ -203,6 +434,52 @@ func (db *SQLDB) countDesiredInstances(logger lager.Logger, q Queryable) int {
 	return desiredInstances
 }
 
+// The catalog's estimate of the number of rows in the table, as of when its
+// statistics were last gathered, which is cheap to read even for large
+// tables unlike COUNT(*).
+func (db *SQLDB) estimateTableRows(logger lager.Logger, q Queryable, table string) (int64, error) {
+	var query string
+	switch db.flavor {
+	case helpers.Postgres:
+		// reltuples is -1 for tables that were never analyzed
+		query = `SELECT GREATEST(reltuples, 0)::bigint FROM pg_class WHERE oid = ?::regclass`
+	case helpers.MySQL:
+		query = `
+			SELECT COALESCE(table_rows, 0)
+				FROM information_schema.tables
+				WHERE table_schema = DATABASE() AND table_name = ?
+		`
+	default:
+		// totally shouldn't happen
+		panic("database flavor not implemented: " + db.flavor)
+	}
+
+	var rows int64
+	err := q.QueryRow(db.helper.Rebind(query), table).Scan(&rows)
+	return rows, err
+}
+
+func (db *SQLDB) estimateTableBytes(logger lager.Logger, q Queryable, table string) (int64, error) {
+	var query string
+	switch db.flavor {
+	case helpers.Postgres:
+		query = `SELECT pg_total_relation_size(?::regclass)`
+	case helpers.MySQL:
+		query = `
+			SELECT COALESCE(data_length + index_length, 0)
+				FROM information_schema.tables
+				WHERE table_schema = DATABASE() AND table_name = ?
+		`
+	default:
+		// totally shouldn't happen
+		panic("database flavor not implemented: " + db.flavor)
+	}
+
+	var bytes int64
+	err := q.QueryRow(db.helper.Rebind(query), table).Scan(&bytes)
+	return bytes, err
+}
+
 func (db *SQLDB) countActualLRPsByState(logger lager.Logger, q Queryable) (claimedCount, unclaimedCount, runningCount, crashedCount, crashingDesiredCount int) {
 	var query string
 	switch db.flavor {
@@ -241,6 +518,132 @@ func (db *SQLDB) countActualLRPsByState(logger lager.Logger, q Queryable) (claim
 	return
 }
 
+// Counts the non-evacuating unclaimed actual LRPs that became unclaimed less
+// than each of the given ages ago, along with the total number of them.
+func (db *SQLDB) countUnclaimedActualLRPsYoungerThan(logger lager.Logger, q Queryable, now time.Time, ages []time.Duration) (counts []int, total int, err error) {
+	columns := make([]string, 0, len(ages)+1)
+	bindings := make([]interface{}, 0, len(ages)+2)
+	for _, age := range ages {
+		columns = append(columns, "COUNT(CASE WHEN since > ? THEN 1 END)")
+		bindings = append(bindings, now.Add(-age).UnixNano())
+	}
+	columns = append(columns, "COUNT(*)")
+	bindings = append(bindings, models.ActualLRPStateUnclaimed, false)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+			FROM actual_lrps
+			WHERE state = ? AND evacuating = ?
+		`,
+		strings.Join(columns, ", "),
+	)
+
+	counts = make([]int, len(ages))
+	dest := make([]interface{}, 0, len(ages)+1)
+	for i := range counts {
+		dest = append(dest, &counts[i])
+	}
+	dest = append(dest, &total)
+
+	row := q.QueryRow(db.helper.Rebind(query), bindings...)
+	err = row.Scan(dest...)
+	if err != nil {
+		logger.Error("failed-counting-unclaimed-actual-lrps-by-age", err)
+		return nil, 0, err
+	}
+	return counts, total, nil
+}
+
+// Counts the instances the convergence steps other than the crashed one would
+// act on, with one aggregate query per step over the rows the step selects.
+// Rows the steps skip in code, as their convergence is suppressed, their
+// domain is not fresh, or they are extras still within their retirement grace
+// period, are left out as well.
+func (db *SQLDB) countConvergenceCandidates(logger lager.Logger, q Queryable, cellSet models.CellSet, now time.Time) (int, error) {
+	type countQuery struct {
+		name     string
+		query    string
+		bindings []interface{}
+	}
+
+	queries := []countQuery{}
+	addCount := func(name string, p convergencePredicate, column string) {
+		query, bindings := p.query(column)
+		queries = append(queries, countQuery{name: name, query: query, bindings: bindings})
+	}
+
+	addCount("stale-unclaimed-actual-lrps", db.staleUnclaimedActualLRPsPredicate(now).notSuppressed(), "COUNT(*)")
+	if len(cellSet) != 0 || !db.skipMissingCellsWhenCellSetEmpty {
+		addCount("actual-lrps-with-missing-cells", db.actualLRPsWithMissingCellsPredicate(cellSet).notSuppressed(), "COUNT(*)")
+	}
+	addCount("orphaned-actual-lrps", db.orphanedActualLRPsPredicate(now), "COUNT(*)")
+	if db.maxInstanceLifetime > 0 {
+		// at most one instance is recycled per desired LRP
+		addCount("aged-running-actual-lrps", db.agedRunningActualLRPsPredicate(now.Add(-db.maxInstanceLifetime)), "COUNT(DISTINCT actual_lrps.process_guid)")
+	}
+
+	extraCondition := "actual_lrps.instance_index >= desired_lrps.instances AND desired_lrps.domain IN (SELECT domains.domain FROM domains WHERE domains.expire_time > ?)"
+	extraBindings := []interface{}{now.Round(time.Second).UnixNano()}
+	if db.extraLRPRetirementMinAge > 0 {
+		extraCondition += " AND actual_lrps.since <= ?"
+		extraBindings = append(extraBindings, now.Add(-db.extraLRPRetirementMinAge).UnixNano())
+	}
+	instanceCounts, bindings := db.lrpInstanceCountsPredicate().notSuppressed().query(
+		"desired_lrps.instances - COUNT(CASE WHEN actual_lrps.instance_index < desired_lrps.instances THEN 1 END) AS missing_instances",
+		"COUNT(CASE WHEN "+extraCondition+" THEN 1 END) AS extra_instances",
+	)
+	queries = append(queries, countQuery{
+		name:     "missing-and-extra-actual-lrps",
+		query:    "SELECT COALESCE(SUM(missing_instances + extra_instances), 0) FROM (" + instanceCounts + ") AS instance_counts",
+		bindings: append(extraBindings, bindings...),
+	})
+
+	total := 0
+	for _, countQuery := range queries {
+		var count int
+		err := q.QueryRow(db.helper.Rebind(countQuery.query), countQuery.bindings...).Scan(&count)
+		if err != nil {
+			logger.Error("failed-counting-"+countQuery.name, err)
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// Selects how long ago and how often the crashed actual LRPs of desired LRPs
+// whose convergence is not suppressed crashed, for the restart policy to
+// decide on.
+func (db *SQLDB) selectCrashedActualLRPCrashes(logger lager.Logger, q Queryable) (*sql.Rows, error) {
+	query, bindings := db.crashedActualLRPsPredicate().notSuppressed().query("actual_lrps.since", "actual_lrps.crash_count")
+
+	return q.Query(db.helper.Rebind(query), bindings...)
+}
+
+// Selects the process guids of the desired LRPs whose convergence is suppressed
+// that the convergence steps collecting start requests and keys to retire
+// would come across.
+func (db *SQLDB) selectSuppressedConvergenceProcessGuids(logger lager.Logger, q Queryable, cellSet models.CellSet, now time.Time) (*sql.Rows, error) {
+	predicates := []convergencePredicate{
+		db.staleUnclaimedActualLRPsPredicate(now),
+		db.lrpInstanceCountsPredicate(),
+		db.crashedActualLRPsPredicate(),
+	}
+	if len(cellSet) != 0 || !db.skipMissingCellsWhenCellSetEmpty {
+		predicates = append(predicates, db.actualLRPsWithMissingCellsPredicate(cellSet))
+	}
+
+	queries := make([]string, 0, len(predicates))
+	bindings := []interface{}{}
+	for _, p := range predicates {
+		query, queryBindings := p.suppressed().query("desired_lrps.process_guid")
+		queries = append(queries, query)
+		bindings = append(bindings, queryBindings...)
+	}
+
+	return q.Query(db.helper.Rebind(strings.Join(queries, " UNION ")), bindings...)
+}
+
 func (db *SQLDB) countTasksByState(logger lager.Logger, q Queryable) (pendingCount, runningCount, completedCount, resolvingCount int) {
 	var query string
 	switch db.flavor {
@@ -0,0 +1,90 @@
+package sqldb
+
+import (
+	"database/sql"
+
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager"
+)
+
+const sequencesTable = "sequences"
+
+// NextSequence increments the named sequence and returns its new value. The
+// first value of a sequence is 1. Concurrent callers, including other BBS
+// instances sharing the database, never receive the same value.
+func (db *SQLDB) NextSequence(logger lager.Logger, name string) (uint64, error) {
+	logger = logger.Session("next-sequence", lager.Data{"name": name})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	var value int64
+	var err error
+	for attempts := 0; attempts < 2; attempts++ {
+		err = db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+			var err error
+			value, err = db.incrementSequence(logger, tx, name)
+			return err
+		})
+		if err != models.ErrResourceExists {
+			break
+		}
+		// another caller created the sequence first, it is there to be
+		// incremented now
+		logger.Debug("sequence-created-concurrently")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(value), nil
+}
+
+// Creates or increments the sequence. On MySQL this is a single upsert, so
+// that the first increments of a new sequence neither race to insert its row
+// nor deadlock on the gap locks an UPDATE of the missing row would take. On
+// Postgres the row is updated, and inserted if it is missing, as in
+// helpers.Upsert; callers racing to insert it fail with a unique violation.
+// Errors are returned unconverted so that transact retries deadlocks.
+func (db *SQLDB) incrementSequence(logger lager.Logger, tx *sql.Tx, name string) (int64, error) {
+	var value int64
+
+	switch db.flavor {
+	case helpers.MySQL:
+		// LAST_INSERT_ID(expr) makes the new value the insert id of the
+		// statement whether the row was inserted or updated.
+		result, err := tx.Exec(
+			`INSERT INTO sequences (name, value) VALUES (?, LAST_INSERT_ID(1))
+				ON DUPLICATE KEY UPDATE value = LAST_INSERT_ID(value + 1)`,
+			name,
+		)
+		if err != nil {
+			logger.Error("failed-incrementing-sequence", err)
+			return 0, err
+		}
+
+		value, err = result.LastInsertId()
+		if err != nil {
+			logger.Error("failed-reading-sequence", err)
+			return 0, err
+		}
+	case helpers.Postgres:
+		err := tx.QueryRow(
+			`UPDATE sequences SET value = value + 1 WHERE name = $1 RETURNING value`,
+			name,
+		).Scan(&value)
+		if err == sql.ErrNoRows {
+			value = 1
+			_, err = tx.Exec(`INSERT INTO sequences (name, value) VALUES ($1, $2)`, name, value)
+		}
+		if err != nil {
+			logger.Error("failed-incrementing-sequence", err)
+			return 0, err
+		}
+	default:
+		// totally shouldn't happen
+		panic("database flavor not implemented: " + db.flavor)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,64 @@
+package sqldb_test
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sequences", func() {
+	Describe("NextSequence", func() {
+		It("hands out increasing values starting at 1", func() {
+			for i := uint64(1); i <= 3; i++ {
+				value, err := sqlDB.NextSequence(logger, "events")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal(i))
+			}
+		})
+
+		It("keeps each named sequence separate", func() {
+			_, err := sqlDB.NextSequence(logger, "events")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sqlDB.NextSequence(logger, "events")
+			Expect(err).NotTo(HaveOccurred())
+
+			value, err := sqlDB.NextSequence(logger, "tasks")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(BeEquivalentTo(1))
+		})
+
+		It("does not hand out duplicate values to concurrent callers", func() {
+			const callers, callsPerCaller = 10, 10
+
+			var lock sync.Mutex
+			values := map[uint64]int{}
+
+			wg := sync.WaitGroup{}
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					for j := 0; j < callsPerCaller; j++ {
+						value, err := sqlDB.NextSequence(logger, "events")
+						Expect(err).NotTo(HaveOccurred())
+
+						lock.Lock()
+						values[value]++
+						lock.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			Expect(values).To(HaveLen(callers * callsPerCaller))
+			for value, count := range values {
+				Expect(count).To(Equal(1), "value %d was handed out %d times", value, count)
+				Expect(value).To(BeNumerically(">=", 1))
+				Expect(value).To(BeNumerically("<=", callers*callsPerCaller))
+			}
+		})
+	})
+})
@@ -2,7 +2,11 @@ package sqldb
 
 import (
 	"database/sql"
+	"fmt"
+	"sync"
+	"time"
 
+	"code.cloudfoundry.org/auctioneer"
 	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
 	"code.cloudfoundry.org/bbs/encryption"
 	"code.cloudfoundry.org/bbs/format"
@@ -16,6 +20,8 @@ import (
 type SQLDB struct {
 	db                     *sql.DB
 	convergenceWorkersSize int
+	convergenceBatchSize   int
+	staleUnclaimedDuration time.Duration
 	updateWorkersSize      int
 	clock                  clock.Clock
 	format                 *format.Format
@@ -23,11 +29,38 @@ type SQLDB struct {
 	serializer             format.Serializer
 	cryptor                encryption.Cryptor
 	encoder                format.Encoder
+	encryptionScheme       format.Encoding
+	encodingPolicy         format.EncodingPolicy
 	flavor                 string
 	helper                 helpers.SQLHelper
 	metronClient           loggregator_v2.IngressClient
+
+	skipMissingCellsWhenCellSetEmpty bool
+	reEncryptionWorkers              int
+	lazyEncodingUpgrades             bool
+	domainStaleWindow                time.Duration
+	extraLRPRetirementMinAge         time.Duration
+	maxInstanceLifetime              time.Duration
+	retireNonRestartableCrashes      bool
+	restartPolicy                    models.RestartPolicy
+	compactConvergenceLogs           bool
+	skipIdleConvergence              bool
+	convergenceLock                  sync.Mutex
+
+	maxStartRequestsPerTick    int
+	maxStartRequestRetries     int
+	deferredStartRequests      []*auctioneer.LRPStartRequest
+	deferredStartRequestsMutex sync.Mutex
+
+	firstCrashEvents     bool
+	firstCrashGuids      map[string]struct{}
+	firstCrashGuidsMutex sync.Mutex
+
+	placementFairnessMetric bool
 }
 
+const encodingLazyUpgradesCounter = "EncodingLazyUpgrades"
+
 type RowScanner interface {
 	Scan(dest ...interface{}) error
 }
@@ -42,7 +75,9 @@ type Queryable interface {
 func NewSQLDB(
 	db *sql.DB,
 	convergenceWorkersSize int,
+	convergenceBatchSize int,
 	updateWorkersSize int,
+	staleUnclaimedDuration time.Duration,
 	serializationFormat *format.Format,
 	cryptor encryption.Cryptor,
 	guidProvider guidprovider.GUIDProvider,
@@ -51,20 +86,106 @@ func NewSQLDB(
 	metronClient loggregator_v2.IngressClient,
 ) *SQLDB {
 	helper := helpers.NewSQLHelper(flavor)
+	if staleUnclaimedDuration <= 0 {
+		staleUnclaimedDuration = models.StaleUnclaimedActualLRPDuration
+	}
 	return &SQLDB{
 		db: db,
 		convergenceWorkersSize: convergenceWorkersSize,
+		convergenceBatchSize:   convergenceBatchSize,
 		updateWorkersSize:      updateWorkersSize,
+		staleUnclaimedDuration: staleUnclaimedDuration,
 		clock:                  clock,
 		format:                 serializationFormat,
 		guidProvider:           guidProvider,
 		serializer:             format.NewSerializer(cryptor),
 		cryptor:                cryptor,
 		encoder:                format.NewEncoder(cryptor),
+		encryptionScheme:       format.BASE64_ENCRYPTED,
 		flavor:                 flavor,
 		helper:                 helper,
 		metronClient:           metronClient,
+		restartPolicy:          models.NewDefaultRestartPolicy(),
+	}
+}
+
+// SetTransactionRetryBudget caps the number of deadlock and bad connection
+// retries per second across all transactions. Once the budget is spent,
+// transactions fail with the original error instead of retrying. A
+// non-positive value leaves retries unbounded.
+func (db *SQLDB) SetTransactionRetryBudget(retriesPerSecond int) {
+	if retriesPerSecond <= 0 {
+		db.helper.SetRetryBudget(nil)
+		return
 	}
+	db.helper.SetRetryBudget(helpers.NewRetryBudget(db.clock, retriesPerSecond))
+}
+
+// SetEncryptionScheme makes the SQLDB encrypt the payloads it writes with the
+// given encrypted encoding, BASE64_ENCRYPTED or CHACHA20_ENCRYPTED, using the
+// ChaCha20 cryptor for the latter. Payloads of either encoding stay readable,
+// so the scheme can be switched and the records re-encrypted afterwards. It
+// replaces the encoder, so it is called before SetNonceReuseDetection.
+func (db *SQLDB) SetEncryptionScheme(chacha20Cryptor encryption.Cryptor, scheme format.Encoding) error {
+	encoder, err := format.NewEncoderWithEncryptionScheme(db.cryptor, chacha20Cryptor, scheme, nil)
+	if err != nil {
+		return err
+	}
+
+	db.encryptionScheme = scheme
+	db.encoder = encoder
+	db.serializer = format.NewSerializerWithEncodingPolicy(db.encoder, db.encodingPolicy)
+	return nil
+}
+
+// The models the SQLDB serializes into its records, keyed by the name an
+// encoding policy refers to them by. Of the scheduling info of a desired LRP
+// only the volume placement is serialized, the rest is stored in columns.
+var encodingPolicyModels = map[string]string{
+	"DesiredLRPRunInfo":        "DesiredLRPRunInfo",
+	"DesiredLRPSchedulingInfo": "VolumePlacement",
+	"ActualLRPNetInfo":         "ActualLRPNetInfo",
+	"TaskDefinition":           "TaskDefinition",
+}
+
+// The model serialized into each blob column an encoding policy applies to.
+var blobColumnModels = map[string]string{
+	"run_info":         "DesiredLRPRunInfo",
+	"volume_placement": "VolumePlacement",
+	"net_info":         "ActualLRPNetInfo",
+	"task_definition":  "TaskDefinition",
+}
+
+// SetEncodingPolicy makes the SQLDB write the records named in the policy,
+// DesiredLRPRunInfo, DesiredLRPSchedulingInfo, ActualLRPNetInfo or
+// TaskDefinition, with the given encoding instead of the encoding of its
+// format. Records of any encoding stay readable.
+func (db *SQLDB) SetEncodingPolicy(policy format.EncodingPolicy) error {
+	modelPolicy := format.EncodingPolicy{}
+	for record, encoding := range policy {
+		model, ok := encodingPolicyModels[record]
+		if !ok {
+			return fmt.Errorf("unknown record in encoding policy: %s", record)
+		}
+		modelPolicy[model] = encoding
+	}
+
+	db.encodingPolicy = modelPolicy
+	db.serializer = format.NewSerializerWithEncodingPolicy(db.encoder, db.encodingPolicy)
+	return nil
+}
+
+// SetNonceReuseDetection makes the SQLDB remember the nonces of the last
+// cacheSize decrypted payloads and count EncryptionNonceReuse whenever a nonce
+// is reused for a different payload. It wraps the encoder the SQLDB already
+// has, so it keeps the encryption scheme set before it.
+func (db *SQLDB) SetNonceReuseDetection(logger lager.Logger, cacheSize int) {
+	if cacheSize <= 0 {
+		return
+	}
+	detector := format.NewNonceReuseDetector(logger, db.metronClient, cacheSize)
+	db.encoder = format.WithNonceReuseDetection(db.encoder, detector)
+	db.serializer = format.NewSerializerWithEncodingPolicy(db.encoder, db.encodingPolicy)
 }
 
 func (db *SQLDB) transact(logger lager.Logger, f func(logger lager.Logger, tx *sql.Tx) error) error {
@@ -75,6 +196,31 @@ func (db *SQLDB) transact(logger lager.Logger, f func(logger lager.Logger, tx *s
 	return nil
 }
 
+// SetLazyEncodingUpgrades makes reads rewrite records that are stored in an
+// older encoding than the SQLDB's format, counting each rewrite as
+// EncodingLazyUpgrades. Only task definitions are upgraded this way.
+func (db *SQLDB) SetLazyEncodingUpgrades(enabled bool) {
+	db.lazyEncodingUpgrades = enabled
+}
+
+func (db *SQLDB) needsEncodingUpgrade(model string, data []byte) bool {
+	return db.lazyEncodingUpgrades && format.PayloadEncoding(data) != db.writeEncoding(model)
+}
+
+// The encoding payloads of the given model end up with when written in the
+// SQLDB's format and encoding policy, which for encrypted encodings is the
+// active encryption scheme.
+func (db *SQLDB) writeEncoding(model string) format.Encoding {
+	encoding := db.format.Encoding
+	if policyEncoding, ok := db.encodingPolicy[model]; ok && encoding != format.LEGACY_UNENCODED {
+		encoding = policyEncoding
+	}
+	if encoding == format.BASE64_ENCRYPTED || encoding == format.CHACHA20_ENCRYPTED {
+		return db.encryptionScheme
+	}
+	return encoding
+}
+
 func (db *SQLDB) serializeModel(logger lager.Logger, model format.Versioner) ([]byte, error) {
 	encodedPayload, err := db.serializer.Marshal(logger, db.format, model)
 	if err != nil {
@@ -0,0 +1,295 @@
+package sqldb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+
+	"code.cloudfoundry.org/auctioneer"
+	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	startRequestBufferTable      = "start_request_buffer"
+	startRequestDeadLettersTable = "start_request_dead_letters"
+)
+
+// DeadLetteredStartRequest is a start request that was moved out of the start
+// request buffer after failing to be delivered too many times.
+type DeadLetteredStartRequest struct {
+	StartRequest *auctioneer.LRPStartRequest
+	// The number of times delivering the start request failed.
+	Attempts int
+	// When the start request was dead-lettered, in nanoseconds since the epoch.
+	DeadLetteredAt int64
+}
+
+// SetMaxStartRequestRetries makes BufferStartRequests move start requests that
+// are still undelivered after being retried maxRetries times to the
+// dead-letter table instead of buffering them again, so that they can be
+// reviewed with DeadLetteredStartRequests. The first failed delivery is not a
+// retry, so a start request is dead-lettered on its maxRetries+2nd failed
+// attempt. A non-positive value retries them forever.
+func (db *SQLDB) SetMaxStartRequestRetries(maxRetries int) {
+	db.maxStartRequestRetries = maxRetries
+}
+
+// BufferStartRequests persists start requests that could not be delivered to
+// the auctioneer so that a later convergence can retry them. A buffered start
+// request for the same process guid is replaced, and counts as another failed
+// attempt of it. Start requests of process guids that were dead-lettered are
+// not buffered again until a start request of the process guid is delivered
+// and acknowledged with AcknowledgeStartRequests. The start requests are stored encrypted, like every
+// other record.
+func (db *SQLDB) BufferStartRequests(logger lager.Logger, startRequests []*auctioneer.LRPStartRequest) error {
+	logger = logger.Session("buffer-start-requests", lager.Data{"start_requests_count": len(startRequests)})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		now := db.clock.Now().UnixNano()
+
+		for _, startRequest := range startRequests {
+			encodedStartRequest, err := db.encodeStartRequest(logger, startRequest)
+			if err != nil {
+				return err
+			}
+
+			var deadLettered int
+			row := db.one(logger, tx, startRequestDeadLettersTable,
+				helpers.ColumnList{"1"}, helpers.NoLockRow,
+				"process_guid = ?", startRequest.ProcessGuid,
+			)
+			err = row.Scan(&deadLettered)
+			if err == nil {
+				logger.Debug("skipping-dead-lettered-start-request", lager.Data{"process_guid": startRequest.ProcessGuid})
+				continue
+			}
+			if err != sql.ErrNoRows {
+				logger.Error("failed-reading-dead-lettered-start-request", err, lager.Data{"process_guid": startRequest.ProcessGuid})
+				return db.convertSQLError(err)
+			}
+
+			var attempts int
+			row = db.one(logger, tx, startRequestBufferTable,
+				helpers.ColumnList{"attempts"}, helpers.LockRow,
+				"process_guid = ?", startRequest.ProcessGuid,
+			)
+			err = row.Scan(&attempts)
+			if err != nil && err != sql.ErrNoRows {
+				logger.Error("failed-reading-start-request-attempts", err, lager.Data{"process_guid": startRequest.ProcessGuid})
+				return db.convertSQLError(err)
+			}
+			attempts++
+
+			if db.maxStartRequestRetries > 0 && attempts > db.maxStartRequestRetries+1 {
+				err = db.deadLetterStartRequest(logger, tx, startRequest.ProcessGuid, encodedStartRequest, attempts, now)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			_, err = db.upsert(logger, tx, startRequestBufferTable,
+				helpers.SQLAttributes{
+					"process_guid":  startRequest.ProcessGuid,
+					"start_request": string(encodedStartRequest),
+					"updated_at":    now,
+					"attempts":      attempts,
+				},
+				"process_guid = ?", startRequest.ProcessGuid,
+			)
+			if err != nil {
+				logger.Error("failed-buffering-start-request", err, lager.Data{"process_guid": startRequest.ProcessGuid})
+				return db.convertSQLError(err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (db *SQLDB) deadLetterStartRequest(logger lager.Logger, tx *sql.Tx, processGuid string, encodedStartRequest []byte, attempts int, now int64) error {
+	logger.Info("dead-lettering-start-request", lager.Data{"process_guid": processGuid, "attempts": attempts})
+
+	_, err := db.upsert(logger, tx, startRequestDeadLettersTable,
+		helpers.SQLAttributes{
+			"process_guid":     processGuid,
+			"start_request":    string(encodedStartRequest),
+			"attempts":         attempts,
+			"dead_lettered_at": now,
+		},
+		"process_guid = ?", processGuid,
+	)
+	if err != nil {
+		logger.Error("failed-dead-lettering-start-request", err, lager.Data{"process_guid": processGuid})
+		return db.convertSQLError(err)
+	}
+
+	_, err = db.delete(logger, tx, startRequestBufferTable, "process_guid = ?", processGuid)
+	if err != nil {
+		logger.Error("failed-deleting-start-request", err, lager.Data{"process_guid": processGuid})
+		return db.convertSQLError(err)
+	}
+
+	return nil
+}
+
+// DeadLetteredStartRequests returns the start requests that were given up on,
+// sorted by process guid. Start requests that cannot be decoded are skipped.
+func (db *SQLDB) DeadLetteredStartRequests(logger lager.Logger) ([]*DeadLetteredStartRequest, error) {
+	logger = logger.Session("dead-lettered-start-requests")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	rows, err := db.all(logger, db.db, startRequestDeadLettersTable,
+		helpers.ColumnList{"start_request", "attempts", "dead_lettered_at"}, helpers.NoLockRow,
+		"",
+	)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	deadLetters := []*DeadLetteredStartRequest{}
+	for rows.Next() {
+		var value string
+		deadLetter := &DeadLetteredStartRequest{}
+		err = rows.Scan(&value, &deadLetter.Attempts, &deadLetter.DeadLetteredAt)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			return nil, db.convertSQLError(err)
+		}
+
+		deadLetter.StartRequest, err = db.decodeStartRequest(logger, value)
+		if err != nil {
+			continue
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	sort.Sort(deadLetteredStartRequests(deadLetters))
+	return deadLetters, nil
+}
+
+type deadLetteredStartRequests []*DeadLetteredStartRequest
+
+func (d deadLetteredStartRequests) Len() int      { return len(d) }
+func (d deadLetteredStartRequests) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d deadLetteredStartRequests) Less(i, j int) bool {
+	return d[i].StartRequest.ProcessGuid < d[j].StartRequest.ProcessGuid
+}
+
+// BufferedStartRequests returns the start requests that are waiting to be
+// redelivered. Start requests that cannot be decoded are skipped.
+func (db *SQLDB) BufferedStartRequests(logger lager.Logger) ([]*auctioneer.LRPStartRequest, error) {
+	logger = logger.Session("buffered-start-requests")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	rows, err := db.all(logger, db.db, startRequestBufferTable,
+		helpers.ColumnList{"start_request"}, helpers.NoLockRow,
+		"",
+	)
+	if err != nil {
+		logger.Error("failed-query", err)
+		return nil, db.convertSQLError(err)
+	}
+	defer rows.Close()
+
+	startRequests := []*auctioneer.LRPStartRequest{}
+	for rows.Next() {
+		var value string
+		err = rows.Scan(&value)
+		if err != nil {
+			logger.Error("failed-scanning-row", err)
+			return nil, db.convertSQLError(err)
+		}
+
+		startRequest, err := db.decodeStartRequest(logger, value)
+		if err != nil {
+			continue
+		}
+		startRequests = append(startRequests, startRequest)
+	}
+
+	if rows.Err() != nil {
+		logger.Error("failed-getting-next-row", rows.Err())
+		return nil, db.convertSQLError(rows.Err())
+	}
+
+	return startRequests, nil
+}
+
+// AcknowledgeStartRequests removes the buffered start requests of the given
+// process guids once they have been delivered, along with their dead letters,
+// so that later failed deliveries of the process guids are buffered again.
+func (db *SQLDB) AcknowledgeStartRequests(logger lager.Logger, processGuids []string) error {
+	logger = logger.Session("acknowledge-start-requests", lager.Data{"process_guids_count": len(processGuids)})
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	if len(processGuids) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, 0, len(processGuids))
+	for _, guid := range processGuids {
+		values = append(values, guid)
+	}
+
+	return db.transact(logger, func(logger lager.Logger, tx *sql.Tx) error {
+		_, err := db.delete(logger, tx, startRequestBufferTable, whereClauseForProcessGuids(processGuids), values...)
+		if err != nil {
+			logger.Error("failed-deleting-start-requests", err)
+			return db.convertSQLError(err)
+		}
+
+		_, err = db.delete(logger, tx, startRequestDeadLettersTable, whereClauseForProcessGuids(processGuids), values...)
+		if err != nil {
+			logger.Error("failed-deleting-dead-lettered-start-requests", err)
+			return db.convertSQLError(err)
+		}
+
+		return nil
+	})
+}
+
+func (db *SQLDB) encodeStartRequest(logger lager.Logger, startRequest *auctioneer.LRPStartRequest) ([]byte, error) {
+	startRequestData, err := json.Marshal(startRequest)
+	if err != nil {
+		logger.Error("failed-marshalling-start-request", err)
+		return nil, models.ErrBadRequest
+	}
+	encodedData, err := db.encoder.Encode(format.BASE64_ENCRYPTED, startRequestData)
+	if err != nil {
+		logger.Error("failed-encrypting-start-request", err)
+		return nil, models.ErrBadRequest
+	}
+	return encodedData, nil
+}
+
+func (db *SQLDB) decodeStartRequest(logger lager.Logger, value string) (*auctioneer.LRPStartRequest, error) {
+	startRequestData, err := db.encoder.Decode([]byte(value))
+	if err != nil {
+		logger.Error("failed-decrypting-start-request", err)
+		return nil, models.ErrDeserialize
+	}
+
+	var startRequest auctioneer.LRPStartRequest
+	err = json.Unmarshal(startRequestData, &startRequest)
+	if err != nil {
+		logger.Error("failed-parsing-start-request", err)
+		return nil, models.ErrDeserialize
+	}
+	return &startRequest, nil
+}
@@ -0,0 +1,210 @@
+package sqldb_test
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/auctioneer"
+	"code.cloudfoundry.org/bbs/format"
+	"code.cloudfoundry.org/bbs/models/test/model_helpers"
+	"code.cloudfoundry.org/bbs/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StartRequestBuffer", func() {
+	var startRequest1, startRequest2 auctioneer.LRPStartRequest
+
+	BeforeEach(func() {
+		startRequest1 = auctioneer.NewLRPStartRequestFromModel(model_helpers.NewValidDesiredLRP("buffered-guid-1"), 0, 1)
+		startRequest2 = auctioneer.NewLRPStartRequestFromModel(model_helpers.NewValidDesiredLRP("buffered-guid-2"), 2)
+	})
+
+	Describe("BufferStartRequests", func() {
+		It("persists the start requests", func() {
+			err := sqlDB.BufferStartRequests(logger, []*auctioneer.LRPStartRequest{&startRequest1, &startRequest2})
+			Expect(err).NotTo(HaveOccurred())
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(ConsistOf(&startRequest1, &startRequest2))
+		})
+
+		It("replaces the buffered start request for the same process guid", func() {
+			err := sqlDB.BufferStartRequests(logger, []*auctioneer.LRPStartRequest{&startRequest1})
+			Expect(err).NotTo(HaveOccurred())
+
+			replacement := auctioneer.NewLRPStartRequestFromModel(model_helpers.NewValidDesiredLRP("buffered-guid-1"), 3)
+			err = sqlDB.BufferStartRequests(logger, []*auctioneer.LRPStartRequest{&replacement})
+			Expect(err).NotTo(HaveOccurred())
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(ConsistOf(&replacement))
+		})
+
+		It("stores the start requests encrypted", func() {
+			err := sqlDB.BufferStartRequests(logger, []*auctioneer.LRPStartRequest{&startRequest1})
+			Expect(err).NotTo(HaveOccurred())
+
+			queryStr := "SELECT start_request FROM start_request_buffer WHERE process_guid = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+
+			var value string
+			err = db.QueryRow(queryStr, "buffered-guid-1").Scan(&value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.PayloadEncoding([]byte(value))).To(Equal(format.BASE64_ENCRYPTED))
+			Expect(strings.Contains(value, "buffered-guid-1")).To(BeFalse())
+		})
+	})
+
+	Context("when the start requests have a retry cap", func() {
+		BeforeEach(func() {
+			sqlDB.SetMaxStartRequestRetries(2)
+		})
+
+		bufferStartRequests := func(times int, startRequests ...*auctioneer.LRPStartRequest) {
+			for i := 0; i < times; i++ {
+				err := sqlDB.BufferStartRequests(logger, startRequests)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+
+		It("keeps buffering start requests until they exceed the cap", func() {
+			bufferStartRequests(3, &startRequest1)
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(ConsistOf(&startRequest1))
+
+			deadLetters, err := sqlDB.DeadLetteredStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadLetters).To(BeEmpty())
+		})
+
+		It("moves a start request exceeding the cap to the dead-letter store", func() {
+			bufferStartRequests(3, &startRequest1, &startRequest2)
+			bufferStartRequests(1, &startRequest1)
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(ConsistOf(&startRequest2))
+
+			deadLetters, err := sqlDB.DeadLetteredStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadLetters).To(HaveLen(1))
+			Expect(deadLetters[0].StartRequest).To(Equal(&startRequest1))
+			Expect(deadLetters[0].Attempts).To(Equal(4))
+			Expect(deadLetters[0].DeadLetteredAt).To(Equal(fakeClock.Now().UnixNano()))
+		})
+
+		It("does not buffer a dead-lettered start request again", func() {
+			bufferStartRequests(4, &startRequest1)
+			bufferStartRequests(2, &startRequest1)
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(BeEmpty())
+
+			deadLetters, err := sqlDB.DeadLetteredStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadLetters).To(HaveLen(1))
+			Expect(deadLetters[0].Attempts).To(Equal(4))
+		})
+
+		It("buffers a dead-lettered start request again once a start request of it is acknowledged", func() {
+			bufferStartRequests(4, &startRequest1)
+			err := sqlDB.AcknowledgeStartRequests(logger, []string{"buffered-guid-1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			deadLetters, err := sqlDB.DeadLetteredStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadLetters).To(BeEmpty())
+
+			bufferStartRequests(1, &startRequest1)
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(ConsistOf(&startRequest1))
+		})
+
+		It("starts counting again once a start request is acknowledged", func() {
+			bufferStartRequests(3, &startRequest1)
+			err := sqlDB.AcknowledgeStartRequests(logger, []string{"buffered-guid-1"})
+			Expect(err).NotTo(HaveOccurred())
+			bufferStartRequests(1, &startRequest1)
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(ConsistOf(&startRequest1))
+		})
+
+		It("stores the dead-lettered start requests encrypted", func() {
+			bufferStartRequests(4, &startRequest1)
+
+			queryStr := "SELECT start_request FROM start_request_dead_letters WHERE process_guid = ?"
+			if test_helpers.UsePostgres() {
+				queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+			}
+
+			var value string
+			err := db.QueryRow(queryStr, "buffered-guid-1").Scan(&value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.PayloadEncoding([]byte(value))).To(Equal(format.BASE64_ENCRYPTED))
+		})
+	})
+
+	Describe("BufferedStartRequests", func() {
+		It("returns no start requests when nothing is buffered", func() {
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(BeEmpty())
+		})
+
+		Context("when a buffered start request cannot be decoded", func() {
+			BeforeEach(func() {
+				queryStr := "INSERT INTO start_request_buffer (process_guid, start_request, updated_at) VALUES (?, ?, ?)"
+				if test_helpers.UsePostgres() {
+					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+				}
+				_, err := db.Exec(queryStr, "garbage-guid", "{{", 0)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = sqlDB.BufferStartRequests(logger, []*auctioneer.LRPStartRequest{&startRequest1})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("skips it", func() {
+				startRequests, err := sqlDB.BufferedStartRequests(logger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(startRequests).To(ConsistOf(&startRequest1))
+			})
+		})
+	})
+
+	Describe("AcknowledgeStartRequests", func() {
+		BeforeEach(func() {
+			err := sqlDB.BufferStartRequests(logger, []*auctioneer.LRPStartRequest{&startRequest1, &startRequest2})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes the start requests of the given process guids", func() {
+			err := sqlDB.AcknowledgeStartRequests(logger, []string{"buffered-guid-1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(ConsistOf(&startRequest2))
+		})
+
+		It("does nothing when no process guids are given", func() {
+			err := sqlDB.AcknowledgeStartRequests(logger, []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			startRequests, err := sqlDB.BufferedStartRequests(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(startRequests).To(HaveLen(2))
+		})
+	})
+})
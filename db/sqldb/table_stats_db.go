@@ -0,0 +1,37 @@
+package sqldb
+
+import (
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager"
+)
+
+var statTables = []string{desiredLRPsTable, actualLRPsTable, tasksTable}
+
+// TableStats returns the approximate number of rows in and size of the
+// desired_lrps, actual_lrps and tasks tables, keyed by table name. Both are
+// read from the database catalog rather than counted, so they are only as
+// current as the tables' statistics.
+func (db *SQLDB) TableStats(logger lager.Logger) (map[string]models.TableStat, error) {
+	logger = logger.Session("table-stats")
+	logger.Debug("starting")
+	defer logger.Debug("complete")
+
+	stats := make(map[string]models.TableStat, len(statTables))
+	for _, table := range statTables {
+		rows, err := db.estimateTableRows(logger, db.db, table)
+		if err != nil {
+			logger.Error("failed-estimating-rows", err, lager.Data{"table": table})
+			return nil, db.convertSQLError(err)
+		}
+
+		bytes, err := db.estimateTableBytes(logger, db.db, table)
+		if err != nil {
+			logger.Error("failed-estimating-size", err, lager.Data{"table": table})
+			return nil, db.convertSQLError(err)
+		}
+
+		stats[table] = models.TableStat{Rows: rows, Bytes: bytes}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,60 @@
+package sqldb_test
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/bbs/models/test/model_helpers"
+	"code.cloudfoundry.org/bbs/test_helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TableStats", func() {
+	BeforeEach(func() {
+		for i := 0; i < 2; i++ {
+			err := sqlDB.DesireLRP(logger, model_helpers.NewValidDesiredLRP(fmt.Sprintf("desired-%d", i)))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		for i := 0; i < 3; i++ {
+			_, err := sqlDB.CreateUnclaimedActualLRP(logger, &models.ActualLRPKey{ProcessGuid: "desired-0", Index: int32(i), Domain: "some-domain"})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		_, err := sqlDB.DesireTask(logger, model_helpers.NewValidTaskDefinition(), "task-guid", "some-domain")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	analyze := func() {
+		for _, table := range []string{"desired_lrps", "actual_lrps", "tasks"} {
+			query := "ANALYZE TABLE " + table
+			if test_helpers.UsePostgres() {
+				query = "ANALYZE " + table
+			}
+			_, err := db.Exec(query)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	It("estimates the row counts of the seeded tables from their statistics", func() {
+		analyze()
+
+		stats, err := sqlDB.TableStats(logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stats).To(HaveLen(3))
+		Expect(stats["desired_lrps"].Rows).To(BeEquivalentTo(2))
+		Expect(stats["actual_lrps"].Rows).To(BeEquivalentTo(3))
+		Expect(stats["tasks"].Rows).To(BeEquivalentTo(1))
+	})
+
+	It("returns an estimate of the size of each table", func() {
+		stats, err := sqlDB.TableStats(logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		for table, stat := range stats {
+			Expect(stat.Bytes).To(BeNumerically(">", 0), table)
+		}
+	})
+})
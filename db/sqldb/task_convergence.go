@@ -27,7 +27,17 @@ const (
 	cellDisappearedFailureReason = "cell disappeared before completion"
 )
 
+// ConvergeTasks never runs at the same time as ConvergeLRPs or Converge on the
+// same SQLDB, so that LRP and task convergence do not load the database at
+// once.
 func (db *SQLDB) ConvergeTasks(logger lager.Logger, cellSet models.CellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) ([]*auctioneer.TaskStartRequest, []*models.Task, []models.Event) {
+	db.convergenceLock.Lock()
+	defer db.convergenceLock.Unlock()
+
+	return db.convergeTasks(logger, cellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+}
+
+func (db *SQLDB) convergeTasks(logger lager.Logger, cellSet models.CellSet, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration time.Duration) ([]*auctioneer.TaskStartRequest, []*models.Task, []models.Event) {
 	logger.Info("starting")
 	defer logger.Info("completed")
 
@@ -396,4 +396,42 @@ var _ = Describe("Convergence of Tasks", func() {
 			})
 		})
 	})
+
+	Describe("convergence counters", func() {
+		It("bumps the convergence counter", func() {
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(0))
+			sqlDB.ConvergeTasks(logger, models.CellSet{}, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+			Expect(fakeMetronClient.IncrementCounterArgsForCall(0)).To(Equal("ConvergenceTaskRuns"))
+			sqlDB.ConvergeTasks(logger, models.CellSet{}, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(2))
+			Expect(fakeMetronClient.IncrementCounterArgsForCall(1)).To(Equal("ConvergenceTaskRuns"))
+		})
+
+		It("reports the duration that it took to converge on every run", func() {
+			sqlDB.ConvergeTasks(logger, models.CellSet{}, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+			sqlDB.ConvergeTasks(logger, models.CellSet{}, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+
+			Expect(fakeMetronClient.SendDurationCallCount()).To(Equal(2))
+			for i := 0; i < 2; i++ {
+				name, _ := fakeMetronClient.SendDurationArgsForCall(i)
+				Expect(name).To(Equal("ConvergenceTaskDuration"))
+			}
+		})
+
+		It("emits the task state counts on every run", func() {
+			sqlDB.ConvergeTasks(logger, models.CellSet{}, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+			sqlDB.ConvergeTasks(logger, models.CellSet{}, kickTasksDuration, expirePendingTaskDuration, expireCompletedTaskDuration)
+
+			names := []string{}
+			for i := 0; i < fakeMetronClient.SendMetricCallCount(); i++ {
+				name, _ := fakeMetronClient.SendMetricArgsForCall(i)
+				names = append(names, name)
+			}
+			Expect(names).To(Equal([]string{
+				"TasksPending", "TasksRunning", "TasksCompleted", "TasksResolving",
+				"TasksPending", "TasksRunning", "TasksCompleted", "TasksResolving",
+			}))
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/bbs/db/sqldb/helpers"
+	"code.cloudfoundry.org/bbs/format"
 	"code.cloudfoundry.org/bbs/models"
 	"code.cloudfoundry.org/lager"
 )
@@ -189,6 +190,11 @@ func (db *SQLDB) CancelTask(logger lager.Logger, taskGuid string) (*models.Task,
 		beforeTask = *afterTask
 		cellID = afterTask.CellId
 
+		if afterTask.State == models.Task_Completed {
+			logger.Info("task-already-completed")
+			return nil
+		}
+
 		if err = afterTask.ValidateTransitionTo(models.Task_Completed); err != nil {
 			if afterTask.State != models.Task_Pending {
 				logger.Error("failed-to-transition-task-to-completed", err)
@@ -403,12 +409,14 @@ func (db *SQLDB) fetchTaskForUpdate(logger lager.Logger, taskGuid string, querya
 func (db *SQLDB) fetchTasks(logger lager.Logger, rows *sql.Rows, queryable Queryable, abortOnError bool) ([]*models.Task, int, error) {
 	tasks := []*models.Task{}
 	invalidGuids := []string{}
+	upgrades := []taskDefinitionUpgrade{}
 	var err error
 	for rows.Next() {
 		var task *models.Task
 		var guid string
+		var taskDefData []byte
 
-		task, guid, err = db.fetchTaskInternal(logger, rows)
+		task, guid, taskDefData, err = db.fetchTaskInternal(logger, rows)
 		if err == models.ErrDeserialize {
 			invalidGuids = append(invalidGuids, guid)
 			if abortOnError {
@@ -417,6 +425,9 @@ func (db *SQLDB) fetchTasks(logger lager.Logger, rows *sql.Rows, queryable Query
 			continue
 		}
 		tasks = append(tasks, task)
+		if err == nil && db.needsEncodingUpgrade("TaskDefinition", taskDefData) {
+			upgrades = append(upgrades, taskDefinitionUpgrade{guid, taskDefData, task.TaskDefinition})
+		}
 	}
 
 	if err == nil {
@@ -429,18 +440,63 @@ func (db *SQLDB) fetchTasks(logger lager.Logger, rows *sql.Rows, queryable Query
 		db.deleteInvalidTasks(logger, queryable, invalidGuids...)
 	}
 
+	for _, upgrade := range upgrades {
+		db.upgradeTaskDefinitionEncoding(logger, queryable, upgrade)
+	}
+
 	return tasks, len(invalidGuids), err
 }
 
 func (db *SQLDB) fetchTask(logger lager.Logger, scanner RowScanner, queryable Queryable) (*models.Task, error) {
-	task, guid, err := db.fetchTaskInternal(logger, scanner)
+	task, guid, taskDefData, err := db.fetchTaskInternal(logger, scanner)
 	if err == models.ErrDeserialize {
 		db.deleteInvalidTasks(logger, queryable, guid)
 	}
+	if err == nil && db.needsEncodingUpgrade("TaskDefinition", taskDefData) {
+		db.upgradeTaskDefinitionEncoding(logger, queryable, taskDefinitionUpgrade{guid, taskDefData, task.TaskDefinition})
+	}
 	return task, err
 }
 
-func (db *SQLDB) fetchTaskInternal(logger lager.Logger, scanner RowScanner) (*models.Task, string, error) {
+// The stored task definition of a task that was read in an older encoding.
+type taskDefinitionUpgrade struct {
+	guid       string
+	storedData []byte
+	taskDef    *models.TaskDefinition
+}
+
+// Rewrites the task definition in the current encoding, unless it has been
+// changed since it was read.
+func (db *SQLDB) upgradeTaskDefinitionEncoding(logger lager.Logger, queryable Queryable, upgrade taskDefinitionUpgrade) {
+	logger = logger.Session("upgrade-task-definition-encoding", lager.Data{"guid": upgrade.guid})
+
+	taskDefData, err := db.serializeModel(logger, upgrade.taskDef)
+	if err != nil {
+		return
+	}
+
+	if format.PayloadEncoding(taskDefData) == format.PayloadEncoding(upgrade.storedData) {
+		return
+	}
+
+	result, err := db.update(logger, queryable, tasksTable,
+		helpers.SQLAttributes{"task_definition": taskDefData},
+		"guid = ? AND task_definition = ?", upgrade.guid, upgrade.storedData,
+	)
+	if err != nil {
+		logger.Error("failed-updating-task-definition", err)
+		return
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil || rowsAffected == 0 {
+		return
+	}
+
+	db.metronClient.IncrementCounter(encodingLazyUpgradesCounter)
+}
+
+func (db *SQLDB) fetchTaskInternal(logger lager.Logger, scanner RowScanner) (*models.Task, string, []byte, error) {
 	var guid, domain, cellID, failureReason string
 	var result sql.NullString
 	var createdAt, updatedAt, firstCompletedAt int64
@@ -463,18 +519,18 @@ func (db *SQLDB) fetchTaskInternal(logger lager.Logger, scanner RowScanner) (*mo
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
 	if err != nil {
 		logger.Error("failed-scanning-row", err)
-		return nil, "", err
+		return nil, "", nil, err
 	}
 
 	var taskDef models.TaskDefinition
 	err = db.deserializeModel(logger, taskDefData, &taskDef)
 	if err != nil {
-		return nil, guid, models.ErrDeserialize
+		return nil, guid, nil, models.ErrDeserialize
 	}
 
 	task := &models.Task{
@@ -490,7 +546,7 @@ func (db *SQLDB) fetchTaskInternal(logger lager.Logger, scanner RowScanner) (*mo
 		FailureReason:    failureReason,
 		TaskDefinition:   &taskDef,
 	}
-	return task, guid, nil
+	return task, guid, taskDefData, nil
 }
 
 func (db *SQLDB) deleteInvalidTasks(logger lager.Logger, queryable Queryable, guids ...string) error {
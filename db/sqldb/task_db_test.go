@@ -214,6 +214,86 @@ var _ = Describe("TaskDB", func() {
 			})
 		})
 
+		Context("when the task definition is stored in a legacy encoding", func() {
+			var expectedTask *models.Task
+
+			storedTaskDefinition := func() []byte {
+				queryStr := "SELECT task_definition FROM tasks WHERE guid = ?"
+				if test_helpers.UsePostgres() {
+					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+				}
+				var taskDefData []byte
+				Expect(db.QueryRow(queryStr, expectedTask.TaskGuid).Scan(&taskDefData)).To(Succeed())
+				return taskDefData
+			}
+
+			BeforeEach(func() {
+				expectedTask = model_helpers.NewValidTask("legacy-task-guid")
+				insertTask(db, serializer, expectedTask, false)
+
+				legacyTaskDefData, err := serializer.Marshal(logger, format.LEGACY_FORMATTING, expectedTask.TaskDefinition)
+				Expect(err).NotTo(HaveOccurred())
+
+				queryStr := "UPDATE tasks SET task_definition = ? WHERE guid = ?"
+				if test_helpers.UsePostgres() {
+					queryStr = test_helpers.ReplaceQuestionMarks(queryStr)
+				}
+				_, err = db.Exec(queryStr, legacyTaskDefData, expectedTask.TaskGuid)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when lazy encoding upgrades are enabled", func() {
+				BeforeEach(func() {
+					sqlDB.SetLazyEncodingUpgrades(true)
+				})
+
+				It("upgrades the stored task definition to the current encoding and counts the upgrade", func() {
+					task, err := sqlDB.TaskByGuid(logger, expectedTask.TaskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task).To(Equal(expectedTask))
+
+					Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+					Expect(fakeMetronClient.IncrementCounterArgsForCall(0)).To(Equal("EncodingLazyUpgrades"))
+
+					taskDefData := storedTaskDefinition()
+					Expect(format.PayloadEncoding(taskDefData)).To(Equal(format.BASE64_ENCRYPTED))
+
+					var taskDef models.TaskDefinition
+					Expect(serializer.Unmarshal(logger, taskDefData, &taskDef)).To(Succeed())
+					Expect(&taskDef).To(Equal(expectedTask.TaskDefinition))
+				})
+
+				It("does not upgrade the record again on later reads", func() {
+					_, err := sqlDB.TaskByGuid(logger, expectedTask.TaskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = sqlDB.TaskByGuid(logger, expectedTask.TaskGuid)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+				})
+
+				It("upgrades records read through Tasks", func() {
+					tasks, err := sqlDB.Tasks(logger, models.TaskFilter{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(tasks).To(ConsistOf(expectedTask))
+
+					Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+					Expect(format.PayloadEncoding(storedTaskDefinition())).To(Equal(format.BASE64_ENCRYPTED))
+				})
+			})
+
+			Context("when lazy encoding upgrades are disabled", func() {
+				It("leaves the stored task definition alone", func() {
+					task, err := sqlDB.TaskByGuid(logger, expectedTask.TaskGuid)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(task).To(Equal(expectedTask))
+
+					Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(0))
+					Expect(format.PayloadEncoding(storedTaskDefinition())).To(Equal(format.LEGACY_UNENCODED))
+				})
+			})
+		})
+
 		Context("when there is invalid data", func() {
 			BeforeEach(func() {
 				task1 := model_helpers.NewValidTask("a-guid")
@@ -475,11 +555,14 @@ var _ = Describe("TaskDB", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
-			It("returns an InvalidStateTransition error", func() {
-				_, _, _, err := sqlDB.CancelTask(logger, taskGuid)
-				modelErr := models.ConvertError(err)
-				Expect(modelErr).NotTo(BeNil())
-				Expect(modelErr.Type).To(Equal(models.Error_InvalidStateTransition))
+			It("leaves the task untouched and does not return an error", func() {
+				fakeClock.Increment(time.Second)
+
+				before, after, cellID, err := sqlDB.CancelTask(logger, taskGuid)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(before).To(Equal(beforeTask))
+				Expect(after).To(Equal(beforeTask))
+				Expect(cellID).To(Equal(""))
 
 				task, err := sqlDB.TaskByGuid(logger, taskGuid)
 				Expect(err).NotTo(HaveOccurred())
@@ -22,6 +22,16 @@ type Decryptor interface {
 	Decrypt(encrypted Encrypted) ([]byte, error)
 }
 
+// KeyNotFoundError is returned when decrypting with a key label the key
+// manager has no decryption key for.
+type KeyNotFoundError struct {
+	Label string
+}
+
+func (e KeyNotFoundError) Error() string {
+	return fmt.Sprintf("Key with label %q was not found", e.Label)
+}
+
 //go:generate counterfeiter . Cryptor
 
 type Cryptor interface {
@@ -32,21 +42,51 @@ type Cryptor interface {
 type cryptor struct {
 	keyManager KeyManager
 	prng       io.Reader
+	newAEAD    func(Key) (cipher.AEAD, error)
 }
 
+// NewCryptor returns a Cryptor that encrypts with AES-256 in GCM mode.
 func NewCryptor(keyManager KeyManager, prng io.Reader) Cryptor {
 	return &cryptor{
 		keyManager: keyManager,
 		prng:       prng,
+		newAEAD:    newGCM,
+	}
+}
+
+// NewChaCha20Cryptor returns a Cryptor that encrypts with ChaCha20-Poly1305,
+// using the same keys as the Cryptor returned by NewCryptor. Its nonces are
+// NonceSize bytes long too.
+func NewChaCha20Cryptor(keyManager KeyManager, prng io.Reader) Cryptor {
+	return &cryptor{
+		keyManager: keyManager,
+		prng:       prng,
+		newAEAD:    newChaCha20Poly1305,
 	}
 }
 
+func newGCM(key Key) (cipher.AEAD, error) {
+	aead, err := cipher.NewGCM(key.Block())
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create GCM-wrapped cipher: %q", err)
+	}
+	return aead, nil
+}
+
+func newChaCha20Poly1305(key Key) (cipher.AEAD, error) {
+	aead, err := key.ChaCha20Poly1305()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create ChaCha20-Poly1305 cipher: %q", err)
+	}
+	return aead, nil
+}
+
 func (c *cryptor) Encrypt(plaintext []byte) (Encrypted, error) {
 	key := c.keyManager.EncryptionKey()
 
-	aead, err := cipher.NewGCM(key.Block())
+	aead, err := c.newAEAD(key)
 	if err != nil {
-		return Encrypted{}, fmt.Errorf("Unable to create GCM-wrapped cipher: %q", err)
+		return Encrypted{}, err
 	}
 
 	nonce := make([]byte, aead.NonceSize())
@@ -62,12 +102,12 @@ func (c *cryptor) Encrypt(plaintext []byte) (Encrypted, error) {
 func (d *cryptor) Decrypt(encrypted Encrypted) ([]byte, error) {
 	key := d.keyManager.DecryptionKey(encrypted.KeyLabel)
 	if key == nil {
-		return nil, fmt.Errorf("Key with label %q was not found", encrypted.KeyLabel)
+		return nil, KeyNotFoundError{Label: encrypted.KeyLabel}
 	}
 
-	aead, err := cipher.NewGCM(key.Block())
+	aead, err := d.newAEAD(key)
 	if err != nil {
-		return nil, fmt.Errorf("Unable to create GCM-wrapped cipher: %q", err)
+		return nil, err
 	}
 
 	return aead.Open(nil, encrypted.Nonce, encrypted.CipherText, nil)
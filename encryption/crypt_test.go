@@ -79,6 +79,7 @@ var _ = Describe("Crypt", func() {
 			_, err := cryptor.Decrypt(encrypted)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError(`Key with label "doesnt-exist" was not found`))
+			Expect(err).To(Equal(encryption.KeyNotFoundError{Label: "doesnt-exist"}))
 		})
 	})
 
@@ -132,4 +133,33 @@ var _ = Describe("Crypt", func() {
 			Expect(err).To(MatchError(HavePrefix("Unable to create GCM-wrapped cipher:")))
 		})
 	})
+
+	Describe("NewChaCha20Cryptor", func() {
+		var chachaCryptor encryption.Cryptor
+
+		JustBeforeEach(func() {
+			chachaCryptor = encryption.NewChaCha20Cryptor(keyManager, prng)
+		})
+
+		It("successfully encrypts and decrypts with a key", func() {
+			input := []byte("some plaintext data")
+
+			encrypted, err := chachaCryptor.Encrypt(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encrypted.CipherText).NotTo(Equal(input))
+			Expect(encrypted.Nonce).To(HaveLen(encryption.NonceSize))
+
+			plaintext, err := chachaCryptor.Decrypt(encrypted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plaintext).To(Equal(input))
+		})
+
+		It("does not produce ciphertext the AES cryptor can decrypt", func() {
+			encrypted, err := chachaCryptor.Encrypt([]byte("some plaintext data"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = cryptor.Decrypt(encrypted)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -11,8 +11,9 @@ import (
 type FakeKey struct {
 	LabelStub        func() string
 	labelMutex       sync.RWMutex
-	labelArgsForCall []struct{}
-	labelReturns     struct {
+	labelArgsForCall []struct {
+	}
+	labelReturns struct {
 		result1 string
 	}
 	labelReturnsOnCall map[int]struct {
@@ -20,13 +21,26 @@ type FakeKey struct {
 	}
 	BlockStub        func() cipher.Block
 	blockMutex       sync.RWMutex
-	blockArgsForCall []struct{}
-	blockReturns     struct {
+	blockArgsForCall []struct {
+	}
+	blockReturns struct {
 		result1 cipher.Block
 	}
 	blockReturnsOnCall map[int]struct {
 		result1 cipher.Block
 	}
+	ChaCha20Poly1305Stub        func() (cipher.AEAD, error)
+	chaCha20Poly1305Mutex       sync.RWMutex
+	chaCha20Poly1305ArgsForCall []struct {
+	}
+	chaCha20Poly1305Returns struct {
+		result1 cipher.AEAD
+		result2 error
+	}
+	chaCha20Poly1305ReturnsOnCall map[int]struct {
+		result1 cipher.AEAD
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -34,7 +48,8 @@ type FakeKey struct {
 func (fake *FakeKey) Label() string {
 	fake.labelMutex.Lock()
 	ret, specificReturn := fake.labelReturnsOnCall[len(fake.labelArgsForCall)]
-	fake.labelArgsForCall = append(fake.labelArgsForCall, struct{}{})
+	fake.labelArgsForCall = append(fake.labelArgsForCall, struct {
+	}{})
 	fake.recordInvocation("Label", []interface{}{})
 	fake.labelMutex.Unlock()
 	if fake.LabelStub != nil {
@@ -74,7 +89,8 @@ func (fake *FakeKey) LabelReturnsOnCall(i int, result1 string) {
 func (fake *FakeKey) Block() cipher.Block {
 	fake.blockMutex.Lock()
 	ret, specificReturn := fake.blockReturnsOnCall[len(fake.blockArgsForCall)]
-	fake.blockArgsForCall = append(fake.blockArgsForCall, struct{}{})
+	fake.blockArgsForCall = append(fake.blockArgsForCall, struct {
+	}{})
 	fake.recordInvocation("Block", []interface{}{})
 	fake.blockMutex.Unlock()
 	if fake.BlockStub != nil {
@@ -111,6 +127,50 @@ func (fake *FakeKey) BlockReturnsOnCall(i int, result1 cipher.Block) {
 	}{result1}
 }
 
+func (fake *FakeKey) ChaCha20Poly1305() (cipher.AEAD, error) {
+	fake.chaCha20Poly1305Mutex.Lock()
+	ret, specificReturn := fake.chaCha20Poly1305ReturnsOnCall[len(fake.chaCha20Poly1305ArgsForCall)]
+	fake.chaCha20Poly1305ArgsForCall = append(fake.chaCha20Poly1305ArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ChaCha20Poly1305", []interface{}{})
+	fake.chaCha20Poly1305Mutex.Unlock()
+	if fake.ChaCha20Poly1305Stub != nil {
+		return fake.ChaCha20Poly1305Stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.chaCha20Poly1305Returns.result1, fake.chaCha20Poly1305Returns.result2
+}
+
+func (fake *FakeKey) ChaCha20Poly1305CallCount() int {
+	fake.chaCha20Poly1305Mutex.RLock()
+	defer fake.chaCha20Poly1305Mutex.RUnlock()
+	return len(fake.chaCha20Poly1305ArgsForCall)
+}
+
+func (fake *FakeKey) ChaCha20Poly1305Returns(result1 cipher.AEAD, result2 error) {
+	fake.ChaCha20Poly1305Stub = nil
+	fake.chaCha20Poly1305Returns = struct {
+		result1 cipher.AEAD
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKey) ChaCha20Poly1305ReturnsOnCall(i int, result1 cipher.AEAD, result2 error) {
+	fake.ChaCha20Poly1305Stub = nil
+	if fake.chaCha20Poly1305ReturnsOnCall == nil {
+		fake.chaCha20Poly1305ReturnsOnCall = make(map[int]struct {
+			result1 cipher.AEAD
+			result2 error
+		})
+	}
+	fake.chaCha20Poly1305ReturnsOnCall[i] = struct {
+		result1 cipher.AEAD
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeKey) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -118,6 +178,8 @@ func (fake *FakeKey) Invocations() map[string][][]interface{} {
 	defer fake.labelMutex.RUnlock()
 	fake.blockMutex.RLock()
 	defer fake.blockMutex.RUnlock()
+	fake.chaCha20Poly1305Mutex.RLock()
+	defer fake.chaCha20Poly1305Mutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -5,6 +5,8 @@ import (
 	"crypto/cipher"
 	"crypto/sha256"
 	"errors"
+
+	"golang.org/x/crypto/chacha20poly1305"
 )
 
 //go:generate counterfeiter . Key
@@ -12,11 +14,16 @@ import (
 type Key interface {
 	Label() string
 	Block() cipher.Block
+
+	// ChaCha20Poly1305 returns a ChaCha20-Poly1305 AEAD keyed with the same
+	// key material as Block.
+	ChaCha20Poly1305() (cipher.AEAD, error)
 }
 
 type key struct {
-	block cipher.Block
-	label string
+	block    cipher.Block
+	material []byte
+	label    string
 }
 
 func NewKey(label, phrase string) (Key, error) {
@@ -35,8 +42,9 @@ func NewKey(label, phrase string) (Key, error) {
 	}
 
 	return &key{
-		label: label,
-		block: block,
+		label:    label,
+		block:    block,
+		material: hash[:],
 	}, nil
 }
 
@@ -47,3 +55,7 @@ func (k *key) Label() string {
 func (k *key) Block() cipher.Block {
 	return k.block
 }
+
+func (k *key) ChaCha20Poly1305() (cipher.AEAD, error) {
+	return chacha20poly1305.New(k.material)
+}
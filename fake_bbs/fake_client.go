@@ -185,6 +185,20 @@ type FakeClient struct {
 		result1 []*models.ActualLRPGroup
 		result2 error
 	}
+	ActualLRPGroupsByStateStub        func(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error)
+	actualLRPGroupsByStateMutex       sync.RWMutex
+	actualLRPGroupsByStateArgsForCall []struct {
+		logger lager.Logger
+		state  models.ActualLRPState
+	}
+	actualLRPGroupsByStateReturns struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}
+	actualLRPGroupsByStateReturnsOnCall map[int]struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}
 	ActualLRPGroupByProcessGuidAndIndexStub        func(logger lager.Logger, processGuid string, index int) (*models.ActualLRPGroup, error)
 	actualLRPGroupByProcessGuidAndIndexMutex       sync.RWMutex
 	actualLRPGroupByProcessGuidAndIndexArgsForCall []struct {
@@ -212,6 +226,20 @@ type FakeClient struct {
 	retireActualLRPReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RetireActualLRPsByProcessGuidStub        func(logger lager.Logger, processGuid string) (int, error)
+	retireActualLRPsByProcessGuidMutex       sync.RWMutex
+	retireActualLRPsByProcessGuidArgsForCall []struct {
+		logger      lager.Logger
+		processGuid string
+	}
+	retireActualLRPsByProcessGuidReturns struct {
+		result1 int
+		result2 error
+	}
+	retireActualLRPsByProcessGuidReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	DesiredLRPsStub        func(lager.Logger, models.DesiredLRPFilter) ([]*models.DesiredLRP, error)
 	desiredLRPsMutex       sync.RWMutex
 	desiredLRPsArgsForCall []struct {
@@ -254,6 +282,19 @@ type FakeClient struct {
 		result1 []*models.DesiredLRPSchedulingInfo
 		result2 error
 	}
+	DesiredLRPProcessGuidsStub        func(lager.Logger) ([]string, error)
+	desiredLRPProcessGuidsMutex       sync.RWMutex
+	desiredLRPProcessGuidsArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	desiredLRPProcessGuidsReturns struct {
+		result1 []string
+		result2 error
+	}
+	desiredLRPProcessGuidsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	DesireLRPStub        func(lager.Logger, *models.DesiredLRP) error
 	desireLRPMutex       sync.RWMutex
 	desireLRPArgsForCall []struct {
@@ -266,6 +307,18 @@ type FakeClient struct {
 	desireLRPReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DesireLRPsStub        func(lager.Logger, []*models.DesiredLRP) error
+	desireLRPsMutex       sync.RWMutex
+	desireLRPsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []*models.DesiredLRP
+	}
+	desireLRPsReturns struct {
+		result1 error
+	}
+	desireLRPsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateDesiredLRPStub        func(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) error
 	updateDesiredLRPMutex       sync.RWMutex
 	updateDesiredLRPArgsForCall []struct {
@@ -279,6 +332,19 @@ type FakeClient struct {
 	updateDesiredLRPReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateDesiredLRPRoutesStub        func(logger lager.Logger, processGuid string, routes *models.Routes) error
+	updateDesiredLRPRoutesMutex       sync.RWMutex
+	updateDesiredLRPRoutesArgsForCall []struct {
+		logger      lager.Logger
+		processGuid string
+		routes      *models.Routes
+	}
+	updateDesiredLRPRoutesReturns struct {
+		result1 error
+	}
+	updateDesiredLRPRoutesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemoveDesiredLRPStub        func(logger lager.Logger, processGuid string) error
 	removeDesiredLRPMutex       sync.RWMutex
 	removeDesiredLRPArgsForCall []struct {
@@ -342,6 +408,19 @@ type FakeClient struct {
 	pingReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	ConvergenceReadyStub        func(logger lager.Logger) (bool, error)
+	convergenceReadyMutex       sync.RWMutex
+	convergenceReadyArgsForCall []struct {
+		logger lager.Logger
+	}
+	convergenceReadyReturns struct {
+		result1 bool
+		result2 error
+	}
+	convergenceReadyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	CellsStub        func(logger lager.Logger) ([]*models.CellPresence, error)
 	cellsMutex       sync.RWMutex
 	cellsArgsForCall []struct {
@@ -1021,6 +1100,58 @@ func (fake *FakeClient) ActualLRPGroupsByProcessGuidReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ActualLRPGroupsByState(logger lager.Logger, state models.ActualLRPState) ([]*models.ActualLRPGroup, error) {
+	fake.actualLRPGroupsByStateMutex.Lock()
+	ret, specificReturn := fake.actualLRPGroupsByStateReturnsOnCall[len(fake.actualLRPGroupsByStateArgsForCall)]
+	fake.actualLRPGroupsByStateArgsForCall = append(fake.actualLRPGroupsByStateArgsForCall, struct {
+		logger lager.Logger
+		state  models.ActualLRPState
+	}{logger, state})
+	fake.recordInvocation("ActualLRPGroupsByState", []interface{}{logger, state})
+	fake.actualLRPGroupsByStateMutex.Unlock()
+	if fake.ActualLRPGroupsByStateStub != nil {
+		return fake.ActualLRPGroupsByStateStub(logger, state)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.actualLRPGroupsByStateReturns.result1, fake.actualLRPGroupsByStateReturns.result2
+}
+
+func (fake *FakeClient) ActualLRPGroupsByStateCallCount() int {
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
+	return len(fake.actualLRPGroupsByStateArgsForCall)
+}
+
+func (fake *FakeClient) ActualLRPGroupsByStateArgsForCall(i int) (lager.Logger, models.ActualLRPState) {
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
+	return fake.actualLRPGroupsByStateArgsForCall[i].logger, fake.actualLRPGroupsByStateArgsForCall[i].state
+}
+
+func (fake *FakeClient) ActualLRPGroupsByStateReturns(result1 []*models.ActualLRPGroup, result2 error) {
+	fake.ActualLRPGroupsByStateStub = nil
+	fake.actualLRPGroupsByStateReturns = struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ActualLRPGroupsByStateReturnsOnCall(i int, result1 []*models.ActualLRPGroup, result2 error) {
+	fake.ActualLRPGroupsByStateStub = nil
+	if fake.actualLRPGroupsByStateReturnsOnCall == nil {
+		fake.actualLRPGroupsByStateReturnsOnCall = make(map[int]struct {
+			result1 []*models.ActualLRPGroup
+			result2 error
+		})
+	}
+	fake.actualLRPGroupsByStateReturnsOnCall[i] = struct {
+		result1 []*models.ActualLRPGroup
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, processGuid string, index int) (*models.ActualLRPGroup, error) {
 	fake.actualLRPGroupByProcessGuidAndIndexMutex.Lock()
 	ret, specificReturn := fake.actualLRPGroupByProcessGuidAndIndexReturnsOnCall[len(fake.actualLRPGroupByProcessGuidAndIndexArgsForCall)]
@@ -1123,6 +1254,58 @@ func (fake *FakeClient) RetireActualLRPReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RetireActualLRPsByProcessGuid(logger lager.Logger, processGuid string) (int, error) {
+	fake.retireActualLRPsByProcessGuidMutex.Lock()
+	ret, specificReturn := fake.retireActualLRPsByProcessGuidReturnsOnCall[len(fake.retireActualLRPsByProcessGuidArgsForCall)]
+	fake.retireActualLRPsByProcessGuidArgsForCall = append(fake.retireActualLRPsByProcessGuidArgsForCall, struct {
+		logger      lager.Logger
+		processGuid string
+	}{logger, processGuid})
+	fake.recordInvocation("RetireActualLRPsByProcessGuid", []interface{}{logger, processGuid})
+	fake.retireActualLRPsByProcessGuidMutex.Unlock()
+	if fake.RetireActualLRPsByProcessGuidStub != nil {
+		return fake.RetireActualLRPsByProcessGuidStub(logger, processGuid)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retireActualLRPsByProcessGuidReturns.result1, fake.retireActualLRPsByProcessGuidReturns.result2
+}
+
+func (fake *FakeClient) RetireActualLRPsByProcessGuidCallCount() int {
+	fake.retireActualLRPsByProcessGuidMutex.RLock()
+	defer fake.retireActualLRPsByProcessGuidMutex.RUnlock()
+	return len(fake.retireActualLRPsByProcessGuidArgsForCall)
+}
+
+func (fake *FakeClient) RetireActualLRPsByProcessGuidArgsForCall(i int) (lager.Logger, string) {
+	fake.retireActualLRPsByProcessGuidMutex.RLock()
+	defer fake.retireActualLRPsByProcessGuidMutex.RUnlock()
+	return fake.retireActualLRPsByProcessGuidArgsForCall[i].logger, fake.retireActualLRPsByProcessGuidArgsForCall[i].processGuid
+}
+
+func (fake *FakeClient) RetireActualLRPsByProcessGuidReturns(result1 int, result2 error) {
+	fake.RetireActualLRPsByProcessGuidStub = nil
+	fake.retireActualLRPsByProcessGuidReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RetireActualLRPsByProcessGuidReturnsOnCall(i int, result1 int, result2 error) {
+	fake.RetireActualLRPsByProcessGuidStub = nil
+	if fake.retireActualLRPsByProcessGuidReturnsOnCall == nil {
+		fake.retireActualLRPsByProcessGuidReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.retireActualLRPsByProcessGuidReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) DesiredLRPs(arg1 lager.Logger, arg2 models.DesiredLRPFilter) ([]*models.DesiredLRP, error) {
 	fake.desiredLRPsMutex.Lock()
 	ret, specificReturn := fake.desiredLRPsReturnsOnCall[len(fake.desiredLRPsArgsForCall)]
@@ -1279,6 +1462,57 @@ func (fake *FakeClient) DesiredLRPSchedulingInfosReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeClient) DesiredLRPProcessGuids(arg1 lager.Logger) ([]string, error) {
+	fake.desiredLRPProcessGuidsMutex.Lock()
+	ret, specificReturn := fake.desiredLRPProcessGuidsReturnsOnCall[len(fake.desiredLRPProcessGuidsArgsForCall)]
+	fake.desiredLRPProcessGuidsArgsForCall = append(fake.desiredLRPProcessGuidsArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("DesiredLRPProcessGuids", []interface{}{arg1})
+	fake.desiredLRPProcessGuidsMutex.Unlock()
+	if fake.DesiredLRPProcessGuidsStub != nil {
+		return fake.DesiredLRPProcessGuidsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.desiredLRPProcessGuidsReturns.result1, fake.desiredLRPProcessGuidsReturns.result2
+}
+
+func (fake *FakeClient) DesiredLRPProcessGuidsCallCount() int {
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
+	return len(fake.desiredLRPProcessGuidsArgsForCall)
+}
+
+func (fake *FakeClient) DesiredLRPProcessGuidsArgsForCall(i int) lager.Logger {
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
+	return fake.desiredLRPProcessGuidsArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) DesiredLRPProcessGuidsReturns(result1 []string, result2 error) {
+	fake.DesiredLRPProcessGuidsStub = nil
+	fake.desiredLRPProcessGuidsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DesiredLRPProcessGuidsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.DesiredLRPProcessGuidsStub = nil
+	if fake.desiredLRPProcessGuidsReturnsOnCall == nil {
+		fake.desiredLRPProcessGuidsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.desiredLRPProcessGuidsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) DesireLRP(arg1 lager.Logger, arg2 *models.DesiredLRP) error {
 	fake.desireLRPMutex.Lock()
 	ret, specificReturn := fake.desireLRPReturnsOnCall[len(fake.desireLRPArgsForCall)]
@@ -1328,6 +1562,60 @@ func (fake *FakeClient) DesireLRPReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) DesireLRPs(arg1 lager.Logger, arg2 []*models.DesiredLRP) error {
+	var arg2Copy []*models.DesiredLRP
+	if arg2 != nil {
+		arg2Copy = make([]*models.DesiredLRP, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.desireLRPsMutex.Lock()
+	ret, specificReturn := fake.desireLRPsReturnsOnCall[len(fake.desireLRPsArgsForCall)]
+	fake.desireLRPsArgsForCall = append(fake.desireLRPsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []*models.DesiredLRP
+	}{arg1, arg2Copy})
+	fake.recordInvocation("DesireLRPs", []interface{}{arg1, arg2Copy})
+	fake.desireLRPsMutex.Unlock()
+	if fake.DesireLRPsStub != nil {
+		return fake.DesireLRPsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.desireLRPsReturns.result1
+}
+
+func (fake *FakeClient) DesireLRPsCallCount() int {
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
+	return len(fake.desireLRPsArgsForCall)
+}
+
+func (fake *FakeClient) DesireLRPsArgsForCall(i int) (lager.Logger, []*models.DesiredLRP) {
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
+	return fake.desireLRPsArgsForCall[i].arg1, fake.desireLRPsArgsForCall[i].arg2
+}
+
+func (fake *FakeClient) DesireLRPsReturns(result1 error) {
+	fake.DesireLRPsStub = nil
+	fake.desireLRPsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) DesireLRPsReturnsOnCall(i int, result1 error) {
+	fake.DesireLRPsStub = nil
+	if fake.desireLRPsReturnsOnCall == nil {
+		fake.desireLRPsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.desireLRPsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) UpdateDesiredLRP(logger lager.Logger, processGuid string, update *models.DesiredLRPUpdate) error {
 	fake.updateDesiredLRPMutex.Lock()
 	ret, specificReturn := fake.updateDesiredLRPReturnsOnCall[len(fake.updateDesiredLRPArgsForCall)]
@@ -1378,6 +1666,56 @@ func (fake *FakeClient) UpdateDesiredLRPReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) UpdateDesiredLRPRoutes(logger lager.Logger, processGuid string, routes *models.Routes) error {
+	fake.updateDesiredLRPRoutesMutex.Lock()
+	ret, specificReturn := fake.updateDesiredLRPRoutesReturnsOnCall[len(fake.updateDesiredLRPRoutesArgsForCall)]
+	fake.updateDesiredLRPRoutesArgsForCall = append(fake.updateDesiredLRPRoutesArgsForCall, struct {
+		logger      lager.Logger
+		processGuid string
+		routes      *models.Routes
+	}{logger, processGuid, routes})
+	fake.recordInvocation("UpdateDesiredLRPRoutes", []interface{}{logger, processGuid, routes})
+	fake.updateDesiredLRPRoutesMutex.Unlock()
+	if fake.UpdateDesiredLRPRoutesStub != nil {
+		return fake.UpdateDesiredLRPRoutesStub(logger, processGuid, routes)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateDesiredLRPRoutesReturns.result1
+}
+
+func (fake *FakeClient) UpdateDesiredLRPRoutesCallCount() int {
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
+	return len(fake.updateDesiredLRPRoutesArgsForCall)
+}
+
+func (fake *FakeClient) UpdateDesiredLRPRoutesArgsForCall(i int) (lager.Logger, string, *models.Routes) {
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
+	return fake.updateDesiredLRPRoutesArgsForCall[i].logger, fake.updateDesiredLRPRoutesArgsForCall[i].processGuid, fake.updateDesiredLRPRoutesArgsForCall[i].routes
+}
+
+func (fake *FakeClient) UpdateDesiredLRPRoutesReturns(result1 error) {
+	fake.UpdateDesiredLRPRoutesStub = nil
+	fake.updateDesiredLRPRoutesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) UpdateDesiredLRPRoutesReturnsOnCall(i int, result1 error) {
+	fake.UpdateDesiredLRPRoutesStub = nil
+	if fake.updateDesiredLRPRoutesReturnsOnCall == nil {
+		fake.updateDesiredLRPRoutesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateDesiredLRPRoutesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) RemoveDesiredLRP(logger lager.Logger, processGuid string) error {
 	fake.removeDesiredLRPMutex.Lock()
 	ret, specificReturn := fake.removeDesiredLRPReturnsOnCall[len(fake.removeDesiredLRPArgsForCall)]
@@ -1629,6 +1967,57 @@ func (fake *FakeClient) PingReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeClient) ConvergenceReady(logger lager.Logger) (bool, error) {
+	fake.convergenceReadyMutex.Lock()
+	ret, specificReturn := fake.convergenceReadyReturnsOnCall[len(fake.convergenceReadyArgsForCall)]
+	fake.convergenceReadyArgsForCall = append(fake.convergenceReadyArgsForCall, struct {
+		logger lager.Logger
+	}{logger})
+	fake.recordInvocation("ConvergenceReady", []interface{}{logger})
+	fake.convergenceReadyMutex.Unlock()
+	if fake.ConvergenceReadyStub != nil {
+		return fake.ConvergenceReadyStub(logger)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.convergenceReadyReturns.result1, fake.convergenceReadyReturns.result2
+}
+
+func (fake *FakeClient) ConvergenceReadyCallCount() int {
+	fake.convergenceReadyMutex.RLock()
+	defer fake.convergenceReadyMutex.RUnlock()
+	return len(fake.convergenceReadyArgsForCall)
+}
+
+func (fake *FakeClient) ConvergenceReadyArgsForCall(i int) lager.Logger {
+	fake.convergenceReadyMutex.RLock()
+	defer fake.convergenceReadyMutex.RUnlock()
+	return fake.convergenceReadyArgsForCall[i].logger
+}
+
+func (fake *FakeClient) ConvergenceReadyReturns(result1 bool, result2 error) {
+	fake.ConvergenceReadyStub = nil
+	fake.convergenceReadyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ConvergenceReadyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ConvergenceReadyStub = nil
+	if fake.convergenceReadyReturnsOnCall == nil {
+		fake.convergenceReadyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.convergenceReadyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Cells(logger lager.Logger) ([]*models.CellPresence, error) {
 	fake.cellsMutex.Lock()
 	ret, specificReturn := fake.cellsReturnsOnCall[len(fake.cellsArgsForCall)]
@@ -1709,20 +2098,30 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.actualLRPGroupsMutex.RUnlock()
 	fake.actualLRPGroupsByProcessGuidMutex.RLock()
 	defer fake.actualLRPGroupsByProcessGuidMutex.RUnlock()
+	fake.actualLRPGroupsByStateMutex.RLock()
+	defer fake.actualLRPGroupsByStateMutex.RUnlock()
 	fake.actualLRPGroupByProcessGuidAndIndexMutex.RLock()
 	defer fake.actualLRPGroupByProcessGuidAndIndexMutex.RUnlock()
 	fake.retireActualLRPMutex.RLock()
 	defer fake.retireActualLRPMutex.RUnlock()
+	fake.retireActualLRPsByProcessGuidMutex.RLock()
+	defer fake.retireActualLRPsByProcessGuidMutex.RUnlock()
 	fake.desiredLRPsMutex.RLock()
 	defer fake.desiredLRPsMutex.RUnlock()
 	fake.desiredLRPByProcessGuidMutex.RLock()
 	defer fake.desiredLRPByProcessGuidMutex.RUnlock()
 	fake.desiredLRPSchedulingInfosMutex.RLock()
 	defer fake.desiredLRPSchedulingInfosMutex.RUnlock()
+	fake.desiredLRPProcessGuidsMutex.RLock()
+	defer fake.desiredLRPProcessGuidsMutex.RUnlock()
 	fake.desireLRPMutex.RLock()
 	defer fake.desireLRPMutex.RUnlock()
+	fake.desireLRPsMutex.RLock()
+	defer fake.desireLRPsMutex.RUnlock()
 	fake.updateDesiredLRPMutex.RLock()
 	defer fake.updateDesiredLRPMutex.RUnlock()
+	fake.updateDesiredLRPRoutesMutex.RLock()
+	defer fake.updateDesiredLRPRoutesMutex.RUnlock()
 	fake.removeDesiredLRPMutex.RLock()
 	defer fake.removeDesiredLRPMutex.RUnlock()
 	fake.subscribeToEventsMutex.RLock()
@@ -1733,6 +2132,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.subscribeToEventsByCellIDMutex.RUnlock()
 	fake.pingMutex.RLock()
 	defer fake.pingMutex.RUnlock()
+	fake.convergenceReadyMutex.RLock()
+	defer fake.convergenceReadyMutex.RUnlock()
 	fake.cellsMutex.RLock()
 	defer fake.cellsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -1,8 +1,13 @@
 package format
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
+	"io/ioutil"
+	"sync"
 
 	"code.cloudfoundry.org/bbs/encryption"
 )
@@ -14,66 +19,368 @@ var (
 	UNENCODED        Encoding = [2]byte{'0', '0'}
 	BASE64           Encoding = [2]byte{'0', '1'}
 	BASE64_ENCRYPTED Encoding = [2]byte{'0', '2'}
+
+	// BASE64_COMPRESSED payloads are gzipped before being base64 encoded.
+	// Payloads that do not shrink when compressed are encoded as BASE64
+	// instead.
+	BASE64_COMPRESSED Encoding = [2]byte{'0', '3'}
+
+	// CHACHA20_ENCRYPTED payloads are laid out like BASE64_ENCRYPTED ones, but
+	// are encrypted with ChaCha20-Poly1305 instead of AES-GCM.
+	CHACHA20_ENCRYPTED Encoding = [2]byte{'0', '4'}
 )
 
 const EncodingOffset int = 2
 
+// ErrUnknownKeyLabel is returned by Decode for encrypted payloads whose key
+// label has no decryption key, e.g. when the key was retired before every
+// record encrypted with it was re-encrypted.
+type ErrUnknownKeyLabel struct {
+	Label string
+}
+
+func (e ErrUnknownKeyLabel) Error() string {
+	return fmt.Sprintf("unknown encryption key label %q", e.Label)
+}
+
+// ErrMalformedEncryptedPayload is returned by Decode for encrypted payloads
+// too short to hold the key label, nonce and ciphertext they claim to, e.g.
+// a truncated or corrupt record.
+type ErrMalformedEncryptedPayload struct {
+	Reason string
+}
+
+func (e ErrMalformedEncryptedPayload) Error() string {
+	return "malformed encrypted payload: " + e.Reason
+}
+
+// EncodingCodec encodes and decodes payloads for a registered Encoding. The
+// encoder adds and strips the encoding prefix, so the codec only sees the
+// rest of the payload.
+type EncodingCodec interface {
+	Encode(payload []byte) ([]byte, error)
+	Decode(payload []byte) ([]byte, error)
+}
+
+var (
+	codecsLock sync.RWMutex
+	codecs     = map[Encoding]EncodingCodec{
+		LEGACY_UNENCODED:  unencodedCodec{},
+		UNENCODED:         unencodedCodec{},
+		BASE64:            base64Codec{},
+		BASE64_COMPRESSED: base64CompressedCodec{},
+	}
+)
+
+// RegisterEncoding makes every encoder encode and decode payloads prefixed
+// with the given encoding using the codec, replacing any codec registered for
+// it before. The built-in encodings cannot be replaced. The encoding should not
+// be a prefix that legacy unencoded payloads can start with, as those would
+// then be decoded with the codec.
+func RegisterEncoding(encoding Encoding, codec EncodingCodec) {
+	switch encoding {
+	case LEGACY_UNENCODED, UNENCODED, BASE64, BASE64_ENCRYPTED, BASE64_COMPRESSED, CHACHA20_ENCRYPTED:
+		panic(fmt.Sprintf("cannot replace the built-in encoding %v", encoding))
+	}
+
+	codecsLock.Lock()
+	codecs[encoding] = codec
+	codecsLock.Unlock()
+}
+
+// UnregisterEncoding removes the codec registered for the given encoding, so
+// that payloads with it are unknown again. The built-in encodings cannot be
+// removed.
+func UnregisterEncoding(encoding Encoding) {
+	switch encoding {
+	case LEGACY_UNENCODED, UNENCODED, BASE64, BASE64_ENCRYPTED, BASE64_COMPRESSED, CHACHA20_ENCRYPTED:
+		panic(fmt.Sprintf("cannot remove the built-in encoding %v", encoding))
+	}
+
+	codecsLock.Lock()
+	delete(codecs, encoding)
+	codecsLock.Unlock()
+}
+
+func registeredCodec(encoding Encoding) (EncodingCodec, bool) {
+	codecsLock.RLock()
+	defer codecsLock.RUnlock()
+
+	codec, ok := codecs[encoding]
+	return codec, ok
+}
+
 type encoder struct {
-	cryptor encryption.Cryptor
+	cryptor         encryption.Cryptor
+	chacha20Cryptor encryption.Cryptor
+	encryptedScheme Encoding
 }
 
 type Encoder interface {
 	Encode(encoding Encoding, payload []byte) ([]byte, error)
 	Decode(payload []byte) ([]byte, error)
+
+	// Reencrypt decrypts an encrypted payload with the key it was encrypted
+	// with and encrypts it again with the current encryption key and the
+	// active encrypted scheme. Payloads with any other encoding are returned
+	// unchanged.
+	Reencrypt(payload []byte) ([]byte, error)
 }
 
 func NewEncoder(cryptor encryption.Cryptor) Encoder {
-	return &encoder{cryptor: cryptor}
+	return &encoder{cryptor: cryptor, encryptedScheme: BASE64_ENCRYPTED}
+}
+
+// NewEncoderWithNonceReuseDetection returns an Encoder that reports decrypted
+// payloads whose nonce was already used for another payload to the detector.
+func NewEncoderWithNonceReuseDetection(cryptor encryption.Cryptor, detector *NonceReuseDetector) Encoder {
+	return WithNonceReuseDetection(NewEncoder(cryptor), detector)
+}
+
+// WithNonceReuseDetection wraps the encoder so that, before they are decoded
+// or re-encrypted, encrypted payloads whose nonce was already used for another
+// payload are reported to the detector. Everything else is left to the
+// wrapped encoder.
+func WithNonceReuseDetection(encoder Encoder, detector *NonceReuseDetector) Encoder {
+	return &nonceReuseDetectingEncoder{Encoder: encoder, detector: detector}
+}
+
+type nonceReuseDetectingEncoder struct {
+	Encoder
+	detector *NonceReuseDetector
+}
+
+func (e *nonceReuseDetectingEncoder) Decode(payload []byte) ([]byte, error) {
+	e.observe(payload)
+	return e.Encoder.Decode(payload)
+}
+
+func (e *nonceReuseDetectingEncoder) Reencrypt(payload []byte) ([]byte, error) {
+	e.observe(payload)
+	return e.Encoder.Reencrypt(payload)
+}
+
+// Payloads that cannot be split are not observed; decoding them reports why.
+func (e *nonceReuseDetectingEncoder) observe(payload []byte) {
+	if !isEncrypted(encodingFromPayload(payload)) {
+		return
+	}
+
+	encryptedData, err := decodeBase64(payload[EncodingOffset:])
+	if err != nil {
+		return
+	}
+
+	encrypted, err := splitEncryptedPayload(encryptedData)
+	if err != nil {
+		return
+	}
+	e.detector.observe(encrypted.KeyLabel, encrypted.Nonce, encrypted.CipherText)
+}
+
+// NewEncoderWithEncryptionScheme returns an Encoder that decodes both
+// BASE64_ENCRYPTED payloads, with the AES cryptor, and CHACHA20_ENCRYPTED
+// payloads, with the ChaCha20 cryptor. Payloads encoded with either encrypted
+// encoding are written with the active one, which must be BASE64_ENCRYPTED or
+// CHACHA20_ENCRYPTED. Decrypted payloads of either encoding whose nonce was
+// already used for another payload are reported to the detector, if not nil.
+func NewEncoderWithEncryptionScheme(aesCryptor, chacha20Cryptor encryption.Cryptor, active Encoding, detector *NonceReuseDetector) (Encoder, error) {
+	if active != BASE64_ENCRYPTED && active != CHACHA20_ENCRYPTED {
+		return nil, fmt.Errorf("Not an encrypted encoding: %v", active)
+	}
+
+	var e Encoder = &encoder{
+		cryptor:         aesCryptor,
+		chacha20Cryptor: chacha20Cryptor,
+		encryptedScheme: active,
+	}
+	if detector != nil {
+		e = WithNonceReuseDetection(e, detector)
+	}
+	return e, nil
 }
 
 func (e *encoder) Encode(encoding Encoding, payload []byte) ([]byte, error) {
-	switch encoding {
-	case LEGACY_UNENCODED:
+	if isEncrypted(encoding) {
+		encoding = e.encryptedScheme
+	}
+
+	codec, ok := e.codec(encoding)
+	if !ok {
+		return nil, fmt.Errorf("Unknown encoding: %v", encoding)
+	}
+
+	encoded, err := codec.Encode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if encoding == BASE64_COMPRESSED && len(encoded) >= base64.StdEncoding.EncodedLen(len(payload)) {
+		return e.Encode(BASE64, payload)
+	}
+
+	if encoding == LEGACY_UNENCODED {
+		return encoded, nil
+	}
+	return append(encoding[:], encoded...), nil
+}
+
+func (e *encoder) Decode(payload []byte) ([]byte, error) {
+	encoding := encodingFromPayload(payload)
+	codec, ok := e.codec(encoding)
+	if !ok {
+		return nil, fmt.Errorf("Unknown encoding: %v", encoding)
+	}
+
+	if encoding == LEGACY_UNENCODED {
+		return codec.Decode(payload)
+	}
+	return codec.Decode(payload[EncodingOffset:])
+}
+
+func (e *encoder) Reencrypt(payload []byte) ([]byte, error) {
+	if !isEncrypted(encodingFromPayload(payload)) {
 		return payload, nil
-	case UNENCODED:
-		return append(encoding[:], payload...), nil
-	case BASE64:
-		encoded := encodeBase64(payload)
-		return append(encoding[:], encoded...), nil
+	}
+
+	decoded, err := e.Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+	return e.Encode(e.encryptedScheme, decoded)
+}
+
+// The encrypted encodings need the encoder's cryptors, so they are the
+// encodings that are not in the registry. Encoders built without a ChaCha20
+// cryptor do not know CHACHA20_ENCRYPTED.
+func (e *encoder) codec(encoding Encoding) (EncodingCodec, bool) {
+	switch encoding {
 	case BASE64_ENCRYPTED:
-		encrypted, err := e.encrypt(payload)
-		if err != nil {
-			return nil, err
+		return base64EncryptedCodec{e, e.cryptor}, true
+	case CHACHA20_ENCRYPTED:
+		if e.chacha20Cryptor == nil {
+			return nil, false
 		}
-		encoded := encodeBase64(encrypted)
-		return append(encoding[:], encoded...), nil
-	default:
-		return nil, fmt.Errorf("Unknown encoding: %v", encoding)
+		return base64EncryptedCodec{e, e.chacha20Cryptor}, true
 	}
+	return registeredCodec(encoding)
 }
 
-func (e *encoder) Decode(payload []byte) ([]byte, error) {
+func isEncrypted(encoding Encoding) bool {
+	return encoding == BASE64_ENCRYPTED || encoding == CHACHA20_ENCRYPTED
+}
+
+type unencodedCodec struct{}
+
+func (unencodedCodec) Encode(payload []byte) ([]byte, error) { return payload, nil }
+func (unencodedCodec) Decode(payload []byte) ([]byte, error) { return payload, nil }
+
+type base64Codec struct{}
+
+func (base64Codec) Encode(payload []byte) ([]byte, error) { return encodeBase64(payload), nil }
+func (base64Codec) Decode(payload []byte) ([]byte, error) { return decodeBase64(payload) }
+
+type base64CompressedCodec struct{}
+
+func (base64CompressedCodec) Encode(payload []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write(payload)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return encodeBase64(compressed.Bytes()), nil
+}
+
+func (base64CompressedCodec) Decode(payload []byte) ([]byte, error) {
+	compressed, err := decodeBase64(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
+
+type base64EncryptedCodec struct {
+	encoder *encoder
+	cryptor encryption.Cryptor
+}
+
+func (c base64EncryptedCodec) Encode(payload []byte) ([]byte, error) {
+	encrypted, err := c.encoder.encrypt(c.cryptor, payload)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBase64(encrypted), nil
+}
+
+func (c base64EncryptedCodec) Decode(payload []byte) ([]byte, error) {
+	encrypted, err := decodeBase64(payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.encoder.decrypt(c.cryptor, encrypted)
+}
+
+// The bytes an encrypted payload carries beyond its cleartext, not counting
+// the key label: the label length, the nonce and the AES-GCM or Poly1305 tag,
+// which are both 16 bytes.
+const encryptedPayloadOverhead = 1 + encryption.NonceSize + 16
+
+// EstimatedDecodedSize returns an upper bound on the size Decode would return
+// for the payload, without decoding or decrypting it. The estimate is exact
+// for unencoded payloads; for base64 payloads it may include padding, for
+// encrypted payloads it does not account for the key label, and for compressed
+// payloads it is the size recorded in the gzip trailer.
+func EstimatedDecodedSize(payload []byte) int {
 	encoding := encodingFromPayload(payload)
 	switch encoding {
 	case LEGACY_UNENCODED:
-		return payload, nil
+		return len(payload)
 	case UNENCODED:
-		return payload[EncodingOffset:], nil
+		return len(payload) - EncodingOffset
 	case BASE64:
-		return decodeBase64(payload[EncodingOffset:])
-	case BASE64_ENCRYPTED:
-		encrypted, err := decodeBase64(payload[EncodingOffset:])
-		if err != nil {
-			return nil, err
+		return base64.StdEncoding.DecodedLen(len(payload) - EncodingOffset)
+	case BASE64_ENCRYPTED, CHACHA20_ENCRYPTED:
+		size := base64.StdEncoding.DecodedLen(len(payload)-EncodingOffset) - encryptedPayloadOverhead
+		if size < 0 {
+			return 0
 		}
-		return e.decrypt(encrypted)
+		return size
+	case BASE64_COMPRESSED:
+		return compressedPayloadSize(payload[EncodingOffset:])
 	default:
-		return nil, fmt.Errorf("Unknown encoding: %v", encoding)
+		return len(payload)
 	}
 }
 
-func (e *encoder) encrypt(cleartext []byte) ([]byte, error) {
-	encrypted, err := e.cryptor.Encrypt(cleartext)
+// The last 4 bytes of a gzip stream are the size of the uncompressed data, and
+// the last 8 characters of the padded base64 encoding hold at least the last 4
+// bytes of the stream.
+func compressedPayloadSize(encoded []byte) int {
+	if len(encoded) < 8 {
+		return 0
+	}
+
+	tail, err := decodeBase64(encoded[len(encoded)-8:])
+	if err != nil || len(tail) < 4 {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint32(tail[len(tail)-4:]))
+}
+
+func (e *encoder) encrypt(cryptor encryption.Cryptor, cleartext []byte) ([]byte, error) {
+	encrypted, err := cryptor.Encrypt(cleartext)
 	if err != nil {
 		return nil, err
 	}
@@ -87,21 +394,42 @@ func (e *encoder) encrypt(cleartext []byte) ([]byte, error) {
 	return payload, nil
 }
 
-func (e *encoder) decrypt(encryptedData []byte) ([]byte, error) {
-	labelLength := encryptedData[0]
+func (e *encoder) decrypt(cryptor encryption.Cryptor, encryptedData []byte) ([]byte, error) {
+	encrypted, err := splitEncryptedPayload(encryptedData)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, err := cryptor.Decrypt(encrypted)
+	if _, ok := err.(encryption.KeyNotFoundError); ok {
+		return nil, ErrUnknownKeyLabel{Label: encrypted.KeyLabel}
+	}
+	return decrypted, err
+}
+
+// Splits a decoded encrypted payload into its key label, nonce and ciphertext.
+func splitEncryptedPayload(encryptedData []byte) (encryption.Encrypted, error) {
+	if len(encryptedData) < 1 {
+		return encryption.Encrypted{}, ErrMalformedEncryptedPayload{Reason: "missing key label length"}
+	}
+	labelLength := int(encryptedData[0])
 	encryptedData = encryptedData[1:]
 
+	if len(encryptedData) < labelLength {
+		return encryption.Encrypted{}, ErrMalformedEncryptedPayload{Reason: fmt.Sprintf("key label length %d exceeds the remaining %d bytes", labelLength, len(encryptedData))}
+	}
 	label := string(encryptedData[:labelLength])
 	encryptedData = encryptedData[labelLength:]
 
-	nonce := encryptedData[:encryption.NonceSize]
-	ciphertext := encryptedData[encryption.NonceSize:]
+	if len(encryptedData) < encryption.NonceSize {
+		return encryption.Encrypted{}, ErrMalformedEncryptedPayload{Reason: fmt.Sprintf("nonce needs %d bytes but only %d remain", encryption.NonceSize, len(encryptedData))}
+	}
 
-	return e.cryptor.Decrypt(encryption.Encrypted{
+	return encryption.Encrypted{
 		KeyLabel:   label,
-		Nonce:      nonce,
-		CipherText: ciphertext,
-	})
+		Nonce:      encryptedData[:encryption.NonceSize],
+		CipherText: encryptedData[encryption.NonceSize:],
+	}, nil
 }
 
 func encodeBase64(unencodedPayload []byte) []byte {
@@ -118,6 +446,11 @@ func decodeBase64(encodedPayload []byte) ([]byte, error) {
 	return decodedPayload[:n], err
 }
 
+// PayloadEncoding returns the encoding the payload was encoded with.
+func PayloadEncoding(payload []byte) Encoding {
+	return encodingFromPayload(payload)
+}
+
 func encodingFromPayload(payload []byte) Encoding {
 	if !isEncoded(payload) {
 		return LEGACY_UNENCODED
@@ -125,14 +458,21 @@ func encodingFromPayload(payload []byte) Encoding {
 	return Encoding{payload[0], payload[1]}
 }
 
+// A legacy unencoded protobuf can start with an ASCII digit, so a payload is
+// only treated as encoded when its whole prefix is a known encoding; anything
+// else is LEGACY_UNENCODED.
 func isEncoded(payload []byte) bool {
 	if len(payload) < EncodingOffset {
 		return false
 	}
 
-	if payload[0] < '0' || payload[0] > '9' {
+	encoding := Encoding{payload[0], payload[1]}
+	if encoding == LEGACY_UNENCODED {
 		return false
 	}
-
-	return true
+	if isEncrypted(encoding) {
+		return true
+	}
+	_, ok := registeredCodec(encoding)
+	return ok
 }
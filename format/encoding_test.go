@@ -1,6 +1,7 @@
 package format_test
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"io"
@@ -8,9 +9,12 @@ import (
 	"code.cloudfoundry.org/bbs/encryption"
 	"code.cloudfoundry.org/bbs/encryption/encryptionfakes"
 	"code.cloudfoundry.org/bbs/format"
+	mfakes "code.cloudfoundry.org/go-loggregator/testhelpers/fakes/v1"
+	"code.cloudfoundry.org/lager/lagertest"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("Encoding", func() {
@@ -115,6 +119,40 @@ var _ = Describe("Encoding", func() {
 			})
 		})
 
+		Describe("BASE64_COMPRESSED", func() {
+			It("compresses payloads that shrink and round-trips them", func() {
+				payload := bytes.Repeat([]byte("some-highly-compressible-payload"), 1000)
+				encoded, err := encoder.Encode(format.BASE64_COMPRESSED, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(format.PayloadEncoding(encoded)).To(Equal(format.BASE64_COMPRESSED))
+				Expect(len(encoded)).To(BeNumerically("<", len(payload)/10))
+
+				decoded, err := encoder.Decode(encoded)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(Equal(payload))
+			})
+
+			It("falls back to BASE64 for payloads that do not shrink and round-trips them", func() {
+				payload := []byte("tiny")
+				encoded, err := encoder.Encode(format.BASE64_COMPRESSED, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(format.PayloadEncoding(encoded)).To(Equal(format.BASE64))
+				Expect(encoded).To(Equal(append([]byte("01"), []byte(base64.StdEncoding.EncodeToString(payload))...)))
+
+				decoded, err := encoder.Decode(encoded)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(Equal(payload))
+			})
+
+			It("returns an error if the payload is not valid gzip", func() {
+				payload := append([]byte("03"), []byte(base64.StdEncoding.EncodeToString([]byte("not gzip")))...)
+				_, err := encoder.Decode(payload)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
 		Describe("unkown encoding", func() {
 			It("fails with an unknown encoding error", func() {
 				payload := []byte("some-payload")
@@ -180,15 +218,440 @@ var _ = Describe("Encoding", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(decoded).To(Equal(payload))
 			})
+
+			Context("when the payload was encrypted with a key the cryptor does not have", func() {
+				It("returns an unknown key label error carrying the label", func() {
+					retiredKey, err := encryption.NewKey("retired-label", "some other pass phrase")
+					Expect(err).NotTo(HaveOccurred())
+					retiredKeyManager, err := encryption.NewKeyManager(retiredKey, nil)
+					Expect(err).NotTo(HaveOccurred())
+					retiredEncoder := format.NewEncoder(encryption.NewCryptor(retiredKeyManager, prng))
+
+					encoded, err := retiredEncoder.Encode(format.BASE64_ENCRYPTED, []byte("payload"))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = encoder.Decode(encoded)
+					Expect(err).To(Equal(format.ErrUnknownKeyLabel{Label: "retired-label"}))
+				})
+			})
+
+			Context("when the payload is malformed", func() {
+				encode := func(encrypted []byte) []byte {
+					return append(format.BASE64_ENCRYPTED[:], []byte(base64.StdEncoding.EncodeToString(encrypted))...)
+				}
+
+				It("returns an error for an empty payload", func() {
+					_, err := encoder.Decode(encode([]byte{}))
+					Expect(err).To(BeAssignableToTypeOf(format.ErrMalformedEncryptedPayload{}))
+				})
+
+				It("returns an error for a payload holding only the label length", func() {
+					_, err := encoder.Decode(encode([]byte{5}))
+					Expect(err).To(BeAssignableToTypeOf(format.ErrMalformedEncryptedPayload{}))
+				})
+
+				It("returns an error when the label length exceeds the remaining bytes", func() {
+					_, err := encoder.Decode(encode([]byte{200, 'l', 'a', 'b', 'e', 'l'}))
+					Expect(err).To(BeAssignableToTypeOf(format.ErrMalformedEncryptedPayload{}))
+					Expect(err.Error()).To(ContainSubstring("key label length 200"))
+				})
+
+				It("returns an error when the nonce is truncated", func() {
+					_, err := encoder.Decode(encode([]byte{5, 'l', 'a', 'b', 'e', 'l', 1, 2, 3}))
+					Expect(err).To(BeAssignableToTypeOf(format.ErrMalformedEncryptedPayload{}))
+				})
+			})
 		})
 
 		Describe("unkown encoding", func() {
-			It("fails with an unknown encoding error", func() {
+			It("treats the payload as LEGACY_UNENCODED", func() {
 				payload := []byte("99some-payload")
-				_, err := encoder.Decode(payload)
+				decoded, err := encoder.Decode(payload)
 
-				Expect(err).To(HaveOccurred())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(Equal(payload))
+			})
+		})
+
+		Context("when a legacy protobuf payload starts with a digit", func() {
+			It("returns the payload back", func() {
+				// field 6, wire type 2 (0x32 is '2'), length 3 (0x03)
+				payload := []byte{'2', 0x03, 'a', 'b', 'c'}
+				Expect(format.PayloadEncoding(payload)).To(Equal(format.LEGACY_UNENCODED))
+
+				decoded, err := encoder.Decode(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(Equal(payload))
+			})
+		})
+	})
+
+	Describe("Reencrypt", func() {
+		var oldKey, newKey encryption.Key
+
+		keyLabel := func(encoded []byte) string {
+			encrypted, err := base64.StdEncoding.DecodeString(string(encoded[format.EncodingOffset:]))
+			Expect(err).NotTo(HaveOccurred())
+			return string(encrypted[1 : 1+int(encrypted[0])])
+		}
+
+		BeforeEach(func() {
+			var err error
+			oldKey, err = encryption.NewKey("old-label", "old pass phrase")
+			Expect(err).NotTo(HaveOccurred())
+			newKey, err = encryption.NewKey("new-label", "new pass phrase")
+			Expect(err).NotTo(HaveOccurred())
+
+			keyManager, err := encryption.NewKeyManager(newKey, []encryption.Key{oldKey})
+			Expect(err).NotTo(HaveOccurred())
+			cryptor = encryption.NewCryptor(keyManager, prng)
+		})
+
+		It("re-encrypts encrypted payloads with the current encryption key", func() {
+			oldKeyManager, err := encryption.NewKeyManager(oldKey, nil)
+			Expect(err).NotTo(HaveOccurred())
+			oldEncoder := format.NewEncoder(encryption.NewCryptor(oldKeyManager, prng))
+
+			payload := []byte("some-payload")
+			encoded, err := oldEncoder.Encode(format.BASE64_ENCRYPTED, payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyLabel(encoded)).To(Equal("old-label"))
+
+			reencrypted, err := encoder.Reencrypt(encoded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.PayloadEncoding(reencrypted)).To(Equal(format.BASE64_ENCRYPTED))
+			Expect(keyLabel(reencrypted)).To(Equal("new-label"))
+
+			newKeyManager, err := encryption.NewKeyManager(newKey, nil)
+			Expect(err).NotTo(HaveOccurred())
+			decoded, err := format.NewEncoder(encryption.NewCryptor(newKeyManager, prng)).Decode(reencrypted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(Equal(payload))
+		})
+
+		It("returns payloads that are not encrypted unchanged", func() {
+			encoded := append([]byte("00"), []byte("some-payload")...)
+			reencrypted, err := encoder.Reencrypt(encoded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reencrypted).To(Equal(encoded))
+		})
+
+		Context("when the payload was encrypted with an unknown key", func() {
+			It("returns an unknown key label error", func() {
+				retiredKey, err := encryption.NewKey("retired-label", "retired pass phrase")
+				Expect(err).NotTo(HaveOccurred())
+				retiredKeyManager, err := encryption.NewKeyManager(retiredKey, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				encoded, err := format.NewEncoder(encryption.NewCryptor(retiredKeyManager, prng)).Encode(format.BASE64_ENCRYPTED, []byte("some-payload"))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = encoder.Reencrypt(encoded)
+				Expect(err).To(Equal(format.ErrUnknownKeyLabel{Label: "retired-label"}))
+			})
+		})
+	})
+
+	Describe("CHACHA20_ENCRYPTED", func() {
+		var (
+			chacha20Cryptor encryption.Cryptor
+			aesEncoder      format.Encoder
+			chacha20Encoder format.Encoder
+		)
+
+		BeforeEach(func() {
+			key, err := encryption.NewKey("label", "some pass phrase")
+			Expect(err).NotTo(HaveOccurred())
+			keyManager, err := encryption.NewKeyManager(key, nil)
+			Expect(err).NotTo(HaveOccurred())
+			chacha20Cryptor = encryption.NewChaCha20Cryptor(keyManager, prng)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			aesEncoder, err = format.NewEncoderWithEncryptionScheme(cryptor, chacha20Cryptor, format.BASE64_ENCRYPTED, nil)
+			Expect(err).NotTo(HaveOccurred())
+			chacha20Encoder, err = format.NewEncoderWithEncryptionScheme(cryptor, chacha20Cryptor, format.CHACHA20_ENCRYPTED, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("writes encrypted payloads with the active scheme", func() {
+			for _, encoding := range []format.Encoding{format.BASE64_ENCRYPTED, format.CHACHA20_ENCRYPTED} {
+				encoded, err := aesEncoder.Encode(encoding, []byte("some-payload"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(format.PayloadEncoding(encoded)).To(Equal(format.BASE64_ENCRYPTED))
+
+				encoded, err = chacha20Encoder.Encode(encoding, []byte("some-payload"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(format.PayloadEncoding(encoded)).To(Equal(format.CHACHA20_ENCRYPTED))
+			}
+		})
+
+		It("decodes payloads of both encrypted schemes in the same store, whichever is active", func() {
+			store := map[string][]byte{}
+
+			var err error
+			store["aes"], err = aesEncoder.Encode(format.BASE64_ENCRYPTED, []byte("aes-payload"))
+			Expect(err).NotTo(HaveOccurred())
+			store["chacha20"], err = chacha20Encoder.Encode(format.CHACHA20_ENCRYPTED, []byte("chacha20-payload"))
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, encoder := range []format.Encoder{aesEncoder, chacha20Encoder} {
+				decoded, err := encoder.Decode(store["aes"])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(Equal([]byte("aes-payload")))
+
+				decoded, err = encoder.Decode(store["chacha20"])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(Equal([]byte("chacha20-payload")))
+			}
+		})
+
+		It("re-encrypts AES payloads with the active scheme", func() {
+			encoded, err := aesEncoder.Encode(format.BASE64_ENCRYPTED, []byte("some-payload"))
+			Expect(err).NotTo(HaveOccurred())
+
+			reencrypted, err := chacha20Encoder.Reencrypt(encoded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.PayloadEncoding(reencrypted)).To(Equal(format.CHACHA20_ENCRYPTED))
+
+			decoded, err := aesEncoder.Decode(reencrypted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(Equal([]byte("some-payload")))
+		})
+
+		It("is not decoded by encoders without a ChaCha20 cryptor", func() {
+			encoded, err := chacha20Encoder.Encode(format.CHACHA20_ENCRYPTED, []byte("some-payload"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = encoder.Decode(encoded)
+			Expect(err).To(MatchError(ContainSubstring("Unknown encoding")))
+		})
+
+		It("does not accept an unencrypted active scheme", func() {
+			_, err := format.NewEncoderWithEncryptionScheme(cryptor, chacha20Cryptor, format.BASE64, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("RegisterEncoding", func() {
+		reversed := format.Encoding([2]byte{'9', '8'})
+
+		BeforeEach(func() {
+			format.RegisterEncoding(reversed, reversingCodec{})
+		})
+
+		AfterEach(func() {
+			format.UnregisterEncoding(reversed)
+		})
+
+		It("encodes payloads with the registered codec and an encoding type prefix", func() {
+			encoded, err := encoder.Encode(reversed, []byte("some-payload"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded).To(Equal([]byte("98daolyap-emos")))
+		})
+
+		It("round-trips payloads through the registered codec", func() {
+			payload := []byte("some-payload")
+			encoded, err := encoder.Encode(reversed, payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.PayloadEncoding(encoded)).To(Equal(reversed))
+
+			decoded, err := encoder.Decode(encoded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(Equal(payload))
+		})
+
+		It("does not allow replacing the built-in encodings", func() {
+			Expect(func() { format.RegisterEncoding(format.BASE64, reversingCodec{}) }).To(Panic())
+			Expect(func() { format.RegisterEncoding(format.BASE64_ENCRYPTED, reversingCodec{}) }).To(Panic())
+			Expect(func() { format.RegisterEncoding(format.CHACHA20_ENCRYPTED, reversingCodec{}) }).To(Panic())
+		})
+
+		It("stops encoding payloads with an unregistered codec", func() {
+			format.UnregisterEncoding(reversed)
+
+			_, err := encoder.Encode(reversed, []byte("some-payload"))
+			Expect(err).To(MatchError(ContainSubstring("Unknown encoding")))
+			Expect(func() { format.UnregisterEncoding(format.BASE64) }).To(Panic())
+		})
+	})
+
+	Describe("nonce reuse detection", func() {
+		var (
+			logger           *lagertest.TestLogger
+			fakeMetronClient *mfakes.FakeIngressClient
+			detector         *format.NonceReuseDetector
+		)
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("test")
+			fakeMetronClient = new(mfakes.FakeIngressClient)
+		})
+
+		JustBeforeEach(func() {
+			detector = format.NewNonceReuseDetector(logger, fakeMetronClient, 10)
+			encoder = format.NewEncoderWithNonceReuseDetection(cryptor, detector)
+		})
+
+		It("counts payloads that reuse the nonce of another payload", func() {
+			// the zero reader makes the cryptor use the same nonce every time
+			first, err := encoder.Encode(format.BASE64_ENCRYPTED, []byte("first payload"))
+			Expect(err).NotTo(HaveOccurred())
+			second, err := encoder.Encode(format.BASE64_ENCRYPTED, []byte("second payload"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = encoder.Decode(first)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(0))
+
+			_, err = encoder.Decode(second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+			Expect(fakeMetronClient.IncrementCounterArgsForCall(0)).To(Equal("EncryptionNonceReuse"))
+			Expect(logger).To(gbytes.Say("nonce-reused"))
+		})
+
+		It("does not count decrypting the same payload again", func() {
+			payload, err := encoder.Encode(format.BASE64_ENCRYPTED, []byte("payload"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = encoder.Decode(payload)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = encoder.Decode(payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(0))
+		})
+
+		It("counts CHACHA20_ENCRYPTED payloads that reuse the nonce of another payload", func() {
+			key, err := encryption.NewKey("label", "some pass phrase")
+			Expect(err).NotTo(HaveOccurred())
+			keyManager, err := encryption.NewKeyManager(key, nil)
+			Expect(err).NotTo(HaveOccurred())
+			chacha20Cryptor := encryption.NewChaCha20Cryptor(keyManager, prng)
+
+			encoder, err = format.NewEncoderWithEncryptionScheme(cryptor, chacha20Cryptor, format.CHACHA20_ENCRYPTED, detector)
+			Expect(err).NotTo(HaveOccurred())
+
+			first, err := encoder.Encode(format.CHACHA20_ENCRYPTED, []byte("first payload"))
+			Expect(err).NotTo(HaveOccurred())
+			second, err := encoder.Encode(format.CHACHA20_ENCRYPTED, []byte("second payload"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = encoder.Decode(first)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = encoder.Decode(second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+			Expect(fakeMetronClient.IncrementCounterArgsForCall(0)).To(Equal("EncryptionNonceReuse"))
+		})
+	})
+
+	Describe("WithNonceReuseDetection", func() {
+		var (
+			fakeMetronClient *mfakes.FakeIngressClient
+			chacha20Cryptor  encryption.Cryptor
+		)
+
+		BeforeEach(func() {
+			fakeMetronClient = new(mfakes.FakeIngressClient)
+
+			key, err := encryption.NewKey("label", "some pass phrase")
+			Expect(err).NotTo(HaveOccurred())
+			keyManager, err := encryption.NewKeyManager(key, nil)
+			Expect(err).NotTo(HaveOccurred())
+			chacha20Cryptor = encryption.NewChaCha20Cryptor(keyManager, prng)
+
+			schemeEncoder, err := format.NewEncoderWithEncryptionScheme(cryptor, chacha20Cryptor, format.CHACHA20_ENCRYPTED, nil)
+			Expect(err).NotTo(HaveOccurred())
+			detector := format.NewNonceReuseDetector(lagertest.NewTestLogger("test"), fakeMetronClient, 10)
+			encoder = format.WithNonceReuseDetection(schemeEncoder, detector)
+		})
+
+		It("keeps the encryption scheme of the wrapped encoder", func() {
+			encoded, err := encoder.Encode(format.BASE64_ENCRYPTED, []byte("payload"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.PayloadEncoding(encoded)).To(Equal(format.CHACHA20_ENCRYPTED))
+
+			decoded, err := encoder.Decode(encoded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(Equal([]byte("payload")))
+		})
+
+		It("counts payloads that reuse the nonce of another payload", func() {
+			// the zero reader makes the cryptor use the same nonce every time
+			first, err := encoder.Encode(format.CHACHA20_ENCRYPTED, []byte("first payload"))
+			Expect(err).NotTo(HaveOccurred())
+			second, err := encoder.Encode(format.CHACHA20_ENCRYPTED, []byte("second payload"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = encoder.Decode(first)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = encoder.Reencrypt(second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+		})
+
+		It("does not observe unencrypted or malformed payloads", func() {
+			encoded, err := encoder.Encode(format.BASE64, []byte("payload"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = encoder.Decode(encoded)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = encoder.Decode([]byte("04AA"))
+			Expect(err).To(BeAssignableToTypeOf(format.ErrMalformedEncryptedPayload{}))
+
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("EstimatedDecodedSize", func() {
+		var payload []byte
+
+		BeforeEach(func() {
+			payload = []byte("some-payload-that-is-not-a-multiple-of-three")
+		})
+
+		for _, e := range []struct {
+			name     string
+			encoding format.Encoding
+		}{
+			{"LEGACY_UNENCODED", format.LEGACY_UNENCODED},
+			{"UNENCODED", format.UNENCODED},
+			{"BASE64", format.BASE64},
+			{"BASE64_ENCRYPTED", format.BASE64_ENCRYPTED},
+		} {
+			e := e
+			It("is an upper bound on the decoded size of "+e.name+" payloads", func() {
+				encoded, err := encoder.Encode(e.encoding, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				decoded, err := encoder.Decode(encoded)
+				Expect(err).NotTo(HaveOccurred())
+
+				estimate := format.EstimatedDecodedSize(encoded)
+				Expect(estimate).To(BeNumerically(">=", len(decoded)))
+				Expect(estimate).To(BeNumerically("<=", len(decoded)+len("label")+2))
 			})
+		}
+
+		It("is exact for unencoded payloads", func() {
+			encoded, err := encoder.Encode(format.UNENCODED, payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.EstimatedDecodedSize(encoded)).To(Equal(len(payload)))
+		})
+
+		It("is exact for compressed payloads", func() {
+			payload = bytes.Repeat(payload, 100)
+			encoded, err := encoder.Encode(format.BASE64_COMPRESSED, payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format.PayloadEncoding(encoded)).To(Equal(format.BASE64_COMPRESSED))
+			Expect(format.EstimatedDecodedSize(encoded)).To(Equal(len(payload)))
+		})
+
+		It("does not return a negative size for truncated encrypted payloads", func() {
+			Expect(format.EstimatedDecodedSize([]byte("02AA"))).To(Equal(0))
 		})
 	})
 })
@@ -201,3 +664,16 @@ func (zr *zeroReader) Read(target []byte) (int, error) {
 	}
 	return len(target), nil
 }
+
+type reversingCodec struct{}
+
+func (reversingCodec) Encode(payload []byte) ([]byte, error) { return reverse(payload), nil }
+func (reversingCodec) Decode(payload []byte) ([]byte, error) { return reverse(payload), nil }
+
+func reverse(payload []byte) []byte {
+	reversed := make([]byte, len(payload))
+	for i, b := range payload {
+		reversed[len(payload)-1-i] = b
+	}
+	return reversed
+}
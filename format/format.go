@@ -1,6 +1,8 @@
 package format
 
 import (
+	"reflect"
+
 	"code.cloudfoundry.org/bbs/encryption"
 	"code.cloudfoundry.org/lager"
 )
@@ -17,8 +19,14 @@ var (
 	ENCRYPTED_PROTO   *Format = NewFormat(BASE64_ENCRYPTED, PROTO)
 )
 
+// EncodingPolicy maps a model type name (e.g. "TaskDefinition",
+// "ActualLRPNetInfo") to the encoding used when writing models of that type.
+// Models without an entry use the encoding of the requested Format.
+type EncodingPolicy map[string]Encoding
+
 type serializer struct {
 	encoder Encoder
+	policy  EncodingPolicy
 }
 
 type Serializer interface {
@@ -32,17 +40,34 @@ func NewSerializer(cryptor encryption.Cryptor) Serializer {
 	}
 }
 
+func NewSerializerWithEncodingPolicy(encoder Encoder, policy EncodingPolicy) Serializer {
+	return &serializer{
+		encoder: encoder,
+		policy:  policy,
+	}
+}
+
+func NewSerializerWithEncoder(encoder Encoder) Serializer {
+	return &serializer{
+		encoder: encoder,
+	}
+}
+
 func NewFormat(encoding Encoding, format EnvelopeFormat) *Format {
 	return &Format{encoding, format}
 }
 
 func (s *serializer) Marshal(logger lager.Logger, format *Format, model Versioner) ([]byte, error) {
+	if normalizer, ok := model.(Normalizer); ok {
+		model = normalizer.Normalized()
+	}
+
 	envelopedPayload, err := MarshalEnvelope(format.EnvelopeFormat, model)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.encoder.Encode(format.Encoding, envelopedPayload)
+	return s.encoder.Encode(s.encodingFor(format, model), envelopedPayload)
 }
 
 func (s *serializer) Unmarshal(logger lager.Logger, encodedPayload []byte, model Versioner) error {
@@ -52,3 +77,22 @@ func (s *serializer) Unmarshal(logger lager.Logger, encodedPayload []byte, model
 	}
 	return UnmarshalEnvelope(logger, unencodedPayload, model)
 }
+
+func (s *serializer) encodingFor(format *Format, model Versioner) Encoding {
+	if format.Encoding == LEGACY_UNENCODED || len(s.policy) == 0 {
+		return format.Encoding
+	}
+
+	if encoding, ok := s.policy[modelTypeName(model)]; ok {
+		return encoding
+	}
+	return format.Encoding
+}
+
+func modelTypeName(model Versioner) string {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
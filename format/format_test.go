@@ -106,6 +106,105 @@ var _ = Describe("Format", func() {
 		})
 	})
 
+	Describe("EncodingPolicy", func() {
+		var desiredLRP *models.DesiredLRP
+
+		BeforeEach(func() {
+			desiredLRP = model_helpers.NewValidDesiredLRP("some-guid")
+			serializer = format.NewSerializerWithEncodingPolicy(format.NewEncoder(cryptor), format.EncodingPolicy{
+				"Task": format.UNENCODED,
+			})
+		})
+
+		It("writes models without a policy entry using the format's encoding", func() {
+			encoded, err := serializer.Marshal(logger, format.ENCRYPTED_PROTO, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded[:format.EncodingOffset]).To(BeEquivalentTo(format.BASE64_ENCRYPTED[:]))
+			Expect(cryptor.EncryptCallCount()).To(Equal(1))
+		})
+
+		It("writes models with a policy entry using the configured encoding", func() {
+			encoded, err := serializer.Marshal(logger, format.ENCRYPTED_PROTO, task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded[:format.EncodingOffset]).To(BeEquivalentTo(format.UNENCODED[:]))
+			Expect(cryptor.EncryptCallCount()).To(Equal(0))
+		})
+
+		It("still decodes payloads regardless of how they were written", func() {
+			encodedTask, err := serializer.Marshal(logger, format.ENCRYPTED_PROTO, task)
+			Expect(err).NotTo(HaveOccurred())
+			encodedDesiredLRP, err := serializer.Marshal(logger, format.ENCRYPTED_PROTO, desiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+
+			var decodedTask models.Task
+			err = serializer.Unmarshal(logger, encodedTask, &decodedTask)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decodedTask).To(Equal(*task))
+
+			var decodedDesiredLRP models.DesiredLRP
+			err = serializer.Unmarshal(logger, encodedDesiredLRP, &decodedDesiredLRP)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decodedDesiredLRP).To(Equal(*desiredLRP))
+		})
+
+		It("does not apply the policy to legacy formatting", func() {
+			jsonEncodedTask, err := json.Marshal(task)
+			Expect(err).NotTo(HaveOccurred())
+
+			encoded, err := serializer.Marshal(logger, format.LEGACY_FORMATTING, task)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded).To(MatchJSON(jsonEncodedTask))
+		})
+	})
+
+	Describe("normalization", func() {
+		var withNilRoutes, withEmptyRoutes *models.DesiredLRP
+
+		BeforeEach(func() {
+			withNilRoutes = model_helpers.NewValidDesiredLRP("some-guid")
+			withNilRoutes.Routes = nil
+			withEmptyRoutes = model_helpers.NewValidDesiredLRP("some-guid")
+			withEmptyRoutes.Routes = &models.Routes{}
+		})
+
+		for _, f := range []struct {
+			name   string
+			format *format.Format
+		}{
+			{"FORMATTED_JSON", format.FORMATTED_JSON},
+			{"ENCODED_PROTO", format.ENCODED_PROTO},
+		} {
+			f := f
+			It("marshals nil and empty routes to identical "+f.name+" bytes", func() {
+				nilEncoded, err := serializer.Marshal(logger, f.format, withNilRoutes)
+				Expect(err).NotTo(HaveOccurred())
+				emptyEncoded, err := serializer.Marshal(logger, f.format, withEmptyRoutes)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(emptyEncoded).To(Equal(nilEncoded))
+			})
+		}
+
+		It("marshals nil and empty scheduling info routes to identical bytes", func() {
+			schedulingInfo := withNilRoutes.DesiredLRPSchedulingInfo()
+			schedulingInfo.Routes = nil
+			nilEncoded, err := serializer.Marshal(logger, format.FORMATTED_JSON, &schedulingInfo)
+			Expect(err).NotTo(HaveOccurred())
+
+			schedulingInfo.Routes = models.Routes{}
+			emptyEncoded, err := serializer.Marshal(logger, format.FORMATTED_JSON, &schedulingInfo)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(emptyEncoded).To(Equal(nilEncoded))
+		})
+
+		It("does not modify the marshalled model", func() {
+			_, err := serializer.Marshal(logger, format.ENCODED_PROTO, withEmptyRoutes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(withEmptyRoutes.Routes).To(Equal(&models.Routes{}))
+		})
+	})
+
 	Describe("Unmarshal", func() {
 		Describe("LEGACY_FORMATTING", func() {
 			It("unmarshals the JSON data as-is without an envelope", func() {
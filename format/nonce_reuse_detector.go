@@ -0,0 +1,65 @@
+package format
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	loggregator_v2 "code.cloudfoundry.org/go-loggregator/compatibility"
+	"code.cloudfoundry.org/lager"
+)
+
+const nonceReuseCounter = "EncryptionNonceReuse"
+
+var errNonceReused = errors.New("nonce reused for a different payload")
+
+// NonceReuseDetector remembers the nonces of the most recently decrypted
+// payloads and reports when a nonce is seen again for a different ciphertext
+// under the same key, which indicates a misbehaving cryptor. Decrypting the
+// same payload repeatedly is not reported.
+type NonceReuseDetector struct {
+	logger       lager.Logger
+	metronClient loggregator_v2.IngressClient
+	size         int
+
+	lock   sync.Mutex
+	seen   map[string][sha256.Size]byte
+	oldest []string
+}
+
+func NewNonceReuseDetector(logger lager.Logger, metronClient loggregator_v2.IngressClient, size int) *NonceReuseDetector {
+	return &NonceReuseDetector{
+		logger:       logger.Session("nonce-reuse-detector"),
+		metronClient: metronClient,
+		size:         size,
+		seen:         map[string][sha256.Size]byte{},
+	}
+}
+
+func (d *NonceReuseDetector) observe(keyLabel string, nonce, ciphertext []byte) {
+	if d.size <= 0 {
+		return
+	}
+
+	key := keyLabel + ":" + hex.EncodeToString(nonce)
+	digest := sha256.Sum256(ciphertext)
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if seenDigest, ok := d.seen[key]; ok {
+		if seenDigest != digest {
+			d.logger.Error("nonce-reused", errNonceReused, lager.Data{"key_label": keyLabel})
+			d.metronClient.IncrementCounter(nonceReuseCounter)
+		}
+		return
+	}
+
+	if len(d.oldest) >= d.size {
+		delete(d.seen, d.oldest[0])
+		d.oldest = d.oldest[1:]
+	}
+	d.seen[key] = digest
+	d.oldest = append(d.oldest, key)
+}
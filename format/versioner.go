@@ -18,6 +18,14 @@ type Versioner interface {
 	Validate() error
 }
 
+// Normalizer is implemented by models with several representations of the same
+// value, such as nil and empty collections, that would serialize differently.
+// The serializer marshals the normalized model instead, so that equal models
+// always serialize to the same bytes.
+type Normalizer interface {
+	Normalized() Versioner
+}
+
 //go:generate counterfeiter . ProtoVersioner
 type ProtoVersioner interface {
 	proto.Message
@@ -57,6 +57,24 @@ func (h *ActualLRPHandler) ActualLRPGroupsByProcessGuid(logger lager.Logger, w h
 	exitIfUnrecoverable(logger, h.exitChan, response.Error)
 }
 
+func (h *ActualLRPHandler) ActualLRPGroupsByState(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger = logger.Session("actual-lrp-groups-by-state")
+
+	request := &models.ActualLRPGroupsByStateRequest{}
+	response := &models.ActualLRPGroupsResponse{}
+
+	err = parseRequest(logger, req, request)
+	if err == nil {
+		response.ActualLrpGroups, err = h.db.ActualLRPGroupsByState(logger, models.ActualLRPState(request.State))
+	}
+
+	response.Error = models.ConvertError(err)
+
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
+
 func (h *ActualLRPHandler) ActualLRPGroupByProcessGuidAndIndex(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
 	var err error
 	logger = logger.Session("actual-lrp-group-by-process-guid-and-index")
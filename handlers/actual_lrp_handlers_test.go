@@ -311,6 +311,92 @@ var _ = Describe("ActualLRP Handlers", func() {
 		})
 	})
 
+	Describe("ActualLRPGroupsByState", func() {
+		var requestBody interface{}
+
+		BeforeEach(func() {
+			requestBody = &models.ActualLRPGroupsByStateRequest{
+				State: models.ActualLRPStateCrashed,
+			}
+
+			actualLRP1 = models.ActualLRP{
+				ActualLRPKey: models.NewActualLRPKey(
+					"process-guid-0",
+					1,
+					"domain-0",
+				),
+				ActualLRPInstanceKey: models.NewActualLRPInstanceKey(
+					"instance-guid-0",
+					"cell-id-0",
+				),
+				State: models.ActualLRPStateCrashed,
+				Since: 1138,
+			}
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			handler.ActualLRPGroupsByState(logger, responseRecorder, request)
+		})
+
+		Context("when reading actual lrps from DB succeeds", func() {
+			var actualLRPGroups []*models.ActualLRPGroup
+
+			BeforeEach(func() {
+				actualLRPGroups = []*models.ActualLRPGroup{{Instance: &actualLRP1}}
+				fakeActualLRPDB.ActualLRPGroupsByStateReturns(actualLRPGroups, nil)
+			})
+
+			It("fetches actual lrp groups by state", func() {
+				Expect(fakeActualLRPDB.ActualLRPGroupsByStateCallCount()).To(Equal(1))
+				_, state := fakeActualLRPDB.ActualLRPGroupsByStateArgsForCall(0)
+				Expect(state).To(Equal(models.ActualLRPState(models.ActualLRPStateCrashed)))
+			})
+
+			It("returns a list of actual lrp groups", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+
+				response := &models.ActualLRPGroupsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(BeNil())
+				Expect(response.ActualLrpGroups).To(Equal(actualLRPGroups))
+			})
+		})
+
+		Context("when the state is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.ActualLRPGroupsByStateRequest{State: "EXPLODED"}
+			})
+
+			It("responds with an invalid request error without querying the DB", func() {
+				response := &models.ActualLRPGroupsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+				Expect(fakeActualLRPDB.ActualLRPGroupsByStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeActualLRPDB.ActualLRPGroupsByStateReturns([]*models.ActualLRPGroup{}, models.ErrUnknownError)
+			})
+
+			It("provides relevant error information", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+
+				response := &models.ActualLRPGroupsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(Equal(models.ErrUnknownError))
+			})
+		})
+	})
+
 	Describe("ActualLRPGroupByProcessGuidAndIndex", func() {
 		var (
 			processGuid       = "process-guid"
@@ -14,10 +14,12 @@ import (
 type ActualLRPLifecycleController interface {
 	ClaimActualLRP(logger lager.Logger, processGuid string, index int32, actualLRPInstanceKey *models.ActualLRPInstanceKey) error
 	StartActualLRP(logger lager.Logger, actualLRPKey *models.ActualLRPKey, actualLRPInstanceKey *models.ActualLRPInstanceKey, actualLRPNetInfo *models.ActualLRPNetInfo) error
+	ApplyActualLRPLifecycleOperations(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]error, error)
 	CrashActualLRP(logger lager.Logger, actualLRPKey *models.ActualLRPKey, actualLRPInstanceKey *models.ActualLRPInstanceKey, errorMessage string) error
 	FailActualLRP(logger lager.Logger, key *models.ActualLRPKey, errorMessage string) error
 	RemoveActualLRP(logger lager.Logger, processGuid string, index int32, instanceKey *models.ActualLRPInstanceKey) error
 	RetireActualLRP(logger lager.Logger, key *models.ActualLRPKey) error
+	RetireActualLRPsByProcessGuid(logger lager.Logger, processGuid string) (int, error)
 }
 
 type ActualLRPLifecycleHandler struct {
@@ -79,6 +81,33 @@ func (h *ActualLRPLifecycleHandler) StartActualLRP(logger lager.Logger, w http.R
 	response.Error = models.ConvertError(err)
 }
 
+func (h *ActualLRPLifecycleHandler) ActualLRPLifecycleBatch(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
+	logger = logger.Session("actual-lrp-lifecycle-batch")
+
+	request := &models.ActualLRPLifecycleBatchRequest{}
+	response := &models.ActualLRPLifecycleBatchResponse{}
+
+	defer func() { exitIfUnrecoverable(logger, h.exitChan, response.Error) }()
+	defer writeResponse(w, response)
+
+	err := parseRequest(logger, req, request)
+	if err != nil {
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	errs, err := h.controller.ApplyActualLRPLifecycleOperations(logger, request.Operations)
+	if err != nil {
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	response.Results = make([]*models.ActualLRPLifecycleResponse, 0, len(errs))
+	for _, err := range errs {
+		response.Results = append(response.Results, &models.ActualLRPLifecycleResponse{Error: models.ConvertError(err)})
+	}
+}
+
 func (h *ActualLRPLifecycleHandler) CrashActualLRP(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
 	logger = logger.Session("crash-actual-lrp")
 
@@ -158,3 +187,23 @@ func (h *ActualLRPLifecycleHandler) RetireActualLRP(logger lager.Logger, w http.
 	err = h.controller.RetireActualLRP(logger, request.ActualLrpKey)
 	response.Error = models.ConvertError(err)
 }
+
+func (h *ActualLRPLifecycleHandler) RetireActualLRPsByProcessGuid(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
+	logger = logger.Session("retire-actual-lrps-by-process-guid")
+	request := &models.RetireActualLRPsByProcessGuidRequest{}
+	response := &models.RetireActualLRPsByProcessGuidResponse{}
+
+	var err error
+	defer func() { exitIfUnrecoverable(logger, h.exitChan, response.Error) }()
+	defer writeResponse(w, response)
+
+	err = parseRequest(logger, req, request)
+	if err != nil {
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	retired, err := h.controller.RetireActualLRPsByProcessGuid(logger, request.ProcessGuid)
+	response.RetiredCount = int32(retired)
+	response.Error = models.ConvertError(err)
+}
@@ -200,6 +200,68 @@ var _ = Describe("ActualLRP Lifecycle Handlers", func() {
 		})
 	})
 
+	Describe("ActualLRPLifecycleBatch", func() {
+		var (
+			request    *http.Request
+			response   *models.ActualLRPLifecycleBatchResponse
+			operations []*models.ActualLRPLifecycleOperation
+		)
+
+		BeforeEach(func() {
+			key := models.NewActualLRPKey("process-guid", 1, "domain-0")
+			instanceKey := models.NewActualLRPInstanceKey("instance-guid-0", "cell-id-0")
+			netInfo := models.NewActualLRPNetInfo("1.1.1.1", "2.2.2.2", models.NewPortMapping(10, 20))
+			operations = []*models.ActualLRPLifecycleOperation{
+				{Claim: &models.ClaimActualLRPRequest{ProcessGuid: "process-guid", Index: 1, ActualLrpInstanceKey: &instanceKey}},
+				{Start: &models.StartActualLRPRequest{ActualLrpKey: &key, ActualLrpInstanceKey: &instanceKey, ActualLrpNetInfo: &netInfo}},
+			}
+			fakeController.ApplyActualLRPLifecycleOperationsReturns([]error{nil, models.ErrActualLRPCannotBeStarted}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request = newTestRequest(&models.ActualLRPLifecycleBatchRequest{Operations: operations})
+			handler.ActualLRPLifecycleBatch(logger, responseRecorder, request)
+
+			response = &models.ActualLRPLifecycleBatchResponse{}
+			err := response.Unmarshal(responseRecorder.Body.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("calls the controller with the operations and returns the result of each", func() {
+			Expect(fakeController.ApplyActualLRPLifecycleOperationsCallCount()).To(Equal(1))
+			_, actualOperations := fakeController.ApplyActualLRPLifecycleOperationsArgsForCall(0)
+			Expect(actualOperations).To(Equal(operations))
+
+			Expect(response.Error).To(BeNil())
+			Expect(response.Results).To(Equal([]*models.ActualLRPLifecycleResponse{
+				{},
+				{Error: models.ErrActualLRPCannotBeStarted},
+			}))
+		})
+
+		Context("when the controller fails the whole batch", func() {
+			BeforeEach(func() {
+				fakeController.ApplyActualLRPLifecycleOperationsReturns(nil, models.ErrUnknownError)
+			})
+
+			It("returns the error and no results", func() {
+				Expect(response.Error).To(Equal(models.ErrUnknownError))
+				Expect(response.Results).To(BeEmpty())
+			})
+		})
+
+		Context("when an unrecoverable error is returned", func() {
+			BeforeEach(func() {
+				fakeController.ApplyActualLRPLifecycleOperationsReturns(nil, models.NewUnrecoverableError(nil))
+			})
+
+			It("logs and writes to the exit channel", func() {
+				Eventually(logger).Should(gbytes.Say("unrecoverable-error"))
+				Eventually(exitCh).Should(Receive())
+			})
+		})
+	})
+
 	Describe("CrashActualLRP", func() {
 		var (
 			processGuid  = "process-guid"
@@ -345,6 +407,75 @@ var _ = Describe("ActualLRP Lifecycle Handlers", func() {
 		})
 	})
 
+	Describe("RetireActualLRPsByProcessGuid", func() {
+		var (
+			request     *http.Request
+			response    *models.RetireActualLRPsByProcessGuidResponse
+			processGuid = "process-guid"
+
+			requestBody interface{}
+		)
+
+		BeforeEach(func() {
+			requestBody = &models.RetireActualLRPsByProcessGuidRequest{
+				ProcessGuid: processGuid,
+			}
+			fakeController.RetireActualLRPsByProcessGuidReturns(3, nil)
+		})
+
+		JustBeforeEach(func() {
+			request = newTestRequest(requestBody)
+			handler.RetireActualLRPsByProcessGuid(logger, responseRecorder, request)
+
+			response = &models.RetireActualLRPsByProcessGuidResponse{}
+			err := response.Unmarshal(responseRecorder.Body.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("calls the controller and returns the number of retired instances", func() {
+			Expect(fakeController.RetireActualLRPsByProcessGuidCallCount()).To(Equal(1))
+			_, actualProcessGuid := fakeController.RetireActualLRPsByProcessGuidArgsForCall(0)
+			Expect(actualProcessGuid).To(Equal(processGuid))
+
+			Expect(response.Error).To(BeNil())
+			Expect(response.RetiredCount).To(BeEquivalentTo(3))
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.RetireActualLRPsByProcessGuidRequest{}
+			})
+
+			It("responds with an error and does not call the controller", func() {
+				Expect(response.Error).NotTo(BeNil())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+				Expect(fakeController.RetireActualLRPsByProcessGuidCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when an unrecoverable error is returned", func() {
+			BeforeEach(func() {
+				fakeController.RetireActualLRPsByProcessGuidReturns(0, models.NewUnrecoverableError(nil))
+			})
+
+			It("logs and writes to the exit channel", func() {
+				Eventually(logger).Should(gbytes.Say("unrecoverable-error"))
+				Eventually(exitCh).Should(Receive())
+			})
+		})
+
+		Context("when a recoverable error is returned", func() {
+			BeforeEach(func() {
+				fakeController.RetireActualLRPsByProcessGuidReturns(1, errors.New("could not stop lrp"))
+			})
+
+			It("returns the error and the number of instances retired so far", func() {
+				Expect(response.Error.Message).To(Equal("could not stop lrp"))
+				Expect(response.RetiredCount).To(BeEquivalentTo(1))
+			})
+		})
+	})
+
 	Describe("FailActualLRP", func() {
 		var (
 			request     *http.Request
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/bbs/controllers"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fake_controllers/fake_convergence_history.go . ConvergenceHistory
+
+type ConvergenceHistory interface {
+	ConvergenceHistory() []controllers.ConvergenceSummary
+}
+
+type ConvergenceHistoryHandler struct {
+	history ConvergenceHistory
+}
+
+func NewConvergenceHistoryHandler(history ConvergenceHistory) *ConvergenceHistoryHandler {
+	return &ConvergenceHistoryHandler{history: history}
+}
+
+// ConvergenceHistory responds with the summaries of the most recent LRP
+// convergence runs as a JSON array, newest first.
+func (h *ConvergenceHistoryHandler) ConvergenceHistory(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
+	logger = logger.Session("convergence-history")
+
+	payload, err := json.Marshal(h.history.ConvergenceHistory())
+	if err != nil {
+		logger.Error("failed-marshalling-history", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	w.Write(payload)
+}
@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/bbs/controllers"
+	"code.cloudfoundry.org/bbs/handlers"
+	"code.cloudfoundry.org/bbs/handlers/fake_controllers"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Convergence History Handler", func() {
+	var (
+		logger           *lagertest.TestLogger
+		responseRecorder *httptest.ResponseRecorder
+		handler          *handlers.ConvergenceHistoryHandler
+		fakeHistory      *fake_controllers.FakeConvergenceHistory
+	)
+
+	BeforeEach(func() {
+		fakeHistory = new(fake_controllers.FakeConvergenceHistory)
+		logger = lagertest.NewTestLogger("test")
+		responseRecorder = httptest.NewRecorder()
+		handler = handlers.NewConvergenceHistoryHandler(fakeHistory)
+	})
+
+	Describe("ConvergenceHistory", func() {
+		JustBeforeEach(func() {
+			handler.ConvergenceHistory(logger, responseRecorder, newTestRequest(""))
+		})
+
+		Context("when convergences have completed", func() {
+			var history []controllers.ConvergenceSummary
+
+			BeforeEach(func() {
+				completedAt := time.Unix(1490000000, 0).UTC()
+				history = []controllers.ConvergenceSummary{
+					{CompletedAt: completedAt.Add(time.Minute), Duration: time.Second, StartRequests: 2},
+					{CompletedAt: completedAt, Duration: 2 * time.Second, LRPsRetired: 1},
+				}
+				fakeHistory.ConvergenceHistoryReturns(history)
+			})
+
+			It("responds with the history as JSON", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				Expect(responseRecorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+				response := []controllers.ConvergenceSummary{}
+				err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response).To(Equal(history))
+			})
+		})
+
+		Context("before any convergence has completed", func() {
+			BeforeEach(func() {
+				fakeHistory.ConvergenceHistoryReturns([]controllers.ConvergenceSummary{})
+			})
+
+			It("responds with an empty JSON array", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				Expect(responseRecorder.Body.String()).To(Equal("[]"))
+			})
+		})
+	})
+})
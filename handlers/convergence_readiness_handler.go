@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fake_controllers/fake_convergence_readiness.go . ConvergenceReadiness
+
+type ConvergenceReadiness interface {
+	Converged() bool
+}
+
+type ConvergenceReadinessHandler struct {
+	readiness ConvergenceReadiness
+}
+
+func NewConvergenceReadinessHandler(readiness ConvergenceReadiness) *ConvergenceReadinessHandler {
+	return &ConvergenceReadinessHandler{readiness: readiness}
+}
+
+func (h *ConvergenceReadinessHandler) ConvergenceReadiness(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
+	response := &models.ConvergenceReadinessResponse{}
+	response.Converged = h.readiness.Converged()
+	writeResponse(w, response)
+}
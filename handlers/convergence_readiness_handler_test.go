@@ -0,0 +1,65 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/bbs/handlers"
+	"code.cloudfoundry.org/bbs/handlers/fake_controllers"
+	"code.cloudfoundry.org/bbs/models"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Convergence Readiness Handler", func() {
+	var (
+		logger           *lagertest.TestLogger
+		responseRecorder *httptest.ResponseRecorder
+		handler          *handlers.ConvergenceReadinessHandler
+		fakeReadiness    *fake_controllers.FakeConvergenceReadiness
+	)
+
+	BeforeEach(func() {
+		fakeReadiness = new(fake_controllers.FakeConvergenceReadiness)
+		logger = lagertest.NewTestLogger("test")
+		responseRecorder = httptest.NewRecorder()
+		handler = handlers.NewConvergenceReadinessHandler(fakeReadiness)
+	})
+
+	Describe("ConvergenceReadiness", func() {
+		JustBeforeEach(func() {
+			handler.ConvergenceReadiness(logger, responseRecorder, newTestRequest(""))
+		})
+
+		Context("before the first convergence has completed", func() {
+			BeforeEach(func() {
+				fakeReadiness.ConvergedReturns(false)
+			})
+
+			It("reports not converged", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+
+				response := &models.ConvergenceReadinessResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Converged).To(BeFalse())
+			})
+		})
+
+		Context("once a convergence has completed", func() {
+			BeforeEach(func() {
+				fakeReadiness.ConvergedReturns(true)
+			})
+
+			It("reports converged", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+
+				response := &models.ConvergenceReadinessResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Converged).To(BeTrue())
+			})
+		})
+	})
+})
@@ -105,6 +105,17 @@ func (h *DesiredLRPHandler) DesiredLRPSchedulingInfos(logger lager.Logger, w htt
 	exitIfUnrecoverable(logger, h.exitChan, response.Error)
 }
 
+func (h *DesiredLRPHandler) DesiredLRPProcessGuids(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
+	var err error
+	logger = logger.Session("desired-lrp-process-guids")
+
+	response := &models.DesiredLRPProcessGuidsResponse{}
+	response.ProcessGuids, err = h.desiredLRPDB.DesiredLRPProcessGuids(logger)
+	response.Error = models.ConvertError(err)
+	writeResponse(w, response)
+	exitIfUnrecoverable(logger, h.exitChan, response.Error)
+}
+
 func (h *DesiredLRPHandler) DesireDesiredLRP(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
 	logger = logger.Session("desire-lrp")
 
@@ -137,6 +148,41 @@ func (h *DesiredLRPHandler) DesireDesiredLRP(logger lager.Logger, w http.Respons
 	h.startInstanceRange(logger, 0, schedulingInfo.Instances, &schedulingInfo)
 }
 
+func (h *DesiredLRPHandler) DesireDesiredLRPs(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
+	logger = logger.Session("desire-lrps")
+
+	request := &models.DesireLRPsRequest{}
+	response := &models.DesiredLRPLifecycleResponse{}
+	defer func() { exitIfUnrecoverable(logger, h.exitChan, response.Error) }()
+	defer writeResponse(w, response)
+
+	err := parseRequest(logger, req, request)
+	if err != nil {
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	err = h.desiredLRPDB.DesireLRPs(logger, request.DesiredLrps)
+	if err != nil {
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	for _, desired := range request.DesiredLrps {
+		desiredLRP, err := h.desiredLRPDB.DesiredLRPByProcessGuid(logger, desired.ProcessGuid)
+		if err != nil {
+			logger.Error("failed-fetching-desired-lrp", err, lager.Data{"process_guid": desired.ProcessGuid})
+			response.Error = models.ConvertError(err)
+			return
+		}
+
+		go h.desiredHub.Emit(models.NewDesiredLRPCreatedEvent(desiredLRP))
+
+		schedulingInfo := desired.DesiredLRPSchedulingInfo()
+		h.startInstanceRange(logger, 0, schedulingInfo.Instances, &schedulingInfo)
+	}
+}
+
 func (h *DesiredLRPHandler) UpdateDesiredLRP(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
 	logger = logger.Session("update-desired-lrp")
 
@@ -192,6 +238,35 @@ func (h *DesiredLRPHandler) UpdateDesiredLRP(logger lager.Logger, w http.Respons
 	go h.desiredHub.Emit(models.NewDesiredLRPChangedEvent(beforeDesiredLRP, desiredLRP))
 }
 
+func (h *DesiredLRPHandler) UpdateDesiredLRPRoutes(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
+	logger = logger.Session("update-desired-lrp-routes")
+
+	request := &models.UpdateDesiredLRPRoutesRequest{}
+	response := &models.DesiredLRPLifecycleResponse{}
+	defer func() { exitIfUnrecoverable(logger, h.exitChan, response.Error) }()
+	defer writeResponse(w, response)
+
+	err := parseRequest(logger, req, request)
+	if err != nil {
+		logger.Error("failed-parsing-request", err)
+		response.Error = models.ConvertError(err)
+		return
+	}
+
+	logger = logger.WithData(lager.Data{"guid": request.ProcessGuid})
+
+	logger.Debug("updating-desired-lrp-routes")
+	beforeDesiredLRP, afterDesiredLRP, err := h.desiredLRPDB.UpdateDesiredLRPRoutes(logger, request.ProcessGuid, request.Routes)
+	if err != nil {
+		logger.Debug("failed-updating-desired-lrp-routes")
+		response.Error = models.ConvertError(err)
+		return
+	}
+	logger.Debug("completed-updating-desired-lrp-routes")
+
+	go h.desiredHub.Emit(models.NewDesiredLRPChangedEvent(beforeDesiredLRP, afterDesiredLRP))
+}
+
 func (h *DesiredLRPHandler) RemoveDesiredLRP(logger lager.Logger, w http.ResponseWriter, req *http.Request) {
 	logger = logger.Session("remove-desired-lrp")
 
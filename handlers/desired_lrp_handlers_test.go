@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -365,6 +366,62 @@ var _ = Describe("DesiredLRP Handlers", func() {
 		})
 	})
 
+	Describe("DesiredLRPProcessGuids", func() {
+		JustBeforeEach(func() {
+			request := newTestRequest("")
+			handler.DesiredLRPProcessGuids(logger, responseRecorder, request)
+		})
+
+		Context("when reading process guids from DB succeeds", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesiredLRPProcessGuidsReturns([]string{"guid-1", "guid-2"}, nil)
+			})
+
+			It("returns all of the process guids", func() {
+				Expect(fakeDesiredLRPDB.DesiredLRPProcessGuidsCallCount()).To(Equal(1))
+
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := models.DesiredLRPProcessGuidsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(BeNil())
+				Expect(response.ProcessGuids).To(ConsistOf("guid-1", "guid-2"))
+			})
+
+			It("does not fetch the full desired lrps", func() {
+				Expect(fakeDesiredLRPDB.DesiredLRPsCallCount()).To(Equal(0))
+				Expect(fakeDesiredLRPDB.DesiredLRPSchedulingInfosCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the DB returns an unrecoverable error", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesiredLRPProcessGuidsReturns(nil, models.NewUnrecoverableError(nil))
+			})
+
+			It("logs and writes to the exit channel", func() {
+				Eventually(logger).Should(gbytes.Say("unrecoverable-error"))
+				Eventually(exitCh).Should(Receive())
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesiredLRPProcessGuidsReturns(nil, models.ErrUnknownError)
+			})
+
+			It("provides relevant error information", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := models.DesiredLRPProcessGuidsResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(Equal(models.ErrUnknownError))
+			})
+		})
+	})
+
 	Describe("DesireDesiredLRP", func() {
 		var (
 			desiredLRP *models.DesiredLRP
@@ -518,6 +575,137 @@ var _ = Describe("DesiredLRP Handlers", func() {
 		})
 	})
 
+	Describe("DesireDesiredLRPs", func() {
+		var (
+			desiredLRPs []*models.DesiredLRP
+
+			requestBody interface{}
+		)
+
+		BeforeEach(func() {
+			desiredLRPs = []*models.DesiredLRP{
+				model_helpers.NewValidDesiredLRP("web-guid"),
+				model_helpers.NewValidDesiredLRP("worker-guid"),
+			}
+			desiredLRPs[0].Instances = 2
+			desiredLRPs[1].Instances = 3
+			requestBody = &models.DesireLRPsRequest{
+				DesiredLrps: desiredLRPs,
+			}
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			handler.DesireDesiredLRPs(logger, responseRecorder, request)
+		})
+
+		Context("when creating the desired lrps in DB succeeds", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesireLRPsReturns(nil)
+				fakeActualLRPDB.CreateUnclaimedActualLRPStub = func(_ lager.Logger, key *models.ActualLRPKey) (*models.ActualLRPGroup, error) {
+					return &models.ActualLRPGroup{Instance: model_helpers.NewValidActualLRP(key.ProcessGuid, key.Index)}, nil
+				}
+				fakeDesiredLRPDB.DesiredLRPByProcessGuidStub = func(_ lager.Logger, processGuid string) (*models.DesiredLRP, error) {
+					for _, desiredLRP := range desiredLRPs {
+						if desiredLRP.ProcessGuid == processGuid {
+							return desiredLRP, nil
+						}
+					}
+					return nil, models.ErrResourceNotFound
+				}
+			})
+
+			It("creates the desired lrps in one call", func() {
+				Expect(fakeDesiredLRPDB.DesireLRPsCallCount()).To(Equal(1))
+				_, actualDesiredLRPs := fakeDesiredLRPDB.DesireLRPsArgsForCall(0)
+				Expect(actualDesiredLRPs).To(Equal(desiredLRPs))
+				Expect(fakeDesiredLRPDB.DesireLRPCallCount()).To(Equal(0))
+
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := models.DesiredLRPLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Error).To(BeNil())
+			})
+
+			It("emits a create event for each desired lrp", func() {
+				Eventually(desiredHub.EmitCallCount).Should(Equal(2))
+				createdLRPs := []*models.DesiredLRP{}
+				for i := 0; i < 2; i++ {
+					createEvent, ok := desiredHub.EmitArgsForCall(i).(*models.DesiredLRPCreatedEvent)
+					Expect(ok).To(BeTrue())
+					createdLRPs = append(createdLRPs, createEvent.DesiredLrp)
+				}
+				Expect(createdLRPs).To(ConsistOf(desiredLRPs[0], desiredLRPs[1]))
+			})
+
+			It("creates the unclaimed actual lrps of every desired lrp", func() {
+				Expect(fakeActualLRPDB.CreateUnclaimedActualLRPCallCount()).To(Equal(5))
+				Eventually(actualHub.EmitCallCount).Should(Equal(5))
+			})
+
+			It("requests an auction for each desired lrp", func() {
+				Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(2))
+
+				_, startRequests := fakeAuctioneerClient.RequestLRPAuctionsArgsForCall(0)
+				Expect(startRequests).To(HaveLen(1))
+				Expect(startRequests[0].ProcessGuid).To(Equal("web-guid"))
+				Expect(startRequests[0].Indices).To(ConsistOf(0, 1))
+
+				_, startRequests = fakeAuctioneerClient.RequestLRPAuctionsArgsForCall(1)
+				Expect(startRequests).To(HaveLen(1))
+				Expect(startRequests[0].ProcessGuid).To(Equal("worker-guid"))
+				Expect(startRequests[0].Indices).To(ConsistOf(0, 1, 2))
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.DesireLRPsRequest{}
+			})
+
+			It("responds with an invalid request error and does not create anything", func() {
+				response := models.DesiredLRPLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Error).NotTo(BeNil())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+
+				Expect(fakeDesiredLRPDB.DesireLRPsCallCount()).To(Equal(0))
+				Expect(fakeActualLRPDB.CreateUnclaimedActualLRPCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the DB returns an unrecoverable error", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesireLRPsReturns(models.NewUnrecoverableError(nil))
+			})
+
+			It("logs and writes to the exit channel", func() {
+				Eventually(logger).Should(gbytes.Say("unrecoverable-error"))
+				Eventually(exitCh).Should(Receive())
+			})
+		})
+
+		Context("when creating the desired lrps in DB fails", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.DesireLRPsReturns(models.ErrResourceExists)
+			})
+
+			It("responds with the error and does not start any instances", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := models.DesiredLRPLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Error).To(Equal(models.ErrResourceExists))
+
+				Expect(fakeActualLRPDB.CreateUnclaimedActualLRPCallCount()).To(Equal(0))
+				Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(0))
+				Consistently(desiredHub.EmitCallCount).Should(Equal(0))
+			})
+		})
+	})
+
 	Describe("UpdateDesiredLRP", func() {
 		var (
 			processGuid      string
@@ -823,6 +1011,117 @@ var _ = Describe("DesiredLRP Handlers", func() {
 		})
 	})
 
+	Describe("UpdateDesiredLRPRoutes", func() {
+		var (
+			processGuid      string
+			routes           *models.Routes
+			beforeDesiredLRP *models.DesiredLRP
+			afterDesiredLRP  *models.DesiredLRP
+
+			requestBody interface{}
+		)
+
+		BeforeEach(func() {
+			processGuid = "some-guid"
+			routeContent := json.RawMessage(`{"port":8080}`)
+			routes = &models.Routes{"new-router": &routeContent}
+
+			beforeDesiredLRP = model_helpers.NewValidDesiredLRP(processGuid)
+			afterDesiredLRP = model_helpers.NewValidDesiredLRP(processGuid)
+			afterDesiredLRP.Routes = routes
+
+			requestBody = &models.UpdateDesiredLRPRoutesRequest{
+				ProcessGuid: processGuid,
+				Routes:      routes,
+			}
+		})
+
+		JustBeforeEach(func() {
+			request := newTestRequest(requestBody)
+			handler.UpdateDesiredLRPRoutes(logger, responseRecorder, request)
+		})
+
+		Context("when updating the routes in the DB succeeds", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.UpdateDesiredLRPRoutesReturns(beforeDesiredLRP, afterDesiredLRP, nil)
+			})
+
+			It("updates the routes of the desired lrp", func() {
+				Expect(fakeDesiredLRPDB.UpdateDesiredLRPRoutesCallCount()).To(Equal(1))
+				_, actualProcessGuid, actualRoutes := fakeDesiredLRPDB.UpdateDesiredLRPRoutesArgsForCall(0)
+				Expect(actualProcessGuid).To(Equal(processGuid))
+				Expect(actualRoutes).To(Equal(routes))
+
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := models.DesiredLRPLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Error).To(BeNil())
+			})
+
+			It("emits a change event to the hub", func() {
+				Eventually(desiredHub.EmitCallCount).Should(Equal(1))
+				event := desiredHub.EmitArgsForCall(0)
+				changeEvent, ok := event.(*models.DesiredLRPChangedEvent)
+				Expect(ok).To(BeTrue())
+				Expect(changeEvent.Before).To(Equal(beforeDesiredLRP))
+				Expect(changeEvent.After).To(Equal(afterDesiredLRP))
+			})
+
+			It("does not touch the actual lrps", func() {
+				Expect(fakeDesiredLRPDB.DesiredLRPByProcessGuidCallCount()).To(Equal(0))
+				Expect(fakeActualLRPDB.ActualLRPGroupsByProcessGuidCallCount()).To(Equal(0))
+				Expect(fakeAuctioneerClient.RequestLRPAuctionsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the request is invalid", func() {
+			BeforeEach(func() {
+				requestBody = &models.UpdateDesiredLRPRoutesRequest{
+					ProcessGuid: processGuid,
+				}
+			})
+
+			It("responds with an invalid request error and does not update the DB", func() {
+				response := models.DesiredLRPLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Error).NotTo(BeNil())
+				Expect(response.Error.Type).To(Equal(models.Error_InvalidRequest))
+
+				Expect(fakeDesiredLRPDB.UpdateDesiredLRPRoutesCallCount()).To(Equal(0))
+				Consistently(desiredHub.EmitCallCount).Should(Equal(0))
+			})
+		})
+
+		Context("when the DB returns an unrecoverable error", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.UpdateDesiredLRPRoutesReturns(nil, nil, models.NewUnrecoverableError(nil))
+			})
+
+			It("logs and writes to the exit channel", func() {
+				Eventually(logger).Should(gbytes.Say("unrecoverable-error"))
+				Eventually(exitCh).Should(Receive())
+			})
+		})
+
+		Context("when the DB errors out", func() {
+			BeforeEach(func() {
+				fakeDesiredLRPDB.UpdateDesiredLRPRoutesReturns(nil, nil, models.ErrResourceNotFound)
+			})
+
+			It("provides relevant error information and emits no event", func() {
+				Expect(responseRecorder.Code).To(Equal(http.StatusOK))
+				response := models.DesiredLRPLifecycleResponse{}
+				err := response.Unmarshal(responseRecorder.Body.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(response.Error).To(Equal(models.ErrResourceNotFound))
+				Consistently(desiredHub.EmitCallCount).Should(Equal(0))
+			})
+		})
+	})
+
 	Describe("RemoveDesiredLRP", func() {
 		var (
 			processGuid string
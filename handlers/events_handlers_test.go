@@ -10,6 +10,8 @@ import (
 	"code.cloudfoundry.org/bbs/events/eventfakes"
 	"code.cloudfoundry.org/bbs/format"
 	"code.cloudfoundry.org/bbs/handlers"
+	"code.cloudfoundry.org/bbs/handlers/middleware"
+	"code.cloudfoundry.org/bbs/handlers/middleware/fakes"
 	"code.cloudfoundry.org/bbs/models"
 	"code.cloudfoundry.org/bbs/models/test/model_helpers"
 	"code.cloudfoundry.org/lager"
@@ -179,6 +181,27 @@ var _ = Describe("Event Handlers", func() {
 			ItStreamsEventsFromHub(&desiredHub)
 			ItRecoversFromLostConnections(&desiredHub)
 
+			It("streams events when served behind the tracing and latency middleware", func() {
+				server := httptest.NewServer(middleware.TraceWrap("EventStream_r0", middleware.RecordLatency(func(w http.ResponseWriter, r *http.Request) {
+					handler.Subscribe_r0(logger, w, r)
+				}, &fakes.FakeEmitter{}), middleware.NewLagerTracer(logger)))
+				defer server.Close()
+
+				response, err := http.Get(server.URL)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				reader := sse.NewReadCloser(response.Body)
+				defer reader.Close()
+
+				desiredHub.Emit(&eventfakes.FakeEvent{Token: "A"})
+
+				Expect(reader.Next()).To(Equal(sse.Event{
+					ID:   "0",
+					Name: "fake",
+					Data: []byte(base64.StdEncoding.EncodeToString([]byte("A"))),
+				}))
+			})
+
 			It("migrates desired lrps down to v0", func() {
 				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					handler.Subscribe_r0(logger, w, r)
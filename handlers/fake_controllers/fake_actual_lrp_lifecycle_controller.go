@@ -38,6 +38,20 @@ type FakeActualLRPLifecycleController struct {
 	startActualLRPReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApplyActualLRPLifecycleOperationsStub        func(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]error, error)
+	applyActualLRPLifecycleOperationsMutex       sync.RWMutex
+	applyActualLRPLifecycleOperationsArgsForCall []struct {
+		logger     lager.Logger
+		operations []*models.ActualLRPLifecycleOperation
+	}
+	applyActualLRPLifecycleOperationsReturns struct {
+		result1 []error
+		result2 error
+	}
+	applyActualLRPLifecycleOperationsReturnsOnCall map[int]struct {
+		result1 []error
+		result2 error
+	}
 	CrashActualLRPStub        func(logger lager.Logger, actualLRPKey *models.ActualLRPKey, actualLRPInstanceKey *models.ActualLRPInstanceKey, errorMessage string) error
 	crashActualLRPMutex       sync.RWMutex
 	crashActualLRPArgsForCall []struct {
@@ -91,6 +105,20 @@ type FakeActualLRPLifecycleController struct {
 	retireActualLRPReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RetireActualLRPsByProcessGuidStub        func(logger lager.Logger, processGuid string) (int, error)
+	retireActualLRPsByProcessGuidMutex       sync.RWMutex
+	retireActualLRPsByProcessGuidArgsForCall []struct {
+		logger      lager.Logger
+		processGuid string
+	}
+	retireActualLRPsByProcessGuidReturns struct {
+		result1 int
+		result2 error
+	}
+	retireActualLRPsByProcessGuidReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -197,6 +225,63 @@ func (fake *FakeActualLRPLifecycleController) StartActualLRPReturnsOnCall(i int,
 	}{result1}
 }
 
+func (fake *FakeActualLRPLifecycleController) ApplyActualLRPLifecycleOperations(logger lager.Logger, operations []*models.ActualLRPLifecycleOperation) ([]error, error) {
+	var operationsCopy []*models.ActualLRPLifecycleOperation
+	if operations != nil {
+		operationsCopy = make([]*models.ActualLRPLifecycleOperation, len(operations))
+		copy(operationsCopy, operations)
+	}
+	fake.applyActualLRPLifecycleOperationsMutex.Lock()
+	ret, specificReturn := fake.applyActualLRPLifecycleOperationsReturnsOnCall[len(fake.applyActualLRPLifecycleOperationsArgsForCall)]
+	fake.applyActualLRPLifecycleOperationsArgsForCall = append(fake.applyActualLRPLifecycleOperationsArgsForCall, struct {
+		logger     lager.Logger
+		operations []*models.ActualLRPLifecycleOperation
+	}{logger, operationsCopy})
+	fake.recordInvocation("ApplyActualLRPLifecycleOperations", []interface{}{logger, operationsCopy})
+	fake.applyActualLRPLifecycleOperationsMutex.Unlock()
+	if fake.ApplyActualLRPLifecycleOperationsStub != nil {
+		return fake.ApplyActualLRPLifecycleOperationsStub(logger, operations)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.applyActualLRPLifecycleOperationsReturns.result1, fake.applyActualLRPLifecycleOperationsReturns.result2
+}
+
+func (fake *FakeActualLRPLifecycleController) ApplyActualLRPLifecycleOperationsCallCount() int {
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
+	return len(fake.applyActualLRPLifecycleOperationsArgsForCall)
+}
+
+func (fake *FakeActualLRPLifecycleController) ApplyActualLRPLifecycleOperationsArgsForCall(i int) (lager.Logger, []*models.ActualLRPLifecycleOperation) {
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
+	return fake.applyActualLRPLifecycleOperationsArgsForCall[i].logger, fake.applyActualLRPLifecycleOperationsArgsForCall[i].operations
+}
+
+func (fake *FakeActualLRPLifecycleController) ApplyActualLRPLifecycleOperationsReturns(result1 []error, result2 error) {
+	fake.ApplyActualLRPLifecycleOperationsStub = nil
+	fake.applyActualLRPLifecycleOperationsReturns = struct {
+		result1 []error
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActualLRPLifecycleController) ApplyActualLRPLifecycleOperationsReturnsOnCall(i int, result1 []error, result2 error) {
+	fake.ApplyActualLRPLifecycleOperationsStub = nil
+	if fake.applyActualLRPLifecycleOperationsReturnsOnCall == nil {
+		fake.applyActualLRPLifecycleOperationsReturnsOnCall = make(map[int]struct {
+			result1 []error
+			result2 error
+		})
+	}
+	fake.applyActualLRPLifecycleOperationsReturnsOnCall[i] = struct {
+		result1 []error
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeActualLRPLifecycleController) CrashActualLRP(logger lager.Logger, actualLRPKey *models.ActualLRPKey, actualLRPInstanceKey *models.ActualLRPInstanceKey, errorMessage string) error {
 	fake.crashActualLRPMutex.Lock()
 	ret, specificReturn := fake.crashActualLRPReturnsOnCall[len(fake.crashActualLRPArgsForCall)]
@@ -398,6 +483,58 @@ func (fake *FakeActualLRPLifecycleController) RetireActualLRPReturnsOnCall(i int
 	}{result1}
 }
 
+func (fake *FakeActualLRPLifecycleController) RetireActualLRPsByProcessGuid(logger lager.Logger, processGuid string) (int, error) {
+	fake.retireActualLRPsByProcessGuidMutex.Lock()
+	ret, specificReturn := fake.retireActualLRPsByProcessGuidReturnsOnCall[len(fake.retireActualLRPsByProcessGuidArgsForCall)]
+	fake.retireActualLRPsByProcessGuidArgsForCall = append(fake.retireActualLRPsByProcessGuidArgsForCall, struct {
+		logger      lager.Logger
+		processGuid string
+	}{logger, processGuid})
+	fake.recordInvocation("RetireActualLRPsByProcessGuid", []interface{}{logger, processGuid})
+	fake.retireActualLRPsByProcessGuidMutex.Unlock()
+	if fake.RetireActualLRPsByProcessGuidStub != nil {
+		return fake.RetireActualLRPsByProcessGuidStub(logger, processGuid)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retireActualLRPsByProcessGuidReturns.result1, fake.retireActualLRPsByProcessGuidReturns.result2
+}
+
+func (fake *FakeActualLRPLifecycleController) RetireActualLRPsByProcessGuidCallCount() int {
+	fake.retireActualLRPsByProcessGuidMutex.RLock()
+	defer fake.retireActualLRPsByProcessGuidMutex.RUnlock()
+	return len(fake.retireActualLRPsByProcessGuidArgsForCall)
+}
+
+func (fake *FakeActualLRPLifecycleController) RetireActualLRPsByProcessGuidArgsForCall(i int) (lager.Logger, string) {
+	fake.retireActualLRPsByProcessGuidMutex.RLock()
+	defer fake.retireActualLRPsByProcessGuidMutex.RUnlock()
+	return fake.retireActualLRPsByProcessGuidArgsForCall[i].logger, fake.retireActualLRPsByProcessGuidArgsForCall[i].processGuid
+}
+
+func (fake *FakeActualLRPLifecycleController) RetireActualLRPsByProcessGuidReturns(result1 int, result2 error) {
+	fake.RetireActualLRPsByProcessGuidStub = nil
+	fake.retireActualLRPsByProcessGuidReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeActualLRPLifecycleController) RetireActualLRPsByProcessGuidReturnsOnCall(i int, result1 int, result2 error) {
+	fake.RetireActualLRPsByProcessGuidStub = nil
+	if fake.retireActualLRPsByProcessGuidReturnsOnCall == nil {
+		fake.retireActualLRPsByProcessGuidReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.retireActualLRPsByProcessGuidReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeActualLRPLifecycleController) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -405,6 +542,8 @@ func (fake *FakeActualLRPLifecycleController) Invocations() map[string][][]inter
 	defer fake.claimActualLRPMutex.RUnlock()
 	fake.startActualLRPMutex.RLock()
 	defer fake.startActualLRPMutex.RUnlock()
+	fake.applyActualLRPLifecycleOperationsMutex.RLock()
+	defer fake.applyActualLRPLifecycleOperationsMutex.RUnlock()
 	fake.crashActualLRPMutex.RLock()
 	defer fake.crashActualLRPMutex.RUnlock()
 	fake.failActualLRPMutex.RLock()
@@ -413,6 +552,8 @@ func (fake *FakeActualLRPLifecycleController) Invocations() map[string][][]inter
 	defer fake.removeActualLRPMutex.RUnlock()
 	fake.retireActualLRPMutex.RLock()
 	defer fake.retireActualLRPMutex.RUnlock()
+	fake.retireActualLRPsByProcessGuidMutex.RLock()
+	defer fake.retireActualLRPsByProcessGuidMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -25,17 +25,32 @@ func New(
 	accessLogger lager.Logger,
 	updateWorkers int,
 	convergenceWorkersSize int,
+	requestLogSampleRate int,
+	maxConcurrentListRequests int,
+	knownPlacementTags []string,
 	emitter middleware.Emitter,
+	tracer middleware.Tracer,
 	db db.DB,
 	desiredHub, actualHub, taskHub events.Hub,
 	taskCompletionClient taskworkpool.TaskCompletionClient,
 	serviceClient serviceclient.ServiceClient,
 	auctioneerClient auctioneer.Client,
 	repClientFactory rep.ClientFactory,
+	convergenceReadiness ConvergenceReadiness,
+	convergenceHistory ConvergenceHistory,
 	migrationsDone <-chan struct{},
 	exitChan chan struct{},
 ) http.Handler {
+	logSampling := middleware.WithLogSampling(requestLogSampleRate)
+	routeEmitter := func(routeName string) middleware.Emitter {
+		return middleware.NewRouteEmitter(routeName, emitter)
+	}
+	// the list routes share their concurrency limit
+	limitList := middleware.NewConcurrencyLimiter(maxConcurrentListRequests)
+
 	pingHandler := NewPingHandler()
+	convergenceReadinessHandler := NewConvergenceReadinessHandler(convergenceReadiness)
+	convergenceHistoryHandler := NewConvergenceHistoryHandler(convergenceHistory)
 	domainHandler := NewDomainHandler(db, exitChan)
 	actualLRPHandler := NewActualLRPHandler(db, exitChan)
 	actualLRPController := controllers.NewActualLRPLifecycleController(db, db, db, auctioneerClient, serviceClient, repClientFactory, actualHub)
@@ -43,6 +58,7 @@ func New(
 	evacuationHandler := NewEvacuationHandler(db, db, db, actualHub, auctioneerClient, exitChan)
 	desiredLRPHandler := NewDesiredLRPHandler(updateWorkers, db, db, desiredHub, actualHub, auctioneerClient, repClientFactory, serviceClient, exitChan)
 	taskController := controllers.NewTaskController(db, taskCompletionClient, auctioneerClient, serviceClient, repClientFactory, taskHub)
+	taskController.SetKnownPlacementTags(knownPlacementTags)
 	taskHandler := NewTaskHandler(taskController, exitChan)
 	eventsHandler := NewEventHandler(desiredHub, actualHub)
 	taskEventsHandler := NewTaskEventHandler(taskHub)
@@ -50,72 +66,86 @@ func New(
 
 	actions := rata.Handlers{
 		// Ping
-		bbs.PingRoute: middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, pingHandler.Ping), emitter),
+		bbs.PingRoute: middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, pingHandler.Ping, logSampling), routeEmitter(bbs.PingRoute)),
+
+		// Convergence
+		bbs.ConvergenceReadinessRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, convergenceReadinessHandler.ConvergenceReadiness, logSampling), routeEmitter(bbs.ConvergenceReadinessRoute))),
+		bbs.ConvergenceHistoryRoute:   route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, convergenceHistoryHandler.ConvergenceHistory, logSampling), routeEmitter(bbs.ConvergenceHistoryRoute))),
 
 		// Domains
-		bbs.DomainsRoute:      route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, domainHandler.Domains), emitter)),
-		bbs.UpsertDomainRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, domainHandler.Upsert), emitter)),
+		bbs.DomainsRoute:      route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, domainHandler.Domains, logSampling), routeEmitter(bbs.DomainsRoute))),
+		bbs.UpsertDomainRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, domainHandler.Upsert, logSampling), routeEmitter(bbs.UpsertDomainRoute))),
 
 		// Actual LRPs
-		bbs.ActualLRPGroupsRoute:                     route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPHandler.ActualLRPGroups), emitter)),
-		bbs.ActualLRPGroupsByProcessGuidRoute:        route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPHandler.ActualLRPGroupsByProcessGuid), emitter)),
-		bbs.ActualLRPGroupByProcessGuidAndIndexRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPHandler.ActualLRPGroupByProcessGuidAndIndex), emitter)),
+		bbs.ActualLRPGroupsRoute:                     route(middleware.RecordLatency(limitList(middleware.LogWrap(logger, accessLogger, actualLRPHandler.ActualLRPGroups, logSampling)), routeEmitter(bbs.ActualLRPGroupsRoute))),
+		bbs.ActualLRPGroupsByProcessGuidRoute:        route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPHandler.ActualLRPGroupsByProcessGuid, logSampling), routeEmitter(bbs.ActualLRPGroupsByProcessGuidRoute))),
+		bbs.ActualLRPGroupsByStateRoute:              route(middleware.RecordLatency(limitList(middleware.LogWrap(logger, accessLogger, actualLRPHandler.ActualLRPGroupsByState, logSampling)), routeEmitter(bbs.ActualLRPGroupsByStateRoute))),
+		bbs.ActualLRPGroupByProcessGuidAndIndexRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPHandler.ActualLRPGroupByProcessGuidAndIndex, logSampling), routeEmitter(bbs.ActualLRPGroupByProcessGuidAndIndexRoute))),
 
 		// Actual LRP Lifecycle
-		bbs.ClaimActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.ClaimActualLRP), emitter)),
-		bbs.StartActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.StartActualLRP), emitter)),
-		bbs.CrashActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.CrashActualLRP), emitter)),
-		bbs.RetireActualLRPRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.RetireActualLRP), emitter)),
-		bbs.FailActualLRPRoute:   route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.FailActualLRP), emitter)),
-		bbs.RemoveActualLRPRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.RemoveActualLRP), emitter)),
+		bbs.ClaimActualLRPRoute:                route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.ClaimActualLRP, logSampling), routeEmitter(bbs.ClaimActualLRPRoute))),
+		bbs.StartActualLRPRoute:                route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.StartActualLRP, logSampling), routeEmitter(bbs.StartActualLRPRoute))),
+		bbs.ActualLRPLifecycleBatchRoute:       route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.ActualLRPLifecycleBatch, logSampling), routeEmitter(bbs.ActualLRPLifecycleBatchRoute))),
+		bbs.CrashActualLRPRoute:                route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.CrashActualLRP, logSampling), routeEmitter(bbs.CrashActualLRPRoute))),
+		bbs.RetireActualLRPRoute:               route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.RetireActualLRP, logSampling), routeEmitter(bbs.RetireActualLRPRoute))),
+		bbs.RetireActualLRPsByProcessGuidRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.RetireActualLRPsByProcessGuid, logSampling), routeEmitter(bbs.RetireActualLRPsByProcessGuidRoute))),
+		bbs.FailActualLRPRoute:                 route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.FailActualLRP, logSampling), routeEmitter(bbs.FailActualLRPRoute))),
+		bbs.RemoveActualLRPRoute:               route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, actualLRPLifecycleHandler.RemoveActualLRP, logSampling), routeEmitter(bbs.RemoveActualLRPRoute))),
 
 		// Evacuation
-		bbs.RemoveEvacuatingActualLRPRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.RemoveEvacuatingActualLRP), emitter)),
-		bbs.EvacuateClaimedActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.EvacuateClaimedActualLRP), emitter)),
-		bbs.EvacuateCrashedActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.EvacuateCrashedActualLRP), emitter)),
-		bbs.EvacuateStoppedActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.EvacuateStoppedActualLRP), emitter)),
-		bbs.EvacuateRunningActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.EvacuateRunningActualLRP), emitter)),
+		bbs.RemoveEvacuatingActualLRPRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.RemoveEvacuatingActualLRP, logSampling), routeEmitter(bbs.RemoveEvacuatingActualLRPRoute))),
+		bbs.EvacuateClaimedActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.EvacuateClaimedActualLRP, logSampling), routeEmitter(bbs.EvacuateClaimedActualLRPRoute))),
+		bbs.EvacuateCrashedActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.EvacuateCrashedActualLRP, logSampling), routeEmitter(bbs.EvacuateCrashedActualLRPRoute))),
+		bbs.EvacuateStoppedActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.EvacuateStoppedActualLRP, logSampling), routeEmitter(bbs.EvacuateStoppedActualLRPRoute))),
+		bbs.EvacuateRunningActualLRPRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, evacuationHandler.EvacuateRunningActualLRP, logSampling), routeEmitter(bbs.EvacuateRunningActualLRPRoute))),
 
 		// Desired LRPs
-		bbs.DesiredLRPsRoute:               route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPs), emitter)),
-		bbs.DesiredLRPByProcessGuidRoute:   route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPByProcessGuid), emitter)),
-		bbs.DesiredLRPSchedulingInfosRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPSchedulingInfos), emitter)),
-		bbs.DesireDesiredLRPRoute:          route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesireDesiredLRP), emitter)),
-		bbs.UpdateDesiredLRPRoute:          route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.UpdateDesiredLRP), emitter)),
-		bbs.RemoveDesiredLRPRoute:          route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.RemoveDesiredLRP), emitter)),
-
-		bbs.DesiredLRPsRoute_r0:             route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPs_r0), emitter)),
-		bbs.DesiredLRPsRoute_r1:             route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPs_r1), emitter)),
-		bbs.DesiredLRPByProcessGuidRoute_r0: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPByProcessGuid_r0), emitter)),
-		bbs.DesiredLRPByProcessGuidRoute_r1: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPByProcessGuid_r1), emitter)),
-		bbs.DesireDesiredLRPRoute_r0:        route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesireDesiredLRP_r0), emitter)),
-		bbs.DesireDesiredLRPRoute_r1:        route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesireDesiredLRP_r1), emitter)),
+		bbs.DesiredLRPsRoute:               route(middleware.RecordLatency(limitList(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPs, logSampling)), routeEmitter(bbs.DesiredLRPsRoute))),
+		bbs.DesiredLRPByProcessGuidRoute:   route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPByProcessGuid, logSampling), routeEmitter(bbs.DesiredLRPByProcessGuidRoute))),
+		bbs.DesiredLRPSchedulingInfosRoute: route(middleware.RecordLatency(limitList(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPSchedulingInfos, logSampling)), routeEmitter(bbs.DesiredLRPSchedulingInfosRoute))),
+		bbs.DesiredLRPProcessGuidsRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPProcessGuids, logSampling), routeEmitter(bbs.DesiredLRPProcessGuidsRoute))),
+		bbs.DesireDesiredLRPRoute:          route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesireDesiredLRP, logSampling), routeEmitter(bbs.DesireDesiredLRPRoute))),
+		bbs.DesireDesiredLRPsRoute:         route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesireDesiredLRPs, logSampling), routeEmitter(bbs.DesireDesiredLRPsRoute))),
+		bbs.UpdateDesiredLRPRoute:          route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.UpdateDesiredLRP, logSampling), routeEmitter(bbs.UpdateDesiredLRPRoute))),
+		bbs.UpdateDesiredLRPRoutesRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.UpdateDesiredLRPRoutes, logSampling), routeEmitter(bbs.UpdateDesiredLRPRoutesRoute))),
+		bbs.RemoveDesiredLRPRoute:          route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.RemoveDesiredLRP, logSampling), routeEmitter(bbs.RemoveDesiredLRPRoute))),
+
+		bbs.DesiredLRPsRoute_r0:             route(middleware.RecordLatency(limitList(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPs_r0, logSampling)), routeEmitter(bbs.DesiredLRPsRoute_r0))),
+		bbs.DesiredLRPsRoute_r1:             route(middleware.RecordLatency(limitList(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPs_r1, logSampling)), routeEmitter(bbs.DesiredLRPsRoute_r1))),
+		bbs.DesiredLRPByProcessGuidRoute_r0: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPByProcessGuid_r0, logSampling), routeEmitter(bbs.DesiredLRPByProcessGuidRoute_r0))),
+		bbs.DesiredLRPByProcessGuidRoute_r1: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesiredLRPByProcessGuid_r1, logSampling), routeEmitter(bbs.DesiredLRPByProcessGuidRoute_r1))),
+		bbs.DesireDesiredLRPRoute_r0:        route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesireDesiredLRP_r0, logSampling), routeEmitter(bbs.DesireDesiredLRPRoute_r0))),
+		bbs.DesireDesiredLRPRoute_r1:        route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, desiredLRPHandler.DesireDesiredLRP_r1, logSampling), routeEmitter(bbs.DesireDesiredLRPRoute_r1))),
 
 		// Tasks
-		bbs.TasksRoute:         route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.Tasks), emitter)),
-		bbs.TaskByGuidRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.TaskByGuid), emitter)),
-		bbs.DesireTaskRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.DesireTask), emitter)),
-		bbs.StartTaskRoute:     route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.StartTask), emitter)),
-		bbs.CancelTaskRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.CancelTask), emitter)),
-		bbs.FailTaskRoute:      route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.FailTask), emitter)),
-		bbs.CompleteTaskRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.CompleteTask), emitter)),
-		bbs.ResolvingTaskRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.ResolvingTask), emitter)),
-		bbs.DeleteTaskRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.DeleteTask), emitter)),
-
-		bbs.TasksRoute_r1:      route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.Tasks_r1), emitter)),
-		bbs.TasksRoute_r0:      route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.Tasks_r0), emitter)),
-		bbs.TaskByGuidRoute_r1: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.TaskByGuid_r1), emitter)),
-		bbs.TaskByGuidRoute_r0: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.TaskByGuid_r0), emitter)),
-		bbs.DesireTaskRoute_r1: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.DesireTask_r1), emitter)),
-		bbs.DesireTaskRoute_r0: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.DesireTask_r0), emitter)),
+		bbs.TasksRoute:         route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.Tasks, logSampling), routeEmitter(bbs.TasksRoute))),
+		bbs.TaskByGuidRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.TaskByGuid, logSampling), routeEmitter(bbs.TaskByGuidRoute))),
+		bbs.DesireTaskRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.DesireTask, logSampling), routeEmitter(bbs.DesireTaskRoute))),
+		bbs.StartTaskRoute:     route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.StartTask, logSampling), routeEmitter(bbs.StartTaskRoute))),
+		bbs.CancelTaskRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.CancelTask, logSampling), routeEmitter(bbs.CancelTaskRoute))),
+		bbs.FailTaskRoute:      route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.FailTask, logSampling), routeEmitter(bbs.FailTaskRoute))),
+		bbs.CompleteTaskRoute:  route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.CompleteTask, logSampling), routeEmitter(bbs.CompleteTaskRoute))),
+		bbs.ResolvingTaskRoute: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.ResolvingTask, logSampling), routeEmitter(bbs.ResolvingTaskRoute))),
+		bbs.DeleteTaskRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.DeleteTask, logSampling), routeEmitter(bbs.DeleteTaskRoute))),
+
+		bbs.TasksRoute_r1:      route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.Tasks_r1, logSampling), routeEmitter(bbs.TasksRoute_r1))),
+		bbs.TasksRoute_r0:      route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.Tasks_r0, logSampling), routeEmitter(bbs.TasksRoute_r0))),
+		bbs.TaskByGuidRoute_r1: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.TaskByGuid_r1, logSampling), routeEmitter(bbs.TaskByGuidRoute_r1))),
+		bbs.TaskByGuidRoute_r0: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.TaskByGuid_r0, logSampling), routeEmitter(bbs.TaskByGuidRoute_r0))),
+		bbs.DesireTaskRoute_r1: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.DesireTask_r1, logSampling), routeEmitter(bbs.DesireTaskRoute_r1))),
+		bbs.DesireTaskRoute_r0: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, taskHandler.DesireTask_r0, logSampling), routeEmitter(bbs.DesireTaskRoute_r0))),
 
 		// Events
-		bbs.EventStreamRoute_r0:     route(middleware.LogWrap(logger, accessLogger, eventsHandler.Subscribe_r0)),
-		bbs.TaskEventStreamRoute_r0: route(middleware.LogWrap(logger, accessLogger, taskEventsHandler.Subscribe_r0)),
+		bbs.EventStreamRoute_r0:     route(middleware.LogWrap(logger, accessLogger, eventsHandler.Subscribe_r0, logSampling)),
+		bbs.TaskEventStreamRoute_r0: route(middleware.LogWrap(logger, accessLogger, taskEventsHandler.Subscribe_r0, logSampling)),
 
 		// Cells
-		bbs.CellsRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, cellsHandler.Cells), emitter)),
-		bbs.CellsRoute_r1: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, cellsHandler.Cells), emitter)),
+		bbs.CellsRoute:    route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, cellsHandler.Cells, logSampling), routeEmitter(bbs.CellsRoute))),
+		bbs.CellsRoute_r1: route(middleware.RecordLatency(middleware.LogWrap(logger, accessLogger, cellsHandler.Cells, logSampling), routeEmitter(bbs.CellsRoute_r1))),
+	}
+
+	for routeName, handler := range actions {
+		actions[routeName] = middleware.RecordRequestCount(middleware.TraceWrap(routeName, handler, tracer), routeEmitter(routeName))
 	}
 
 	handler, err := rata.NewRouter(bbs.Routes, actions)
@@ -19,6 +19,24 @@ type FakeEmitter struct {
 	updateLatencyArgsForCall []struct {
 		latency time.Duration
 	}
+	UpdateLatencyWithStatusStub        func(latency time.Duration, statusCode int)
+	updateLatencyWithStatusMutex       sync.RWMutex
+	updateLatencyWithStatusArgsForCall []struct {
+		latency    time.Duration
+		statusCode int
+	}
+	IncrementRouteCounterStub        func(route string, delta int)
+	incrementRouteCounterMutex       sync.RWMutex
+	incrementRouteCounterArgsForCall []struct {
+		route string
+		delta int
+	}
+	UpdateRouteLatencyStub        func(route string, latency time.Duration)
+	updateRouteLatencyMutex       sync.RWMutex
+	updateRouteLatencyArgsForCall []struct {
+		route   string
+		latency time.Duration
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -71,6 +89,81 @@ func (fake *FakeEmitter) UpdateLatencyArgsForCall(i int) time.Duration {
 	return fake.updateLatencyArgsForCall[i].latency
 }
 
+func (fake *FakeEmitter) UpdateLatencyWithStatus(latency time.Duration, statusCode int) {
+	fake.updateLatencyWithStatusMutex.Lock()
+	fake.updateLatencyWithStatusArgsForCall = append(fake.updateLatencyWithStatusArgsForCall, struct {
+		latency    time.Duration
+		statusCode int
+	}{latency, statusCode})
+	fake.recordInvocation("UpdateLatencyWithStatus", []interface{}{latency, statusCode})
+	fake.updateLatencyWithStatusMutex.Unlock()
+	if fake.UpdateLatencyWithStatusStub != nil {
+		fake.UpdateLatencyWithStatusStub(latency, statusCode)
+	}
+}
+
+func (fake *FakeEmitter) UpdateLatencyWithStatusCallCount() int {
+	fake.updateLatencyWithStatusMutex.RLock()
+	defer fake.updateLatencyWithStatusMutex.RUnlock()
+	return len(fake.updateLatencyWithStatusArgsForCall)
+}
+
+func (fake *FakeEmitter) UpdateLatencyWithStatusArgsForCall(i int) (time.Duration, int) {
+	fake.updateLatencyWithStatusMutex.RLock()
+	defer fake.updateLatencyWithStatusMutex.RUnlock()
+	return fake.updateLatencyWithStatusArgsForCall[i].latency, fake.updateLatencyWithStatusArgsForCall[i].statusCode
+}
+
+func (fake *FakeEmitter) IncrementRouteCounter(route string, delta int) {
+	fake.incrementRouteCounterMutex.Lock()
+	fake.incrementRouteCounterArgsForCall = append(fake.incrementRouteCounterArgsForCall, struct {
+		route string
+		delta int
+	}{route, delta})
+	fake.recordInvocation("IncrementRouteCounter", []interface{}{route, delta})
+	fake.incrementRouteCounterMutex.Unlock()
+	if fake.IncrementRouteCounterStub != nil {
+		fake.IncrementRouteCounterStub(route, delta)
+	}
+}
+
+func (fake *FakeEmitter) IncrementRouteCounterCallCount() int {
+	fake.incrementRouteCounterMutex.RLock()
+	defer fake.incrementRouteCounterMutex.RUnlock()
+	return len(fake.incrementRouteCounterArgsForCall)
+}
+
+func (fake *FakeEmitter) IncrementRouteCounterArgsForCall(i int) (string, int) {
+	fake.incrementRouteCounterMutex.RLock()
+	defer fake.incrementRouteCounterMutex.RUnlock()
+	return fake.incrementRouteCounterArgsForCall[i].route, fake.incrementRouteCounterArgsForCall[i].delta
+}
+
+func (fake *FakeEmitter) UpdateRouteLatency(route string, latency time.Duration) {
+	fake.updateRouteLatencyMutex.Lock()
+	fake.updateRouteLatencyArgsForCall = append(fake.updateRouteLatencyArgsForCall, struct {
+		route   string
+		latency time.Duration
+	}{route, latency})
+	fake.recordInvocation("UpdateRouteLatency", []interface{}{route, latency})
+	fake.updateRouteLatencyMutex.Unlock()
+	if fake.UpdateRouteLatencyStub != nil {
+		fake.UpdateRouteLatencyStub(route, latency)
+	}
+}
+
+func (fake *FakeEmitter) UpdateRouteLatencyCallCount() int {
+	fake.updateRouteLatencyMutex.RLock()
+	defer fake.updateRouteLatencyMutex.RUnlock()
+	return len(fake.updateRouteLatencyArgsForCall)
+}
+
+func (fake *FakeEmitter) UpdateRouteLatencyArgsForCall(i int) (string, time.Duration) {
+	fake.updateRouteLatencyMutex.RLock()
+	defer fake.updateRouteLatencyMutex.RUnlock()
+	return fake.updateRouteLatencyArgsForCall[i].route, fake.updateRouteLatencyArgsForCall[i].latency
+}
+
 func (fake *FakeEmitter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -78,6 +171,12 @@ func (fake *FakeEmitter) Invocations() map[string][][]interface{} {
 	defer fake.incrementCounterMutex.RUnlock()
 	fake.updateLatencyMutex.RLock()
 	defer fake.updateLatencyMutex.RUnlock()
+	fake.updateLatencyWithStatusMutex.RLock()
+	defer fake.updateLatencyWithStatusMutex.RUnlock()
+	fake.incrementRouteCounterMutex.RLock()
+	defer fake.incrementRouteCounterMutex.RUnlock()
+	fake.updateRouteLatencyMutex.RLock()
+	defer fake.updateRouteLatencyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -1,10 +1,17 @@
 package middleware
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/nu7hatch/gouuid"
 )
 
 const (
@@ -12,33 +19,146 @@ const (
 	RequestCount   = "RequestCount"
 )
 
+// RequestIdHeader carries the id that correlates the logs of a request. LogWrap
+// generates one for requests without it and echoes it in the response.
+const RequestIdHeader = "X-Vcap-Request-Id"
+
+type requestIdKey struct{}
+
+// RequestIdFromContext returns the id LogWrap attached to the context of the
+// request, or an empty string outside of LogWrap.
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+// Returns the request id of the request, generating one if the request has
+// none, and the request with the id attached to its context.
+func withRequestId(r *http.Request) (string, *http.Request) {
+	requestId := r.Header.Get(RequestIdHeader)
+	if requestId == "" {
+		guid, err := uuid.NewV4()
+		if err == nil {
+			requestId = guid.String()
+		}
+	}
+	return requestId, r.WithContext(context.WithValue(r.Context(), requestIdKey{}, requestId))
+}
+
 type LoggableHandlerFunc func(logger lager.Logger, w http.ResponseWriter, r *http.Request)
 
 //go:generate counterfeiter -o fakes/fake_emitter.go . Emitter
 type Emitter interface {
 	IncrementCounter(delta int)
 	UpdateLatency(latency time.Duration)
+
+	// UpdateLatencyWithStatus records the latency of a request that was
+	// answered with the given HTTP status code.
+	UpdateLatencyWithStatus(latency time.Duration, statusCode int)
+
+	// IncrementRouteCounter and UpdateRouteLatency record the requests to the
+	// named route separately from, and without adding to, the totals.
+	IncrementRouteCounter(route string, delta int)
+	UpdateRouteLatency(route string, latency time.Duration)
+}
+
+// NewRouteEmitter returns an emitter that counts requests only under the route
+// name, leaving the total request count to be recorded around the router, and
+// records their latency both under the route name and in the totals of the
+// given emitter.
+func NewRouteEmitter(route string, emitter Emitter) Emitter {
+	return &routeEmitter{route: route, Emitter: emitter}
+}
+
+type routeEmitter struct {
+	Emitter
+	route string
+}
+
+func (e *routeEmitter) IncrementCounter(delta int) {
+	e.Emitter.IncrementRouteCounter(e.route, delta)
+}
+
+func (e *routeEmitter) UpdateLatency(latency time.Duration) {
+	e.Emitter.UpdateLatency(latency)
+	e.Emitter.UpdateRouteLatency(e.route, latency)
+}
+
+func (e *routeEmitter) UpdateLatencyWithStatus(latency time.Duration, statusCode int) {
+	e.Emitter.UpdateLatencyWithStatus(latency, statusCode)
+	e.Emitter.UpdateRouteLatency(e.route, latency)
+}
+
+// LogWrapOption configures the request logging done by LogWrap.
+type LogWrapOption func(*logWrapConfig)
+
+// WithLogSampling logs the serving and done messages of 1 in every n requests
+// at info level, so that occasional full traces are available without running
+// at debug level. The remaining requests are logged at debug level as usual.
+// A non-positive n disables sampling.
+func WithLogSampling(n int) LogWrapOption {
+	return func(c *logWrapConfig) {
+		if n > 0 {
+			c.sampleEvery = uint64(n)
+		}
+	}
 }
 
-func LogWrap(logger, accessLogger lager.Logger, loggableHandlerFunc LoggableHandlerFunc) http.HandlerFunc {
-	lagerDataFromReq := func(r *http.Request) lager.Data {
+type logWrapConfig struct {
+	sampleEvery uint64
+	requests    uint64
+}
+
+func (c *logWrapConfig) sampled() bool {
+	if c.sampleEvery == 0 {
+		return false
+	}
+	return (atomic.AddUint64(&c.requests, 1)-1)%c.sampleEvery == 0
+}
+
+// Logs that the request is being served and returns the function that logs
+// that it is done.
+func (c *logWrapConfig) logServing(requestLog lager.Logger) func() {
+	if !c.sampled() {
+		requestLog.Debug("serving")
+		return func() { requestLog.Debug("done") }
+	}
+
+	start := time.Now()
+	requestLog.Info("serving", lager.Data{"sampled": true})
+	return func() {
+		requestLog.Info("done", lager.Data{"sampled": true, "duration": time.Since(start)})
+	}
+}
+
+func LogWrap(logger, accessLogger lager.Logger, loggableHandlerFunc LoggableHandlerFunc, options ...LogWrapOption) http.HandlerFunc {
+	lagerDataFromReq := func(r *http.Request, requestId string) lager.Data {
 		return lager.Data{
-			"method":  r.Method,
-			"request": r.URL.String(),
+			"method":     r.Method,
+			"request":    r.URL.String(),
+			"request-id": requestId,
 		}
 	}
 
+	config := &logWrapConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
 	if accessLogger != nil {
 		return func(w http.ResponseWriter, r *http.Request) {
-			requestLog := logger.Session("request", lagerDataFromReq(r))
-			requestAccessLogger := accessLogger.Session("request", lagerDataFromReq(r))
+			requestId, r := withRequestId(r)
+			w.Header().Set(RequestIdHeader, requestId)
+
+			requestLog := logger.Session("request", lagerDataFromReq(r, requestId))
+			requestAccessLogger := accessLogger.Session("request", lagerDataFromReq(r, requestId))
 
 			requestAccessLogger.Info("serving")
 
-			requestLog.Debug("serving")
+			logDone := config.logServing(requestLog)
 
 			start := time.Now()
-			defer requestLog.Debug("done")
+			defer logDone()
 			defer func() {
 				requestAccessLogger.Info("done", lager.Data{"duration": time.Since(start)})
 			}()
@@ -46,10 +166,13 @@ func LogWrap(logger, accessLogger lager.Logger, loggableHandlerFunc LoggableHand
 		}
 	} else {
 		return func(w http.ResponseWriter, r *http.Request) {
-			requestLog := logger.Session("request", lagerDataFromReq(r))
+			requestId, r := withRequestId(r)
+			w.Header().Set(RequestIdHeader, requestId)
+
+			requestLog := logger.Session("request", lagerDataFromReq(r, requestId))
 
-			requestLog.Debug("serving")
-			defer requestLog.Debug("done")
+			logDone := config.logServing(requestLog)
+			defer logDone()
 
 			loggableHandlerFunc(requestLog, w, r)
 
@@ -57,11 +180,184 @@ func LogWrap(logger, accessLogger lager.Logger, loggableHandlerFunc LoggableHand
 	}
 }
 
+// RecordLatency reports the latency of every request to the emitter together
+// with the status code the handler responded with, which is 200 when the
+// handler never calls WriteHeader.
 func RecordLatency(f http.HandlerFunc, emitter Emitter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
-		f(w, r)
-		emitter.UpdateLatency(time.Since(startTime))
+		statusWriter := &statusCapturingWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		f(statusWriter, r)
+		emitter.UpdateLatencyWithStatus(time.Since(startTime), statusWriter.statusCode)
+	}
+}
+
+// Remembers the status code of the response. Like net/http, only the first
+// call to WriteHeader counts, and writing the body implies a 200.
+type statusCapturingWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+}
+
+func (w *statusCapturingWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.statusCode = statusCode
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusCapturingWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush, Hijack and CloseNotify forward to the wrapped writer, so that the
+// event stream handlers keep working behind the middleware.
+func (w *statusCapturingWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		flusher.Flush()
+	}
+}
+
+func (w *statusCapturingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	w.wroteHeader = true
+	return hijacker.Hijack()
+}
+
+// CloseNotify returns a channel that is never closed when the wrapped writer
+// cannot notify of closed connections.
+func (w *statusCapturingWriter) CloseNotify() <-chan bool {
+	if closeNotifier, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return closeNotifier.CloseNotify()
+	}
+	return make(chan bool)
+}
+
+//go:generate counterfeiter -o fakes/fake_tracer.go . Tracer
+
+// Tracer starts the spans TraceWrap records requests with, so that an
+// OpenTelemetry style tracing library can be plugged in without the BBS
+// depending on it.
+type Tracer interface {
+	// Start starts a span with the given name as a child of any span in ctx,
+	// and returns the context carrying the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+//go:generate counterfeiter -o fakes/fake_span.go . Span
+type Span interface {
+	SetAttribute(key string, value interface{})
+	End()
+}
+
+const (
+	TraceAttributeMethod   = "http.method"
+	TraceAttributeRoute    = "http.route"
+	TraceAttributeStatus   = "http.status_code"
+	TraceAttributeDuration = "duration_ms"
+)
+
+// TraceWrap records every request in a span named after the route, with the
+// method, route, status code and duration of the request as attributes. The
+// handler is served with the context carrying the span. A nil tracer does not
+// trace the handler.
+func TraceWrap(route string, handler http.Handler, tracer Tracer) http.HandlerFunc {
+	if tracer == nil {
+		return handler.ServeHTTP
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracer.Start(r.Context(), route)
+		defer span.End()
+
+		span.SetAttribute(TraceAttributeMethod, r.Method)
+		span.SetAttribute(TraceAttributeRoute, route)
+
+		startTime := time.Now()
+		statusWriter := &statusCapturingWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		handler.ServeHTTP(statusWriter, r.WithContext(ctx))
+
+		span.SetAttribute(TraceAttributeStatus, statusWriter.statusCode)
+		span.SetAttribute(TraceAttributeDuration, float64(time.Since(startTime))/float64(time.Millisecond))
+	}
+}
+
+// NewLagerTracer returns a tracer that logs every span, with its attributes,
+// when the span ends. It traces requests for deployments without a tracing
+// library.
+func NewLagerTracer(logger lager.Logger) Tracer {
+	return &lagerTracer{logger: logger}
+}
+
+type lagerTracer struct {
+	logger lager.Logger
+}
+
+func (t *lagerTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, &lagerSpan{logger: t.logger, data: lager.Data{"span": name}}
+}
+
+type lagerSpan struct {
+	logger lager.Logger
+
+	lock sync.Mutex
+	data lager.Data
+}
+
+func (s *lagerSpan) SetAttribute(key string, value interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.data[key] = value
+}
+
+func (s *lagerSpan) End() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.logger.Info("span", s.data)
+}
+
+// LimitConcurrency serves at most max requests with the handler at a time.
+// Requests beyond that are not queued, but rejected with a 503 and a
+// Retry-After header. A non-positive max does not limit the handler.
+func LimitConcurrency(max int, handler http.Handler) http.HandlerFunc {
+	return NewConcurrencyLimiter(max)(handler)
+}
+
+// A ConcurrencyLimiter wraps handlers so that they serve at most its max
+// requests at a time between them, like LimitConcurrency does for a single
+// handler.
+type ConcurrencyLimiter func(handler http.Handler) http.HandlerFunc
+
+// NewConcurrencyLimiter returns a limiter whose handlers share max slots. A
+// non-positive max does not limit them.
+func NewConcurrencyLimiter(max int) ConcurrencyLimiter {
+	if max <= 0 {
+		return func(handler http.Handler) http.HandlerFunc {
+			return handler.ServeHTTP
+		}
+	}
+
+	slots := make(chan struct{}, max)
+	return func(handler http.Handler) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			defer func() { <-slots }()
+
+			handler.ServeHTTP(w, r)
+		}
 	}
 }
 
@@ -1,7 +1,10 @@
 package middleware_test
 
 import (
+	"context"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"code.cloudfoundry.org/bbs/handlers/middleware"
@@ -36,6 +39,342 @@ var _ = Describe("Middleware", func() {
 		})
 	})
 
+	Describe("NewRouteEmitter", func() {
+		var emitter *fakes.FakeEmitter
+
+		BeforeEach(func() {
+			emitter = &fakes.FakeEmitter{}
+		})
+
+		It("counts the requests of each route under its name and records their latency under it and in the totals", func() {
+			desireHandler := middleware.RecordRequestCount(
+				middleware.RecordLatency(func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(10 * time.Millisecond)
+				}, middleware.NewRouteEmitter("DesireDesiredLRP_r2", emitter)),
+				middleware.NewRouteEmitter("DesireDesiredLRP_r2", emitter),
+			)
+			pingHandler := middleware.RecordRequestCount(
+				middleware.RecordLatency(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				}, middleware.NewRouteEmitter("Ping", emitter)),
+				middleware.NewRouteEmitter("Ping", emitter),
+			)
+
+			desireHandler.ServeHTTP(httptest.NewRecorder(), nil)
+			pingHandler.ServeHTTP(httptest.NewRecorder(), nil)
+			pingHandler.ServeHTTP(httptest.NewRecorder(), nil)
+
+			Expect(emitter.IncrementCounterCallCount()).To(Equal(0))
+			Expect(emitter.UpdateLatencyWithStatusCallCount()).To(Equal(3))
+
+			routeCounts := map[string]int{}
+			for i := 0; i < emitter.IncrementRouteCounterCallCount(); i++ {
+				route, delta := emitter.IncrementRouteCounterArgsForCall(i)
+				routeCounts[route] += delta
+			}
+			Expect(routeCounts).To(Equal(map[string]int{"DesireDesiredLRP_r2": 1, "Ping": 2}))
+
+			Expect(emitter.UpdateRouteLatencyCallCount()).To(Equal(3))
+			route, latency := emitter.UpdateRouteLatencyArgsForCall(0)
+			Expect(route).To(Equal("DesireDesiredLRP_r2"))
+			Expect(latency).To(BeNumerically(">=", 10*time.Millisecond))
+			route, _ = emitter.UpdateRouteLatencyArgsForCall(1)
+			Expect(route).To(Equal("Ping"))
+
+			_, statusCode := emitter.UpdateLatencyWithStatusArgsForCall(1)
+			Expect(statusCode).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	Describe("RecordLatency", func() {
+		var emitter *fakes.FakeEmitter
+
+		BeforeEach(func() {
+			emitter = &fakes.FakeEmitter{}
+		})
+
+		It("reports the latency with the status code the handler wrote", func() {
+			handler := middleware.RecordLatency(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(10 * time.Millisecond)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}, emitter)
+
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, nil)
+			Expect(response.Code).To(Equal(http.StatusServiceUnavailable))
+
+			Expect(emitter.UpdateLatencyWithStatusCallCount()).To(Equal(1))
+			latency, statusCode := emitter.UpdateLatencyWithStatusArgsForCall(0)
+			Expect(latency).To(BeNumerically(">=", 10*time.Millisecond))
+			Expect(statusCode).To(Equal(http.StatusServiceUnavailable))
+		})
+
+		It("reports a 200 when the handler never writes a status code", func() {
+			handler := middleware.RecordLatency(func(w http.ResponseWriter, r *http.Request) {}, emitter)
+
+			handler.ServeHTTP(httptest.NewRecorder(), nil)
+
+			Expect(emitter.UpdateLatencyWithStatusCallCount()).To(Equal(1))
+			_, statusCode := emitter.UpdateLatencyWithStatusArgsForCall(0)
+			Expect(statusCode).To(Equal(http.StatusOK))
+		})
+
+		It("reports the first status code the handler wrote", func() {
+			handler := middleware.RecordLatency(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("body"))
+				w.WriteHeader(http.StatusInternalServerError)
+			}, emitter)
+
+			handler.ServeHTTP(httptest.NewRecorder(), nil)
+
+			_, statusCode := emitter.UpdateLatencyWithStatusArgsForCall(0)
+			Expect(statusCode).To(Equal(http.StatusOK))
+		})
+
+		It("serves the handler with a writer that can flush, hijack and notify of closed connections", func() {
+			var canFlush, canCloseNotify bool
+			var hijackErr error
+			server := httptest.NewServer(middleware.RecordLatency(func(w http.ResponseWriter, r *http.Request) {
+				_, canFlush = w.(http.Flusher)
+				_, canCloseNotify = w.(http.CloseNotifier)
+
+				conn, rw, err := w.(http.Hijacker).Hijack()
+				hijackErr = err
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nok")
+				rw.Flush()
+			}, emitter))
+			defer server.Close()
+
+			response, err := http.Get(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("ok"))
+
+			Expect(hijackErr).NotTo(HaveOccurred())
+			Expect(canFlush).To(BeTrue())
+			Expect(canCloseNotify).To(BeTrue())
+		})
+
+		It("fails to hijack when the wrapped writer cannot be hijacked", func() {
+			var hijackErr error
+			handler := middleware.RecordLatency(func(w http.ResponseWriter, r *http.Request) {
+				_, _, hijackErr = w.(http.Hijacker).Hijack()
+			}, emitter)
+
+			handler.ServeHTTP(httptest.NewRecorder(), nil)
+			Expect(hijackErr).To(HaveOccurred())
+		})
+	})
+
+	Describe("TraceWrap", func() {
+		type spanKey struct{}
+
+		var (
+			tracer *fakes.FakeTracer
+			span   *fakes.FakeSpan
+		)
+
+		BeforeEach(func() {
+			span = &fakes.FakeSpan{}
+			tracer = &fakes.FakeTracer{}
+			tracer.StartStub = func(ctx context.Context, name string) (context.Context, middleware.Span) {
+				return context.WithValue(ctx, spanKey{}, span), span
+			}
+		})
+
+		attributes := func() map[string]interface{} {
+			attributes := map[string]interface{}{}
+			for i := 0; i < span.SetAttributeCallCount(); i++ {
+				key, value := span.SetAttributeArgsForCall(i)
+				attributes[key] = value
+			}
+			return attributes
+		}
+
+		It("records a span with the route and status of the request", func() {
+			var handlerCtx context.Context
+			handler := middleware.TraceWrap("DesiredLRPByProcessGuid_r2", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerCtx = r.Context()
+				Expect(span.EndCallCount()).To(BeZero())
+				w.WriteHeader(http.StatusNotFound)
+			}), tracer)
+
+			request, err := http.NewRequest("POST", "/v1/desired_lrps/get_by_process_guid.r2", nil)
+			Expect(err).NotTo(HaveOccurred())
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, request)
+			Expect(response.Code).To(Equal(http.StatusNotFound))
+
+			Expect(tracer.StartCallCount()).To(Equal(1))
+			_, name := tracer.StartArgsForCall(0)
+			Expect(name).To(Equal("DesiredLRPByProcessGuid_r2"))
+
+			Expect(handlerCtx.Value(spanKey{})).To(Equal(span))
+
+			Expect(attributes()).To(HaveKeyWithValue(middleware.TraceAttributeMethod, "POST"))
+			Expect(attributes()).To(HaveKeyWithValue(middleware.TraceAttributeRoute, "DesiredLRPByProcessGuid_r2"))
+			Expect(attributes()).To(HaveKeyWithValue(middleware.TraceAttributeStatus, http.StatusNotFound))
+			Expect(attributes()).To(HaveKey(middleware.TraceAttributeDuration))
+			Expect(span.EndCallCount()).To(Equal(1))
+		})
+
+		It("serves the handler untraced without a tracer", func() {
+			called := false
+			handler := middleware.TraceWrap("Ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}), nil)
+
+			request, err := http.NewRequest("GET", "/ping", nil)
+			Expect(err).NotTo(HaveOccurred())
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+			Expect(called).To(BeTrue())
+		})
+	})
+
+	Describe("NewLagerTracer", func() {
+		It("logs each span with its attributes when it ends", func() {
+			logger := lagertest.NewTestLogger("test")
+			tracer := middleware.NewLagerTracer(logger)
+
+			handler := middleware.TraceWrap("Ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}), tracer)
+
+			request, err := http.NewRequest("GET", "/v1/ping", nil)
+			Expect(err).NotTo(HaveOccurred())
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+
+			logs := logger.LogMessages()
+			Expect(logs).To(ConsistOf("test.span"))
+			data := logger.Logs()[0].Data
+			Expect(data).To(HaveKeyWithValue("span", "Ping"))
+			Expect(data).To(HaveKeyWithValue(middleware.TraceAttributeMethod, "GET"))
+			Expect(data).To(HaveKeyWithValue(middleware.TraceAttributeRoute, "Ping"))
+			Expect(data).To(HaveKeyWithValue(middleware.TraceAttributeStatus, BeNumerically("==", http.StatusTeapot)))
+			Expect(data).To(HaveKey(middleware.TraceAttributeDuration))
+		})
+	})
+
+	Describe("LimitConcurrency", func() {
+		var (
+			started chan struct{}
+			release chan struct{}
+			handler http.HandlerFunc
+		)
+
+		BeforeEach(func() {
+			started = make(chan struct{})
+			release = make(chan struct{})
+			handler = middleware.LimitConcurrency(2, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				started <- struct{}{}
+				<-release
+			}))
+		})
+
+		serveInBackground := func() <-chan *httptest.ResponseRecorder {
+			done := make(chan *httptest.ResponseRecorder, 1)
+			go func() {
+				defer GinkgoRecover()
+				response := httptest.NewRecorder()
+				handler.ServeHTTP(response, nil)
+				done <- response
+			}()
+			return done
+		}
+
+		It("serves up to max concurrent requests and rejects the next one with a 503", func() {
+			first := serveInBackground()
+			Eventually(started).Should(Receive())
+			second := serveInBackground()
+			Eventually(started).Should(Receive())
+
+			response := httptest.NewRecorder()
+			handler.ServeHTTP(response, nil)
+			Expect(response.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(response.Header().Get("Retry-After")).NotTo(BeEmpty())
+
+			close(release)
+			Expect((<-first).Code).To(Equal(http.StatusOK))
+			Expect((<-second).Code).To(Equal(http.StatusOK))
+		})
+
+		It("frees a slot once a request completes", func() {
+			close(release)
+			for i := 0; i < 3; i++ {
+				done := serveInBackground()
+				Eventually(started).Should(Receive())
+				Expect((<-done).Code).To(Equal(http.StatusOK))
+			}
+		})
+
+		It("frees the slot of a handler that panics", func() {
+			handler = middleware.LimitConcurrency(1, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				panic("boom")
+			}))
+			Expect(func() { handler.ServeHTTP(httptest.NewRecorder(), nil) }).To(Panic())
+			Expect(func() { handler.ServeHTTP(httptest.NewRecorder(), nil) }).To(Panic())
+		})
+
+		It("does not limit the handler when max is not positive", func() {
+			handler = middleware.LimitConcurrency(0, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				started <- struct{}{}
+				<-release
+			}))
+
+			done := []<-chan *httptest.ResponseRecorder{}
+			for i := 0; i < 5; i++ {
+				done = append(done, serveInBackground())
+				Eventually(started).Should(Receive())
+			}
+
+			close(release)
+			for _, d := range done {
+				Expect((<-d).Code).To(Equal(http.StatusOK))
+			}
+		})
+	})
+
+	Describe("NewConcurrencyLimiter", func() {
+		It("shares the limit between the handlers it wraps", func() {
+			started := make(chan struct{})
+			release := make(chan struct{})
+			limit := middleware.NewConcurrencyLimiter(1)
+			blocking := limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				started <- struct{}{}
+				<-release
+			}))
+			other := limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			done := make(chan *httptest.ResponseRecorder, 1)
+			go func() {
+				defer GinkgoRecover()
+				response := httptest.NewRecorder()
+				blocking.ServeHTTP(response, nil)
+				done <- response
+			}()
+			Eventually(started).Should(Receive())
+
+			response := httptest.NewRecorder()
+			other.ServeHTTP(response, nil)
+			Expect(response.Code).To(Equal(http.StatusServiceUnavailable))
+
+			close(release)
+			Expect((<-done).Code).To(Equal(http.StatusOK))
+
+			response = httptest.NewRecorder()
+			other.ServeHTTP(response, nil)
+			Expect(response.Code).To(Equal(http.StatusOK))
+		})
+	})
+
 	Describe("LogWrap", func() {
 		var (
 			logger              *lagertest.TestLogger
@@ -55,7 +394,7 @@ var _ = Describe("Middleware", func() {
 			handler := middleware.LogWrap(logger, nil, loggableHandlerFunc)
 			req, err := http.NewRequest("GET", "http://example.com", nil)
 			Expect(err).NotTo(HaveOccurred())
-			handler.ServeHTTP(nil, req)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
 			Expect(logger.Buffer()).To(gbytes.Say("test-session.request.serving"))
 			Expect(logger.Buffer()).To(gbytes.Say("\"session\":\"1\""))
 			Expect(logger.Buffer()).To(gbytes.Say("test-session.request.logger-group.written-in-loggable-handler"))
@@ -76,7 +415,7 @@ var _ = Describe("Middleware", func() {
 				handler := middleware.LogWrap(logger, accessLogger, loggableHandlerFunc)
 				req, err := http.NewRequest("GET", "http://example.com", nil)
 				Expect(err).NotTo(HaveOccurred())
-				handler.ServeHTTP(nil, req)
+				handler.ServeHTTP(httptest.NewRecorder(), req)
 				Expect(logger.Buffer()).To(gbytes.Say("test-session.request.serving"))
 				Expect(logger.Buffer()).To(gbytes.Say("\"session\":\"1\""))
 				Expect(accessLogger.Buffer()).To(gbytes.Say("test-access-session.request.serving"))
@@ -89,5 +428,109 @@ var _ = Describe("Middleware", func() {
 				Expect(logger.Buffer()).To(gbytes.Say("\"session\":\"1\""))
 			})
 		})
+
+		Context("request ids", func() {
+			var (
+				accessLogger      *lagertest.TestLogger
+				contextRequestIds []string
+			)
+
+			BeforeEach(func() {
+				accessLogger = lagertest.NewTestLogger("test-access-session")
+				contextRequestIds = []string{}
+				loggableHandlerFunc = func(logger lager.Logger, w http.ResponseWriter, r *http.Request) {
+					contextRequestIds = append(contextRequestIds, middleware.RequestIdFromContext(r.Context()))
+				}
+			})
+
+			expectRequestIdInLogs := func(logger *lagertest.TestLogger, requestId string) {
+				Expect(logger.Logs()).NotTo(BeEmpty())
+				for _, log := range logger.Logs() {
+					Expect(log.Data).To(HaveKeyWithValue("request-id", requestId), log.Message)
+				}
+			}
+
+			It("preserves and echoes a provided request id", func() {
+				handler := middleware.LogWrap(logger, accessLogger, loggableHandlerFunc)
+				req, err := http.NewRequest("GET", "http://example.com", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set(middleware.RequestIdHeader, "some-request-id")
+
+				response := httptest.NewRecorder()
+				handler.ServeHTTP(response, req)
+
+				Expect(response.Header().Get(middleware.RequestIdHeader)).To(Equal("some-request-id"))
+				Expect(contextRequestIds).To(Equal([]string{"some-request-id"}))
+				expectRequestIdInLogs(logger, "some-request-id")
+				expectRequestIdInLogs(accessLogger, "some-request-id")
+			})
+
+			It("generates and echoes a request id when none is provided", func() {
+				handler := middleware.LogWrap(logger, nil, loggableHandlerFunc)
+
+				responses := []*httptest.ResponseRecorder{}
+				for i := 0; i < 2; i++ {
+					req, err := http.NewRequest("GET", "http://example.com", nil)
+					Expect(err).NotTo(HaveOccurred())
+					response := httptest.NewRecorder()
+					handler.ServeHTTP(response, req)
+					responses = append(responses, response)
+				}
+
+				first := responses[0].Header().Get(middleware.RequestIdHeader)
+				second := responses[1].Header().Get(middleware.RequestIdHeader)
+				Expect(first).NotTo(BeEmpty())
+				Expect(second).NotTo(Equal(first))
+				Expect(contextRequestIds).To(Equal([]string{first, second}))
+			})
+		})
+
+		Context("with log sampling", func() {
+			serve := func(handler http.HandlerFunc, times int) {
+				for i := 0; i < times; i++ {
+					req, err := http.NewRequest("GET", "http://example.com", nil)
+					Expect(err).NotTo(HaveOccurred())
+					handler.ServeHTTP(httptest.NewRecorder(), req)
+				}
+			}
+
+			countLogs := func(message string, level lager.LogLevel) int {
+				count := 0
+				for _, log := range logger.Logs() {
+					if log.Message == message && log.LogLevel == level {
+						count++
+					}
+				}
+				return count
+			}
+
+			It("logs every request at info level when sampling 1 in 1", func() {
+				handler := middleware.LogWrap(logger, nil, loggableHandlerFunc, middleware.WithLogSampling(1))
+				serve(handler, 10)
+
+				Expect(countLogs("test-session.request.serving", lager.INFO)).To(Equal(10))
+				Expect(countLogs("test-session.request.done", lager.INFO)).To(Equal(10))
+				Expect(countLogs("test-session.request.serving", lager.DEBUG)).To(Equal(0))
+			})
+
+			It("logs about 1% of requests at info level when sampling 1 in 100", func() {
+				handler := middleware.LogWrap(logger, nil, loggableHandlerFunc, middleware.WithLogSampling(100))
+				serve(handler, 1000)
+
+				Expect(countLogs("test-session.request.serving", lager.INFO)).To(BeNumerically("~", 10, 1))
+				Expect(countLogs("test-session.request.done", lager.INFO)).To(BeNumerically("~", 10, 1))
+				Expect(countLogs("test-session.request.serving", lager.DEBUG)).To(BeNumerically("~", 990, 1))
+			})
+
+			It("still logs sampled requests to the access logger as usual", func() {
+				accessLogger := lagertest.NewTestLogger("test-access-session")
+				handler := middleware.LogWrap(logger, accessLogger, loggableHandlerFunc, middleware.WithLogSampling(1))
+				serve(handler, 1)
+
+				Expect(countLogs("test-session.request.serving", lager.INFO)).To(Equal(1))
+				Expect(accessLogger.Buffer()).To(gbytes.Say("test-access-session.request.serving"))
+				Expect(accessLogger.Buffer()).To(gbytes.Say("test-access-session.request.done"))
+			})
+		})
 	})
 })
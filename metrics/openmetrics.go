@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	loggregator_v2 "code.cloudfoundry.org/go-loggregator/compatibility"
+)
+
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+var invalidMetricNameChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// DefaultMaxOpenMetrics is the number of distinct metrics an
+// OpenMetricsRecorder keeps unless told otherwise.
+const DefaultMaxOpenMetrics = 1000
+
+const (
+	openMetricGauge   = "gauge"
+	openMetricCounter = "counter"
+)
+
+// OpenMetricsRecorder is a metron client that remembers the last value of
+// every value and duration metric and the running total of every counter it
+// emits, and serves them in the OpenMetrics text format so that they can be
+// scraped without a Prometheus client. Values and durations are exposed as
+// gauges, durations in seconds.
+//
+// At most maxMetrics distinct metrics are kept, as metric names may contain
+// process guids or domains. Once that many are kept, metrics with new names
+// are only forwarded. So are metrics whose OpenMetrics name is already taken
+// by a metric emitted under another name, or of another type, so that every
+// name has a single type.
+type OpenMetricsRecorder struct {
+	loggregator_v2.IngressClient
+
+	maxMetrics int
+
+	lock    sync.Mutex
+	metrics map[string]*openMetric
+}
+
+type openMetric struct {
+	emittedName string
+	metricType  string
+	gauge       float64
+	counter     uint64
+}
+
+// NewOpenMetricsRecorder returns a recorder that keeps at most maxMetrics
+// distinct metrics, or DefaultMaxOpenMetrics if maxMetrics is not positive.
+func NewOpenMetricsRecorder(client loggregator_v2.IngressClient, maxMetrics int) *OpenMetricsRecorder {
+	if maxMetrics <= 0 {
+		maxMetrics = DefaultMaxOpenMetrics
+	}
+
+	return &OpenMetricsRecorder{
+		IngressClient: client,
+		maxMetrics:    maxMetrics,
+		metrics:       map[string]*openMetric{},
+	}
+}
+
+func (r *OpenMetricsRecorder) IncrementCounter(name string) error {
+	r.addToCounter(name, 1)
+	return r.IngressClient.IncrementCounter(name)
+}
+
+func (r *OpenMetricsRecorder) IncrementCounterWithDelta(name string, value uint64) error {
+	r.addToCounter(name, value)
+	return r.IngressClient.IncrementCounterWithDelta(name, value)
+}
+
+func (r *OpenMetricsRecorder) SendDuration(name string, value time.Duration) error {
+	r.setGauge(name+"_seconds", value.Seconds())
+	return r.IngressClient.SendDuration(name, value)
+}
+
+func (r *OpenMetricsRecorder) SendMetric(name string, value int) error {
+	r.setGauge(name, float64(value))
+	return r.IngressClient.SendMetric(name, value)
+}
+
+func (r *OpenMetricsRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	w.Header().Set("Content-Type", openMetricsContentType)
+
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		metric := r.metrics[name]
+		fmt.Fprintf(w, "# TYPE %s %s\n", name, metric.metricType)
+		switch metric.metricType {
+		case openMetricGauge:
+			fmt.Fprintf(w, "%s %g\n", name, metric.gauge)
+		case openMetricCounter:
+			fmt.Fprintf(w, "%s_total %d\n", name, metric.counter)
+		}
+	}
+	fmt.Fprint(w, "# EOF\n")
+}
+
+func (r *OpenMetricsRecorder) setGauge(name string, value float64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	metric := r.metric(name, openMetricGauge)
+	if metric != nil {
+		metric.gauge = value
+	}
+}
+
+func (r *OpenMetricsRecorder) addToCounter(name string, delta uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	metric := r.metric(name, openMetricCounter)
+	if metric != nil {
+		metric.counter += delta
+	}
+}
+
+// Returns the metric kept for the emitted name, keeping a new one if there is
+// room, or nil if it is not to be kept. The caller must hold the lock.
+func (r *OpenMetricsRecorder) metric(emittedName, metricType string) *openMetric {
+	name := openMetricName(emittedName)
+
+	metric, ok := r.metrics[name]
+	if ok {
+		if metric.emittedName != emittedName || metric.metricType != metricType {
+			return nil
+		}
+		return metric
+	}
+
+	if len(r.metrics) >= r.maxMetrics {
+		return nil
+	}
+
+	metric = &openMetric{emittedName: emittedName, metricType: metricType}
+	r.metrics[name] = metric
+	return metric
+}
+
+// Metric names such as "Domain.cf-apps" are not valid OpenMetrics names, so
+// every character outside [a-zA-Z0-9_:] is replaced with an underscore.
+func openMetricName(name string) string {
+	name = invalidMetricNameChars.ReplaceAllString(name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,127 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/bbs/metrics"
+	mfakes "code.cloudfoundry.org/go-loggregator/testhelpers/fakes/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenMetricsRecorder", func() {
+	var (
+		fakeMetronClient *mfakes.FakeIngressClient
+		recorder         *metrics.OpenMetricsRecorder
+	)
+
+	BeforeEach(func() {
+		fakeMetronClient = new(mfakes.FakeIngressClient)
+		recorder = metrics.NewOpenMetricsRecorder(fakeMetronClient, 0)
+	})
+
+	scrape := func() *httptest.ResponseRecorder {
+		response := httptest.NewRecorder()
+		request, err := http.NewRequest("GET", "/metrics", nil)
+		Expect(err).NotTo(HaveOccurred())
+		recorder.ServeHTTP(response, request)
+		return response
+	}
+
+	It("forwards metrics to the wrapped client", func() {
+		Expect(recorder.SendMetric("LRPsMissing", 3)).To(Succeed())
+		Expect(recorder.SendDuration("ConvergenceLRPDuration", time.Second)).To(Succeed())
+		Expect(recorder.IncrementCounter("ConvergenceLRPRuns")).To(Succeed())
+		Expect(recorder.IncrementCounterWithDelta("RequestCount", 5)).To(Succeed())
+
+		Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(1))
+		Expect(fakeMetronClient.SendDurationCallCount()).To(Equal(1))
+		Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+		Expect(fakeMetronClient.IncrementCounterWithDeltaCallCount()).To(Equal(1))
+	})
+
+	It("renders the last value of gauges and the total of counters as OpenMetrics", func() {
+		recorder.SendMetric("LRPsMissing", 3)
+		recorder.SendMetric("LRPsMissing", 2)
+		recorder.SendMetric("Domain.cf-apps", 1)
+		recorder.SendDuration("ConvergenceLRPDuration", 1500*time.Millisecond)
+		recorder.IncrementCounter("ConvergenceLRPRuns")
+		recorder.IncrementCounterWithDelta("ConvergenceLRPRuns", 2)
+
+		response := scrape()
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(response.Header().Get("Content-Type")).To(HavePrefix("application/openmetrics-text"))
+
+		body := response.Body.String()
+		Expect(body).To(HaveSuffix("# EOF\n"))
+
+		lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+		sample := regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]* -?[0-9.e+]+$`)
+		typeLine := regexp.MustCompile(`^# TYPE [a-zA-Z_:][a-zA-Z0-9_:]* (gauge|counter)$`)
+		for _, line := range lines[:len(lines)-1] {
+			Expect(sample.MatchString(line) || typeLine.MatchString(line)).To(BeTrue(), line)
+		}
+
+		Expect(lines).To(ContainElement("# TYPE LRPsMissing gauge"))
+		Expect(lines).To(ContainElement("LRPsMissing 2"))
+		Expect(lines).To(ContainElement("# TYPE Domain_cf_apps gauge"))
+		Expect(lines).To(ContainElement("Domain_cf_apps 1"))
+		Expect(lines).To(ContainElement("# TYPE ConvergenceLRPDuration_seconds gauge"))
+		Expect(lines).To(ContainElement("ConvergenceLRPDuration_seconds 1.5"))
+		Expect(lines).To(ContainElement("# TYPE ConvergenceLRPRuns counter"))
+		Expect(lines).To(ContainElement("ConvergenceLRPRuns_total 3"))
+	})
+
+	It("keeps the first of the metrics whose names sanitise to the same name", func() {
+		recorder.SendMetric("Domain.cf-apps", 1)
+		recorder.SendMetric("Domain_cf_apps", 2)
+		recorder.SendMetric("Domain.cf-apps", 3)
+
+		Expect(scrape().Body.String()).To(Equal("# TYPE Domain_cf_apps gauge\nDomain_cf_apps 3\n# EOF\n"))
+		Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(3))
+	})
+
+	It("renders a single type for a name emitted as both a gauge and a counter", func() {
+		recorder.IncrementCounter("LRPsMissing")
+		recorder.SendMetric("LRPsMissing", 3)
+		recorder.IncrementCounter("LRPsMissing")
+
+		Expect(scrape().Body.String()).To(Equal("# TYPE LRPsMissing counter\nLRPsMissing_total 2\n# EOF\n"))
+		Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(1))
+	})
+
+	Context("when the maximum number of metrics is kept", func() {
+		BeforeEach(func() {
+			recorder = metrics.NewOpenMetricsRecorder(fakeMetronClient, 2)
+			recorder.SendMetric("LRPsMissing", 1)
+			recorder.IncrementCounter("ConvergenceLRPRuns")
+		})
+
+		It("only forwards metrics with new names", func() {
+			recorder.SendMetric("LRPsExtra", 5)
+			recorder.IncrementCounter("ConvergenceTaskRuns")
+
+			body := scrape().Body.String()
+			Expect(body).NotTo(ContainSubstring("LRPsExtra"))
+			Expect(body).NotTo(ContainSubstring("ConvergenceTaskRuns"))
+			Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(2))
+			Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(2))
+		})
+
+		It("keeps updating the metrics it already keeps", func() {
+			recorder.SendMetric("LRPsMissing", 4)
+			recorder.IncrementCounter("ConvergenceLRPRuns")
+
+			Expect(scrape().Body.String()).To(Equal("# TYPE ConvergenceLRPRuns counter\nConvergenceLRPRuns_total 2\n# TYPE LRPsMissing gauge\nLRPsMissing 4\n# EOF\n"))
+		})
+	})
+
+	It("renders only the EOF marker when nothing has been emitted", func() {
+		Expect(scrape().Body.String()).To(Equal("# EOF\n"))
+	})
+})
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"time"
+
+	loggregator_v2 "code.cloudfoundry.org/go-loggregator/compatibility"
+)
+
+// NewPrefixedMetronClient returns a metron client that prepends prefix to the
+// name of every counter, duration and value metric it emits, so that several
+// BBS deployments can report into the same metrics backend. An empty prefix
+// returns the client unchanged.
+func NewPrefixedMetronClient(client loggregator_v2.IngressClient, prefix string) loggregator_v2.IngressClient {
+	if prefix == "" {
+		return client
+	}
+	return &prefixedMetronClient{IngressClient: client, prefix: prefix}
+}
+
+type prefixedMetronClient struct {
+	loggregator_v2.IngressClient
+	prefix string
+}
+
+func (c *prefixedMetronClient) IncrementCounter(name string) error {
+	return c.IngressClient.IncrementCounter(c.prefix + name)
+}
+
+func (c *prefixedMetronClient) IncrementCounterWithDelta(name string, value uint64) error {
+	return c.IngressClient.IncrementCounterWithDelta(c.prefix+name, value)
+}
+
+func (c *prefixedMetronClient) SendDuration(name string, value time.Duration) error {
+	return c.IngressClient.SendDuration(c.prefix+name, value)
+}
+
+func (c *prefixedMetronClient) SendMetric(name string, value int) error {
+	return c.IngressClient.SendMetric(c.prefix+name, value)
+}
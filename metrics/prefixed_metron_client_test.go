@@ -0,0 +1,51 @@
+package metrics_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/bbs/metrics"
+	mfakes "code.cloudfoundry.org/go-loggregator/testhelpers/fakes/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrefixedMetronClient", func() {
+	var fakeMetronClient *mfakes.FakeIngressClient
+
+	BeforeEach(func() {
+		fakeMetronClient = new(mfakes.FakeIngressClient)
+	})
+
+	It("prefixes the names of emitted metrics", func() {
+		client := metrics.NewPrefixedMetronClient(fakeMetronClient, "diego-west.")
+
+		Expect(client.SendMetric("LRPsDesired", 3)).To(Succeed())
+		Expect(client.SendDuration("RequestLatency", time.Second)).To(Succeed())
+		Expect(client.IncrementCounter("ConvergenceLRPRuns")).To(Succeed())
+		Expect(client.IncrementCounterWithDelta("RequestCount", 5)).To(Succeed())
+
+		Expect(fakeMetronClient.SendMetricCallCount()).To(Equal(1))
+		name, value := fakeMetronClient.SendMetricArgsForCall(0)
+		Expect(name).To(Equal("diego-west.LRPsDesired"))
+		Expect(value).To(Equal(3))
+
+		Expect(fakeMetronClient.SendDurationCallCount()).To(Equal(1))
+		name, duration := fakeMetronClient.SendDurationArgsForCall(0)
+		Expect(name).To(Equal("diego-west.RequestLatency"))
+		Expect(duration).To(Equal(time.Second))
+
+		Expect(fakeMetronClient.IncrementCounterCallCount()).To(Equal(1))
+		Expect(fakeMetronClient.IncrementCounterArgsForCall(0)).To(Equal("diego-west.ConvergenceLRPRuns"))
+
+		Expect(fakeMetronClient.IncrementCounterWithDeltaCallCount()).To(Equal(1))
+		name, delta := fakeMetronClient.IncrementCounterWithDeltaArgsForCall(0)
+		Expect(name).To(Equal("diego-west.RequestCount"))
+		Expect(delta).To(BeEquivalentTo(5))
+	})
+
+	It("returns the client unchanged when the prefix is empty", func() {
+		client := metrics.NewPrefixedMetronClient(fakeMetronClient, "")
+		Expect(client).To(BeIdenticalTo(fakeMetronClient))
+	})
+})
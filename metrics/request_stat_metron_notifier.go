@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"fmt"
 	"os"
 	"sync"
 	"sync/atomic"
@@ -17,19 +18,25 @@ const (
 )
 
 type RequestStatMetronNotifier struct {
-	logger            lager.Logger
-	ticker            clock.Ticker
-	requestCount      uint64
-	maxRequestLatency time.Duration
-	lock              sync.Mutex
-	metronClient      loggregator_v2.IngressClient
+	logger                         lager.Logger
+	ticker                         clock.Ticker
+	requestCount                   uint64
+	maxRequestLatency              time.Duration
+	maxRequestLatencyByStatusClass map[int]time.Duration
+	requestCountByRoute            map[string]uint64
+	maxRequestLatencyByRoute       map[string]time.Duration
+	lock                           sync.Mutex
+	metronClient                   loggregator_v2.IngressClient
 }
 
 func NewRequestStatMetronNotifier(logger lager.Logger, ticker clock.Ticker, metronClient loggregator_v2.IngressClient) *RequestStatMetronNotifier {
 	return &RequestStatMetronNotifier{
-		logger:       logger,
-		ticker:       ticker,
-		metronClient: metronClient,
+		logger:                         logger,
+		ticker:                         ticker,
+		metronClient:                   metronClient,
+		maxRequestLatencyByStatusClass: map[int]time.Duration{},
+		requestCountByRoute:            map[string]uint64{},
+		maxRequestLatencyByRoute:       map[string]time.Duration{},
 	}
 }
 
@@ -45,6 +52,61 @@ func (notifier *RequestStatMetronNotifier) UpdateLatency(latency time.Duration)
 	}
 }
 
+// UpdateLatencyWithStatus updates the maximum request latency like
+// UpdateLatency, and the maximum latency of the requests in the status code's
+// class, which is emitted as e.g. RequestLatency.5xx.
+func (notifier *RequestStatMetronNotifier) UpdateLatencyWithStatus(latency time.Duration, statusCode int) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+	if latency > notifier.maxRequestLatency {
+		notifier.maxRequestLatency = latency
+	}
+	class := statusCode / 100
+	if latency > notifier.maxRequestLatencyByStatusClass[class] {
+		notifier.maxRequestLatencyByStatusClass[class] = latency
+	}
+}
+
+// IncrementRouteCounter counts requests to the route, which are emitted as
+// e.g. RequestCount.DesireDesiredLRP_r2. It does not add to RequestCount.
+func (notifier *RequestStatMetronNotifier) IncrementRouteCounter(route string, delta int) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+	notifier.requestCountByRoute[route] += uint64(delta)
+}
+
+// UpdateRouteLatency updates the maximum latency of the requests to the
+// route, which is emitted as e.g. RequestLatency.DesireDesiredLRP_r2. It does
+// not update RequestLatency.
+func (notifier *RequestStatMetronNotifier) UpdateRouteLatency(route string, latency time.Duration) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+	if latency > notifier.maxRequestLatencyByRoute[route] {
+		notifier.maxRequestLatencyByRoute[route] = latency
+	}
+}
+
+func (notifier *RequestStatMetronNotifier) readAndResetRouteStats() (map[string]uint64, map[string]time.Duration) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	counts, latencies := notifier.requestCountByRoute, notifier.maxRequestLatencyByRoute
+	notifier.requestCountByRoute = map[string]uint64{}
+	notifier.maxRequestLatencyByRoute = map[string]time.Duration{}
+
+	return counts, latencies
+}
+
+func (notifier *RequestStatMetronNotifier) readAndResetLatencyByStatusClass() map[int]time.Duration {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	latencies := notifier.maxRequestLatencyByStatusClass
+	notifier.maxRequestLatencyByStatusClass = map[int]time.Duration{}
+
+	return latencies
+}
+
 func (notifier *RequestStatMetronNotifier) ReadAndResetLatency() time.Duration {
 	notifier.lock.Lock()
 	defer notifier.lock.Unlock()
@@ -74,6 +136,17 @@ func (notifier *RequestStatMetronNotifier) Run(signals <-chan os.Signal, ready c
 				logger.Info("sending-latency", lager.Data{"latency": latency})
 				notifier.metronClient.SendDuration(requestLatency, latency)
 			}
+			for class, latency := range notifier.readAndResetLatencyByStatusClass() {
+				notifier.metronClient.SendDuration(fmt.Sprintf("%s.%dxx", requestLatency, class), latency)
+			}
+
+			counts, latencies := notifier.readAndResetRouteStats()
+			for route, count := range counts {
+				notifier.metronClient.IncrementCounterWithDelta(requestCounter+"."+route, count)
+			}
+			for route, latency := range latencies {
+				notifier.metronClient.SendDuration(requestLatency+"."+route, latency)
+			}
 		case <-signals:
 			return nil
 		}
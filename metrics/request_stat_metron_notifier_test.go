@@ -99,4 +99,61 @@ var _ = Describe("PeriodicMetronCountNotifier", func() {
 			return durationMap["RequestLatency"]
 		}).Should(Equal(3 * time.Second))
 	})
+	It("should emit the request latency per status code class periodically", func() {
+		mn.UpdateLatencyWithStatus(time.Second, 200)
+		mn.UpdateLatencyWithStatus(2*time.Second, 204)
+		mn.UpdateLatencyWithStatus(3*time.Second, 503)
+		fakeClock.WaitForWatcherAndIncrement(reportInterval)
+
+		Eventually(func() map[string]time.Duration {
+			metricsLock.Lock()
+			defer metricsLock.Unlock()
+			durations := map[string]time.Duration{}
+			for name, duration := range durationMap {
+				durations[name] = duration
+			}
+			return durations
+		}).Should(Equal(map[string]time.Duration{
+			"RequestLatency":     3 * time.Second,
+			"RequestLatency.2xx": 2 * time.Second,
+			"RequestLatency.5xx": 3 * time.Second,
+		}))
+	})
+
+	It("should emit the request count and latency per route periodically", func() {
+		mn.IncrementRouteCounter("Ping", 1)
+		mn.IncrementRouteCounter("Ping", 1)
+		mn.IncrementRouteCounter("DesireDesiredLRP_r2", 1)
+		mn.UpdateRouteLatency("Ping", time.Millisecond)
+		mn.UpdateRouteLatency("DesireDesiredLRP_r2", 2*time.Second)
+		mn.UpdateRouteLatency("DesireDesiredLRP_r2", time.Second)
+		fakeClock.WaitForWatcherAndIncrement(reportInterval)
+
+		Eventually(func() map[string]uint64 {
+			metricsLock.Lock()
+			defer metricsLock.Unlock()
+			counters := map[string]uint64{}
+			for name, counter := range counterMap {
+				counters[name] = counter
+			}
+			return counters
+		}).Should(Equal(map[string]uint64{
+			"RequestCount":                     0,
+			"RequestCount.Ping":                2,
+			"RequestCount.DesireDesiredLRP_r2": 1,
+		}))
+
+		Eventually(func() map[string]time.Duration {
+			metricsLock.Lock()
+			defer metricsLock.Unlock()
+			durations := map[string]time.Duration{}
+			for name, duration := range durationMap {
+				durations[name] = duration
+			}
+			return durations
+		}).Should(Equal(map[string]time.Duration{
+			"RequestLatency.Ping":                time.Millisecond,
+			"RequestLatency.DesireDesiredLRP_r2": 2 * time.Second,
+		}))
+	})
 })
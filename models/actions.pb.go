@@ -85,6 +85,7 @@
 		DesireLRPRequest
 		UpdateDesiredLRPRequest
 		RemoveDesiredLRPRequest
+		DesiredLRPProcessGuidsResponse
 		DomainsResponse
 		UpsertDomainResponse
 		UpsertDomainRequest
@@ -112,6 +113,7 @@
 		ModificationTag
 		Network
 		PingResponse
+		ConvergenceReadinessResponse
 		PortRange
 		ICMPInfo
 		SecurityGroupRule
@@ -2,6 +2,9 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,6 +21,9 @@ const (
 	RetireActualLRPRetryAttempts = 5
 )
 
+// ActualLRPState is one of the ActualLRPState* constants.
+type ActualLRPState string
+
 var ActualLRPStates = []string{
 	ActualLRPStateUnclaimed,
 	ActualLRPStateClaimed,
@@ -25,9 +31,25 @@ var ActualLRPStates = []string{
 	ActualLRPStateCrashed,
 }
 
+// ActualLRPChange describes how an actual LRP group changed between two
+// writes, as computed by ActualLRPGroup.Diff.
 type ActualLRPChange struct {
 	Before *ActualLRPGroup
 	After  *ActualLRPGroup
+
+	// The resolved actual LRP moved to another state, or between the instance
+	// and the evacuating side of the group.
+	StateChanged bool
+	// The resolved actual LRP was given a different address or ports.
+	NetInfoChanged bool
+	// The resolved actual LRP was placed on a different cell.
+	CellChanged bool
+}
+
+// Changed reports whether anything in the group changed, including fields
+// not covered by the targeted change kinds such as the crash count.
+func (change ActualLRPChange) Changed() bool {
+	return !change.Before.Equal(change.After)
 }
 
 type ActualLRPFilter struct {
@@ -43,6 +65,13 @@ func NewActualLRPInstanceKey(instanceGuid string, cellId string) ActualLRPInstan
 	return ActualLRPInstanceKey{instanceGuid, cellId}
 }
 
+// IndexInstanceKey is the claim of one index of an actual LRP by the instance
+// with the instance key.
+type IndexInstanceKey struct {
+	Index       int32
+	InstanceKey *ActualLRPInstanceKey
+}
+
 func NewActualLRPNetInfo(address string, instanceAddress string, ports ...*PortMapping) ActualLRPNetInfo {
 	return ActualLRPNetInfo{address, ports, instanceAddress}
 }
@@ -55,6 +84,31 @@ func (info ActualLRPNetInfo) Empty() bool {
 	return info.Address == "" && len(info.Ports) == 0
 }
 
+// MergePorts returns a copy of the net info with the additional port mappings
+// merged into its ports. Mappings are deduplicated by container port, with an
+// additional mapping replacing an existing one for the same container port.
+func (info ActualLRPNetInfo) MergePorts(additional []*PortMapping) ActualLRPNetInfo {
+	ports := make([]*PortMapping, 0, len(info.Ports)+len(additional))
+	positions := make(map[uint32]int, len(info.Ports)+len(additional))
+
+	for _, mappings := range [][]*PortMapping{info.Ports, additional} {
+		for _, mapping := range mappings {
+			if mapping == nil {
+				continue
+			}
+			if i, ok := positions[mapping.ContainerPort]; ok {
+				ports[i] = mapping
+				continue
+			}
+			positions[mapping.ContainerPort] = len(ports)
+			ports = append(ports, mapping)
+		}
+	}
+
+	info.Ports = ports
+	return info
+}
+
 func (*ActualLRPNetInfo) Version() format.Version {
 	return format.V0
 }
@@ -170,6 +224,92 @@ func (group ActualLRPGroup) Resolve() (*ActualLRP, bool) {
 	}
 }
 
+// Diff compares the group, as it was before a write, with the other group, as
+// it is after it. A nil group, or one without any actual LRP, differs from a
+// populated one in every change kind.
+func (group *ActualLRPGroup) Diff(other *ActualLRPGroup) ActualLRPChange {
+	change := ActualLRPChange{Before: group, After: other}
+
+	before, beforeEvacuating, beforeOk := group.resolveIfPresent()
+	after, afterEvacuating, afterOk := other.resolveIfPresent()
+	switch {
+	case !beforeOk && !afterOk:
+		return change
+	case !beforeOk || !afterOk:
+		change.StateChanged = true
+		change.NetInfoChanged = true
+		change.CellChanged = true
+		return change
+	}
+
+	change.StateChanged = before.State != after.State || beforeEvacuating != afterEvacuating
+	change.NetInfoChanged = !before.ActualLRPNetInfo.Equal(&after.ActualLRPNetInfo)
+	change.CellChanged = before.CellId != after.CellId
+	return change
+}
+
+func (group *ActualLRPGroup) resolveIfPresent() (*ActualLRP, bool, bool) {
+	if group == nil || (group.Instance == nil && group.Evacuating == nil) {
+		return nil, false, false
+	}
+	actual, evacuating := group.Resolve()
+	return actual, evacuating, true
+}
+
+// PlacementFairness scores how evenly the given actual LRPs are spread across
+// the cells they are placed on, from 0 when nearly every instance is on the
+// same cell to 1 when every cell holds the same number of instances. Only
+// instances with a cell, as resolved from each group, are counted.
+func PlacementFairness(actuals []*ActualLRPGroup) float64 {
+	return PlacementFairnessAcrossCellSet(actuals, nil)
+}
+
+// PlacementFairnessAcrossCellSet is the PlacementFairness of the given actual
+// LRPs across the cells of the cell set, where cells holding no instance
+// count against the score.
+func PlacementFairnessAcrossCellSet(actuals []*ActualLRPGroup, cellSet CellSet) float64 {
+	instancesByCell := map[string]int{}
+	for _, group := range actuals {
+		actual, _ := group.Resolve()
+		if actual.CellId != "" {
+			instancesByCell[actual.CellId]++
+		}
+	}
+	return PlacementFairnessByCell(instancesByCell, cellSet)
+}
+
+// PlacementFairnessByCell is the PlacementFairnessAcrossCellSet of the given
+// number of instances per cell: the entropy of the distribution normalized by its
+// maximum across every cell of the cell set, along with any cell holding
+// instances that is missing from it. Fewer than two instances or cells are
+// always fair.
+func PlacementFairnessByCell(instancesByCell map[string]int, cellSet CellSet) float64 {
+	cellCount := len(cellSet)
+	counts := make([]int, 0, len(instancesByCell))
+	total := 0
+	for cellID, count := range instancesByCell {
+		if count > 0 {
+			counts = append(counts, count)
+			total += count
+			if _, ok := cellSet[cellID]; !ok {
+				cellCount++
+			}
+		}
+	}
+
+	if total < 2 || cellCount < 2 {
+		return 1
+	}
+
+	sort.Ints(counts)
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log(p)
+	}
+	return entropy / math.Log(float64(cellCount))
+}
+
 func NewUnclaimedActualLRP(lrpKey ActualLRPKey, since int64) *ActualLRP {
 	return &ActualLRP{
 		ActualLRPKey: lrpKey,
@@ -266,6 +406,25 @@ func (actual ActualLRP) Validate() error {
 	return nil
 }
 
+// orderKeySeparator sorts before any character of a process guid, so that a
+// guid orders before every guid it is a prefix of.
+const orderKeySeparator = "\x00"
+
+// OrderKey returns a key whose lexical ordering matches ordering by process
+// guid and then numerically by index.
+func (key ActualLRPKey) OrderKey() string {
+	return fmt.Sprintf("%s%s%010d", key.ProcessGuid, orderKeySeparator, key.Index)
+}
+
+// ActualLRPKeysByOrderKey sorts actual LRP keys by their OrderKey.
+type ActualLRPKeysByOrderKey []*ActualLRPKey
+
+func (keys ActualLRPKeysByOrderKey) Len() int      { return len(keys) }
+func (keys ActualLRPKeysByOrderKey) Swap(i, j int) { keys[i], keys[j] = keys[j], keys[i] }
+func (keys ActualLRPKeysByOrderKey) Less(i, j int) bool {
+	return keys[i].OrderKey() < keys[j].OrderKey()
+}
+
 func (key *ActualLRPKey) Validate() error {
 	var validationError ValidationError
 
@@ -160,6 +160,7 @@ type ActualLRP struct {
 	PlacementError       string          `protobuf:"bytes,7,opt,name=placement_error,json=placementError" json:"placement_error,omitempty"`
 	Since                int64           `protobuf:"varint,8,opt,name=since" json:"since"`
 	ModificationTag      ModificationTag `protobuf:"bytes,9,opt,name=modification_tag,json=modificationTag" json:"modification_tag"`
+	Annotation           string          `protobuf:"bytes,10,opt,name=annotation" json:"annotation,omitempty"`
 }
 
 func (m *ActualLRP) Reset()                    { *m = ActualLRP{} }
@@ -208,6 +209,13 @@ func (m *ActualLRP) GetModificationTag() ModificationTag {
 	return ModificationTag{}
 }
 
+func (m *ActualLRP) GetAnnotation() string {
+	if m != nil {
+		return m.Annotation
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ActualLRPGroup)(nil), "models.ActualLRPGroup")
 	proto.RegisterType((*PortMapping)(nil), "models.PortMapping")
@@ -444,6 +452,9 @@ func (this *ActualLRP) Equal(that interface{}) bool {
 	if !this.ModificationTag.Equal(&that1.ModificationTag) {
 		return false
 	}
+	if this.Annotation != that1.Annotation {
+		return false
+	}
 	return true
 }
 func (this *ActualLRPGroup) GoString() string {
@@ -513,7 +524,7 @@ func (this *ActualLRP) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 13)
+	s := make([]string, 0, 14)
 	s = append(s, "&models.ActualLRP{")
 	s = append(s, "ActualLRPKey: "+strings.Replace(this.ActualLRPKey.GoString(), `&`, ``, 1)+",\n")
 	s = append(s, "ActualLRPInstanceKey: "+strings.Replace(this.ActualLRPInstanceKey.GoString(), `&`, ``, 1)+",\n")
@@ -524,6 +535,7 @@ func (this *ActualLRP) GoString() string {
 	s = append(s, "PlacementError: "+fmt.Sprintf("%#v", this.PlacementError)+",\n")
 	s = append(s, "Since: "+fmt.Sprintf("%#v", this.Since)+",\n")
 	s = append(s, "ModificationTag: "+strings.Replace(this.ModificationTag.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "Annotation: "+fmt.Sprintf("%#v", this.Annotation)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -755,6 +767,10 @@ func (m *ActualLRP) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n6
+	dAtA[i] = 0x52
+	i++
+	i = encodeVarintActualLrp(dAtA, i, uint64(len(m.Annotation)))
+	i += copy(dAtA[i:], m.Annotation)
 	return i, nil
 }
 
@@ -863,6 +879,8 @@ func (m *ActualLRP) Size() (n int) {
 	n += 1 + sovActualLrp(uint64(m.Since))
 	l = m.ModificationTag.Size()
 	n += 1 + l + sovActualLrp(uint64(l))
+	l = len(m.Annotation)
+	n += 1 + l + sovActualLrp(uint64(l))
 	return n
 }
 
@@ -950,6 +968,7 @@ func (this *ActualLRP) String() string {
 		`PlacementError:` + fmt.Sprintf("%v", this.PlacementError) + `,`,
 		`Since:` + fmt.Sprintf("%v", this.Since) + `,`,
 		`ModificationTag:` + strings.Replace(strings.Replace(this.ModificationTag.String(), "ModificationTag", "ModificationTag", 1), `&`, ``, 1) + `,`,
+		`Annotation:` + fmt.Sprintf("%v", this.Annotation) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1814,6 +1833,35 @@ func (m *ActualLRP) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthActualLrp
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Annotation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipActualLrp(dAtA[iNdEx:])
@@ -1943,48 +1991,49 @@ var (
 func init() { proto.RegisterFile("actual_lrp.proto", fileDescriptorActualLrp) }
 
 var fileDescriptorActualLrp = []byte{
-	// 677 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xcd, 0x4e, 0xdb, 0x4a,
-	0x18, 0x8d, 0x81, 0x00, 0xf9, 0x12, 0x42, 0xee, 0x80, 0xc0, 0x37, 0xe2, 0x3a, 0x10, 0xe9, 0xaa,
-	0x54, 0x85, 0xa0, 0xa2, 0xbe, 0x00, 0x54, 0x15, 0x50, 0xa0, 0x42, 0x51, 0xbb, 0xac, 0xdc, 0xc1,
-	0x9e, 0x98, 0x51, 0xe3, 0x19, 0x77, 0x3c, 0xae, 0x9a, 0x5d, 0x1f, 0xa1, 0x8f, 0xd1, 0x37, 0xe8,
-	0x23, 0x94, 0x25, 0xcb, 0xae, 0xa2, 0xe2, 0x6e, 0x2a, 0x56, 0x3c, 0x42, 0x35, 0xe3, 0x9f, 0x4e,
-	0x13, 0x75, 0x05, 0x3e, 0xe7, 0x7c, 0xe7, 0x38, 0xe7, 0xfb, 0x64, 0x68, 0x61, 0x4f, 0x26, 0x78,
-	0xe8, 0x0e, 0x45, 0xd4, 0x8b, 0x04, 0x97, 0x1c, 0xcd, 0x87, 0xdc, 0x27, 0xc3, 0xb8, 0xbd, 0x1b,
-	0x50, 0x79, 0x95, 0x5c, 0xf6, 0x3c, 0x1e, 0xee, 0x05, 0x3c, 0xe0, 0x7b, 0x9a, 0xbe, 0x4c, 0x06,
-	0xfa, 0x49, 0x3f, 0xe8, 0xff, 0xb2, 0xb1, 0xf6, 0x5a, 0xc8, 0x7d, 0x3a, 0xa0, 0x1e, 0x96, 0x94,
-	0x33, 0x57, 0xe2, 0x20, 0xc3, 0xbb, 0x02, 0x9a, 0x07, 0x3a, 0xe2, 0xac, 0x7f, 0x71, 0x24, 0x78,
-	0x12, 0xa1, 0x5d, 0x58, 0xa4, 0x2c, 0x96, 0x98, 0x79, 0xc4, 0xb6, 0x36, 0xad, 0xed, 0xfa, 0xfe,
-	0x3f, 0xbd, 0x2c, 0xb3, 0x57, 0x2a, 0xfb, 0xa5, 0x04, 0x3d, 0x06, 0x20, 0xef, 0xb1, 0x97, 0x60,
-	0x49, 0x59, 0x60, 0xcf, 0xfc, 0x6d, 0xc0, 0x10, 0x75, 0x5f, 0x43, 0xfd, 0x82, 0x0b, 0x79, 0x8e,
-	0xa3, 0x88, 0xb2, 0x00, 0x3d, 0x82, 0xa6, 0xc7, 0x99, 0xc4, 0x94, 0x11, 0xe1, 0x46, 0x5c, 0x48,
-	0x1d, 0xbb, 0x74, 0x38, 0x77, 0x3d, 0xee, 0x54, 0xfa, 0x4b, 0x25, 0xa7, 0x66, 0xd0, 0x16, 0xd4,
-	0xae, 0x78, 0x2c, 0x33, 0xdd, 0x8c, 0xa1, 0x5b, 0x54, 0xb0, 0x92, 0x74, 0xdf, 0x41, 0xa3, 0xcc,
-	0x3d, 0x25, 0x23, 0xf4, 0x00, 0x1a, 0x91, 0xe0, 0x1e, 0x89, 0x63, 0x37, 0x48, 0xa8, 0xaf, 0xdd,
-	0x6b, 0xf9, 0x54, 0x3d, 0x67, 0x8e, 0x12, 0xea, 0xa3, 0x36, 0x54, 0x29, 0xf3, 0xc9, 0x07, 0xed,
-	0x5b, 0xcd, 0x15, 0x19, 0x84, 0x36, 0x60, 0xde, 0xe7, 0x21, 0xa6, 0xcc, 0x9e, 0x35, 0xc6, 0x73,
-	0xac, 0xfb, 0x06, 0x56, 0xcb, 0xc8, 0x93, 0xbc, 0x19, 0x15, 0xfd, 0x10, 0x96, 0x8a, 0xa2, 0xa6,
-	0xb3, 0x1b, 0x05, 0xa5, 0xc3, 0xff, 0x83, 0x05, 0x8f, 0x0c, 0x87, 0x2e, 0xf5, 0x75, 0x7c, 0x99,
-	0xa0, 0xc0, 0x13, 0xbf, 0xfb, 0xc5, 0x82, 0x56, 0x19, 0xf1, 0x82, 0xc8, 0x13, 0x36, 0xe0, 0xc8,
-	0x81, 0x05, 0xec, 0xfb, 0x82, 0xc4, 0xf1, 0x1f, 0xc6, 0x05, 0x88, 0x9e, 0x40, 0x55, 0xf5, 0x14,
-	0xdb, 0x33, 0x9b, 0xb3, 0xdb, 0xf5, 0xfd, 0x95, 0x62, 0x2d, 0x46, 0xfb, 0x87, 0xb5, 0xbb, 0x71,
-	0x27, 0x53, 0xf5, 0xb3, 0x3f, 0xe8, 0x1c, 0x5a, 0xe5, 0x4b, 0x17, 0xf6, 0xd9, 0x8f, 0xee, 0x2a,
-	0xfb, 0xbb, 0x71, 0xa7, 0x3d, 0xc9, 0xef, 0xf0, 0x90, 0x4a, 0x12, 0x46, 0x72, 0xd4, 0x5f, 0x2e,
-	0xb8, 0x83, 0x8c, 0xea, 0x7e, 0x9d, 0x83, 0x5a, 0xf9, 0xe6, 0xe8, 0x18, 0x9a, 0xbf, 0x4f, 0xda,
-	0x7d, 0x4b, 0x46, 0xf9, 0x8d, 0xad, 0x4e, 0x9d, 0xcc, 0x29, 0x19, 0x1d, 0x36, 0x54, 0xe0, 0xcd,
-	0xb8, 0x63, 0xdd, 0xe9, 0xc2, 0xb2, 0xc9, 0x33, 0x11, 0xa9, 0x6e, 0x31, 0xac, 0x1b, 0x4e, 0xe5,
-	0x1b, 0x29, 0xcb, 0xec, 0x0a, 0x37, 0xa6, 0x2c, 0x8d, 0xd5, 0x4c, 0x58, 0xaf, 0x96, 0xd6, 0xe6,
-	0xfa, 0x5e, 0xc1, 0x8a, 0x11, 0xc1, 0x88, 0x74, 0x29, 0x1b, 0x70, 0x5d, 0x46, 0x7d, 0xdf, 0x9e,
-	0xb2, 0xcf, 0xd7, 0x32, 0x61, 0xdd, 0x2a, 0xad, 0x8b, 0xb5, 0xfd, 0x0f, 0x75, 0x4f, 0xe0, 0xf8,
-	0xca, 0xf5, 0x78, 0xc2, 0xa4, 0x3d, 0x67, 0x5c, 0x1b, 0x68, 0xe2, 0xa9, 0xc2, 0xd1, 0x01, 0x34,
-	0x32, 0x99, 0x20, 0x38, 0xe6, 0xcc, 0xae, 0xea, 0x1d, 0x38, 0xf9, 0x0e, 0xd6, 0x4c, 0xce, 0xe8,
-	0x3f, 0xb3, 0xee, 0x6b, 0x58, 0x5d, 0x74, 0x2c, 0xb1, 0x24, 0xf6, 0xbc, 0x71, 0x1e, 0x19, 0x84,
-	0x9e, 0xc3, 0x72, 0x34, 0xc4, 0x1e, 0x09, 0x09, 0x93, 0x2e, 0x11, 0x82, 0x0b, 0x7b, 0x41, 0xab,
-	0xb6, 0xf2, 0x84, 0x7f, 0x27, 0x68, 0x23, 0xa4, 0x59, 0x52, 0xcf, 0x14, 0xa3, 0x73, 0xa8, 0xfa,
-	0x60, 0x2c, 0x6e, 0x5a, 0xdb, 0xb3, 0x65, 0x8e, 0x82, 0xd0, 0x31, 0xb4, 0x26, 0xbf, 0x3d, 0x76,
-	0x4d, 0x37, 0xb8, 0x5e, 0x34, 0x78, 0x6e, 0xf0, 0x2f, 0x71, 0x90, 0xcf, 0x2f, 0x87, 0x13, 0xf0,
-	0xce, 0xcd, 0xad, 0x53, 0xf9, 0x76, 0xeb, 0x54, 0xee, 0x6f, 0x1d, 0xeb, 0x63, 0xea, 0x58, 0x9f,
-	0x53, 0xc7, 0xba, 0x4e, 0x1d, 0xeb, 0x26, 0x75, 0xac, 0xef, 0xa9, 0x63, 0xfd, 0x4c, 0x9d, 0xca,
-	0x7d, 0xea, 0x58, 0x9f, 0x7e, 0x38, 0x95, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0c, 0x3e, 0xf9,
-	0x79, 0x3b, 0x05, 0x00, 0x00,
+	// 700 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xcd, 0x4e, 0xdb, 0x4c,
+	0x14, 0x8d, 0x03, 0x09, 0xe4, 0x26, 0x84, 0x7c, 0x43, 0x04, 0xfe, 0x22, 0xea, 0x40, 0xa4, 0xaa,
+	0x54, 0x85, 0xa0, 0xa2, 0x2e, 0xbb, 0x21, 0x55, 0x05, 0x14, 0xa8, 0x50, 0xd4, 0x2e, 0x2b, 0x77,
+	0xb0, 0x27, 0x66, 0xd4, 0x78, 0xc6, 0xb5, 0xc7, 0x55, 0xb3, 0xeb, 0xa6, 0xfb, 0x3e, 0x46, 0xdf,
+	0xa0, 0xaf, 0xc0, 0x92, 0x65, 0x57, 0x51, 0x49, 0x37, 0x15, 0x2b, 0x1e, 0xa1, 0x9a, 0xf1, 0x0f,
+	0x43, 0xa2, 0xae, 0x12, 0x9f, 0x73, 0xee, 0x39, 0xd7, 0xf7, 0x5e, 0x19, 0x1a, 0xd8, 0x11, 0x31,
+	0x1e, 0xda, 0xc3, 0x30, 0xe8, 0x06, 0x21, 0x17, 0x1c, 0x95, 0x7d, 0xee, 0x92, 0x61, 0xd4, 0xda,
+	0xf1, 0xa8, 0xb8, 0x88, 0xcf, 0xbb, 0x0e, 0xf7, 0x77, 0x3d, 0xee, 0xf1, 0x5d, 0x45, 0x9f, 0xc7,
+	0x03, 0xf5, 0xa4, 0x1e, 0xd4, 0xbf, 0xa4, 0xac, 0xb5, 0xea, 0x73, 0x97, 0x0e, 0xa8, 0x83, 0x05,
+	0xe5, 0xcc, 0x16, 0xd8, 0x4b, 0xf0, 0x4e, 0x08, 0xf5, 0x7d, 0x15, 0x71, 0xd2, 0x3f, 0x3b, 0x08,
+	0x79, 0x1c, 0xa0, 0x1d, 0x58, 0xa4, 0x2c, 0x12, 0x98, 0x39, 0xc4, 0x34, 0x36, 0x8c, 0xad, 0xea,
+	0xde, 0x7f, 0xdd, 0x24, 0xb3, 0x9b, 0x2b, 0xfb, 0xb9, 0x04, 0x3d, 0x05, 0x20, 0x9f, 0xb0, 0x13,
+	0x63, 0x41, 0x99, 0x67, 0x16, 0xff, 0x55, 0xa0, 0x89, 0x3a, 0xef, 0xa0, 0x7a, 0xc6, 0x43, 0x71,
+	0x8a, 0x83, 0x80, 0x32, 0x0f, 0x3d, 0x81, 0xba, 0xc3, 0x99, 0xc0, 0x94, 0x91, 0xd0, 0x0e, 0x78,
+	0x28, 0x54, 0xec, 0x52, 0x6f, 0xfe, 0x72, 0xdc, 0x2e, 0xf4, 0x97, 0x72, 0x4e, 0xd6, 0xa0, 0x4d,
+	0xa8, 0x5c, 0xf0, 0x48, 0x24, 0xba, 0xa2, 0xa6, 0x5b, 0x94, 0xb0, 0x94, 0x74, 0x3e, 0x42, 0x2d,
+	0xcf, 0x3d, 0x26, 0x23, 0xf4, 0x08, 0x6a, 0x41, 0xc8, 0x1d, 0x12, 0x45, 0xb6, 0x17, 0x53, 0x57,
+	0xb9, 0x57, 0xd2, 0xaa, 0x6a, 0xca, 0x1c, 0xc4, 0xd4, 0x45, 0x2d, 0x28, 0x51, 0xe6, 0x92, 0xcf,
+	0xca, 0xb7, 0x94, 0x2a, 0x12, 0x08, 0xad, 0x43, 0xd9, 0xe5, 0x3e, 0xa6, 0xcc, 0x9c, 0xd3, 0xca,
+	0x53, 0xac, 0xf3, 0x1e, 0x9a, 0x79, 0xe4, 0x51, 0x3a, 0x19, 0x19, 0xfd, 0x18, 0x96, 0xb2, 0x41,
+	0xcd, 0x66, 0xd7, 0x32, 0x4a, 0x85, 0x3f, 0x80, 0x05, 0x87, 0x0c, 0x87, 0x36, 0x75, 0xcd, 0xa2,
+	0x26, 0x2a, 0x4b, 0xf0, 0xc8, 0xed, 0xfc, 0x30, 0xa0, 0x91, 0x47, 0xbc, 0x26, 0xe2, 0x88, 0x0d,
+	0x38, 0xb2, 0x60, 0x01, 0xbb, 0x6e, 0x48, 0xa2, 0xe8, 0x9e, 0x71, 0x06, 0xa2, 0x67, 0x50, 0x92,
+	0x73, 0x8a, 0xcc, 0xe2, 0xc6, 0xdc, 0x56, 0x75, 0x6f, 0x25, 0x5b, 0x8b, 0x36, 0xfd, 0x5e, 0xe5,
+	0x66, 0xdc, 0x4e, 0x54, 0xfd, 0xe4, 0x07, 0x9d, 0x42, 0x23, 0x6f, 0x3a, 0xb3, 0x4f, 0x5e, 0xba,
+	0x23, 0xed, 0x6f, 0xc6, 0xed, 0xd6, 0x34, 0xbf, 0xcd, 0x7d, 0x2a, 0x88, 0x1f, 0x88, 0x51, 0x7f,
+	0x39, 0xe3, 0xf6, 0x13, 0xaa, 0xf3, 0xb5, 0x04, 0x95, 0xbc, 0x73, 0x74, 0x08, 0xf5, 0xbb, 0x93,
+	0xb6, 0x3f, 0x90, 0x51, 0x7a, 0x63, 0xcd, 0x99, 0x93, 0x39, 0x26, 0xa3, 0x5e, 0x4d, 0x06, 0x5e,
+	0x8d, 0xdb, 0xc6, 0x8d, 0x1a, 0x58, 0x52, 0x79, 0x12, 0x06, 0x72, 0xb6, 0x18, 0xd6, 0x34, 0xa7,
+	0xbc, 0x23, 0x69, 0x99, 0x5c, 0xe1, 0xfa, 0x8c, 0xa5, 0xb6, 0x9a, 0x29, 0xeb, 0x66, 0x6e, 0xad,
+	0xaf, 0xef, 0x2d, 0xac, 0x68, 0x11, 0x8c, 0x08, 0x9b, 0xb2, 0x01, 0x57, 0xc3, 0xa8, 0xee, 0x99,
+	0x33, 0xf6, 0xe9, 0x5a, 0xa6, 0xac, 0x1b, 0xb9, 0x75, 0xb6, 0xb6, 0x87, 0x50, 0x75, 0x42, 0x1c,
+	0x5d, 0xd8, 0x0e, 0x8f, 0x99, 0x30, 0xe7, 0xb5, 0x6b, 0x03, 0x45, 0xbc, 0x90, 0x38, 0xda, 0x87,
+	0x5a, 0x22, 0x0b, 0x09, 0x8e, 0x38, 0x33, 0x4b, 0x6a, 0x07, 0x56, 0xba, 0x83, 0x55, 0x9d, 0xd3,
+	0xe6, 0x9f, 0x58, 0xf7, 0x15, 0x2c, 0x2f, 0x3a, 0x12, 0x58, 0x10, 0xb3, 0xac, 0x9d, 0x47, 0x02,
+	0xa1, 0x57, 0xb0, 0x1c, 0x0c, 0xb1, 0x43, 0x7c, 0xc2, 0x84, 0x4d, 0xc2, 0x90, 0x87, 0xe6, 0x82,
+	0x52, 0x6d, 0xa6, 0x09, 0xff, 0x4f, 0xd1, 0x5a, 0x48, 0x3d, 0xa7, 0x5e, 0x4a, 0x46, 0xe5, 0x50,
+	0xf9, 0xc1, 0x58, 0xdc, 0x30, 0xb6, 0xe6, 0xf2, 0x1c, 0x09, 0xa1, 0x43, 0x68, 0x4c, 0x7f, 0x7b,
+	0xcc, 0x8a, 0x9a, 0xe0, 0x5a, 0x36, 0xc1, 0x53, 0x8d, 0x7f, 0x83, 0xbd, 0xb4, 0x7e, 0xd9, 0xbf,
+	0x0f, 0xa3, 0xe7, 0x00, 0x98, 0x31, 0x2e, 0x14, 0x60, 0x82, 0x6a, 0x76, 0x3d, 0x6d, 0xb6, 0x79,
+	0xc7, 0x68, 0x7d, 0x6a, 0xfa, 0xde, 0xf6, 0xd5, 0xb5, 0x55, 0xf8, 0x79, 0x6d, 0x15, 0x6e, 0xaf,
+	0x2d, 0xe3, 0xcb, 0xc4, 0x32, 0xbe, 0x4f, 0x2c, 0xe3, 0x72, 0x62, 0x19, 0x57, 0x13, 0xcb, 0xf8,
+	0x35, 0xb1, 0x8c, 0x3f, 0x13, 0xab, 0x70, 0x3b, 0xb1, 0x8c, 0x6f, 0xbf, 0xad, 0xc2, 0xdf, 0x00,
+	0x00, 0x00, 0xff, 0xff, 0x0e, 0xea, 0xe2, 0x0b, 0x79, 0x05, 0x00, 0x00,
 }
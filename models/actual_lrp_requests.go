@@ -18,6 +18,27 @@ func (request *ActualLRPGroupsByProcessGuidRequest) Validate() error {
 	return nil
 }
 
+func (request *ActualLRPGroupsByStateRequest) Validate() error {
+	var validationError ValidationError
+
+	validState := false
+	for _, state := range ActualLRPStates {
+		if request.State == state {
+			validState = true
+			break
+		}
+	}
+	if !validState {
+		validationError = validationError.Append(ErrInvalidField{"state"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
 func (request *ActualLRPGroupByProcessGuidAndIndexRequest) Validate() error {
 	var validationError ValidationError
 
@@ -160,6 +181,54 @@ func (request *RetireActualLRPRequest) Validate() error {
 	return nil
 }
 
+func (request *RetireActualLRPsByProcessGuidRequest) Validate() error {
+	var validationError ValidationError
+
+	if request.ProcessGuid == "" {
+		validationError = validationError.Append(ErrInvalidField{"process_guid"})
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (operation *ActualLRPLifecycleOperation) Validate() error {
+	var validationError ValidationError
+
+	switch {
+	case (operation.Claim == nil) == (operation.Start == nil):
+		validationError = validationError.Append(ErrInvalidField{"operation"})
+	case operation.Claim != nil:
+		validationError = validationError.Check(operation.Claim)
+	default:
+		validationError = validationError.Check(operation.Start)
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+// Validate accepts every batch: its operations are validated one at a time
+// when the batch is applied, so that an invalid operation fails on its own.
+func (request *ActualLRPLifecycleBatchRequest) Validate() error {
+	return nil
+}
+
+// ActualLRPLifecycleOperationResult is the outcome of a single operation of
+// an ActualLRPLifecycleBatchRequest. Before and After are only set when the
+// operation succeeded.
+type ActualLRPLifecycleOperationResult struct {
+	Before *ActualLRPGroup
+	After  *ActualLRPGroup
+	Error  error
+}
+
 func (request *RemoveEvacuatingActualLRPRequest) Validate() error {
 	var validationError ValidationError
 
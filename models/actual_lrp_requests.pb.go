@@ -128,6 +128,23 @@ func (m *ActualLRPGroupsByProcessGuidRequest) GetProcessGuid() string {
 	return ""
 }
 
+type ActualLRPGroupsByStateRequest struct {
+	State string `protobuf:"bytes,1,opt,name=state" json:"state"`
+}
+
+func (m *ActualLRPGroupsByStateRequest) Reset()      { *m = ActualLRPGroupsByStateRequest{} }
+func (*ActualLRPGroupsByStateRequest) ProtoMessage() {}
+func (*ActualLRPGroupsByStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorActualLrpRequests, []int{5}
+}
+
+func (m *ActualLRPGroupsByStateRequest) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
 type ActualLRPGroupByProcessGuidAndIndexRequest struct {
 	ProcessGuid string `protobuf:"bytes,1,opt,name=process_guid,json=processGuid" json:"process_guid"`
 	Index       int32  `protobuf:"varint,2,opt,name=index" json:"index"`
@@ -138,7 +155,7 @@ func (m *ActualLRPGroupByProcessGuidAndIndexRequest) Reset() {
 }
 func (*ActualLRPGroupByProcessGuidAndIndexRequest) ProtoMessage() {}
 func (*ActualLRPGroupByProcessGuidAndIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorActualLrpRequests, []int{5}
+	return fileDescriptorActualLrpRequests, []int{6}
 }
 
 func (m *ActualLRPGroupByProcessGuidAndIndexRequest) GetProcessGuid() string {
@@ -164,7 +181,7 @@ type ClaimActualLRPRequest struct {
 func (m *ClaimActualLRPRequest) Reset()      { *m = ClaimActualLRPRequest{} }
 func (*ClaimActualLRPRequest) ProtoMessage() {}
 func (*ClaimActualLRPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorActualLrpRequests, []int{6}
+	return fileDescriptorActualLrpRequests, []int{7}
 }
 
 func (m *ClaimActualLRPRequest) GetProcessGuid() string {
@@ -197,7 +214,7 @@ type StartActualLRPRequest struct {
 func (m *StartActualLRPRequest) Reset()      { *m = StartActualLRPRequest{} }
 func (*StartActualLRPRequest) ProtoMessage() {}
 func (*StartActualLRPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorActualLrpRequests, []int{7}
+	return fileDescriptorActualLrpRequests, []int{8}
 }
 
 func (m *StartActualLRPRequest) GetActualLrpKey() *ActualLRPKey {
@@ -230,7 +247,7 @@ type CrashActualLRPRequest struct {
 func (m *CrashActualLRPRequest) Reset()      { *m = CrashActualLRPRequest{} }
 func (*CrashActualLRPRequest) ProtoMessage() {}
 func (*CrashActualLRPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorActualLrpRequests, []int{8}
+	return fileDescriptorActualLrpRequests, []int{9}
 }
 
 func (m *CrashActualLRPRequest) GetActualLrpKey() *ActualLRPKey {
@@ -262,7 +279,7 @@ type FailActualLRPRequest struct {
 func (m *FailActualLRPRequest) Reset()      { *m = FailActualLRPRequest{} }
 func (*FailActualLRPRequest) ProtoMessage() {}
 func (*FailActualLRPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorActualLrpRequests, []int{9}
+	return fileDescriptorActualLrpRequests, []int{10}
 }
 
 func (m *FailActualLRPRequest) GetActualLrpKey() *ActualLRPKey {
@@ -286,7 +303,7 @@ type RetireActualLRPRequest struct {
 func (m *RetireActualLRPRequest) Reset()      { *m = RetireActualLRPRequest{} }
 func (*RetireActualLRPRequest) ProtoMessage() {}
 func (*RetireActualLRPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorActualLrpRequests, []int{10}
+	return fileDescriptorActualLrpRequests, []int{11}
 }
 
 func (m *RetireActualLRPRequest) GetActualLrpKey() *ActualLRPKey {
@@ -296,6 +313,115 @@ func (m *RetireActualLRPRequest) GetActualLrpKey() *ActualLRPKey {
 	return nil
 }
 
+type RetireActualLRPsByProcessGuidRequest struct {
+	ProcessGuid string `protobuf:"bytes,1,opt,name=process_guid,json=processGuid" json:"process_guid"`
+}
+
+func (m *RetireActualLRPsByProcessGuidRequest) Reset()      { *m = RetireActualLRPsByProcessGuidRequest{} }
+func (*RetireActualLRPsByProcessGuidRequest) ProtoMessage() {}
+func (*RetireActualLRPsByProcessGuidRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorActualLrpRequests, []int{12}
+}
+
+func (m *RetireActualLRPsByProcessGuidRequest) GetProcessGuid() string {
+	if m != nil {
+		return m.ProcessGuid
+	}
+	return ""
+}
+
+type RetireActualLRPsByProcessGuidResponse struct {
+	Error        *Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	RetiredCount int32  `protobuf:"varint,2,opt,name=retired_count,json=retiredCount" json:"retired_count"`
+}
+
+func (m *RetireActualLRPsByProcessGuidResponse) Reset()      { *m = RetireActualLRPsByProcessGuidResponse{} }
+func (*RetireActualLRPsByProcessGuidResponse) ProtoMessage() {}
+func (*RetireActualLRPsByProcessGuidResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorActualLrpRequests, []int{13}
+}
+
+func (m *RetireActualLRPsByProcessGuidResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *RetireActualLRPsByProcessGuidResponse) GetRetiredCount() int32 {
+	if m != nil {
+		return m.RetiredCount
+	}
+	return 0
+}
+
+type ActualLRPLifecycleOperation struct {
+	Claim *ClaimActualLRPRequest `protobuf:"bytes,1,opt,name=claim" json:"claim,omitempty"`
+	Start *StartActualLRPRequest `protobuf:"bytes,2,opt,name=start" json:"start,omitempty"`
+}
+
+func (m *ActualLRPLifecycleOperation) Reset()      { *m = ActualLRPLifecycleOperation{} }
+func (*ActualLRPLifecycleOperation) ProtoMessage() {}
+func (*ActualLRPLifecycleOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptorActualLrpRequests, []int{14}
+}
+
+func (m *ActualLRPLifecycleOperation) GetClaim() *ClaimActualLRPRequest {
+	if m != nil {
+		return m.Claim
+	}
+	return nil
+}
+
+func (m *ActualLRPLifecycleOperation) GetStart() *StartActualLRPRequest {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+type ActualLRPLifecycleBatchRequest struct {
+	Operations []*ActualLRPLifecycleOperation `protobuf:"bytes,1,rep,name=operations" json:"operations,omitempty"`
+}
+
+func (m *ActualLRPLifecycleBatchRequest) Reset()      { *m = ActualLRPLifecycleBatchRequest{} }
+func (*ActualLRPLifecycleBatchRequest) ProtoMessage() {}
+func (*ActualLRPLifecycleBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorActualLrpRequests, []int{15}
+}
+
+func (m *ActualLRPLifecycleBatchRequest) GetOperations() []*ActualLRPLifecycleOperation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+type ActualLRPLifecycleBatchResponse struct {
+	Error   *Error                        `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	Results []*ActualLRPLifecycleResponse `protobuf:"bytes,2,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *ActualLRPLifecycleBatchResponse) Reset()      { *m = ActualLRPLifecycleBatchResponse{} }
+func (*ActualLRPLifecycleBatchResponse) ProtoMessage() {}
+func (*ActualLRPLifecycleBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorActualLrpRequests, []int{16}
+}
+
+func (m *ActualLRPLifecycleBatchResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ActualLRPLifecycleBatchResponse) GetResults() []*ActualLRPLifecycleResponse {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 type RemoveActualLRPRequest struct {
 	ProcessGuid          string                `protobuf:"bytes,1,opt,name=process_guid,json=processGuid" json:"process_guid"`
 	Index                int32                 `protobuf:"varint,2,opt,name=index" json:"index"`
@@ -305,7 +431,7 @@ type RemoveActualLRPRequest struct {
 func (m *RemoveActualLRPRequest) Reset()      { *m = RemoveActualLRPRequest{} }
 func (*RemoveActualLRPRequest) ProtoMessage() {}
 func (*RemoveActualLRPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorActualLrpRequests, []int{11}
+	return fileDescriptorActualLrpRequests, []int{17}
 }
 
 func (m *RemoveActualLRPRequest) GetProcessGuid() string {
@@ -335,12 +461,18 @@ func init() {
 	proto.RegisterType((*ActualLRPGroupResponse)(nil), "models.ActualLRPGroupResponse")
 	proto.RegisterType((*ActualLRPGroupsRequest)(nil), "models.ActualLRPGroupsRequest")
 	proto.RegisterType((*ActualLRPGroupsByProcessGuidRequest)(nil), "models.ActualLRPGroupsByProcessGuidRequest")
+	proto.RegisterType((*ActualLRPGroupsByStateRequest)(nil), "models.ActualLRPGroupsByStateRequest")
 	proto.RegisterType((*ActualLRPGroupByProcessGuidAndIndexRequest)(nil), "models.ActualLRPGroupByProcessGuidAndIndexRequest")
 	proto.RegisterType((*ClaimActualLRPRequest)(nil), "models.ClaimActualLRPRequest")
 	proto.RegisterType((*StartActualLRPRequest)(nil), "models.StartActualLRPRequest")
 	proto.RegisterType((*CrashActualLRPRequest)(nil), "models.CrashActualLRPRequest")
 	proto.RegisterType((*FailActualLRPRequest)(nil), "models.FailActualLRPRequest")
 	proto.RegisterType((*RetireActualLRPRequest)(nil), "models.RetireActualLRPRequest")
+	proto.RegisterType((*RetireActualLRPsByProcessGuidRequest)(nil), "models.RetireActualLRPsByProcessGuidRequest")
+	proto.RegisterType((*RetireActualLRPsByProcessGuidResponse)(nil), "models.RetireActualLRPsByProcessGuidResponse")
+	proto.RegisterType((*ActualLRPLifecycleOperation)(nil), "models.ActualLRPLifecycleOperation")
+	proto.RegisterType((*ActualLRPLifecycleBatchRequest)(nil), "models.ActualLRPLifecycleBatchRequest")
+	proto.RegisterType((*ActualLRPLifecycleBatchResponse)(nil), "models.ActualLRPLifecycleBatchResponse")
 	proto.RegisterType((*RemoveActualLRPRequest)(nil), "models.RemoveActualLRPRequest")
 }
 func (this *ActualLRPLifecycleResponse) Equal(that interface{}) bool {
@@ -507,6 +639,36 @@ func (this *ActualLRPGroupsByProcessGuidRequest) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ActualLRPGroupsByStateRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*ActualLRPGroupsByStateRequest)
+	if !ok {
+		that2, ok := that.(ActualLRPGroupsByStateRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.State != that1.State {
+		return false
+	}
+	return true
+}
 func (this *ActualLRPGroupByProcessGuidAndIndexRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
@@ -711,7 +873,7 @@ func (this *RetireActualLRPRequest) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *RemoveActualLRPRequest) Equal(that interface{}) bool {
+func (this *RetireActualLRPsByProcessGuidRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
 			return true
@@ -719,9 +881,9 @@ func (this *RemoveActualLRPRequest) Equal(that interface{}) bool {
 		return false
 	}
 
-	that1, ok := that.(*RemoveActualLRPRequest)
+	that1, ok := that.(*RetireActualLRPsByProcessGuidRequest)
 	if !ok {
-		that2, ok := that.(RemoveActualLRPRequest)
+		that2, ok := that.(RetireActualLRPsByProcessGuidRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -739,90 +901,269 @@ func (this *RemoveActualLRPRequest) Equal(that interface{}) bool {
 	if this.ProcessGuid != that1.ProcessGuid {
 		return false
 	}
-	if this.Index != that1.Index {
+	return true
+}
+func (this *RetireActualLRPsByProcessGuidResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
 		return false
 	}
-	if !this.ActualLrpInstanceKey.Equal(that1.ActualLrpInstanceKey) {
+
+	that1, ok := that.(*RetireActualLRPsByProcessGuidResponse)
+	if !ok {
+		that2, ok := that.(RetireActualLRPsByProcessGuidResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	if this.RetiredCount != that1.RetiredCount {
 		return false
 	}
 	return true
 }
-func (this *ActualLRPLifecycleResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ActualLRPLifecycleOperation) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&models.ActualLRPLifecycleResponse{")
-	if this.Error != nil {
-		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+
+	that1, ok := that.(*ActualLRPLifecycleOperation)
+	if !ok {
+		that2, ok := that.(ActualLRPLifecycleOperation)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ActualLRPGroupsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&models.ActualLRPGroupsResponse{")
-	if this.Error != nil {
-		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	if !this.Claim.Equal(that1.Claim) {
+		return false
 	}
-	if this.ActualLrpGroups != nil {
-		s = append(s, "ActualLrpGroups: "+fmt.Sprintf("%#v", this.ActualLrpGroups)+",\n")
+	if !this.Start.Equal(that1.Start) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *ActualLRPGroupResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ActualLRPLifecycleBatchRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&models.ActualLRPGroupResponse{")
-	if this.Error != nil {
-		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+
+	that1, ok := that.(*ActualLRPLifecycleBatchRequest)
+	if !ok {
+		that2, ok := that.(ActualLRPLifecycleBatchRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.ActualLrpGroup != nil {
-		s = append(s, "ActualLrpGroup: "+fmt.Sprintf("%#v", this.ActualLrpGroup)+",\n")
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ActualLRPGroupsRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Operations) != len(that1.Operations) {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&models.ActualLRPGroupsRequest{")
-	s = append(s, "Domain: "+fmt.Sprintf("%#v", this.Domain)+",\n")
-	s = append(s, "CellId: "+fmt.Sprintf("%#v", this.CellId)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ActualLRPGroupsByProcessGuidRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.Operations {
+		if !this.Operations[i].Equal(that1.Operations[i]) {
+			return false
+		}
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&models.ActualLRPGroupsByProcessGuidRequest{")
-	s = append(s, "ProcessGuid: "+fmt.Sprintf("%#v", this.ProcessGuid)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *ActualLRPGroupByProcessGuidAndIndexRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ActualLRPLifecycleBatchResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&models.ActualLRPGroupByProcessGuidAndIndexRequest{")
-	s = append(s, "ProcessGuid: "+fmt.Sprintf("%#v", this.ProcessGuid)+",\n")
-	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ClaimActualLRPRequest) GoString() string {
-	if this == nil {
+
+	that1, ok := that.(*ActualLRPLifecycleBatchResponse)
+	if !ok {
+		that2, ok := that.(ActualLRPLifecycleBatchResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	if len(this.Results) != len(that1.Results) {
+		return false
+	}
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *RemoveActualLRPRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*RemoveActualLRPRequest)
+	if !ok {
+		that2, ok := that.(RemoveActualLRPRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.ProcessGuid != that1.ProcessGuid {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if !this.ActualLrpInstanceKey.Equal(that1.ActualLrpInstanceKey) {
+		return false
+	}
+	return true
+}
+func (this *ActualLRPLifecycleResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.ActualLRPLifecycleResponse{")
+	if this.Error != nil {
+		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPGroupsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.ActualLRPGroupsResponse{")
+	if this.Error != nil {
+		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	}
+	if this.ActualLrpGroups != nil {
+		s = append(s, "ActualLrpGroups: "+fmt.Sprintf("%#v", this.ActualLrpGroups)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPGroupResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.ActualLRPGroupResponse{")
+	if this.Error != nil {
+		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	}
+	if this.ActualLrpGroup != nil {
+		s = append(s, "ActualLrpGroup: "+fmt.Sprintf("%#v", this.ActualLrpGroup)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPGroupsRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.ActualLRPGroupsRequest{")
+	s = append(s, "Domain: "+fmt.Sprintf("%#v", this.Domain)+",\n")
+	s = append(s, "CellId: "+fmt.Sprintf("%#v", this.CellId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPGroupsByProcessGuidRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.ActualLRPGroupsByProcessGuidRequest{")
+	s = append(s, "ProcessGuid: "+fmt.Sprintf("%#v", this.ProcessGuid)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPGroupsByStateRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.ActualLRPGroupsByStateRequest{")
+	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPGroupByProcessGuidAndIndexRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.ActualLRPGroupByProcessGuidAndIndexRequest{")
+	s = append(s, "ProcessGuid: "+fmt.Sprintf("%#v", this.ProcessGuid)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ClaimActualLRPRequest) GoString() string {
+	if this == nil {
 		return "nil"
 	}
 	s := make([]string, 0, 7)
@@ -894,6 +1235,71 @@ func (this *RetireActualLRPRequest) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *RetireActualLRPsByProcessGuidRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.RetireActualLRPsByProcessGuidRequest{")
+	s = append(s, "ProcessGuid: "+fmt.Sprintf("%#v", this.ProcessGuid)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RetireActualLRPsByProcessGuidResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.RetireActualLRPsByProcessGuidResponse{")
+	if this.Error != nil {
+		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	}
+	s = append(s, "RetiredCount: "+fmt.Sprintf("%#v", this.RetiredCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPLifecycleOperation) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.ActualLRPLifecycleOperation{")
+	if this.Claim != nil {
+		s = append(s, "Claim: "+fmt.Sprintf("%#v", this.Claim)+",\n")
+	}
+	if this.Start != nil {
+		s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPLifecycleBatchRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.ActualLRPLifecycleBatchRequest{")
+	if this.Operations != nil {
+		s = append(s, "Operations: "+fmt.Sprintf("%#v", this.Operations)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ActualLRPLifecycleBatchResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.ActualLRPLifecycleBatchResponse{")
+	if this.Error != nil {
+		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	}
+	if this.Results != nil {
+		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *RemoveActualLRPRequest) GoString() string {
 	if this == nil {
 		return "nil"
@@ -1070,6 +1476,28 @@ func (m *ActualLRPGroupsByProcessGuidRequest) MarshalTo(dAtA []byte) (int, error
 	return i, nil
 }
 
+func (m *ActualLRPGroupsByStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActualLRPGroupsByStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintActualLrpRequests(dAtA, i, uint64(len(m.State)))
+	i += copy(dAtA[i:], m.State)
+	return i, nil
+}
+
 func (m *ActualLRPGroupByProcessGuidAndIndexRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1280,7 +1708,7 @@ func (m *RetireActualLRPRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *RemoveActualLRPRequest) Marshal() (dAtA []byte, err error) {
+func (m *RetireActualLRPsByProcessGuidRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1290,7 +1718,7 @@ func (m *RemoveActualLRPRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RemoveActualLRPRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *RetireActualLRPsByProcessGuidRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -1299,34 +1727,195 @@ func (m *RemoveActualLRPRequest) MarshalTo(dAtA []byte) (int, error) {
 	i++
 	i = encodeVarintActualLrpRequests(dAtA, i, uint64(len(m.ProcessGuid)))
 	i += copy(dAtA[i:], m.ProcessGuid)
-	dAtA[i] = 0x10
-	i++
-	i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.Index))
-	if m.ActualLrpInstanceKey != nil {
-		dAtA[i] = 0x1a
+	return i, nil
+}
+
+func (m *RetireActualLRPsByProcessGuidResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetireActualLRPsByProcessGuidResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.ActualLrpInstanceKey.Size()))
-		n13, err := m.ActualLrpInstanceKey.MarshalTo(dAtA[i:])
+		i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.Error.Size()))
+		n13, err := m.Error.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
 		i += n13
 	}
+	dAtA[i] = 0x10
+	i++
+	i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.RetiredCount))
 	return i, nil
 }
 
-func encodeFixed64ActualLrpRequests(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
+func (m *ActualLRPLifecycleOperation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func encodeFixed32ActualLrpRequests(dAtA []byte, offset int, v uint32) int {
+
+func (m *ActualLRPLifecycleOperation) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Claim != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.Claim.Size()))
+		n14, err := m.Claim.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.Start != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.Start.Size()))
+		n15, err := m.Start.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	return i, nil
+}
+
+func (m *ActualLRPLifecycleBatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActualLRPLifecycleBatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Operations) > 0 {
+		for _, msg := range m.Operations {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintActualLrpRequests(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ActualLRPLifecycleBatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActualLRPLifecycleBatchResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.Error.Size()))
+		n16, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if len(m.Results) > 0 {
+		for _, msg := range m.Results {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintActualLrpRequests(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *RemoveActualLRPRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveActualLRPRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintActualLrpRequests(dAtA, i, uint64(len(m.ProcessGuid)))
+	i += copy(dAtA[i:], m.ProcessGuid)
+	dAtA[i] = 0x10
+	i++
+	i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.Index))
+	if m.ActualLrpInstanceKey != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintActualLrpRequests(dAtA, i, uint64(m.ActualLrpInstanceKey.Size()))
+		n17, err := m.ActualLrpInstanceKey.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	return i, nil
+}
+
+func encodeFixed64ActualLrpRequests(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32ActualLrpRequests(dAtA []byte, offset int, v uint32) int {
 	dAtA[offset] = uint8(v)
 	dAtA[offset+1] = uint8(v >> 8)
 	dAtA[offset+2] = uint8(v >> 16)
@@ -1400,6 +1989,14 @@ func (m *ActualLRPGroupsByProcessGuidRequest) Size() (n int) {
 	return n
 }
 
+func (m *ActualLRPGroupsByStateRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.State)
+	n += 1 + l + sovActualLrpRequests(uint64(l))
+	return n
+}
+
 func (m *ActualLRPGroupByProcessGuidAndIndexRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -1478,6 +2075,67 @@ func (m *RetireActualLRPRequest) Size() (n int) {
 	return n
 }
 
+func (m *RetireActualLRPsByProcessGuidRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ProcessGuid)
+	n += 1 + l + sovActualLrpRequests(uint64(l))
+	return n
+}
+
+func (m *RetireActualLRPsByProcessGuidResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovActualLrpRequests(uint64(l))
+	}
+	n += 1 + sovActualLrpRequests(uint64(m.RetiredCount))
+	return n
+}
+
+func (m *ActualLRPLifecycleOperation) Size() (n int) {
+	var l int
+	_ = l
+	if m.Claim != nil {
+		l = m.Claim.Size()
+		n += 1 + l + sovActualLrpRequests(uint64(l))
+	}
+	if m.Start != nil {
+		l = m.Start.Size()
+		n += 1 + l + sovActualLrpRequests(uint64(l))
+	}
+	return n
+}
+
+func (m *ActualLRPLifecycleBatchRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Operations) > 0 {
+		for _, e := range m.Operations {
+			l = e.Size()
+			n += 1 + l + sovActualLrpRequests(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ActualLRPLifecycleBatchResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovActualLrpRequests(uint64(l))
+	}
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovActualLrpRequests(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *RemoveActualLRPRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -1557,6 +2215,16 @@ func (this *ActualLRPGroupsByProcessGuidRequest) String() string {
 	}, "")
 	return s
 }
+func (this *ActualLRPGroupsByStateRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActualLRPGroupsByStateRequest{`,
+		`State:` + fmt.Sprintf("%v", this.State) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *ActualLRPGroupByProcessGuidAndIndexRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -1625,6 +2293,59 @@ func (this *RetireActualLRPRequest) String() string {
 	}, "")
 	return s
 }
+func (this *RetireActualLRPsByProcessGuidRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RetireActualLRPsByProcessGuidRequest{`,
+		`ProcessGuid:` + fmt.Sprintf("%v", this.ProcessGuid) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RetireActualLRPsByProcessGuidResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RetireActualLRPsByProcessGuidResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`RetiredCount:` + fmt.Sprintf("%v", this.RetiredCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActualLRPLifecycleOperation) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActualLRPLifecycleOperation{`,
+		`Claim:` + strings.Replace(fmt.Sprintf("%v", this.Claim), "ClaimActualLRPRequest", "ClaimActualLRPRequest", 1) + `,`,
+		`Start:` + strings.Replace(fmt.Sprintf("%v", this.Start), "StartActualLRPRequest", "StartActualLRPRequest", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActualLRPLifecycleBatchRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActualLRPLifecycleBatchRequest{`,
+		`Operations:` + strings.Replace(fmt.Sprintf("%v", this.Operations), "ActualLRPLifecycleOperation", "ActualLRPLifecycleOperation", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ActualLRPLifecycleBatchResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ActualLRPLifecycleBatchResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`Results:` + strings.Replace(fmt.Sprintf("%v", this.Results), "ActualLRPLifecycleResponse", "ActualLRPLifecycleResponse", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *RemoveActualLRPRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -2145,7 +2866,7 @@ func (m *ActualLRPGroupsByProcessGuidRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ActualLRPGroupByProcessGuidAndIndexRequest) Unmarshal(dAtA []byte) error {
+func (m *ActualLRPGroupsByStateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2168,15 +2889,15 @@ func (m *ActualLRPGroupByProcessGuidAndIndexRequest) Unmarshal(dAtA []byte) erro
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ActualLRPGroupByProcessGuidAndIndexRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ActualLRPGroupsByStateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActualLRPGroupByProcessGuidAndIndexRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ActualLRPGroupsByStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProcessGuid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2201,27 +2922,8 @@ func (m *ActualLRPGroupByProcessGuidAndIndexRequest) Unmarshal(dAtA []byte) erro
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ProcessGuid = string(dAtA[iNdEx:postIndex])
+			m.State = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
-			}
-			m.Index = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowActualLrpRequests
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Index |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipActualLrpRequests(dAtA[iNdEx:])
@@ -2243,7 +2945,7 @@ func (m *ActualLRPGroupByProcessGuidAndIndexRequest) Unmarshal(dAtA []byte) erro
 	}
 	return nil
 }
-func (m *ClaimActualLRPRequest) Unmarshal(dAtA []byte) error {
+func (m *ActualLRPGroupByProcessGuidAndIndexRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2266,10 +2968,10 @@ func (m *ClaimActualLRPRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ClaimActualLRPRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ActualLRPGroupByProcessGuidAndIndexRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ClaimActualLRPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ActualLRPGroupByProcessGuidAndIndexRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2320,10 +3022,108 @@ func (m *ClaimActualLRPRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpInstanceKey", wireType)
-			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipActualLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClaimActualLRPRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowActualLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClaimActualLRPRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClaimActualLRPRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActualLrpInstanceKey", wireType)
+			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
@@ -2863,6 +3663,498 @@ func (m *RetireActualLRPRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RetireActualLRPsByProcessGuidRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowActualLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetireActualLRPsByProcessGuidRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetireActualLRPsByProcessGuidRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipActualLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RetireActualLRPsByProcessGuidResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowActualLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetireActualLRPsByProcessGuidResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetireActualLRPsByProcessGuidResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetiredCount", wireType)
+			}
+			m.RetiredCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetiredCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipActualLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActualLRPLifecycleOperation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowActualLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActualLRPLifecycleOperation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActualLRPLifecycleOperation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Claim", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Claim == nil {
+				m.Claim = &ClaimActualLRPRequest{}
+			}
+			if err := m.Claim.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Start == nil {
+				m.Start = &StartActualLRPRequest{}
+			}
+			if err := m.Start.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipActualLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActualLRPLifecycleBatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowActualLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActualLRPLifecycleBatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActualLRPLifecycleBatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operations = append(m.Operations, &ActualLRPLifecycleOperation{})
+			if err := m.Operations[len(m.Operations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipActualLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActualLRPLifecycleBatchResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowActualLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActualLRPLifecycleBatchResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActualLRPLifecycleBatchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowActualLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Results = append(m.Results, &ActualLRPLifecycleResponse{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipActualLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthActualLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *RemoveActualLRPRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3102,41 +4394,51 @@ var (
 func init() { proto.RegisterFile("actual_lrp_requests.proto", fileDescriptorActualLrpRequests) }
 
 var fileDescriptorActualLrpRequests = []byte{
-	// 576 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x93, 0xcf, 0x6e, 0xd3, 0x4c,
-	0x14, 0xc5, 0x33, 0xe9, 0xd7, 0x7e, 0x62, 0xd2, 0x96, 0x60, 0xd2, 0x34, 0x44, 0xc5, 0x54, 0xd3,
-	0x05, 0x05, 0x41, 0x2a, 0x75, 0xc9, 0x8a, 0x04, 0x41, 0x14, 0x35, 0x54, 0x95, 0x0b, 0x6b, 0xcb,
-	0xb5, 0x6f, 0xdc, 0x11, 0xb6, 0xc7, 0x9d, 0xb1, 0x11, 0x59, 0x20, 0x10, 0x4f, 0xc0, 0x63, 0xb0,
-	0x85, 0xa7, 0xe8, 0xb2, 0x12, 0x1b, 0x56, 0x88, 0x98, 0x0d, 0xcb, 0xf2, 0x06, 0xc8, 0x33, 0x8e,
-	0x9b, 0x3f, 0x2a, 0x52, 0x50, 0x16, 0xb0, 0xf3, 0x9c, 0x7b, 0xe7, 0x77, 0xce, 0xd5, 0xf5, 0xe0,
-	0x1b, 0x96, 0x1d, 0xc5, 0x96, 0x67, 0x7a, 0x3c, 0x34, 0x39, 0x9c, 0xc4, 0x20, 0x22, 0xd1, 0x08,
-	0x39, 0x8b, 0x98, 0xb6, 0xe4, 0x33, 0x07, 0x3c, 0x51, 0xbf, 0xef, 0xd2, 0xe8, 0x38, 0x3e, 0x6a,
-	0xd8, 0xcc, 0xdf, 0x71, 0x99, 0xcb, 0x76, 0x64, 0xf9, 0x28, 0xee, 0xc9, 0x93, 0x3c, 0xc8, 0x2f,
-	0x75, 0xad, 0x5e, 0xbe, 0x20, 0x66, 0x4a, 0x09, 0x38, 0x67, 0x5c, 0x1d, 0x48, 0x13, 0xd7, 0x9b,
-	0xb2, 0xa1, 0x6b, 0x1c, 0x74, 0x69, 0x0f, 0xec, 0xbe, 0xed, 0x81, 0x01, 0x22, 0x64, 0x81, 0x00,
-	0x6d, 0x0b, 0x2f, 0xca, 0xe6, 0x1a, 0xda, 0x44, 0xdb, 0xa5, 0xdd, 0x95, 0x86, 0xca, 0xd0, 0x78,
-	0x9c, 0x8a, 0x86, 0xaa, 0x91, 0x77, 0x08, 0xaf, 0xe7, 0x8c, 0x36, 0x67, 0x71, 0x28, 0x66, 0x02,
-	0x68, 0x2d, 0x7c, 0x6d, 0x64, 0x6c, 0x57, 0x12, 0x6a, 0xc5, 0xcd, 0x85, 0xed, 0xd2, 0x6e, 0x75,
-	0x78, 0x61, 0xdc, 0xc0, 0xb8, 0xaa, 0x2e, 0x74, 0x79, 0xa8, 0x0c, 0xc9, 0x1b, 0x5c, 0x9d, 0x68,
-	0x99, 0x29, 0xc2, 0x43, 0x5c, 0x9e, 0x8c, 0x50, 0x2b, 0xca, 0xfe, 0xcb, 0x12, 0xac, 0x8e, 0x27,
-	0x20, 0xcf, 0x27, 0x03, 0x08, 0x43, 0xed, 0x4f, 0xdb, 0xc0, 0x4b, 0x0e, 0xf3, 0x2d, 0x1a, 0xc8,
-	0x04, 0x57, 0x5a, 0xff, 0x9d, 0x7e, 0xbd, 0x55, 0x30, 0x32, 0x4d, 0xbb, 0x89, 0xff, 0xb7, 0xc1,
-	0xf3, 0x4c, 0xea, 0x48, 0xc3, 0xbc, 0x9c, 0x8a, 0x1d, 0x87, 0xec, 0xe3, 0xad, 0x09, 0x6c, 0xab,
-	0x7f, 0xc0, 0x99, 0x0d, 0x42, 0xb4, 0x63, 0xea, 0x0c, 0x3d, 0x6e, 0xe3, 0xe5, 0x50, 0xa9, 0xa6,
-	0x1b, 0x53, 0x67, 0xcc, 0xa9, 0x14, 0x5e, 0xf4, 0x93, 0x13, 0x7c, 0x77, 0x9c, 0x37, 0x86, 0x6b,
-	0x06, 0x4e, 0x27, 0x70, 0xe0, 0xd5, 0xac, 0x58, 0xad, 0x8e, 0x17, 0x69, 0x7a, 0x51, 0xce, 0xb0,
-	0x98, 0x75, 0x28, 0x89, 0x7c, 0x44, 0x78, 0xed, 0x91, 0x67, 0x51, 0x3f, 0x37, 0x9e, 0x27, 0x5e,
-	0x3b, 0xc4, 0xeb, 0x23, 0xab, 0xa3, 0x81, 0x88, 0xac, 0xc0, 0x06, 0xf3, 0x05, 0xf4, 0x6b, 0x0b,
-	0x72, 0x83, 0x1b, 0x53, 0x1b, 0xec, 0x64, 0x4d, 0x7b, 0xd0, 0x37, 0x2a, 0xf9, 0x1e, 0x47, 0x54,
-	0xf2, 0x13, 0xe1, 0xb5, 0xc3, 0xc8, 0xe2, 0xd1, 0x54, 0xe6, 0x07, 0x78, 0x75, 0xc4, 0x2e, 0x75,
-	0x51, 0xff, 0x55, 0x65, 0xca, 0x25, 0xa5, 0x2f, 0xe7, 0xf4, 0x3d, 0xe8, 0xff, 0x2e, 0x6a, 0xf1,
-	0x4f, 0xa3, 0x6a, 0x6d, 0x7c, 0x7d, 0x04, 0x1a, 0x40, 0x64, 0xd2, 0xa0, 0xc7, 0xb2, 0xd9, 0x6b,
-	0x53, 0xc0, 0x7d, 0x88, 0x3a, 0x41, 0x8f, 0x19, 0xe5, 0x1c, 0x96, 0x29, 0xe4, 0x73, 0xba, 0x27,
-	0x6e, 0x89, 0xe3, 0xbf, 0x7f, 0xe6, 0x3b, 0x78, 0x45, 0xbe, 0x5b, 0xd3, 0x07, 0x21, 0x2c, 0x17,
-	0xe4, 0xb4, 0xc3, 0x3f, 0x67, 0x59, 0x96, 0x9e, 0xaa, 0x0a, 0x79, 0x8d, 0x2b, 0x4f, 0x2c, 0xea,
-	0xcd, 0x75, 0xa6, 0x29, 0xfb, 0xe2, 0xa5, 0xf6, 0xcf, 0x70, 0xd5, 0x80, 0x88, 0x72, 0x98, 0x67,
-	0x00, 0xf2, 0x09, 0xa5, 0x58, 0x9f, 0xbd, 0x84, 0x7f, 0xe7, 0x4d, 0xb5, 0xee, 0x9d, 0x0d, 0xf4,
-	0xc2, 0x97, 0x81, 0x5e, 0x38, 0x1f, 0xe8, 0xe8, 0x6d, 0xa2, 0xa3, 0x0f, 0x89, 0x8e, 0x4e, 0x13,
-	0x1d, 0x9d, 0x25, 0x3a, 0xfa, 0x96, 0xe8, 0xe8, 0x47, 0xa2, 0x17, 0xce, 0x13, 0x1d, 0xbd, 0xff,
-	0xae, 0x17, 0x7e, 0x05, 0x00, 0x00, 0xff, 0xff, 0xb3, 0x81, 0x21, 0xf4, 0x0a, 0x07, 0x00, 0x00,
+	// 732 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0x4f, 0x4f, 0xd4, 0x4c,
+	0x18, 0xdf, 0x59, 0xde, 0x85, 0xbc, 0xcf, 0x2e, 0xbc, 0xbc, 0x95, 0x3f, 0x75, 0x85, 0x42, 0x06,
+	0x8d, 0x60, 0x74, 0x49, 0xf0, 0xa6, 0x1e, 0x64, 0x89, 0x92, 0x0d, 0x08, 0xa4, 0xe8, 0xb9, 0x29,
+	0xed, 0xec, 0xd2, 0xd8, 0xed, 0x94, 0x99, 0xa9, 0xba, 0x07, 0xa3, 0x31, 0x26, 0x5e, 0xfd, 0x18,
+	0x5e, 0xf5, 0x53, 0x70, 0x24, 0xf1, 0xe2, 0xc9, 0xc8, 0x7a, 0xf1, 0x88, 0xdf, 0xc0, 0x74, 0xba,
+	0x5d, 0xda, 0x2d, 0x60, 0x56, 0x39, 0xe8, 0xad, 0xf3, 0x3c, 0xcf, 0xef, 0xcf, 0xf4, 0x99, 0x79,
+	0x06, 0x2e, 0x9a, 0x96, 0x08, 0x4c, 0xd7, 0x70, 0x99, 0x6f, 0x30, 0xb2, 0x17, 0x10, 0x2e, 0x78,
+	0xc5, 0x67, 0x54, 0x50, 0x65, 0xb0, 0x49, 0x6d, 0xe2, 0xf2, 0xf2, 0x8d, 0x86, 0x23, 0x76, 0x83,
+	0x9d, 0x8a, 0x45, 0x9b, 0x8b, 0x0d, 0xda, 0xa0, 0x8b, 0x32, 0xbd, 0x13, 0xd4, 0xe5, 0x4a, 0x2e,
+	0xe4, 0x57, 0x04, 0x2b, 0x8f, 0x1e, 0x33, 0x76, 0x22, 0x45, 0xc2, 0x18, 0x65, 0xd1, 0x02, 0x2f,
+	0x43, 0x79, 0x59, 0x16, 0xac, 0xeb, 0x5b, 0xeb, 0x4e, 0x9d, 0x58, 0x2d, 0xcb, 0x25, 0x3a, 0xe1,
+	0x3e, 0xf5, 0x38, 0x51, 0xe6, 0xa0, 0x20, 0x8b, 0x55, 0x34, 0x8b, 0xe6, 0x8b, 0x4b, 0xc3, 0x95,
+	0xc8, 0x43, 0xe5, 0x5e, 0x18, 0xd4, 0xa3, 0x1c, 0x7e, 0x85, 0x60, 0xb2, 0xcb, 0xb1, 0xca, 0x68,
+	0xe0, 0xf3, 0xbe, 0x08, 0x94, 0x2a, 0xfc, 0x9f, 0xd8, 0x76, 0x43, 0x32, 0xa8, 0xf9, 0xd9, 0x81,
+	0xf9, 0xe2, 0xd2, 0x44, 0x0c, 0x48, 0x0b, 0xe8, 0xff, 0x45, 0x80, 0x75, 0xe6, 0x47, 0x82, 0xf8,
+	0x05, 0x4c, 0xf4, 0x94, 0xf4, 0x65, 0xe1, 0x2e, 0x8c, 0xf6, 0x5a, 0x50, 0xf3, 0xb3, 0xe8, 0x0c,
+	0x07, 0x23, 0x69, 0x07, 0xf8, 0x51, 0xaf, 0x01, 0xae, 0x47, 0xfd, 0x53, 0xa6, 0x60, 0xd0, 0xa6,
+	0x4d, 0xd3, 0xf1, 0xa4, 0x83, 0x7f, 0xab, 0xff, 0xec, 0x7f, 0x9e, 0xc9, 0xe9, 0x9d, 0x98, 0x32,
+	0x0d, 0x43, 0x16, 0x71, 0x5d, 0xc3, 0xb1, 0xd5, 0x7c, 0x32, 0x1d, 0x06, 0x6b, 0x36, 0xde, 0x80,
+	0xb9, 0x1e, 0xda, 0x6a, 0x6b, 0x8b, 0x51, 0x8b, 0x70, 0xbe, 0x1a, 0x38, 0x76, 0xac, 0x71, 0x15,
+	0x4a, 0x7e, 0x14, 0x35, 0x1a, 0x81, 0x63, 0xa7, 0x94, 0x8a, 0xfe, 0x71, 0x3d, 0xbe, 0x0d, 0xd3,
+	0x19, 0xbe, 0x6d, 0x61, 0x0a, 0x12, 0x33, 0x95, 0xa1, 0xc0, 0xc3, 0x75, 0x8a, 0x22, 0x0a, 0xe1,
+	0x3d, 0xb8, 0x96, 0x06, 0xa7, 0xbc, 0x2c, 0x7b, 0x76, 0xcd, 0xb3, 0xc9, 0xb3, 0x7e, 0x3d, 0x85,
+	0x92, 0x4e, 0x08, 0x94, 0x3f, 0xa0, 0x10, 0x4b, 0xca, 0x10, 0x7e, 0x8f, 0x60, 0x7c, 0xc5, 0x35,
+	0x9d, 0x66, 0x57, 0xf8, 0x3c, 0xe9, 0x95, 0x6d, 0x98, 0x4c, 0xf4, 0xdd, 0xf1, 0xb8, 0x30, 0x3d,
+	0x8b, 0x18, 0x8f, 0x49, 0x4b, 0x1d, 0x90, 0xed, 0x9f, 0xca, 0xb4, 0xbf, 0xd6, 0x29, 0x5a, 0x23,
+	0x2d, 0x7d, 0xac, 0x7b, 0x08, 0x12, 0x51, 0xfc, 0x1d, 0xc1, 0xf8, 0xb6, 0x30, 0x99, 0xc8, 0x78,
+	0xbe, 0x05, 0x23, 0x09, 0xb9, 0x50, 0x25, 0x3a, 0x94, 0x63, 0x19, 0x95, 0x90, 0xbd, 0xd4, 0x65,
+	0x5f, 0x23, 0xad, 0xb3, 0xac, 0xe6, 0x7f, 0xd5, 0xaa, 0xb2, 0x0a, 0x17, 0x12, 0xa4, 0x1e, 0x11,
+	0x86, 0xe3, 0xd5, 0x69, 0x67, 0xef, 0x6a, 0x86, 0x70, 0x83, 0x88, 0x9a, 0x57, 0xa7, 0xfa, 0x68,
+	0x97, 0xac, 0x13, 0xc1, 0x1f, 0xc3, 0x3e, 0x31, 0x93, 0xef, 0xfe, 0xf9, 0x7b, 0x5e, 0x80, 0x61,
+	0x79, 0xe9, 0x8d, 0x26, 0xe1, 0xdc, 0x6c, 0x10, 0x75, 0x20, 0x71, 0x72, 0x4a, 0x32, 0xf5, 0x20,
+	0xca, 0xe0, 0xe7, 0x30, 0x76, 0xdf, 0x74, 0xdc, 0x73, 0xdd, 0x53, 0x46, 0x3e, 0x7f, 0xaa, 0xfc,
+	0x43, 0x98, 0xd0, 0x89, 0x70, 0x18, 0x39, 0x4f, 0x03, 0x78, 0x13, 0x2e, 0xf7, 0xb0, 0xfe, 0xe6,
+	0x4c, 0x79, 0x0a, 0x57, 0x7e, 0x42, 0xd8, 0xcf, 0x28, 0x5e, 0x80, 0x61, 0x26, 0xd9, 0x6c, 0xc3,
+	0xa2, 0x81, 0x27, 0x52, 0xd7, 0xb6, 0xd4, 0x49, 0xad, 0x84, 0x19, 0xfc, 0x06, 0xc1, 0xa5, 0xec,
+	0xeb, 0xb5, 0xe9, 0x13, 0x66, 0x0a, 0x87, 0x7a, 0xca, 0x4d, 0x28, 0x58, 0xe1, 0xec, 0xe8, 0xe8,
+	0x4d, 0xc7, 0x7a, 0x27, 0x0e, 0x14, 0x3d, 0xaa, 0x0d, 0x41, 0x3c, 0xbc, 0xbc, 0x6a, 0x3e, 0x0d,
+	0x3a, 0xf1, 0x46, 0xeb, 0x51, 0x2d, 0x26, 0xa0, 0x65, 0x8d, 0x54, 0x4d, 0x61, 0xed, 0xc6, 0x7f,
+	0x73, 0x05, 0x80, 0xc6, 0xc6, 0xb8, 0x8a, 0xe4, 0xeb, 0x36, 0x97, 0xe9, 0x56, 0x76, 0x13, 0x7a,
+	0x02, 0x86, 0x5f, 0x23, 0x98, 0x39, 0x55, 0xa7, 0x9f, 0x9f, 0x7c, 0x07, 0x86, 0x18, 0xe1, 0x81,
+	0x2b, 0xe2, 0x87, 0x16, 0x9f, 0x6e, 0x25, 0x66, 0xd6, 0x63, 0x08, 0xfe, 0x80, 0xc2, 0x83, 0xd9,
+	0xa4, 0x4f, 0xc8, 0xdf, 0x33, 0x95, 0xab, 0xd7, 0x0f, 0x0e, 0xb5, 0xdc, 0xa7, 0x43, 0x2d, 0x77,
+	0x74, 0xa8, 0xa1, 0x97, 0x6d, 0x0d, 0xbd, 0x6b, 0x6b, 0x68, 0xbf, 0xad, 0xa1, 0x83, 0xb6, 0x86,
+	0xbe, 0xb4, 0x35, 0xf4, 0xad, 0xad, 0xe5, 0x8e, 0xda, 0x1a, 0x7a, 0xfb, 0x55, 0xcb, 0xfd, 0x08,
+	0x00, 0x00, 0xff, 0xff, 0x79, 0xf0, 0x9e, 0x37, 0x89, 0x09, 0x00, 0x00,
 }
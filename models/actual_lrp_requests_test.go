@@ -52,6 +52,44 @@ var _ = Describe("ActualLRP Requests", func() {
 		})
 	})
 
+	Describe("ActualLRPGroupsByStateRequest", func() {
+		Describe("Validate", func() {
+			var request models.ActualLRPGroupsByStateRequest
+
+			BeforeEach(func() {
+				request = models.ActualLRPGroupsByStateRequest{
+					State: models.ActualLRPStateCrashed,
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the State is blank", func() {
+				BeforeEach(func() {
+					request.State = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"state"}))
+				})
+			})
+
+			Context("when the State is not an actual LRP state", func() {
+				BeforeEach(func() {
+					request.State = "EXPLODED"
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"state"}))
+				})
+			})
+		})
+	})
+
 	Describe("ActualLRPGroupByProcessGuidAndIndexRequest", func() {
 		Describe("Validate", func() {
 			var request models.ActualLRPGroupByProcessGuidAndIndexRequest
@@ -260,6 +298,76 @@ var _ = Describe("ActualLRP Requests", func() {
 		})
 	})
 
+	Describe("ActualLRPLifecycleOperation", func() {
+		Describe("Validate", func() {
+			var operation models.ActualLRPLifecycleOperation
+
+			BeforeEach(func() {
+				operation = models.ActualLRPLifecycleOperation{
+					Claim: &models.ClaimActualLRPRequest{
+						ProcessGuid:          "p-guid",
+						Index:                2,
+						ActualLrpInstanceKey: &models.ActualLRPInstanceKey{InstanceGuid: "i-guid", CellId: "c-id"},
+					},
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(operation.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when neither a claim nor a start is given", func() {
+				BeforeEach(func() {
+					operation.Claim = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(operation.Validate()).To(ConsistOf(models.ErrInvalidField{"operation"}))
+				})
+			})
+
+			Context("when both a claim and a start are given", func() {
+				BeforeEach(func() {
+					operation.Start = &models.StartActualLRPRequest{
+						ActualLrpKey:         &models.ActualLRPKey{ProcessGuid: "p-guid", Index: 2, Domain: "domain"},
+						ActualLrpInstanceKey: &models.ActualLRPInstanceKey{InstanceGuid: "i-guid", CellId: "c-id"},
+						ActualLrpNetInfo:     &models.ActualLRPNetInfo{Address: "addr"},
+					}
+				})
+
+				It("returns a validation error", func() {
+					Expect(operation.Validate()).To(ConsistOf(models.ErrInvalidField{"operation"}))
+				})
+			})
+
+			Context("when the claim is invalid", func() {
+				BeforeEach(func() {
+					operation.Claim.ProcessGuid = ""
+				})
+
+				It("returns the claim's validation error", func() {
+					Expect(operation.Validate()).To(ConsistOf(models.ErrInvalidField{"process_guid"}))
+				})
+			})
+
+			Context("when the start is invalid", func() {
+				BeforeEach(func() {
+					operation.Claim = nil
+					operation.Start = &models.StartActualLRPRequest{
+						ActualLrpKey:         &models.ActualLRPKey{ProcessGuid: "p-guid", Index: 2, Domain: "domain"},
+						ActualLrpInstanceKey: &models.ActualLRPInstanceKey{InstanceGuid: "i-guid", CellId: "c-id"},
+					}
+				})
+
+				It("returns the start's validation error", func() {
+					Expect(operation.Validate()).To(ConsistOf(models.ErrInvalidField{"actual_lrp_net_info"}))
+				})
+			})
+		})
+	})
+
 	Describe("CrashActualLRPRequest", func() {
 		Describe("Validate", func() {
 			var request models.CrashActualLRPRequest
@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/bbs/models"
@@ -236,6 +237,34 @@ var _ = Describe("ActualLRP", func() {
 				})
 			})
 		})
+
+		Describe("OrderKey", func() {
+			It("orders indices numerically", func() {
+				two := models.NewActualLRPKey("process-guid", 2, "domain")
+				ten := models.NewActualLRPKey("process-guid", 10, "domain")
+				Expect(two.OrderKey() < ten.OrderKey()).To(BeTrue())
+			})
+
+			It("orders by process guid before index", func() {
+				first := models.NewActualLRPKey("a", 10, "domain")
+				second := models.NewActualLRPKey("a-b", 2, "domain")
+				Expect(first.OrderKey() < second.OrderKey()).To(BeTrue())
+			})
+
+			It("sorts keys by order key", func() {
+				keys := []*models.ActualLRPKey{
+					{ProcessGuid: "process-guid", Index: 10},
+					{ProcessGuid: "process-guid", Index: 2},
+					{ProcessGuid: "other-guid", Index: 1},
+				}
+				sort.Sort(models.ActualLRPKeysByOrderKey(keys))
+				Expect(keys).To(Equal([]*models.ActualLRPKey{
+					{ProcessGuid: "other-guid", Index: 1},
+					{ProcessGuid: "process-guid", Index: 2},
+					{ProcessGuid: "process-guid", Index: 10},
+				}))
+			})
+		})
 	})
 
 	Describe("ActualLRPInstanceKey", func() {
@@ -284,6 +313,59 @@ var _ = Describe("ActualLRP", func() {
 					Expect(netInfo.GetPorts()).To(BeEmpty())
 				})
 			})
+
+			Describe("MergePorts", func() {
+				var netInfo models.ActualLRPNetInfo
+
+				BeforeEach(func() {
+					netInfo = models.NewActualLRPNetInfo("1.2.3.4", "2.2.2.2", models.NewPortMapping(5678, 8080), models.NewPortMapping(1234, 8081))
+				})
+
+				Context("when the additional ports are disjoint", func() {
+					It("appends them to the existing ports", func() {
+						merged := netInfo.MergePorts([]*models.PortMapping{models.NewPortMapping(4321, 9090)})
+
+						Expect(merged.Address).To(Equal("1.2.3.4"))
+						Expect(merged.InstanceAddress).To(Equal("2.2.2.2"))
+						Expect(merged.Ports).To(Equal([]*models.PortMapping{
+							models.NewPortMapping(5678, 8080),
+							models.NewPortMapping(1234, 8081),
+							models.NewPortMapping(4321, 9090),
+						}))
+					})
+				})
+
+				Context("when the additional ports overlap", func() {
+					It("replaces the mappings for the same container port", func() {
+						merged := netInfo.MergePorts([]*models.PortMapping{
+							models.NewPortMapping(9999, 8081),
+							models.NewPortMapping(4321, 9090),
+							models.NewPortMapping(8888, 8081),
+						})
+
+						Expect(merged.Ports).To(Equal([]*models.PortMapping{
+							models.NewPortMapping(5678, 8080),
+							models.NewPortMapping(8888, 8081),
+							models.NewPortMapping(4321, 9090),
+						}))
+					})
+				})
+
+				It("does not modify the original net info", func() {
+					netInfo.MergePorts([]*models.PortMapping{models.NewPortMapping(9999, 8080)})
+
+					Expect(netInfo.Ports).To(Equal([]*models.PortMapping{
+						models.NewPortMapping(5678, 8080),
+						models.NewPortMapping(1234, 8081),
+					}))
+				})
+
+				It("merges into empty net info", func() {
+					merged := models.EmptyActualLRPNetInfo().MergePorts([]*models.PortMapping{models.NewPortMapping(4321, 9090)})
+
+					Expect(merged.Ports).To(Equal([]*models.PortMapping{models.NewPortMapping(4321, 9090)}))
+				})
+			})
 		})
 	})
 
@@ -394,6 +476,182 @@ var _ = Describe("ActualLRP", func() {
 				})
 			})
 		})
+
+		Describe("Diff", func() {
+			var (
+				key         models.ActualLRPKey
+				instanceKey models.ActualLRPInstanceKey
+				netInfo     models.ActualLRPNetInfo
+
+				before *models.ActualLRPGroup
+				after  *models.ActualLRPGroup
+			)
+
+			BeforeEach(func() {
+				key = models.NewActualLRPKey("process-guid", 0, "domain")
+				instanceKey = models.NewActualLRPInstanceKey("instance-guid", "cell-id")
+				netInfo = models.NewActualLRPNetInfo("1.2.3.4", "10.0.0.1", models.NewPortMapping(61000, 8080))
+
+				before = models.NewRunningActualLRPGroup(models.NewRunningActualLRP(key, instanceKey, netInfo, 1138))
+				after = models.NewRunningActualLRPGroup(models.NewRunningActualLRP(key, instanceKey, netInfo, 1138))
+			})
+
+			It("reports no change for equal groups", func() {
+				change := before.Diff(after)
+				Expect(change.Changed()).To(BeFalse())
+				Expect(change.StateChanged).To(BeFalse())
+				Expect(change.NetInfoChanged).To(BeFalse())
+				Expect(change.CellChanged).To(BeFalse())
+			})
+
+			It("keeps both groups on the change", func() {
+				change := before.Diff(after)
+				Expect(change.Before).To(BeIdenticalTo(before))
+				Expect(change.After).To(BeIdenticalTo(after))
+			})
+
+			It("reports a state change", func() {
+				after.Instance.State = models.ActualLRPStateCrashed
+
+				change := before.Diff(after)
+				Expect(change.Changed()).To(BeTrue())
+				Expect(change.StateChanged).To(BeTrue())
+				Expect(change.NetInfoChanged).To(BeFalse())
+				Expect(change.CellChanged).To(BeFalse())
+			})
+
+			It("reports a state change when the resolved actual LRP starts evacuating", func() {
+				after = models.NewEvacuatingActualLRPGroup(after.Instance)
+
+				change := before.Diff(after)
+				Expect(change.StateChanged).To(BeTrue())
+				Expect(change.NetInfoChanged).To(BeFalse())
+				Expect(change.CellChanged).To(BeFalse())
+			})
+
+			It("reports a net info change", func() {
+				after.Instance.ActualLRPNetInfo = models.NewActualLRPNetInfo("1.2.3.4", "10.0.0.1", models.NewPortMapping(61001, 8080))
+
+				change := before.Diff(after)
+				Expect(change.Changed()).To(BeTrue())
+				Expect(change.StateChanged).To(BeFalse())
+				Expect(change.NetInfoChanged).To(BeTrue())
+				Expect(change.CellChanged).To(BeFalse())
+			})
+
+			It("reports a cell change", func() {
+				after.Instance.ActualLRPInstanceKey = models.NewActualLRPInstanceKey("instance-guid", "other-cell-id")
+
+				change := before.Diff(after)
+				Expect(change.Changed()).To(BeTrue())
+				Expect(change.StateChanged).To(BeFalse())
+				Expect(change.NetInfoChanged).To(BeFalse())
+				Expect(change.CellChanged).To(BeTrue())
+			})
+
+			It("reports other changes only through Changed", func() {
+				after.Instance.CrashCount = 1
+
+				change := before.Diff(after)
+				Expect(change.Changed()).To(BeTrue())
+				Expect(change.StateChanged).To(BeFalse())
+				Expect(change.NetInfoChanged).To(BeFalse())
+				Expect(change.CellChanged).To(BeFalse())
+			})
+
+			It("reports every kind of change against a missing group", func() {
+				var missing *models.ActualLRPGroup
+
+				for _, change := range []models.ActualLRPChange{missing.Diff(after), before.Diff(missing)} {
+					Expect(change.Changed()).To(BeTrue())
+					Expect(change.StateChanged).To(BeTrue())
+					Expect(change.NetInfoChanged).To(BeTrue())
+					Expect(change.CellChanged).To(BeTrue())
+				}
+			})
+		})
+	})
+
+	Describe("PlacementFairness", func() {
+		placed := func(instancesByCell map[string]int) []*models.ActualLRPGroup {
+			groups := []*models.ActualLRPGroup{}
+			index := int32(0)
+			for cellID, count := range instancesByCell {
+				for i := 0; i < count; i++ {
+					lrpKey := models.NewActualLRPKey("process-guid", index, "domain")
+					instanceKey := models.NewActualLRPInstanceKey(fmt.Sprintf("instance-guid-%d", index), cellID)
+					groups = append(groups, &models.ActualLRPGroup{Instance: models.NewClaimedActualLRP(lrpKey, instanceKey, 0)})
+					index++
+				}
+			}
+			return groups
+		}
+
+		cells := func(cellIDs ...string) models.CellSet {
+			cellSet := models.CellSet{}
+			for _, cellID := range cellIDs {
+				cellSet.Add(&models.CellPresence{CellId: cellID})
+			}
+			return cellSet
+		}
+
+		It("is 1 when every cell holds the same number of instances", func() {
+			Expect(models.PlacementFairness(placed(map[string]int{"cell-a": 3, "cell-b": 3, "cell-c": 3}))).To(BeNumerically("~", 1.0, 1e-9))
+		})
+
+		It("is near 0 when almost every instance is on the same cell", func() {
+			Expect(models.PlacementFairness(placed(map[string]int{"cell-a": 997, "cell-b": 1, "cell-c": 1, "cell-d": 1}))).To(BeNumerically("<", 0.05))
+		})
+
+		It("is between 0 and 1 for an uneven distribution", func() {
+			fairness := models.PlacementFairness(placed(map[string]int{"cell-a": 4, "cell-b": 2}))
+			Expect(fairness).To(BeNumerically(">", 0))
+			Expect(fairness).To(BeNumerically("<", 1))
+		})
+
+		It("ignores instances without a cell", func() {
+			groups := placed(map[string]int{"cell-a": 2, "cell-b": 2})
+			unclaimed := models.NewUnclaimedActualLRP(models.NewActualLRPKey("process-guid", 10, "domain"), 0)
+			groups = append(groups, &models.ActualLRPGroup{Instance: unclaimed})
+			Expect(models.PlacementFairness(groups)).To(BeNumerically("~", 1.0, 1e-9))
+		})
+
+		It("is 1 when there are fewer than two placed instances", func() {
+			Expect(models.PlacementFairness(nil)).To(Equal(1.0))
+			Expect(models.PlacementFairness(placed(map[string]int{"cell-a": 1}))).To(Equal(1.0))
+		})
+
+		It("is 1 when every instance is on the same cell", func() {
+			Expect(models.PlacementFairness(placed(map[string]int{"cell-a": 5}))).To(Equal(1.0))
+		})
+
+		Describe("PlacementFairnessAcrossCellSet", func() {
+			It("is 1 when every cell of the cell set holds the same number of instances", func() {
+				Expect(models.PlacementFairnessAcrossCellSet(placed(map[string]int{"cell-a": 3, "cell-b": 3}), cells("cell-a", "cell-b"))).To(BeNumerically("~", 1.0, 1e-9))
+			})
+
+			It("is 0 when every instance is on the same cell", func() {
+				Expect(models.PlacementFairnessAcrossCellSet(placed(map[string]int{"cell-a": 5}), cells("cell-a", "cell-b"))).To(BeZero())
+			})
+
+			It("counts the cells holding no instances against it", func() {
+				cellIDs := []string{}
+				for i := 0; i < 100; i++ {
+					cellIDs = append(cellIDs, fmt.Sprintf("cell-%d", i))
+				}
+
+				fairness := models.PlacementFairnessAcrossCellSet(placed(map[string]int{"cell-0": 50, "cell-1": 50}), cells(cellIDs...))
+				Expect(fairness).To(BeNumerically("~", 0.1505, 1e-4))
+			})
+
+			It("counts the cells holding instances that are missing from the cell set", func() {
+				Expect(models.PlacementFairnessAcrossCellSet(placed(map[string]int{"cell-a": 3, "cell-b": 3}), cells("cell-a"))).To(BeNumerically("~", 1.0, 1e-9))
+			})
+
+			It("is 1 when there are fewer than two cells", func() {
+				Expect(models.PlacementFairnessAcrossCellSet(placed(map[string]int{"cell-a": 5}), cells("cell-a"))).To(Equal(1.0))
+			})
+		})
 	})
 
 	Describe("ActualLRP", func() {
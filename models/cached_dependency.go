@@ -1,6 +1,7 @@
 package models
 
 import (
+	"net/url"
 	"strings"
 
 	"code.cloudfoundry.org/bbs/format"
@@ -9,7 +10,7 @@ import (
 func (a *CachedDependency) Validate() error {
 	var validationError ValidationError
 
-	if a.GetFrom() == "" {
+	if a.GetFrom() == "" || !validDownloadLocation(a.GetFrom()) {
 		validationError = validationError.Append(ErrInvalidField{"from"})
 	}
 
@@ -38,6 +39,22 @@ func (a *CachedDependency) Validate() error {
 	return nil
 }
 
+// Locations without a scheme are left for the cell to resolve, but an
+// explicit scheme must be one the cell can download from.
+func validDownloadLocation(location string) bool {
+	u, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case "", "http", "https":
+		return true
+	default:
+		return false
+	}
+}
+
 func validateCachedDependencies(
 	cachedDependencies []*CachedDependency,
 	legacyDownloadUser string,
@@ -22,6 +22,20 @@ var _ = Describe("CachedDependency", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			Context("when 'from' is an http or https url", func() {
+				It("is valid", func() {
+					for _, from := range []string{"http://example.com/bits", "https://example.com/bits"} {
+						cachedDep = &models.CachedDependency{
+							From: from,
+							To:   "local_location",
+						}
+
+						err := cachedDep.Validate()
+						Expect(err).NotTo(HaveOccurred())
+					}
+				})
+			})
+
 			Context("when the action also has valid 'checksum_value' and 'checksum_algorith'", func() {
 				It("is valid", func() {
 					cachedDep = &models.CachedDependency{
@@ -50,6 +64,20 @@ var _ = Describe("CachedDependency", func() {
 					From: "web_location",
 				},
 			},
+			{
+				"from",
+				&models.CachedDependency{
+					From: "ftp://example.com/bits",
+					To:   "local_location",
+				},
+			},
+			{
+				"from",
+				&models.CachedDependency{
+					From: "http://[::1",
+					To:   "local_location",
+				},
+			},
 			{
 				"checksum value",
 				&models.CachedDependency{
@@ -59,6 +59,7 @@ type CellPresence struct {
 	PlacementTags         []string      `protobuf:"bytes,6,rep,name=placement_tags,json=placementTags" json:"placement_tags,omitempty"`
 	OptionalPlacementTags []string      `protobuf:"bytes,7,rep,name=optional_placement_tags,json=optionalPlacementTags" json:"optional_placement_tags,omitempty"`
 	RepUrl                string        `protobuf:"bytes,8,opt,name=rep_url,json=repUrl" json:"rep_url"`
+	Evacuating            bool          `protobuf:"varint,9,opt,name=evacuating" json:"evacuating,omitempty"`
 }
 
 func (m *CellPresence) Reset()                    { *m = CellPresence{} }
@@ -121,6 +122,13 @@ func (m *CellPresence) GetRepUrl() string {
 	return ""
 }
 
+func (m *CellPresence) GetEvacuating() bool {
+	if m != nil {
+		return m.Evacuating
+	}
+	return false
+}
+
 type Provider struct {
 	Name       string   `protobuf:"bytes,1,opt,name=name" json:"name"`
 	Properties []string `protobuf:"bytes,2,rep,name=properties" json:"properties,omitempty"`
@@ -273,6 +281,9 @@ func (this *CellPresence) Equal(that interface{}) bool {
 	if this.RepUrl != that1.RepUrl {
 		return false
 	}
+	if this.Evacuating != that1.Evacuating {
+		return false
+	}
 	return true
 }
 func (this *Provider) Equal(that interface{}) bool {
@@ -367,7 +378,7 @@ func (this *CellPresence) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 12)
+	s := make([]string, 0, 13)
 	s = append(s, "&models.CellPresence{")
 	s = append(s, "CellId: "+fmt.Sprintf("%#v", this.CellId)+",\n")
 	s = append(s, "RepAddress: "+fmt.Sprintf("%#v", this.RepAddress)+",\n")
@@ -385,6 +396,7 @@ func (this *CellPresence) GoString() string {
 		s = append(s, "OptionalPlacementTags: "+fmt.Sprintf("%#v", this.OptionalPlacementTags)+",\n")
 	}
 	s = append(s, "RepUrl: "+fmt.Sprintf("%#v", this.RepUrl)+",\n")
+	s = append(s, "Evacuating: "+fmt.Sprintf("%#v", this.Evacuating)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -534,6 +546,14 @@ func (m *CellPresence) MarshalTo(dAtA []byte) (int, error) {
 	i++
 	i = encodeVarintCells(dAtA, i, uint64(len(m.RepUrl)))
 	i += copy(dAtA[i:], m.RepUrl)
+	dAtA[i] = 0x48
+	i++
+	if m.Evacuating {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
 	return i, nil
 }
 
@@ -683,6 +703,7 @@ func (m *CellPresence) Size() (n int) {
 	}
 	l = len(m.RepUrl)
 	n += 1 + l + sovCells(uint64(l))
+	n += 2
 	return n
 }
 
@@ -754,6 +775,7 @@ func (this *CellPresence) String() string {
 		`PlacementTags:` + fmt.Sprintf("%v", this.PlacementTags) + `,`,
 		`OptionalPlacementTags:` + fmt.Sprintf("%v", this.OptionalPlacementTags) + `,`,
 		`RepUrl:` + fmt.Sprintf("%v", this.RepUrl) + `,`,
+		`Evacuating:` + fmt.Sprintf("%v", this.Evacuating) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1162,6 +1184,26 @@ func (m *CellPresence) Unmarshal(dAtA []byte) error {
 			}
 			m.RepUrl = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evacuating", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCells
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Evacuating = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCells(dAtA[iNdEx:])
@@ -1513,38 +1555,40 @@ var (
 func init() { proto.RegisterFile("cells.proto", fileDescriptorCells) }
 
 var fileDescriptorCells = []byte{
-	// 526 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x8d, 0xc9, 0xf7, 0x98, 0x40, 0xb5, 0x2a, 0xc2, 0xaa, 0xe8, 0x26, 0x0d, 0x54, 0x8a, 0x50,
-	0x49, 0x51, 0x4e, 0x5c, 0x49, 0xc4, 0x81, 0x43, 0xa5, 0xc8, 0x82, 0x23, 0x18, 0xc7, 0x9e, 0x06,
-	0x0b, 0xdb, 0xbb, 0xda, 0xdd, 0x54, 0x0a, 0x27, 0x7e, 0x02, 0x3f, 0x83, 0x1f, 0xc2, 0xa1, 0xc7,
-	0x1e, 0x39, 0x45, 0xc4, 0x5c, 0x50, 0x4e, 0xfd, 0x09, 0x68, 0xd7, 0x75, 0x71, 0x22, 0xf5, 0xe6,
-	0x7d, 0xef, 0xcd, 0x9b, 0x9d, 0x79, 0x6b, 0xb0, 0x03, 0x8c, 0x63, 0x39, 0xe4, 0x82, 0x29, 0x46,
-	0x1a, 0x09, 0x0b, 0x31, 0x96, 0x07, 0x2f, 0xe6, 0x91, 0xfa, 0xbc, 0x98, 0x0d, 0x03, 0x96, 0x9c,
-	0xce, 0xd9, 0x9c, 0x9d, 0x1a, 0x7a, 0xb6, 0x38, 0x37, 0x27, 0x73, 0x30, 0x5f, 0x79, 0xd9, 0x81,
-	0x8d, 0x42, 0x30, 0x91, 0x1f, 0xfa, 0x17, 0x70, 0x7f, 0x82, 0x71, 0x3c, 0xf1, 0xb9, 0x1f, 0x44,
-	0x6a, 0x49, 0x8e, 0xa0, 0x9d, 0x60, 0xc2, 0xc4, 0xd2, 0x4b, 0x66, 0x8e, 0xd5, 0xb3, 0x06, 0xf5,
-	0x71, 0xed, 0x72, 0xd5, 0xad, 0xb8, 0xad, 0x1c, 0x3e, 0x9b, 0x91, 0x43, 0x68, 0x86, 0x91, 0xfc,
-	0xa2, 0x05, 0xf7, 0x4a, 0x82, 0x86, 0x06, 0xcf, 0x66, 0xe4, 0x19, 0x40, 0xc0, 0x52, 0xe5, 0x47,
-	0x29, 0x0a, 0xe9, 0x54, 0x4b, 0x8a, 0x12, 0xde, 0xff, 0x59, 0xcd, 0x1b, 0x4f, 0x05, 0x4a, 0x4c,
-	0x03, 0xd4, 0xae, 0x7a, 0x36, 0x2f, 0x0a, 0x4d, 0xdb, 0x76, 0xe1, 0xaa, 0xc1, 0xb7, 0x21, 0x39,
-	0x06, 0x5b, 0x20, 0xf7, 0xfc, 0x30, 0x14, 0x28, 0xa5, 0x69, 0x5c, 0x48, 0x40, 0x20, 0x7f, 0x9d,
-	0xe3, 0xc4, 0x81, 0xda, 0x57, 0x96, 0xa2, 0x69, 0x5b, 0xf0, 0x06, 0x21, 0x2f, 0xa1, 0x15, 0xdc,
-	0x0c, 0xe9, 0xd4, 0x7a, 0xd6, 0xc0, 0x1e, 0xed, 0x0f, 0xf3, 0xfd, 0x0d, 0xcb, 0x0b, 0x70, 0x6f,
-	0x55, 0xc4, 0x83, 0x3d, 0xc1, 0x98, 0x3a, 0x97, 0x1e, 0x17, 0xec, 0x22, 0x0a, 0xf5, 0x38, 0xf5,
-	0x5e, 0x75, 0x60, 0x8f, 0xf6, 0x8a, 0xca, 0xe9, 0x0d, 0x31, 0xee, 0x6f, 0x56, 0x5d, 0xba, 0xa3,
-	0xf6, 0xe2, 0x48, 0xaa, 0x13, 0x96, 0x44, 0x0a, 0x13, 0xae, 0x96, 0xee, 0xc3, 0x9c, 0x2f, 0x6a,
-	0x24, 0x99, 0xc0, 0x03, 0x1e, 0xfb, 0x01, 0x26, 0x98, 0x2a, 0x4f, 0xf9, 0x73, 0xe9, 0x34, 0x7a,
-	0xd5, 0x41, 0x7b, 0xfc, 0x64, 0xb3, 0xea, 0x3a, 0xdb, 0x4c, 0xc9, 0xa6, 0x73, 0xcb, 0xbc, 0xf3,
-	0xe7, 0x92, 0x7c, 0x80, 0xc7, 0x8c, 0xab, 0x88, 0xa5, 0x7e, 0xec, 0xed, 0xb8, 0x35, 0x8d, 0xdb,
-	0xf1, 0x66, 0xd5, 0x3d, 0xba, 0x43, 0x52, 0xb2, 0x7d, 0x54, 0x48, 0xa6, 0x5b, 0xf6, 0x87, 0xd0,
-	0xd4, 0x7b, 0x5f, 0x88, 0xd8, 0x69, 0x95, 0x63, 0x11, 0xc8, 0xdf, 0x8b, 0xb8, 0xff, 0x11, 0x5a,
-	0xc5, 0x3c, 0x7a, 0xf7, 0xa9, 0x9f, 0xe0, 0x56, 0x7c, 0x06, 0x21, 0xaf, 0x00, 0xb8, 0x60, 0x1c,
-	0x85, 0x8a, 0x50, 0x67, 0xa7, 0xaf, 0xe5, 0x6c, 0x56, 0xdd, 0xfd, 0xff, 0x68, 0xe9, 0x26, 0x25,
-	0x6d, 0xff, 0x13, 0x74, 0x74, 0x3a, 0xd2, 0x45, 0xc9, 0x59, 0x2a, 0x91, 0x3c, 0x85, 0xba, 0x79,
-	0xbe, 0xa6, 0x8b, 0x3d, 0xea, 0x14, 0x49, 0xbc, 0xd1, 0xa0, 0x9b, 0x73, 0xe4, 0x39, 0xd4, 0xcd,
-	0x7f, 0x62, 0x5a, 0xed, 0x04, 0x5d, 0x3c, 0x38, 0x37, 0x97, 0x8c, 0x4f, 0xae, 0xd6, 0xb4, 0xf2,
-	0x6b, 0x4d, 0x2b, 0xd7, 0x6b, 0x6a, 0x7d, 0xcb, 0xa8, 0xf5, 0x23, 0xa3, 0xd6, 0x65, 0x46, 0xad,
-	0xab, 0x8c, 0x5a, 0xbf, 0x33, 0x6a, 0xfd, 0xcd, 0x68, 0xe5, 0x3a, 0xa3, 0xd6, 0xf7, 0x3f, 0xb4,
-	0xf2, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x3c, 0xfb, 0x49, 0xc6, 0x80, 0x03, 0x00, 0x00,
+	// 555 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x41, 0x6f, 0xd3, 0x4c,
+	0x10, 0x8d, 0x9b, 0xa4, 0x49, 0xd6, 0x5f, 0x3e, 0xaa, 0x55, 0x11, 0x56, 0xd5, 0x6e, 0xd2, 0x40,
+	0xa5, 0x08, 0x95, 0x14, 0xe5, 0xc4, 0x81, 0x0b, 0x89, 0x38, 0x70, 0xa8, 0x14, 0x59, 0x70, 0x04,
+	0xe3, 0xd8, 0x53, 0x63, 0x61, 0x7b, 0x57, 0xbb, 0x9b, 0x48, 0xe1, 0x84, 0xf8, 0x05, 0xfc, 0x0c,
+	0x7e, 0x4a, 0x8f, 0x3d, 0x72, 0x8a, 0x88, 0xb9, 0xa0, 0x9c, 0xfa, 0x13, 0xd0, 0xae, 0xeb, 0x66,
+	0x13, 0x89, 0x9b, 0xf7, 0xbd, 0x37, 0x6f, 0x34, 0xf3, 0xc6, 0xc8, 0x0e, 0x20, 0x49, 0xc4, 0x80,
+	0x71, 0x2a, 0x29, 0xde, 0x4f, 0x69, 0x08, 0x89, 0x38, 0x7a, 0x16, 0xc5, 0xf2, 0xd3, 0x6c, 0x3a,
+	0x08, 0x68, 0x7a, 0x11, 0xd1, 0x88, 0x5e, 0x68, 0x7a, 0x3a, 0xbb, 0xd2, 0x2f, 0xfd, 0xd0, 0x5f,
+	0x45, 0xd9, 0x91, 0x0d, 0x9c, 0x53, 0x5e, 0x3c, 0x7a, 0x73, 0xf4, 0xdf, 0x18, 0x92, 0x64, 0xec,
+	0x33, 0x3f, 0x88, 0xe5, 0x02, 0x9f, 0xa2, 0x56, 0x0a, 0x29, 0xe5, 0x0b, 0x2f, 0x9d, 0x3a, 0x56,
+	0xd7, 0xea, 0xd7, 0x47, 0xb5, 0xeb, 0x65, 0xa7, 0xe2, 0x36, 0x0b, 0xf8, 0x72, 0x8a, 0x4f, 0x50,
+	0x23, 0x8c, 0xc5, 0x67, 0x25, 0xd8, 0x33, 0x04, 0xfb, 0x0a, 0xbc, 0x9c, 0xe2, 0x27, 0x08, 0x05,
+	0x34, 0x93, 0x7e, 0x9c, 0x01, 0x17, 0x4e, 0xd5, 0x50, 0x18, 0x78, 0xef, 0x5b, 0xad, 0x68, 0x3c,
+	0xe1, 0x20, 0x20, 0x0b, 0x40, 0xb9, 0xaa, 0xd9, 0xbc, 0x38, 0xd4, 0x6d, 0x5b, 0xa5, 0xab, 0x02,
+	0xdf, 0x84, 0xf8, 0x0c, 0xd9, 0x1c, 0x98, 0xe7, 0x87, 0x21, 0x07, 0x21, 0x9c, 0x3d, 0x43, 0x82,
+	0x38, 0xb0, 0x57, 0x05, 0x8e, 0x1d, 0x54, 0xfb, 0x42, 0x33, 0x70, 0xaa, 0x06, 0xaf, 0x11, 0xfc,
+	0x1c, 0x35, 0x83, 0xbb, 0x21, 0x9d, 0x5a, 0xd7, 0xea, 0xdb, 0xc3, 0xc3, 0x41, 0xb1, 0xbf, 0x81,
+	0xb9, 0x00, 0xf7, 0x5e, 0x85, 0x3d, 0x74, 0xc0, 0x29, 0x95, 0x57, 0xc2, 0x63, 0x9c, 0xce, 0xe3,
+	0x50, 0x8d, 0x53, 0xef, 0x56, 0xfb, 0xf6, 0xf0, 0xa0, 0xac, 0x9c, 0xdc, 0x11, 0xa3, 0xde, 0x7a,
+	0xd9, 0x21, 0x3b, 0x6a, 0x2f, 0x89, 0x85, 0x3c, 0xa7, 0x69, 0x2c, 0x21, 0x65, 0x72, 0xe1, 0x3e,
+	0x28, 0xf8, 0xb2, 0x46, 0xe0, 0x31, 0xfa, 0x9f, 0x25, 0x7e, 0x00, 0x29, 0x64, 0xd2, 0x93, 0x7e,
+	0x24, 0x9c, 0xfd, 0x6e, 0xb5, 0xdf, 0x1a, 0x1d, 0xaf, 0x97, 0x1d, 0x67, 0x9b, 0x31, 0x6c, 0xda,
+	0xf7, 0xcc, 0x5b, 0x3f, 0x12, 0xf8, 0x3d, 0x7a, 0x44, 0x99, 0x8c, 0x69, 0xe6, 0x27, 0xde, 0x8e,
+	0x5b, 0x43, 0xbb, 0x9d, 0xad, 0x97, 0x9d, 0xd3, 0x7f, 0x48, 0x0c, 0xdb, 0x87, 0xa5, 0x64, 0xb2,
+	0x65, 0x7f, 0x82, 0x1a, 0x6a, 0xef, 0x33, 0x9e, 0x38, 0x4d, 0x33, 0x16, 0x0e, 0xec, 0x1d, 0x4f,
+	0xf0, 0x4b, 0x84, 0x60, 0xee, 0x07, 0x33, 0x5f, 0xc6, 0x59, 0xe4, 0xb4, 0xba, 0x56, 0xbf, 0x39,
+	0x3a, 0x56, 0x8a, 0xf5, 0xb2, 0x73, 0xb8, 0x61, 0x8c, 0x3e, 0x86, 0xbe, 0xf7, 0x01, 0x35, 0xcb,
+	0x6d, 0xa8, 0xe4, 0x32, 0x3f, 0x85, 0xad, 0xf0, 0x35, 0x82, 0x5f, 0x20, 0xc4, 0x38, 0x65, 0xc0,
+	0x65, 0x0c, 0x2a, 0x79, 0x35, 0x94, 0xa3, 0xfc, 0x37, 0xa8, 0xe9, 0xbf, 0x41, 0x7b, 0x1f, 0x51,
+	0x5b, 0x65, 0x2b, 0x5c, 0x10, 0x8c, 0x66, 0x02, 0xf0, 0x63, 0x54, 0xd7, 0xc7, 0xaf, 0xbb, 0xd8,
+	0xc3, 0x76, 0x99, 0xe3, 0x6b, 0x05, 0xba, 0x05, 0x87, 0x9f, 0xa2, 0xba, 0xfe, 0xcb, 0x74, 0xab,
+	0x9d, 0x33, 0x29, 0xcf, 0xd5, 0x2d, 0x24, 0xa3, 0xf3, 0x9b, 0x15, 0xa9, 0xfc, 0x5c, 0x91, 0xca,
+	0xed, 0x8a, 0x58, 0x5f, 0x73, 0x62, 0xfd, 0xc8, 0x89, 0x75, 0x9d, 0x13, 0xeb, 0x26, 0x27, 0xd6,
+	0xaf, 0x9c, 0x58, 0x7f, 0x72, 0x52, 0xb9, 0xcd, 0x89, 0xf5, 0xfd, 0x37, 0xa9, 0xfc, 0x0d, 0x00,
+	0x00, 0xff, 0xff, 0x1b, 0xb6, 0x6b, 0x86, 0xbe, 0x03, 0x00, 0x00,
 }
@@ -10,6 +10,11 @@ func (check CheckDefinition) Validate() error {
 	checks := check.GetChecks()
 
 	for _, check := range checks {
+		if check == nil {
+			validationError = validationError.Append(ErrInvalidField{"check"})
+			continue
+		}
+
 		checkError := check.Validate()
 		if checkError != nil {
 			validationError = validationError.Append(checkError)
@@ -42,5 +47,9 @@ func (check Check) Validate() error {
 	} else if !(c.GetPort() > 0 && c.GetPort() <= 65535) {
 		validationError = validationError.Append(ErrInvalidField{"port"})
 	}
+
+	if httpCheck := check.GetHttpCheck(); c != nil && httpCheck != nil && httpCheck.GetPath() == "" {
+		validationError = validationError.Append(ErrInvalidField{"path"})
+	}
 	return validationError.ToError()
 }
@@ -0,0 +1,10 @@
+package models
+
+// CrashingLRPInfo summarizes the crashes of the actual LRPs belonging to a
+// desired LRP.
+type CrashingLRPInfo struct {
+	ProcessGuid       string
+	Domain            string
+	CrashCount        int32
+	CrashingInstances int32
+}
@@ -1,11 +1,13 @@
 package models
 
 import (
+	"encoding/json"
 	"net/url"
 	"regexp"
 	"time"
 
 	"code.cloudfoundry.org/bbs/format"
+	"github.com/gogo/protobuf/proto"
 )
 
 const PreloadedRootFSScheme = "preloaded"
@@ -73,6 +75,7 @@ func NewDesiredLRP(schedInfo DesiredLRPSchedulingInfo, runInfo DesiredLRPRunInfo
 		ImageUsername:                 runInfo.ImageUsername,
 		ImagePassword:                 runInfo.ImagePassword,
 		CheckDefinition:               runInfo.CheckDefinition,
+		SuppressConvergence:           schedInfo.SuppressConvergence,
 	}
 }
 
@@ -127,6 +130,18 @@ func (*DesiredLRP) Version() format.Version {
 	return format.V2
 }
 
+// Normalized returns a shallow copy of the DesiredLRP with empty routes
+// replaced by nil, or the DesiredLRP itself when there is nothing to replace.
+func (d *DesiredLRP) Normalized() format.Versioner {
+	if d.Routes == nil || d.Routes.Normalized() != nil {
+		return d
+	}
+
+	normalized := *d
+	normalized.Routes = nil
+	return &normalized
+}
+
 func (d *DesiredLRP) VersionDownTo(v format.Version) *DesiredLRP {
 
 	versionedLRP := d.Copy()
@@ -174,7 +189,7 @@ func (d *DesiredLRP) DesiredLRPSchedulingInfo() DesiredLRPSchedulingInfo {
 		volumePlacement.DriverNames = append(volumePlacement.DriverNames, mount.Driver)
 	}
 
-	return NewDesiredLRPSchedulingInfo(
+	schedulingInfo := NewDesiredLRPSchedulingInfo(
 		d.DesiredLRPKey(),
 		d.Annotation,
 		d.Instances,
@@ -184,6 +199,8 @@ func (d *DesiredLRP) DesiredLRPSchedulingInfo() DesiredLRPSchedulingInfo {
 		&volumePlacement,
 		d.PlacementTags,
 	)
+	schedulingInfo.SuppressConvergence = d.SuppressConvergence
+	return schedulingInfo
 }
 
 func (d *DesiredLRP) DesiredLRPRunInfo(createdAt time.Time) DesiredLRPRunInfo {
@@ -223,11 +240,187 @@ func (d *DesiredLRP) DesiredLRPRunInfo(createdAt time.Time) DesiredLRPRunInfo {
 	)
 }
 
+// IsValidIndex reports whether index is one of the DesiredLRP's instances,
+// i.e. in [0, Instances). Actual LRPs at other indices are extra.
+func (d *DesiredLRP) IsValidIndex(index int32) bool {
+	return index >= 0 && index < d.Instances
+}
+
+// IsHealthy reports whether every desired instance has a running actual LRP
+// and none of the actual LRPs is crashed or has crashed since it was last
+// placed. Actual LRPs for other process guids or for indices beyond the
+// desired instance count are ignored.
+func (d *DesiredLRP) IsHealthy(actuals []*ActualLRPGroup) bool {
+	running := make(map[int32]bool, d.Instances)
+
+	for _, group := range actuals {
+		if group == nil || (group.Instance == nil && group.Evacuating == nil) {
+			continue
+		}
+
+		actual, _ := group.Resolve()
+		if actual.ProcessGuid != d.ProcessGuid || !d.IsValidIndex(actual.Index) {
+			continue
+		}
+
+		if actual.State == ActualLRPStateCrashed || actual.CrashCount > 0 {
+			return false
+		}
+
+		if actual.State == ActualLRPStateRunning {
+			running[actual.Index] = true
+		}
+	}
+
+	return len(running) == int(d.Instances)
+}
+
+// UpdateProgress reports how far a rolling update of the DesiredLRP has got.
+// The desired annotation carries the version being rolled out and each actual
+// LRP's annotation the version it was started with; current is the number of
+// desired indices with a running actual LRP on the desired version and target
+// is the desired number of instances.
+func (d *DesiredLRP) UpdateProgress(actuals []*ActualLRPGroup) (current, target int) {
+	updated := make(map[int32]bool, d.Instances)
+
+	for _, group := range actuals {
+		if group == nil || (group.Instance == nil && group.Evacuating == nil) {
+			continue
+		}
+
+		actual, _ := group.Resolve()
+		if actual.ProcessGuid != d.ProcessGuid || !d.IsValidIndex(actual.Index) {
+			continue
+		}
+
+		if actual.State == ActualLRPStateRunning && actual.Annotation == d.Annotation {
+			updated[actual.Index] = true
+		}
+	}
+
+	return len(updated), int(d.Instances)
+}
+
+// ResourceTotals is the resources requested by a set of DesiredLRPs, counted
+// once per desired instance.
+type ResourceTotals struct {
+	Instances int64
+	MemoryMb  int64
+	DiskMb    int64
+}
+
+// FleetResourceTotals sums the memory and disk requested by the given
+// DesiredLRPs multiplied by their instance counts.
+func FleetResourceTotals(lrps []*DesiredLRP) ResourceTotals {
+	totals := ResourceTotals{}
+	for _, lrp := range lrps {
+		if lrp == nil {
+			continue
+		}
+
+		instances := int64(lrp.Instances)
+		totals.Instances += instances
+		totals.MemoryMb += instances * int64(lrp.MemoryMb)
+		totals.DiskMb += instances * int64(lrp.DiskMb)
+	}
+	return totals
+}
+
+// Copy returns a deep copy of the DesiredLRP so that callers can mutate the
+// copy without affecting the original.
 func (d *DesiredLRP) Copy() *DesiredLRP {
 	newDesired := *d
+
+	if d.EnvironmentVariables != nil {
+		newDesired.EnvironmentVariables = make([]*EnvironmentVariable, len(d.EnvironmentVariables))
+		for i, envVar := range d.EnvironmentVariables {
+			if envVar != nil {
+				copied := *envVar
+				newDesired.EnvironmentVariables[i] = &copied
+			}
+		}
+	}
+
+	newDesired.Setup = copyAction(d.Setup)
+	newDesired.Action = copyAction(d.Action)
+	newDesired.Monitor = copyAction(d.Monitor)
+
+	if d.Routes != nil {
+		routes := make(Routes, len(*d.Routes))
+		for name, route := range *d.Routes {
+			if route == nil {
+				routes[name] = nil
+				continue
+			}
+			raw := make(json.RawMessage, len(*route))
+			copy(raw, *route)
+			routes[name] = &raw
+		}
+		newDesired.Routes = &routes
+	}
+
+	if d.Ports != nil {
+		newDesired.Ports = append([]uint32{}, d.Ports...)
+	}
+
+	if d.PlacementTags != nil {
+		newDesired.PlacementTags = append([]string{}, d.PlacementTags...)
+	}
+
+	if d.ModificationTag != nil {
+		modificationTag := *d.ModificationTag
+		newDesired.ModificationTag = &modificationTag
+	}
+
+	if d.EgressRules != nil {
+		newDesired.EgressRules = make([]*SecurityGroupRule, len(d.EgressRules))
+		for i, rule := range d.EgressRules {
+			if rule != nil {
+				newDesired.EgressRules[i] = proto.Clone(rule).(*SecurityGroupRule)
+			}
+		}
+	}
+
+	if d.CachedDependencies != nil {
+		newDesired.CachedDependencies = make([]*CachedDependency, len(d.CachedDependencies))
+		for i, dependency := range d.CachedDependencies {
+			if dependency != nil {
+				newDesired.CachedDependencies[i] = proto.Clone(dependency).(*CachedDependency)
+			}
+		}
+	}
+
+	if d.VolumeMounts != nil {
+		newDesired.VolumeMounts = make([]*VolumeMount, len(d.VolumeMounts))
+		for i, mount := range d.VolumeMounts {
+			if mount != nil {
+				newDesired.VolumeMounts[i] = proto.Clone(mount).(*VolumeMount)
+			}
+		}
+	}
+
+	if d.Network != nil {
+		newDesired.Network = proto.Clone(d.Network).(*Network)
+	}
+
+	if d.CertificateProperties != nil {
+		newDesired.CertificateProperties = proto.Clone(d.CertificateProperties).(*CertificateProperties)
+	}
+
+	if d.CheckDefinition != nil {
+		newDesired.CheckDefinition = proto.Clone(d.CheckDefinition).(*CheckDefinition)
+	}
+
 	return &newDesired
 }
 
+func copyAction(action *Action) *Action {
+	if action == nil {
+		return nil
+	}
+	return proto.Clone(action).(*Action)
+}
+
 func (d *DesiredLRP) CreateComponents(createdAt time.Time) (DesiredLRPSchedulingInfo, DesiredLRPRunInfo) {
 	return d.DesiredLRPSchedulingInfo(), d.DesiredLRPRunInfo(createdAt)
 }
@@ -248,6 +441,43 @@ func (d *DesiredLRP) actionsFromCachedDependencies() []ActionInterface {
 	return actions
 }
 
+// DefaultImageRegistryHost is the registry host that ValidateImageSources
+// checks for image rootfses without a host, such as docker:///library/ubuntu,
+// which are pulled from Docker Hub.
+const DefaultImageRegistryHost = "docker.io"
+
+const preloadedLayerRootFSScheme = "preloaded+layer"
+
+// ValidateImageSources checks the rootfs of the desired LRP against the
+// allowed URL schemes and registry hosts. An empty list allows any scheme or
+// host. Preloaded rootfses are not pulled from a registry and pass the host
+// check; any other rootfs without a host is checked as coming from
+// DefaultImageRegistryHost.
+func (desired DesiredLRP) ValidateImageSources(allowedSchemes, allowedHosts []string) error {
+	rootFSURL, err := url.Parse(desired.GetRootFs())
+	if err != nil {
+		return ErrInvalidField{"rootfs"}
+	}
+
+	if len(allowedSchemes) > 0 && !contains(allowedSchemes, rootFSURL.Scheme) {
+		return ErrDisallowedImageSource{RootFs: desired.GetRootFs()}
+	}
+
+	if rootFSURL.Scheme == PreloadedRootFSScheme || rootFSURL.Scheme == preloadedLayerRootFSScheme {
+		return nil
+	}
+
+	host := rootFSURL.Hostname()
+	if host == "" {
+		host = DefaultImageRegistryHost
+	}
+	if len(allowedHosts) > 0 && !contains(allowedHosts, host) {
+		return ErrDisallowedImageSource{RootFs: desired.GetRootFs()}
+	}
+
+	return nil
+}
+
 func (desired DesiredLRP) Validate() error {
 	var validationError ValidationError
 
@@ -295,6 +525,10 @@ func (desired DesiredLRP) Validate() error {
 		}
 	}
 
+	if err := validateRouteCount(desired.Routes); err != nil {
+		validationError = validationError.Append(err)
+	}
+
 	runInfoErrors := desired.DesiredLRPRunInfo(time.Now()).Validate()
 	if runInfoErrors != nil {
 		validationError = validationError.Append(runInfoErrors)
@@ -325,6 +559,10 @@ func (desired *DesiredLRPUpdate) Validate() error {
 		}
 	}
 
+	if err := validateRouteCount(desired.Routes); err != nil {
+		validationError = validationError.Append(err)
+	}
+
 	return validationError.ToError()
 }
 
@@ -388,6 +626,19 @@ func (*DesiredLRPSchedulingInfo) Version() format.Version {
 	return format.V0
 }
 
+// Normalized returns a shallow copy of the DesiredLRPSchedulingInfo with empty
+// routes replaced by nil, or the DesiredLRPSchedulingInfo itself when there is
+// nothing to replace.
+func (s *DesiredLRPSchedulingInfo) Normalized() format.Versioner {
+	if s.Routes == nil || len(s.Routes) != 0 {
+		return s
+	}
+
+	normalized := *s
+	normalized.Routes = nil
+	return &normalized
+}
+
 func (s DesiredLRPSchedulingInfo) Validate() error {
 	var validationError ValidationError
 
@@ -404,6 +655,37 @@ func (s DesiredLRPSchedulingInfo) Validate() error {
 	return validationError.ToError()
 }
 
+// DiffSchedulingInfos compares two snapshots of scheduling infos by process
+// guid. It returns the scheduling infos only in newInfos, the ones only in
+// oldInfos, and the new version of the ones present in both that differ, each
+// in the order of the snapshot they are taken from.
+func DiffSchedulingInfos(oldInfos, newInfos []*DesiredLRPSchedulingInfo) (added, removed, changed []*DesiredLRPSchedulingInfo) {
+	oldByGuid := make(map[string]*DesiredLRPSchedulingInfo, len(oldInfos))
+	for _, info := range oldInfos {
+		oldByGuid[info.ProcessGuid] = info
+	}
+
+	newGuids := make(map[string]struct{}, len(newInfos))
+	for _, info := range newInfos {
+		newGuids[info.ProcessGuid] = struct{}{}
+
+		oldInfo, ok := oldByGuid[info.ProcessGuid]
+		if !ok {
+			added = append(added, info)
+		} else if !oldInfo.Equal(info) {
+			changed = append(changed, info)
+		}
+	}
+
+	for _, info := range oldInfos {
+		if _, ok := newGuids[info.ProcessGuid]; !ok {
+			removed = append(removed, info)
+		}
+	}
+
+	return added, removed, changed
+}
+
 func NewDesiredLRPResource(memoryMb, diskMb, maxPids int32, rootFs string) DesiredLRPResource {
 	return DesiredLRPResource{
 		MemoryMb: memoryMb,
@@ -532,9 +814,7 @@ func (runInfo DesiredLRPRunInfo) Validate() error {
 		validationError = validationError.Append(err)
 	}
 
-	for _, mount := range runInfo.VolumeMounts {
-		validationError = validationError.Check(mount)
-	}
+	validationError = validationError.Append(validateVolumeMounts(runInfo.VolumeMounts))
 
 	if runInfo.ImageUsername == "" && runInfo.ImagePassword != "" {
 		validationError = validationError.Append(ErrInvalidField{"image_username"})
@@ -23,14 +23,15 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type DesiredLRPSchedulingInfo struct {
-	DesiredLRPKey      `protobuf:"bytes,1,opt,name=desired_lrp_key,json=desiredLrpKey,embedded=desired_lrp_key" json:""`
-	Annotation         string `protobuf:"bytes,2,opt,name=annotation" json:"annotation"`
-	Instances          int32  `protobuf:"varint,3,opt,name=instances" json:"instances"`
-	DesiredLRPResource `protobuf:"bytes,4,opt,name=desired_lrp_resource,json=desiredLrpResource,embedded=desired_lrp_resource" json:""`
-	Routes             Routes `protobuf:"bytes,5,opt,name=routes,customtype=Routes" json:"routes"`
-	ModificationTag    `protobuf:"bytes,6,opt,name=modification_tag,json=modificationTag,embedded=modification_tag" json:""`
-	VolumePlacement    *VolumePlacement `protobuf:"bytes,7,opt,name=volume_placement,json=volumePlacement" json:"volume_placement,omitempty"`
-	PlacementTags      []string         `protobuf:"bytes,8,rep,name=PlacementTags" json:"placement_tags,omitempty"`
+	DesiredLRPKey       `protobuf:"bytes,1,opt,name=desired_lrp_key,json=desiredLrpKey,embedded=desired_lrp_key" json:""`
+	Annotation          string `protobuf:"bytes,2,opt,name=annotation" json:"annotation"`
+	Instances           int32  `protobuf:"varint,3,opt,name=instances" json:"instances"`
+	DesiredLRPResource  `protobuf:"bytes,4,opt,name=desired_lrp_resource,json=desiredLrpResource,embedded=desired_lrp_resource" json:""`
+	Routes              Routes `protobuf:"bytes,5,opt,name=routes,customtype=Routes" json:"routes"`
+	ModificationTag     `protobuf:"bytes,6,opt,name=modification_tag,json=modificationTag,embedded=modification_tag" json:""`
+	VolumePlacement     *VolumePlacement `protobuf:"bytes,7,opt,name=volume_placement,json=volumePlacement" json:"volume_placement,omitempty"`
+	PlacementTags       []string         `protobuf:"bytes,8,rep,name=PlacementTags" json:"placement_tags,omitempty"`
+	SuppressConvergence bool             `protobuf:"varint,9,opt,name=suppress_convergence,json=suppressConvergence" json:"suppress_convergence,omitempty"`
 }
 
 func (m *DesiredLRPSchedulingInfo) Reset()      { *m = DesiredLRPSchedulingInfo{} }
@@ -67,6 +68,13 @@ func (m *DesiredLRPSchedulingInfo) GetPlacementTags() []string {
 	return nil
 }
 
+func (m *DesiredLRPSchedulingInfo) GetSuppressConvergence() bool {
+	if m != nil {
+		return m.SuppressConvergence
+	}
+	return false
+}
+
 type DesiredLRPRunInfo struct {
 	DesiredLRPKey                 `protobuf:"bytes,1,opt,name=desired_lrp_key,json=desiredLrpKey,embedded=desired_lrp_key" json:""`
 	EnvironmentVariables          []EnvironmentVariable  `protobuf:"bytes,2,rep,name=environment_variables,json=environmentVariables" json:"env"`
@@ -395,6 +403,7 @@ type DesiredLRP struct {
 	ImageUsername                 string                 `protobuf:"bytes,31,opt,name=image_username,json=imageUsername" json:"image_username,omitempty"`
 	ImagePassword                 string                 `protobuf:"bytes,32,opt,name=image_password,json=imagePassword" json:"image_password,omitempty"`
 	CheckDefinition               *CheckDefinition       `protobuf:"bytes,33,opt,name=check_definition,json=checkDefinition" json:"check_definition,omitempty"`
+	SuppressConvergence           bool                   `protobuf:"varint,34,opt,name=suppress_convergence,json=suppressConvergence" json:"suppress_convergence,omitempty"`
 }
 
 func (m *DesiredLRP) Reset()                    { *m = DesiredLRP{} }
@@ -625,6 +634,13 @@ func (m *DesiredLRP) GetCheckDefinition() *CheckDefinition {
 	return nil
 }
 
+func (m *DesiredLRP) GetSuppressConvergence() bool {
+	if m != nil {
+		return m.SuppressConvergence
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*DesiredLRPSchedulingInfo)(nil), "models.DesiredLRPSchedulingInfo")
 	proto.RegisterType((*DesiredLRPRunInfo)(nil), "models.DesiredLRPRunInfo")
@@ -688,6 +704,9 @@ func (this *DesiredLRPSchedulingInfo) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.SuppressConvergence != that1.SuppressConvergence {
+		return false
+	}
 	return true
 }
 func (this *DesiredLRPRunInfo) Equal(that interface{}) bool {
@@ -1131,13 +1150,16 @@ func (this *DesiredLRP) Equal(that interface{}) bool {
 	if !this.CheckDefinition.Equal(that1.CheckDefinition) {
 		return false
 	}
+	if this.SuppressConvergence != that1.SuppressConvergence {
+		return false
+	}
 	return true
 }
 func (this *DesiredLRPSchedulingInfo) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 12)
+	s := make([]string, 0, 13)
 	s = append(s, "&models.DesiredLRPSchedulingInfo{")
 	s = append(s, "DesiredLRPKey: "+strings.Replace(this.DesiredLRPKey.GoString(), `&`, ``, 1)+",\n")
 	s = append(s, "Annotation: "+fmt.Sprintf("%#v", this.Annotation)+",\n")
@@ -1151,6 +1173,7 @@ func (this *DesiredLRPSchedulingInfo) GoString() string {
 	if this.PlacementTags != nil {
 		s = append(s, "PlacementTags: "+fmt.Sprintf("%#v", this.PlacementTags)+",\n")
 	}
+	s = append(s, "SuppressConvergence: "+fmt.Sprintf("%#v", this.SuppressConvergence)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1277,7 +1300,7 @@ func (this *DesiredLRP) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 37)
+	s := make([]string, 0, 38)
 	s = append(s, "&models.DesiredLRP{")
 	s = append(s, "ProcessGuid: "+fmt.Sprintf("%#v", this.ProcessGuid)+",\n")
 	s = append(s, "Domain: "+fmt.Sprintf("%#v", this.Domain)+",\n")
@@ -1340,6 +1363,7 @@ func (this *DesiredLRP) GoString() string {
 	if this.CheckDefinition != nil {
 		s = append(s, "CheckDefinition: "+fmt.Sprintf("%#v", this.CheckDefinition)+",\n")
 	}
+	s = append(s, "SuppressConvergence: "+fmt.Sprintf("%#v", this.SuppressConvergence)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -1430,6 +1454,14 @@ func (m *DesiredLRPSchedulingInfo) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	dAtA[i] = 0x48
+	i++
+	if m.SuppressConvergence {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
 	return i, nil
 }
 
@@ -2049,6 +2081,16 @@ func (m *DesiredLRP) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n21
 	}
+	dAtA[i] = 0x90
+	i++
+	dAtA[i] = 0x2
+	i++
+	if m.SuppressConvergence {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
 	return i, nil
 }
 
@@ -2103,6 +2145,7 @@ func (m *DesiredLRPSchedulingInfo) Size() (n int) {
 			n += 1 + l + sovDesiredLrp(uint64(l))
 		}
 	}
+	n += 2
 	return n
 }
 
@@ -2342,6 +2385,7 @@ func (m *DesiredLRP) Size() (n int) {
 		l = m.CheckDefinition.Size()
 		n += 2 + l + sovDesiredLrp(uint64(l))
 	}
+	n += 3
 	return n
 }
 
@@ -2371,6 +2415,7 @@ func (this *DesiredLRPSchedulingInfo) String() string {
 		`ModificationTag:` + strings.Replace(strings.Replace(this.ModificationTag.String(), "ModificationTag", "ModificationTag", 1), `&`, ``, 1) + `,`,
 		`VolumePlacement:` + strings.Replace(fmt.Sprintf("%v", this.VolumePlacement), "VolumePlacement", "VolumePlacement", 1) + `,`,
 		`PlacementTags:` + fmt.Sprintf("%v", this.PlacementTags) + `,`,
+		`SuppressConvergence:` + fmt.Sprintf("%v", this.SuppressConvergence) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2502,6 +2547,7 @@ func (this *DesiredLRP) String() string {
 		`ImageUsername:` + fmt.Sprintf("%v", this.ImageUsername) + `,`,
 		`ImagePassword:` + fmt.Sprintf("%v", this.ImagePassword) + `,`,
 		`CheckDefinition:` + strings.Replace(fmt.Sprintf("%v", this.CheckDefinition), "CheckDefinition", "CheckDefinition", 1) + `,`,
+		`SuppressConvergence:` + fmt.Sprintf("%v", this.SuppressConvergence) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -2773,6 +2819,26 @@ func (m *DesiredLRPSchedulingInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.PlacementTags = append(m.PlacementTags, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SuppressConvergence", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDesiredLrp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SuppressConvergence = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDesiredLrp(dAtA[iNdEx:])
@@ -5081,6 +5147,26 @@ func (m *DesiredLRP) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 34:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SuppressConvergence", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDesiredLrp
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SuppressConvergence = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDesiredLrp(dAtA[iNdEx:])
@@ -5210,103 +5296,106 @@ var (
 func init() { proto.RegisterFile("desired_lrp.proto", fileDescriptorDesiredLrp) }
 
 var fileDescriptorDesiredLrp = []byte{
-	// 1565 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xcd, 0x6f, 0xdb, 0x46,
-	0x16, 0x37, 0x2d, 0x5b, 0xb2, 0x47, 0x92, 0x3f, 0xc6, 0x5f, 0x8c, 0x6c, 0x4b, 0xb2, 0x36, 0x48,
-	0xb4, 0x8b, 0xac, 0x03, 0xf8, 0xb2, 0x8b, 0xdd, 0x3d, 0x6c, 0x18, 0xa7, 0x41, 0x91, 0xb8, 0x10,
-	0xe4, 0x38, 0xfd, 0x00, 0x5a, 0x82, 0x26, 0xc7, 0x34, 0x61, 0x92, 0x43, 0xcc, 0x0c, 0xe5, 0x08,
-	0x05, 0x8a, 0xfe, 0x03, 0x05, 0x7a, 0xed, 0xad, 0xc7, 0xde, 0x7b, 0xec, 0xb5, 0x87, 0x1c, 0x73,
-	0x2c, 0x7a, 0x10, 0x1a, 0xf7, 0x52, 0xf8, 0x94, 0x3f, 0xa1, 0x98, 0xe1, 0x50, 0x1a, 0xca, 0xb4,
-	0xe3, 0x00, 0x46, 0x7a, 0x92, 0xe6, 0xbd, 0xdf, 0x7b, 0xf3, 0x66, 0xe6, 0x7d, 0xfc, 0x08, 0x16,
-	0x1d, 0x44, 0x3d, 0x82, 0x1c, 0xd3, 0x27, 0xd1, 0x76, 0x44, 0x30, 0xc3, 0xb0, 0x18, 0x60, 0x07,
-	0xf9, 0xb4, 0xf6, 0x4f, 0xd7, 0x63, 0xc7, 0xf1, 0xe1, 0xb6, 0x8d, 0x83, 0xfb, 0x2e, 0x76, 0xf1,
-	0x7d, 0xa1, 0x3e, 0x8c, 0x8f, 0xc4, 0x4a, 0x2c, 0xc4, 0xbf, 0xc4, 0xac, 0x56, 0xb5, 0x6c, 0xe6,
-	0xe1, 0x90, 0xca, 0xe5, 0x9a, 0x6d, 0xd9, 0xc7, 0xc8, 0x31, 0x1d, 0x14, 0xa1, 0xd0, 0x41, 0xa1,
-	0xdd, 0x97, 0x8a, 0x0d, 0x1b, 0x11, 0xe6, 0x1d, 0x79, 0xb6, 0xc5, 0x90, 0x19, 0x11, 0x1c, 0xf1,
-	0x25, 0x4a, 0xcd, 0xd6, 0x51, 0xd8, 0xf3, 0x08, 0x0e, 0x03, 0x14, 0x32, 0xb3, 0x67, 0x11, 0xcf,
-	0x3a, 0xf4, 0x87, 0xca, 0xd5, 0x00, 0x3b, 0x89, 0xa5, 0x87, 0x43, 0x93, 0x59, 0x6e, 0xba, 0x75,
-	0x88, 0xd8, 0x29, 0x26, 0x27, 0x72, 0xb9, 0x4c, 0x91, 0x1d, 0x13, 0x8f, 0xf5, 0x4d, 0x97, 0xe0,
-	0x58, 0x1e, 0xab, 0x06, 0x7b, 0xd8, 0x8f, 0x03, 0x64, 0x06, 0x38, 0x0e, 0x59, 0xea, 0xd0, 0x3e,
-	0x46, 0xf6, 0x89, 0xe9, 0xa0, 0x23, 0x2f, 0xf4, 0xb8, 0xd3, 0x44, 0xde, 0xfa, 0x79, 0x0a, 0xe8,
-	0xbb, 0xc9, 0xc5, 0x3c, 0xed, 0x76, 0xf6, 0xf9, 0x41, 0x62, 0xdf, 0x0b, 0xdd, 0x0f, 0xc3, 0x23,
-	0x0c, 0x9f, 0x80, 0x79, 0xe5, 0xd2, 0xcc, 0x13, 0xd4, 0xd7, 0xb5, 0xa6, 0xd6, 0x2e, 0xef, 0xac,
-	0x6c, 0x27, 0x37, 0xb7, 0x3d, 0x32, 0x7d, 0x82, 0xfa, 0x46, 0xe5, 0xe5, 0xa0, 0x31, 0xf1, 0x6a,
-	0xd0, 0xd0, 0xce, 0x07, 0x8d, 0x89, 0x6e, 0x55, 0xda, 0x3e, 0x25, 0xd1, 0x13, 0xd4, 0x87, 0xb7,
-	0x01, 0xb0, 0xc2, 0x10, 0x33, 0x71, 0x24, 0x7d, 0xb2, 0xa9, 0xb5, 0x67, 0x8d, 0x29, 0x6e, 0xd0,
-	0x55, 0xe4, 0xb0, 0x05, 0x66, 0xbd, 0x90, 0x32, 0x2b, 0xb4, 0x11, 0xd5, 0x0b, 0x4d, 0xad, 0x3d,
-	0x2d, 0x41, 0x23, 0x31, 0xfc, 0x0c, 0x2c, 0xab, 0x61, 0x11, 0x44, 0x71, 0x4c, 0x6c, 0xa4, 0x4f,
-	0x89, 0xd8, 0x6a, 0x17, 0x63, 0xeb, 0x4a, 0xc4, 0x58, 0x80, 0x70, 0x14, 0x60, 0x8a, 0x80, 0xff,
-	0x05, 0x45, 0x82, 0x63, 0x86, 0xa8, 0x3e, 0x2d, 0xbc, 0x2d, 0xa5, 0xde, 0x3a, 0xfc, 0xba, 0xba,
-	0x42, 0x65, 0xcc, 0x71, 0x37, 0xbf, 0x0e, 0x1a, 0xc5, 0x64, 0xdd, 0x95, 0x26, 0xb0, 0x03, 0x16,
-	0xc6, 0xdf, 0x4d, 0x2f, 0x0a, 0x37, 0x6b, 0xa9, 0x9b, 0x3d, 0x45, 0xff, 0xcc, 0x72, 0xc7, 0x22,
-	0x9a, 0x0f, 0xb2, 0x6a, 0x78, 0x08, 0x16, 0xe4, 0x63, 0x46, 0xbe, 0x65, 0x23, 0x9e, 0x2b, 0x7a,
-	0x29, 0xeb, 0xf1, 0xb9, 0xd0, 0x77, 0x52, 0xb5, 0x51, 0x3f, 0x1f, 0x34, 0x6a, 0xe3, 0x46, 0xf7,
-	0x70, 0xe0, 0x31, 0x14, 0x44, 0xac, 0xdf, 0x9d, 0xef, 0x65, 0x0d, 0xa0, 0x01, 0xaa, 0xc3, 0xc5,
-	0x33, 0xcb, 0xa5, 0xfa, 0x4c, 0xb3, 0xd0, 0x9e, 0x35, 0x36, 0xce, 0x07, 0x0d, 0x7d, 0xe8, 0x80,
-	0x9f, 0x85, 0x2a, 0x5e, 0xb2, 0x26, 0xad, 0x1f, 0x2b, 0x60, 0x51, 0xb9, 0xef, 0x38, 0xbc, 0xf9,
-	0xfc, 0xf9, 0x1c, 0xac, 0xe4, 0x56, 0x8c, 0x3e, 0xd9, 0x2c, 0xb4, 0xcb, 0x3b, 0xeb, 0xa9, 0xcb,
-	0x47, 0x23, 0xd0, 0x73, 0x89, 0x31, 0xca, 0xdc, 0xf1, 0xf9, 0xa0, 0x51, 0x40, 0x61, 0xaf, 0xbb,
-	0x8c, 0x2e, 0x22, 0x28, 0xbc, 0x0d, 0xa6, 0x29, 0x62, 0x71, 0x24, 0x92, 0xae, 0xbc, 0x33, 0x97,
-	0xba, 0x7b, 0x20, 0x6a, 0xbd, 0x9b, 0x28, 0xe1, 0x1d, 0x50, 0x4c, 0x8a, 0x5f, 0x26, 0xdb, 0x38,
-	0x4c, 0x6a, 0x61, 0x1b, 0x94, 0x02, 0x1c, 0x7a, 0x0c, 0x13, 0x99, 0x47, 0xe3, 0xc0, 0x54, 0x0d,
-	0xbf, 0x00, 0x35, 0x07, 0x45, 0x04, 0xf1, 0x26, 0xe1, 0x98, 0x94, 0x59, 0x84, 0x99, 0xcc, 0x0b,
-	0x10, 0x8e, 0x99, 0x49, 0x45, 0xf6, 0x54, 0x8d, 0x2d, 0x19, 0xfe, 0x5a, 0x46, 0x3d, 0x7a, 0x0d,
-	0x5d, 0xeb, 0xae, 0x8d, 0x9c, 0xec, 0x73, 0xd0, 0xb3, 0x04, 0xb3, 0xcf, 0xcb, 0x2e, 0x22, 0x5e,
-	0xcf, 0xf3, 0x91, 0x8b, 0x1c, 0x91, 0x3b, 0x33, 0x69, 0xd9, 0x8d, 0xe4, 0xf0, 0x6f, 0x00, 0xd8,
-	0x51, 0x6c, 0x9e, 0x22, 0xcf, 0x3d, 0x66, 0xfa, 0x8c, 0xd8, 0x55, 0xd6, 0x9d, 0x1d, 0xc5, 0x1f,
-	0x0b, 0x31, 0x5c, 0x06, 0xd3, 0x11, 0x26, 0x8c, 0xea, 0xb3, 0xcd, 0x42, 0xbb, 0xda, 0x4d, 0x16,
-	0xd0, 0x00, 0x15, 0xe4, 0x12, 0x44, 0xa9, 0x49, 0x62, 0xfe, 0x1c, 0x40, 0x3c, 0xc7, 0xad, 0xf4,
-	0xbc, 0xfb, 0xb2, 0x43, 0x3d, 0xe6, 0x0d, 0xaa, 0x1b, 0xfb, 0x48, 0xfa, 0x2d, 0x27, 0x46, 0x5c,
-	0x42, 0xf9, 0xf6, 0x3e, 0x76, 0x4d, 0x59, 0xc7, 0x65, 0xa5, 0x37, 0xcc, 0xfa, 0xd8, 0xdd, 0x4f,
-	0x4a, 0xf3, 0x2e, 0xa8, 0x04, 0x88, 0x11, 0xcf, 0xa6, 0xa6, 0x1b, 0x7b, 0x8e, 0x5e, 0x51, 0x60,
-	0x65, 0xa9, 0x79, 0x1c, 0x7b, 0xc9, 0x61, 0x08, 0x12, 0xf7, 0x69, 0x31, 0xbd, 0xda, 0xd4, 0xda,
-	0x85, 0xe1, 0x61, 0x12, 0xf9, 0x03, 0x06, 0x7d, 0xb0, 0x34, 0xde, 0xb7, 0x3d, 0x44, 0xf5, 0x39,
-	0x11, 0xbd, 0x9e, 0x46, 0xff, 0x50, 0x40, 0x76, 0x87, 0x9d, 0xdd, 0xd8, 0x3a, 0x1f, 0x34, 0x36,
-	0x73, 0x0c, 0x95, 0xd2, 0x80, 0x76, 0xd6, 0xc8, 0x43, 0x14, 0x7e, 0x02, 0x96, 0x7d, 0xe4, 0x5a,
-	0x76, 0xdf, 0x74, 0xf0, 0x69, 0xe8, 0x63, 0xcb, 0x31, 0x63, 0x8a, 0x88, 0x3e, 0x2f, 0xce, 0x70,
-	0x47, 0xbe, 0x6f, 0x3d, 0x0f, 0xa3, 0x7a, 0x4e, 0xf4, 0xbb, 0x52, 0x7d, 0x40, 0x11, 0x81, 0x5f,
-	0x82, 0x26, 0x23, 0x31, 0x15, 0xc9, 0xd3, 0xa7, 0x0c, 0x05, 0xa6, 0x32, 0x75, 0xa8, 0x19, 0x59,
-	0xec, 0x58, 0x5f, 0x10, 0xbb, 0xec, 0xc8, 0x5d, 0xfe, 0xf1, 0x36, 0xbc, 0xb2, 0xe3, 0xa6, 0xc4,
-	0xee, 0x0b, 0xe8, 0x43, 0x05, 0xd9, 0xb1, 0xd8, 0x31, 0x3c, 0x00, 0x55, 0x75, 0xd6, 0x50, 0x7d,
-	0x51, 0x5c, 0xdf, 0x52, 0xb6, 0x37, 0xed, 0x71, 0x9d, 0xb1, 0xce, 0x13, 0x38, 0x83, 0x56, 0xf6,
-	0xa9, 0xf4, 0x46, 0x48, 0x0a, 0xff, 0x0f, 0x4a, 0x72, 0xce, 0xe9, 0x50, 0x54, 0xcf, 0x7c, 0xea,
-	0xf0, 0xa3, 0x44, 0x6c, 0xac, 0x9c, 0x0f, 0x1a, 0x8b, 0x12, 0xa3, 0xb8, 0x49, 0xcd, 0xe0, 0x36,
-	0x58, 0xc8, 0x96, 0x52, 0x40, 0xf5, 0x25, 0x25, 0x11, 0xe6, 0xa8, 0x52, 0x24, 0x7b, 0x14, 0x7e,
-	0x05, 0x56, 0xf3, 0x87, 0xb5, 0xbe, 0x2c, 0x02, 0xd8, 0x1c, 0x26, 0xc4, 0x08, 0xd5, 0x19, 0x82,
-	0x8c, 0xf6, 0xcb, 0xa4, 0x69, 0x35, 0xf3, 0x9d, 0x28, 0x11, 0xae, 0xd8, 0x79, 0x0e, 0xe0, 0x63,
-	0x30, 0xe7, 0x05, 0x96, 0x8b, 0xc4, 0x8b, 0x87, 0x56, 0x80, 0xf4, 0x15, 0xf1, 0x66, 0x4d, 0xf9,
-	0x66, 0x7a, 0x56, 0xab, 0x36, 0x62, 0xa1, 0x39, 0x90, 0x8a, 0x91, 0xa3, 0xc8, 0xa2, 0xf4, 0x14,
-	0x13, 0x47, 0x5f, 0xcd, 0x73, 0x94, 0x6a, 0x2f, 0x38, 0xea, 0x48, 0x05, 0x9f, 0x3c, 0xe3, 0x94,
-	0x41, 0x5f, 0xcb, 0x4e, 0x9e, 0x87, 0x5c, 0xbf, 0x3b, 0x54, 0x27, 0x93, 0x67, 0xdc, 0x48, 0x9d,
-	0x3c, 0x76, 0xd6, 0xa0, 0xf5, 0x8d, 0x06, 0xca, 0xca, 0x5c, 0x85, 0xff, 0x1a, 0x0e, 0x5f, 0x4d,
-	0xe4, 0x51, 0x23, 0x67, 0xf8, 0x6e, 0x27, 0x3f, 0x8f, 0x42, 0x46, 0xfa, 0xe9, 0xe0, 0xad, 0x3d,
-	0x02, 0x65, 0x45, 0x0c, 0x57, 0x41, 0x21, 0x9d, 0x35, 0x69, 0x83, 0xe0, 0x02, 0x58, 0x03, 0xd3,
-	0x3d, 0xcb, 0x8f, 0x91, 0x60, 0x1f, 0x15, 0xa9, 0x49, 0x44, 0xff, 0x99, 0xfc, 0xb7, 0xd6, 0xfa,
-	0x4e, 0x03, 0x0b, 0xa3, 0x89, 0x74, 0x10, 0x39, 0x16, 0x43, 0x59, 0x46, 0xa2, 0x0d, 0x19, 0x89,
-	0xa6, 0x32, 0x92, 0x11, 0x6b, 0x98, 0xbc, 0x9a, 0x35, 0x68, 0x39, 0xac, 0x21, 0x4b, 0x8c, 0x0a,
-	0xc3, 0xa0, 0x35, 0x95, 0x18, 0xb5, 0x4e, 0x41, 0x35, 0x33, 0x2c, 0x79, 0x3b, 0x8c, 0x08, 0xb6,
-	0x79, 0xe3, 0x15, 0xed, 0x50, 0x3d, 0x6d, 0x59, 0x6a, 0x44, 0x3b, 0xdc, 0x00, 0x45, 0x07, 0x07,
-	0x96, 0x97, 0x25, 0x5d, 0x52, 0x06, 0x1b, 0x60, 0x86, 0xb7, 0x5e, 0xe1, 0xa2, 0xa0, 0xe8, 0x4b,
-	0x3e, 0x76, 0xb9, 0x79, 0xeb, 0x7b, 0x0d, 0xc0, 0x8b, 0x54, 0x0a, 0x6e, 0x81, 0xd9, 0x00, 0x05,
-	0x98, 0xf4, 0xcd, 0xe0, 0x50, 0xb9, 0x96, 0x89, 0xee, 0x4c, 0x22, 0xde, 0x3b, 0x84, 0x9b, 0xa0,
-	0xe4, 0x78, 0xf4, 0x84, 0x03, 0x26, 0x15, 0x40, 0x91, 0x0b, 0xf7, 0x0e, 0xe1, 0x5d, 0x50, 0x22,
-	0x18, 0x33, 0xf3, 0x88, 0xca, 0x8d, 0xe7, 0x64, 0x8e, 0x16, 0xb9, 0xf8, 0x48, 0x5c, 0x10, 0x66,
-	0x1f, 0x50, 0x1e, 0x62, 0x60, 0xbd, 0x30, 0x23, 0xcf, 0xa1, 0x62, 0xec, 0xa6, 0x8e, 0x4a, 0x81,
-	0xf5, 0xa2, 0xe3, 0x39, 0xb4, 0xf5, 0xd3, 0x3c, 0x00, 0xa3, 0x10, 0x6f, 0xea, 0x66, 0xae, 0x1d,
-	0x5f, 0x26, 0x43, 0xa6, 0xf2, 0x39, 0xeb, 0xa7, 0x97, 0xb1, 0x97, 0xe9, 0xb7, 0xb3, 0x97, 0xd2,
-	0x35, 0x99, 0x4b, 0xf1, 0x7a, 0xcc, 0xa5, 0x74, 0x25, 0x73, 0x39, 0xba, 0x92, 0x8f, 0x24, 0xcc,
-	0xe0, 0xef, 0xf2, 0x22, 0x1a, 0x0a, 0x32, 0xc5, 0x84, 0xf4, 0x7a, 0xbc, 0x44, 0x61, 0x48, 0xb3,
-	0x57, 0x33, 0x24, 0x25, 0x8d, 0x40, 0x4e, 0x1a, 0x65, 0x12, 0xb1, 0x9c, 0x9b, 0x88, 0x59, 0x76,
-	0x53, 0xc9, 0x67, 0x37, 0x59, 0xa2, 0x54, 0xbd, 0x84, 0x28, 0x0d, 0x39, 0xd0, 0x9c, 0xca, 0x81,
-	0x46, 0xf5, 0x3f, 0xff, 0xee, 0xf5, 0x9f, 0x25, 0x3f, 0x0b, 0xf9, 0xe4, 0x47, 0x2d, 0xd3, 0xc5,
-	0x9c, 0x32, 0xbd, 0xc0, 0x8e, 0xe0, 0x65, 0xec, 0x28, 0xdb, 0x6e, 0x96, 0x2e, 0xf9, 0x0e, 0xfb,
-	0xdf, 0x18, 0xab, 0x5b, 0x7e, 0x0b, 0xab, 0xcb, 0xf2, 0x39, 0x23, 0xe7, 0x43, 0x68, 0xe5, 0xca,
-	0x0f, 0xa1, 0x8b, 0x9f, 0x3e, 0x97, 0x10, 0xb4, 0xd5, 0xf7, 0x4b, 0xd0, 0xd6, 0xde, 0x0b, 0x41,
-	0xd3, 0xdf, 0x1b, 0x41, 0xbb, 0x75, 0xd3, 0x04, 0xad, 0x76, 0x73, 0x04, 0x6d, 0xfd, 0x0a, 0x82,
-	0x76, 0xe1, 0x23, 0x75, 0xe3, 0x9d, 0x3f, 0x52, 0x33, 0x73, 0x64, 0x33, 0x67, 0x8e, 0x5c, 0xc1,
-	0x02, 0xeb, 0x7f, 0x11, 0x0b, 0x6c, 0xdc, 0x14, 0x0b, 0x6c, 0xde, 0x1c, 0x0b, 0xdc, 0xba, 0x59,
-	0x16, 0x68, 0xdc, 0x7b, 0xf5, 0xba, 0x3e, 0xf1, 0xcb, 0xeb, 0xfa, 0xc4, 0x9b, 0xd7, 0x75, 0xed,
-	0xeb, 0xb3, 0xba, 0xf6, 0xc3, 0x59, 0x5d, 0x7b, 0x79, 0x56, 0xd7, 0x5e, 0x9d, 0xd5, 0xb5, 0xdf,
-	0xce, 0xea, 0xda, 0x1f, 0x67, 0xf5, 0x89, 0x37, 0x67, 0x75, 0xed, 0xdb, 0xdf, 0xeb, 0x13, 0x7f,
-	0x06, 0x00, 0x00, 0xff, 0xff, 0xb3, 0x50, 0x4a, 0x1d, 0xc7, 0x13, 0x00, 0x00,
+	// 1607 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x4f, 0x6f, 0xdb, 0xc8,
+	0x15, 0x37, 0x2d, 0x4b, 0xb2, 0x46, 0x92, 0xff, 0x8c, 0x65, 0x7b, 0x22, 0xdb, 0x92, 0xac, 0x06,
+	0x89, 0x5a, 0xa4, 0x0e, 0xe0, 0x4b, 0x8b, 0xb6, 0x87, 0x86, 0x76, 0x1a, 0x14, 0x89, 0x0b, 0x41,
+	0x8e, 0xd3, 0xa6, 0x40, 0x4b, 0xd0, 0xe4, 0x98, 0x26, 0x2c, 0x72, 0x88, 0x99, 0xa1, 0x1c, 0xa1,
+	0x40, 0xd1, 0x2f, 0x50, 0xa0, 0xa7, 0x02, 0x3d, 0x75, 0x8f, 0x7b, 0xdf, 0x2f, 0x91, 0x63, 0x8e,
+	0x8b, 0x3d, 0x08, 0x1b, 0xef, 0x65, 0xe1, 0x53, 0x3e, 0xc2, 0x82, 0xc3, 0xa1, 0x34, 0x94, 0x69,
+	0xc7, 0xc1, 0x1a, 0xd9, 0x93, 0x3d, 0xef, 0xf7, 0xde, 0x9b, 0x37, 0x33, 0xef, 0xcf, 0x8f, 0x02,
+	0xcb, 0x36, 0x66, 0x2e, 0xc5, 0xb6, 0xd1, 0xa7, 0xc1, 0x4e, 0x40, 0x09, 0x27, 0xb0, 0xe0, 0x11,
+	0x1b, 0xf7, 0x59, 0xfd, 0x97, 0x8e, 0xcb, 0x4f, 0xc3, 0xe3, 0x1d, 0x8b, 0x78, 0x8f, 0x1d, 0xe2,
+	0x90, 0xc7, 0x02, 0x3e, 0x0e, 0x4f, 0xc4, 0x4a, 0x2c, 0xc4, 0x7f, 0xb1, 0x59, 0xbd, 0x6a, 0x5a,
+	0xdc, 0x25, 0x3e, 0x93, 0xcb, 0x75, 0xcb, 0xb4, 0x4e, 0xb1, 0x6d, 0xd8, 0x38, 0xc0, 0xbe, 0x8d,
+	0x7d, 0x6b, 0x28, 0x81, 0x4d, 0x0b, 0x53, 0xee, 0x9e, 0xb8, 0x96, 0xc9, 0xb1, 0x11, 0x50, 0x12,
+	0x44, 0x4b, 0x9c, 0x98, 0x6d, 0x60, 0x7f, 0xe0, 0x52, 0xe2, 0x7b, 0xd8, 0xe7, 0xc6, 0xc0, 0xa4,
+	0xae, 0x79, 0xdc, 0x1f, 0x83, 0x6b, 0x1e, 0xb1, 0x63, 0x4b, 0x97, 0xf8, 0x06, 0x37, 0x9d, 0x64,
+	0x6b, 0x1f, 0xf3, 0x73, 0x42, 0xcf, 0xe4, 0xb2, 0xc6, 0xb0, 0x15, 0x52, 0x97, 0x0f, 0x0d, 0x87,
+	0x92, 0x50, 0x1e, 0xab, 0x0e, 0x07, 0xa4, 0x1f, 0x7a, 0xd8, 0xf0, 0x48, 0xe8, 0xf3, 0xc4, 0xa1,
+	0x75, 0x8a, 0xad, 0x33, 0xc3, 0xc6, 0x27, 0xae, 0xef, 0x46, 0x4e, 0x63, 0x79, 0xfb, 0xff, 0x79,
+	0x80, 0xf6, 0xe3, 0x8b, 0x79, 0xd1, 0xeb, 0x1e, 0x46, 0x07, 0x09, 0xfb, 0xae, 0xef, 0xfc, 0xd1,
+	0x3f, 0x21, 0xf0, 0x39, 0x58, 0x54, 0x2e, 0xcd, 0x38, 0xc3, 0x43, 0xa4, 0xb5, 0xb4, 0x4e, 0x79,
+	0x77, 0x75, 0x27, 0xbe, 0xb9, 0x9d, 0x89, 0xe9, 0x73, 0x3c, 0xd4, 0x2b, 0x6f, 0x47, 0xcd, 0x99,
+	0x77, 0xa3, 0xa6, 0x76, 0x39, 0x6a, 0xce, 0xf4, 0xaa, 0xd2, 0xf6, 0x05, 0x0d, 0x9e, 0xe3, 0x21,
+	0xbc, 0x0f, 0x80, 0xe9, 0xfb, 0x84, 0x8b, 0x23, 0xa1, 0xd9, 0x96, 0xd6, 0x29, 0xe9, 0x73, 0x91,
+	0x41, 0x4f, 0x91, 0xc3, 0x36, 0x28, 0xb9, 0x3e, 0xe3, 0xa6, 0x6f, 0x61, 0x86, 0x72, 0x2d, 0xad,
+	0x93, 0x97, 0x4a, 0x13, 0x31, 0xfc, 0x2b, 0xa8, 0xa9, 0x61, 0x51, 0xcc, 0x48, 0x48, 0x2d, 0x8c,
+	0xe6, 0x44, 0x6c, 0xf5, 0xab, 0xb1, 0xf5, 0xa4, 0xc6, 0x54, 0x80, 0x70, 0x12, 0x60, 0xa2, 0x01,
+	0x7f, 0x0b, 0x0a, 0x94, 0x84, 0x1c, 0x33, 0x94, 0x17, 0xde, 0x56, 0x12, 0x6f, 0xdd, 0xe8, 0xba,
+	0x7a, 0x02, 0xd2, 0x17, 0x22, 0x37, 0xdf, 0x8c, 0x9a, 0x85, 0x78, 0xdd, 0x93, 0x26, 0xb0, 0x0b,
+	0x96, 0xa6, 0xdf, 0x0d, 0x15, 0x84, 0x9b, 0xf5, 0xc4, 0xcd, 0x81, 0x82, 0xbf, 0x34, 0x9d, 0xa9,
+	0x88, 0x16, 0xbd, 0x34, 0x0c, 0x8f, 0xc1, 0x92, 0x7c, 0xcc, 0xa0, 0x6f, 0x5a, 0x38, 0xca, 0x15,
+	0x54, 0x4c, 0x7b, 0x7c, 0x25, 0xf0, 0x6e, 0x02, 0xeb, 0x8d, 0xcb, 0x51, 0xb3, 0x3e, 0x6d, 0xf4,
+	0x88, 0x78, 0x2e, 0xc7, 0x5e, 0xc0, 0x87, 0xbd, 0xc5, 0x41, 0xda, 0x00, 0xea, 0xa0, 0x3a, 0x5e,
+	0xbc, 0x34, 0x1d, 0x86, 0xe6, 0x5b, 0xb9, 0x4e, 0x49, 0xdf, 0xbc, 0x1c, 0x35, 0xd1, 0xd8, 0x41,
+	0x74, 0x16, 0xa6, 0x78, 0x49, 0x9b, 0xc0, 0xd7, 0xa0, 0xc6, 0xc2, 0x20, 0xa0, 0x98, 0x31, 0xc3,
+	0x22, 0xfe, 0x00, 0x53, 0x07, 0xfb, 0x16, 0x46, 0xa5, 0x96, 0xd6, 0x99, 0xd7, 0x1f, 0x44, 0x87,
+	0xbc, 0x1c, 0x35, 0x1b, 0x59, 0x3a, 0x8a, 0xd3, 0x95, 0x04, 0xdf, 0x9b, 0xc0, 0xed, 0xaf, 0x2a,
+	0x60, 0x59, 0x79, 0xca, 0xd0, 0xbf, 0xfb, 0xd4, 0xfc, 0x1b, 0x58, 0xcd, 0x2c, 0x46, 0x34, 0xdb,
+	0xca, 0x75, 0xca, 0xbb, 0x1b, 0x89, 0xcb, 0xa7, 0x13, 0xa5, 0x57, 0x52, 0x47, 0x2f, 0xcb, 0xb3,
+	0xe5, 0xb0, 0x3f, 0xe8, 0xd5, 0xf0, 0x55, 0x0d, 0x06, 0xef, 0x83, 0x3c, 0xc3, 0x3c, 0x0c, 0x44,
+	0x3e, 0x97, 0x77, 0x17, 0x12, 0x77, 0x4f, 0x44, 0x1b, 0xe9, 0xc5, 0x20, 0x7c, 0x00, 0x0a, 0x71,
+	0x5f, 0x41, 0x73, 0x99, 0x6a, 0x12, 0x85, 0x1d, 0x50, 0xf4, 0x88, 0xef, 0x72, 0x42, 0x51, 0x3e,
+	0x53, 0x31, 0x81, 0xe1, 0xdf, 0x41, 0xdd, 0xc6, 0x01, 0xc5, 0x51, 0xff, 0xb1, 0x0d, 0xc6, 0x4d,
+	0xca, 0x0d, 0xee, 0x7a, 0x98, 0x84, 0xdc, 0x60, 0x22, 0x31, 0xab, 0xfa, 0xb6, 0x0c, 0x7f, 0x3d,
+	0x05, 0x4f, 0xde, 0x04, 0x69, 0xbd, 0xf5, 0x89, 0x93, 0xc3, 0x48, 0xe9, 0x65, 0xac, 0x73, 0x18,
+	0x55, 0x74, 0x40, 0xdd, 0x81, 0xdb, 0xc7, 0x0e, 0xb6, 0x45, 0x5a, 0xce, 0x27, 0x15, 0x3d, 0x91,
+	0xc3, 0x9f, 0x01, 0x60, 0x05, 0xa1, 0x71, 0x8e, 0x5d, 0xe7, 0x94, 0xa3, 0x79, 0xb1, 0xab, 0x2c,
+	0x69, 0x2b, 0x08, 0xff, 0x2c, 0xc4, 0xb0, 0x06, 0xf2, 0x01, 0xa1, 0x9c, 0xa1, 0x52, 0x2b, 0xd7,
+	0xa9, 0xf6, 0xe2, 0x05, 0xd4, 0x41, 0x05, 0x3b, 0x22, 0x5f, 0x68, 0x18, 0x3d, 0x07, 0x10, 0xcf,
+	0x71, 0x2f, 0x39, 0xef, 0xa1, 0x6c, 0x7e, 0xcf, 0xa2, 0xde, 0xd7, 0x0b, 0xfb, 0x58, 0xfa, 0x2d,
+	0xc7, 0x46, 0x91, 0x84, 0x45, 0xdb, 0xf7, 0x89, 0x63, 0xc8, 0x16, 0x51, 0x56, 0xda, 0x4e, 0xa9,
+	0x4f, 0x9c, 0xc3, 0xb8, 0xea, 0x1f, 0x82, 0x8a, 0x87, 0x39, 0x75, 0x2d, 0x66, 0x38, 0xa1, 0x6b,
+	0xa3, 0x8a, 0xa2, 0x56, 0x96, 0xc8, 0xb3, 0xd0, 0x8d, 0x0f, 0x43, 0xb1, 0xb8, 0x4f, 0x93, 0xa3,
+	0x6a, 0x4b, 0xeb, 0xe4, 0xc6, 0x87, 0x89, 0xe5, 0x4f, 0x38, 0xec, 0x83, 0x95, 0xe9, 0x91, 0xe0,
+	0x62, 0x86, 0x16, 0x44, 0xf4, 0x28, 0x89, 0x7e, 0x4f, 0xa8, 0xec, 0x8f, 0x87, 0x86, 0xbe, 0x7d,
+	0x39, 0x6a, 0x6e, 0x65, 0x18, 0x2a, 0x05, 0x02, 0xad, 0xb4, 0x91, 0x8b, 0x19, 0xfc, 0x0b, 0xa8,
+	0xf5, 0xb1, 0x63, 0x5a, 0x43, 0xc3, 0x26, 0xe7, 0x7e, 0x9f, 0x98, 0xb6, 0x11, 0x32, 0x4c, 0xd1,
+	0xa2, 0x38, 0xc3, 0xb8, 0xf4, 0xb2, 0x74, 0x54, 0xcf, 0x31, 0xbe, 0x2f, 0xe1, 0x23, 0x86, 0x29,
+	0xfc, 0x07, 0x68, 0x71, 0x1a, 0x32, 0x91, 0x3c, 0x43, 0xc6, 0xb1, 0x67, 0x28, 0x03, 0x8d, 0x19,
+	0x81, 0xc9, 0x4f, 0xd1, 0x92, 0xd8, 0x65, 0x57, 0xee, 0xf2, 0x8b, 0x8f, 0xe9, 0x2b, 0x3b, 0x6e,
+	0x49, 0xdd, 0x43, 0xa1, 0xba, 0xa7, 0x68, 0x76, 0x4d, 0x7e, 0x0a, 0x8f, 0x40, 0x55, 0x1d, 0x63,
+	0x0c, 0x2d, 0xb7, 0x72, 0x6a, 0x3f, 0x8e, 0xdb, 0xde, 0x41, 0x84, 0xe9, 0x1b, 0x51, 0x02, 0xa7,
+	0xb4, 0x95, 0x7d, 0x2a, 0x83, 0x89, 0x26, 0x83, 0xbf, 0x07, 0x45, 0x39, 0x42, 0x11, 0x14, 0xd5,
+	0xb3, 0x98, 0x38, 0xfc, 0x53, 0x2c, 0xd6, 0x57, 0x2f, 0x47, 0xcd, 0x65, 0xa9, 0xa3, 0xb8, 0x49,
+	0xcc, 0xe0, 0x0e, 0x58, 0x4a, 0x97, 0x92, 0xc7, 0xd0, 0x8a, 0x92, 0x08, 0x0b, 0x4c, 0x29, 0x92,
+	0x03, 0x06, 0xff, 0x09, 0xd6, 0xb2, 0x79, 0x00, 0xaa, 0x89, 0x00, 0xb6, 0xc6, 0x09, 0x31, 0xd1,
+	0xea, 0x8e, 0x95, 0xf4, 0xce, 0xdb, 0xb8, 0x69, 0xb5, 0xb2, 0x9d, 0x28, 0x11, 0xae, 0x5a, 0x59,
+	0x0e, 0xe0, 0x33, 0xb0, 0xe0, 0x7a, 0xa6, 0x83, 0xc5, 0x8b, 0xfb, 0xa6, 0x87, 0xd1, 0xaa, 0x78,
+	0xb3, 0x96, 0x7c, 0x33, 0x94, 0x46, 0xd5, 0x1e, 0x2f, 0x90, 0x23, 0x09, 0x4c, 0x1c, 0x05, 0x26,
+	0x63, 0xe7, 0x84, 0xda, 0x68, 0x2d, 0xcb, 0x51, 0x82, 0x5e, 0x71, 0xd4, 0x95, 0x40, 0x34, 0xd4,
+	0xa6, 0xd9, 0x08, 0x5a, 0x4f, 0x0f, 0xb5, 0xbd, 0x08, 0xdf, 0x1f, 0xc3, 0xf1, 0x50, 0x9b, 0x36,
+	0x52, 0x87, 0x9a, 0x95, 0x36, 0x68, 0xff, 0x5b, 0x03, 0x65, 0x65, 0x64, 0xc3, 0x5f, 0x8d, 0xe7,
+	0xba, 0x26, 0xf2, 0xa8, 0x99, 0x31, 0xd7, 0x77, 0xe2, 0x3f, 0x4f, 0x7d, 0x4e, 0x87, 0xc9, 0x4c,
+	0xaf, 0x3f, 0x05, 0x65, 0x45, 0x0c, 0xd7, 0x40, 0x2e, 0x99, 0x35, 0x49, 0x83, 0x88, 0x04, 0xb0,
+	0x0e, 0xf2, 0x03, 0xb3, 0x1f, 0x62, 0x41, 0x6c, 0x2a, 0x12, 0x89, 0x45, 0xbf, 0x99, 0xfd, 0xb5,
+	0xd6, 0xfe, 0x9f, 0x06, 0x96, 0x26, 0x13, 0xe9, 0x28, 0xb0, 0x4d, 0x8e, 0xd3, 0x64, 0x47, 0x1b,
+	0x93, 0x1d, 0x4d, 0x25, 0x3b, 0x13, 0x42, 0x32, 0x7b, 0x33, 0x21, 0xd1, 0x32, 0x08, 0x49, 0x9a,
+	0x73, 0xe5, 0xc6, 0x41, 0x6b, 0x2a, 0xe7, 0x6a, 0x9f, 0x83, 0x6a, 0x6a, 0x58, 0x46, 0xed, 0x30,
+	0xa0, 0xc4, 0xc2, 0x4c, 0xb6, 0x43, 0xf5, 0xb4, 0x65, 0x89, 0x88, 0x76, 0xb8, 0x09, 0x0a, 0x36,
+	0xf1, 0x4c, 0x37, 0xcd, 0xe7, 0xa4, 0x0c, 0x36, 0xc1, 0x7c, 0xd4, 0x7a, 0x85, 0x8b, 0x9c, 0x82,
+	0x17, 0xfb, 0xc4, 0x89, 0xcc, 0xdb, 0x5f, 0x68, 0x00, 0x5e, 0x65, 0x69, 0x70, 0x1b, 0x94, 0x3c,
+	0xec, 0x11, 0x3a, 0x34, 0xbc, 0x63, 0xe5, 0x5a, 0x66, 0x7a, 0xf3, 0xb1, 0xf8, 0xe0, 0x18, 0x6e,
+	0x81, 0xa2, 0xed, 0xb2, 0xb3, 0x48, 0x61, 0x56, 0x51, 0x28, 0x44, 0xc2, 0x83, 0x63, 0xf8, 0x10,
+	0x14, 0x29, 0x21, 0xdc, 0x38, 0x61, 0x72, 0xe3, 0x05, 0x99, 0xa3, 0x85, 0x48, 0x7c, 0x22, 0x2e,
+	0x88, 0xf0, 0x3f, 0xb0, 0x28, 0x44, 0xcf, 0x7c, 0x63, 0x04, 0xae, 0xcd, 0xd0, 0x9c, 0xe2, 0xa8,
+	0xe8, 0x99, 0x6f, 0xba, 0xae, 0xcd, 0xda, 0xff, 0x5d, 0x02, 0x60, 0x12, 0xe2, 0x5d, 0xdd, 0xcc,
+	0xad, 0xe3, 0x4b, 0x65, 0xc8, 0x5c, 0x36, 0x1d, 0x7e, 0x7d, 0x1d, 0x7b, 0xc9, 0x7f, 0x9c, 0xbd,
+	0x14, 0x6f, 0xc9, 0x5c, 0x0a, 0xb7, 0x63, 0x2e, 0xc5, 0x1b, 0x99, 0xcb, 0xc9, 0x8d, 0x7c, 0x24,
+	0x66, 0x06, 0x3f, 0x97, 0x17, 0xd1, 0x54, 0x34, 0x13, 0x1d, 0x9f, 0xdd, 0x8e, 0x97, 0x28, 0x0c,
+	0xa9, 0x74, 0x33, 0x43, 0x52, 0xd2, 0x08, 0x64, 0xa4, 0x51, 0x2a, 0x11, 0xcb, 0x99, 0x89, 0x98,
+	0x66, 0x37, 0x95, 0x6c, 0x76, 0x93, 0x26, 0x4a, 0xd5, 0x6b, 0x88, 0xd2, 0x98, 0x03, 0x2d, 0xa8,
+	0x1c, 0x68, 0x52, 0xff, 0x8b, 0x9f, 0x5e, 0xff, 0x69, 0xf2, 0xb3, 0x94, 0x4d, 0x7e, 0xd4, 0x32,
+	0x5d, 0xce, 0x28, 0xd3, 0x2b, 0xec, 0x08, 0x5e, 0xc7, 0x8e, 0xd2, 0xed, 0x66, 0xe5, 0x9a, 0x4f,
+	0xbc, 0xdf, 0x4d, 0xb1, 0xba, 0xda, 0x47, 0x58, 0x5d, 0x9a, 0xcf, 0xe9, 0x19, 0xdf, 0x58, 0xab,
+	0x37, 0x7e, 0x63, 0x5d, 0xfd, 0xaa, 0xba, 0x86, 0xa0, 0xad, 0x7d, 0x5e, 0x82, 0xb6, 0xfe, 0x59,
+	0x08, 0x1a, 0xfa, 0x6c, 0x04, 0xed, 0xde, 0x5d, 0x13, 0xb4, 0xfa, 0xdd, 0x11, 0xb4, 0x8d, 0x1b,
+	0x08, 0xda, 0x95, 0xef, 0xdf, 0xcd, 0x4f, 0xff, 0xfe, 0x55, 0xe7, 0xc8, 0x56, 0xc6, 0x1c, 0xb9,
+	0x81, 0x05, 0x36, 0x7e, 0x22, 0x16, 0xd8, 0xbc, 0x2b, 0x16, 0xd8, 0xba, 0x3b, 0x16, 0xb8, 0x7d,
+	0xb7, 0x2c, 0xf0, 0xda, 0x9f, 0x25, 0xda, 0x3f, 0xfa, 0x67, 0x09, 0xfd, 0xd1, 0xbb, 0xf7, 0x8d,
+	0x99, 0xaf, 0xdf, 0x37, 0x66, 0x3e, 0xbc, 0x6f, 0x68, 0xff, 0xba, 0x68, 0x68, 0x5f, 0x5e, 0x34,
+	0xb4, 0xb7, 0x17, 0x0d, 0xed, 0xdd, 0x45, 0x43, 0xfb, 0xf6, 0xa2, 0xa1, 0x7d, 0x7f, 0xd1, 0x98,
+	0xf9, 0x70, 0xd1, 0xd0, 0xfe, 0xf3, 0x5d, 0x63, 0xe6, 0x87, 0x00, 0x00, 0x00, 0xff, 0xff, 0xf3,
+	0x28, 0x52, 0xb5, 0x7d, 0x14, 0x00, 0x00,
 }
@@ -25,6 +25,28 @@ func (request *DesireLRPRequest) Validate() error {
 		validationError = validationError.Append(ErrInvalidField{"desired_lrp"})
 	} else if err := request.DesiredLrp.Validate(); err != nil {
 		validationError = validationError.Append(err)
+	} else if err := request.DesiredLrp.ValidateImageSources(allowedImageSchemes, allowedImageHosts); err != nil {
+		validationError = validationError.Append(err)
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
+func (request *DesireLRPsRequest) Validate() error {
+	var validationError ValidationError
+
+	if len(request.DesiredLrps) == 0 {
+		validationError = validationError.Append(ErrInvalidField{"desired_lrps"})
+	}
+
+	for _, desiredLRP := range request.DesiredLrps {
+		if err := (&DesireLRPRequest{DesiredLrp: desiredLRP}).Validate(); err != nil {
+			validationError = validationError.Append(err)
+		}
 	}
 
 	if !validationError.Empty() {
@@ -54,6 +76,26 @@ func (request *UpdateDesiredLRPRequest) Validate() error {
 	return nil
 }
 
+func (request *UpdateDesiredLRPRoutesRequest) Validate() error {
+	var validationError ValidationError
+
+	if request.ProcessGuid == "" {
+		validationError = validationError.Append(ErrInvalidField{"process_guid"})
+	}
+
+	if request.Routes == nil {
+		validationError = validationError.Append(ErrInvalidField{"routes"})
+	} else if err := (&DesiredLRPUpdate{Routes: request.Routes}).Validate(); err != nil {
+		validationError = validationError.Append(err)
+	}
+
+	if !validationError.Empty() {
+		return validationError
+	}
+
+	return nil
+}
+
 func (request *RemoveDesiredLRPRequest) Validate() error {
 	var validationError ValidationError
 
@@ -170,6 +170,23 @@ func (m *DesireLRPRequest) GetDesiredLrp() *DesiredLRP {
 	return nil
 }
 
+type DesireLRPsRequest struct {
+	DesiredLrps []*DesiredLRP `protobuf:"bytes,1,rep,name=desired_lrps,json=desiredLrps" json:"desired_lrps,omitempty"`
+}
+
+func (m *DesireLRPsRequest) Reset()      { *m = DesireLRPsRequest{} }
+func (*DesireLRPsRequest) ProtoMessage() {}
+func (*DesireLRPsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorDesiredLrpRequests, []int{7}
+}
+
+func (m *DesireLRPsRequest) GetDesiredLrps() []*DesiredLRP {
+	if m != nil {
+		return m.DesiredLrps
+	}
+	return nil
+}
+
 type UpdateDesiredLRPRequest struct {
 	ProcessGuid string            `protobuf:"bytes,1,opt,name=process_guid,json=processGuid" json:"process_guid"`
 	Update      *DesiredLRPUpdate `protobuf:"bytes,2,opt,name=update" json:"update,omitempty"`
@@ -178,7 +195,7 @@ type UpdateDesiredLRPRequest struct {
 func (m *UpdateDesiredLRPRequest) Reset()      { *m = UpdateDesiredLRPRequest{} }
 func (*UpdateDesiredLRPRequest) ProtoMessage() {}
 func (*UpdateDesiredLRPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorDesiredLrpRequests, []int{7}
+	return fileDescriptorDesiredLrpRequests, []int{8}
 }
 
 func (m *UpdateDesiredLRPRequest) GetProcessGuid() string {
@@ -195,6 +212,24 @@ func (m *UpdateDesiredLRPRequest) GetUpdate() *DesiredLRPUpdate {
 	return nil
 }
 
+type UpdateDesiredLRPRoutesRequest struct {
+	ProcessGuid string  `protobuf:"bytes,1,opt,name=process_guid,json=processGuid" json:"process_guid"`
+	Routes      *Routes `protobuf:"bytes,2,opt,name=routes,customtype=Routes" json:"routes,omitempty"`
+}
+
+func (m *UpdateDesiredLRPRoutesRequest) Reset()      { *m = UpdateDesiredLRPRoutesRequest{} }
+func (*UpdateDesiredLRPRoutesRequest) ProtoMessage() {}
+func (*UpdateDesiredLRPRoutesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorDesiredLrpRequests, []int{9}
+}
+
+func (m *UpdateDesiredLRPRoutesRequest) GetProcessGuid() string {
+	if m != nil {
+		return m.ProcessGuid
+	}
+	return ""
+}
+
 type RemoveDesiredLRPRequest struct {
 	ProcessGuid string `protobuf:"bytes,1,opt,name=process_guid,json=processGuid" json:"process_guid"`
 }
@@ -202,7 +237,7 @@ type RemoveDesiredLRPRequest struct {
 func (m *RemoveDesiredLRPRequest) Reset()      { *m = RemoveDesiredLRPRequest{} }
 func (*RemoveDesiredLRPRequest) ProtoMessage() {}
 func (*RemoveDesiredLRPRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorDesiredLrpRequests, []int{8}
+	return fileDescriptorDesiredLrpRequests, []int{10}
 }
 
 func (m *RemoveDesiredLRPRequest) GetProcessGuid() string {
@@ -212,6 +247,31 @@ func (m *RemoveDesiredLRPRequest) GetProcessGuid() string {
 	return ""
 }
 
+type DesiredLRPProcessGuidsResponse struct {
+	Error        *Error   `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	ProcessGuids []string `protobuf:"bytes,2,rep,name=process_guids,json=processGuids" json:"process_guids,omitempty"`
+}
+
+func (m *DesiredLRPProcessGuidsResponse) Reset()      { *m = DesiredLRPProcessGuidsResponse{} }
+func (*DesiredLRPProcessGuidsResponse) ProtoMessage() {}
+func (*DesiredLRPProcessGuidsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorDesiredLrpRequests, []int{11}
+}
+
+func (m *DesiredLRPProcessGuidsResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *DesiredLRPProcessGuidsResponse) GetProcessGuids() []string {
+	if m != nil {
+		return m.ProcessGuids
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*DesiredLRPLifecycleResponse)(nil), "models.DesiredLRPLifecycleResponse")
 	proto.RegisterType((*DesiredLRPsResponse)(nil), "models.DesiredLRPsResponse")
@@ -220,8 +280,11 @@ func init() {
 	proto.RegisterType((*DesiredLRPSchedulingInfosResponse)(nil), "models.DesiredLRPSchedulingInfosResponse")
 	proto.RegisterType((*DesiredLRPByProcessGuidRequest)(nil), "models.DesiredLRPByProcessGuidRequest")
 	proto.RegisterType((*DesireLRPRequest)(nil), "models.DesireLRPRequest")
+	proto.RegisterType((*DesireLRPsRequest)(nil), "models.DesireLRPsRequest")
 	proto.RegisterType((*UpdateDesiredLRPRequest)(nil), "models.UpdateDesiredLRPRequest")
+	proto.RegisterType((*UpdateDesiredLRPRoutesRequest)(nil), "models.UpdateDesiredLRPRoutesRequest")
 	proto.RegisterType((*RemoveDesiredLRPRequest)(nil), "models.RemoveDesiredLRPRequest")
+	proto.RegisterType((*DesiredLRPProcessGuidsResponse)(nil), "models.DesiredLRPProcessGuidsResponse")
 }
 func (this *DesiredLRPLifecycleResponse) Equal(that interface{}) bool {
 	if that == nil {
@@ -460,6 +523,41 @@ func (this *DesireLRPRequest) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *DesireLRPsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*DesireLRPsRequest)
+	if !ok {
+		that2, ok := that.(DesireLRPsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if len(this.DesiredLrps) != len(that1.DesiredLrps) {
+		return false
+	}
+	for i := range this.DesiredLrps {
+		if !this.DesiredLrps[i].Equal(that1.DesiredLrps[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (this *UpdateDesiredLRPRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
@@ -493,6 +591,43 @@ func (this *UpdateDesiredLRPRequest) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *UpdateDesiredLRPRoutesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*UpdateDesiredLRPRoutesRequest)
+	if !ok {
+		that2, ok := that.(UpdateDesiredLRPRoutesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.ProcessGuid != that1.ProcessGuid {
+		return false
+	}
+	if that1.Routes == nil {
+		if this.Routes != nil {
+			return false
+		}
+	} else if !this.Routes.Equal(*that1.Routes) {
+		return false
+	}
+	return true
+}
 func (this *RemoveDesiredLRPRequest) Equal(that interface{}) bool {
 	if that == nil {
 		if this == nil {
@@ -523,6 +658,44 @@ func (this *RemoveDesiredLRPRequest) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *DesiredLRPProcessGuidsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*DesiredLRPProcessGuidsResponse)
+	if !ok {
+		that2, ok := that.(DesiredLRPProcessGuidsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if !this.Error.Equal(that1.Error) {
+		return false
+	}
+	if len(this.ProcessGuids) != len(that1.ProcessGuids) {
+		return false
+	}
+	for i := range this.ProcessGuids {
+		if this.ProcessGuids[i] != that1.ProcessGuids[i] {
+			return false
+		}
+	}
+	return true
+}
 func (this *DesiredLRPLifecycleResponse) GoString() string {
 	if this == nil {
 		return "nil"
@@ -615,6 +788,18 @@ func (this *DesireLRPRequest) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *DesireLRPsRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.DesireLRPsRequest{")
+	if this.DesiredLrps != nil {
+		s = append(s, "DesiredLrps: "+fmt.Sprintf("%#v", this.DesiredLrps)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *UpdateDesiredLRPRequest) GoString() string {
 	if this == nil {
 		return "nil"
@@ -628,6 +813,19 @@ func (this *UpdateDesiredLRPRequest) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *UpdateDesiredLRPRoutesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.UpdateDesiredLRPRoutesRequest{")
+	s = append(s, "ProcessGuid: "+fmt.Sprintf("%#v", this.ProcessGuid)+",\n")
+	if this.Routes != nil {
+		s = append(s, "Routes: "+valueToGoStringDesiredLrpRequests(this.Routes, "Routes")+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *RemoveDesiredLRPRequest) GoString() string {
 	if this == nil {
 		return "nil"
@@ -638,6 +836,21 @@ func (this *RemoveDesiredLRPRequest) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *DesiredLRPProcessGuidsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&models.DesiredLRPProcessGuidsResponse{")
+	if this.Error != nil {
+		s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	}
+	if this.ProcessGuids != nil {
+		s = append(s, "ProcessGuids: "+fmt.Sprintf("%#v", this.ProcessGuids)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringDesiredLrpRequests(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -879,6 +1092,36 @@ func (m *DesireLRPRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *DesireLRPsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DesireLRPsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.DesiredLrps) > 0 {
+		for _, msg := range m.DesiredLrps {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDesiredLrpRequests(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
 func (m *UpdateDesiredLRPRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -911,7 +1154,7 @@ func (m *UpdateDesiredLRPRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *RemoveDesiredLRPRequest) Marshal() (dAtA []byte, err error) {
+func (m *UpdateDesiredLRPRoutesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -921,7 +1164,7 @@ func (m *RemoveDesiredLRPRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RemoveDesiredLRPRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateDesiredLRPRoutesRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -930,49 +1173,124 @@ func (m *RemoveDesiredLRPRequest) MarshalTo(dAtA []byte) (int, error) {
 	i++
 	i = encodeVarintDesiredLrpRequests(dAtA, i, uint64(len(m.ProcessGuid)))
 	i += copy(dAtA[i:], m.ProcessGuid)
+	if m.Routes != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDesiredLrpRequests(dAtA, i, uint64(m.Routes.Size()))
+		n8, err := m.Routes.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
 	return i, nil
 }
 
-func encodeFixed64DesiredLrpRequests(dAtA []byte, offset int, v uint64) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	dAtA[offset+4] = uint8(v >> 32)
-	dAtA[offset+5] = uint8(v >> 40)
-	dAtA[offset+6] = uint8(v >> 48)
-	dAtA[offset+7] = uint8(v >> 56)
-	return offset + 8
-}
-func encodeFixed32DesiredLrpRequests(dAtA []byte, offset int, v uint32) int {
-	dAtA[offset] = uint8(v)
-	dAtA[offset+1] = uint8(v >> 8)
-	dAtA[offset+2] = uint8(v >> 16)
-	dAtA[offset+3] = uint8(v >> 24)
-	return offset + 4
-}
-func encodeVarintDesiredLrpRequests(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *RemoveDesiredLRPRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *DesiredLRPLifecycleResponse) Size() (n int) {
+
+func (m *RemoveDesiredLRPRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovDesiredLrpRequests(uint64(l))
-	}
-	return n
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintDesiredLrpRequests(dAtA, i, uint64(len(m.ProcessGuid)))
+	i += copy(dAtA[i:], m.ProcessGuid)
+	return i, nil
 }
 
-func (m *DesiredLRPsResponse) Size() (n int) {
-	var l int
-	_ = l
+func (m *DesiredLRPProcessGuidsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DesiredLRPProcessGuidsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDesiredLrpRequests(dAtA, i, uint64(m.Error.Size()))
+		n9, err := m.Error.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if len(m.ProcessGuids) > 0 {
+		for _, s := range m.ProcessGuids {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func encodeFixed64DesiredLrpRequests(dAtA []byte, offset int, v uint64) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	dAtA[offset+4] = uint8(v >> 32)
+	dAtA[offset+5] = uint8(v >> 40)
+	dAtA[offset+6] = uint8(v >> 48)
+	dAtA[offset+7] = uint8(v >> 56)
+	return offset + 8
+}
+func encodeFixed32DesiredLrpRequests(dAtA []byte, offset int, v uint32) int {
+	dAtA[offset] = uint8(v)
+	dAtA[offset+1] = uint8(v >> 8)
+	dAtA[offset+2] = uint8(v >> 16)
+	dAtA[offset+3] = uint8(v >> 24)
+	return offset + 4
+}
+func encodeVarintDesiredLrpRequests(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *DesiredLRPLifecycleResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovDesiredLrpRequests(uint64(l))
+	}
+	return n
+}
+
+func (m *DesiredLRPsResponse) Size() (n int) {
+	var l int
+	_ = l
 	if m.Error != nil {
 		l = m.Error.Size()
 		n += 1 + l + sovDesiredLrpRequests(uint64(l))
@@ -1048,6 +1366,18 @@ func (m *DesireLRPRequest) Size() (n int) {
 	return n
 }
 
+func (m *DesireLRPsRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.DesiredLrps) > 0 {
+		for _, e := range m.DesiredLrps {
+			l = e.Size()
+			n += 1 + l + sovDesiredLrpRequests(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *UpdateDesiredLRPRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -1060,6 +1390,18 @@ func (m *UpdateDesiredLRPRequest) Size() (n int) {
 	return n
 }
 
+func (m *UpdateDesiredLRPRoutesRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ProcessGuid)
+	n += 1 + l + sovDesiredLrpRequests(uint64(l))
+	if m.Routes != nil {
+		l = m.Routes.Size()
+		n += 1 + l + sovDesiredLrpRequests(uint64(l))
+	}
+	return n
+}
+
 func (m *RemoveDesiredLRPRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -1068,6 +1410,22 @@ func (m *RemoveDesiredLRPRequest) Size() (n int) {
 	return n
 }
 
+func (m *DesiredLRPProcessGuidsResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovDesiredLrpRequests(uint64(l))
+	}
+	if len(m.ProcessGuids) > 0 {
+		for _, s := range m.ProcessGuids {
+			l = len(s)
+			n += 1 + l + sovDesiredLrpRequests(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovDesiredLrpRequests(x uint64) (n int) {
 	for {
 		n++
@@ -1155,6 +1513,16 @@ func (this *DesireLRPRequest) String() string {
 	}, "")
 	return s
 }
+func (this *DesireLRPsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DesireLRPsRequest{`,
+		`DesiredLrps:` + strings.Replace(fmt.Sprintf("%v", this.DesiredLrps), "DesiredLRP", "DesiredLRP", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *UpdateDesiredLRPRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -1166,6 +1534,17 @@ func (this *UpdateDesiredLRPRequest) String() string {
 	}, "")
 	return s
 }
+func (this *UpdateDesiredLRPRoutesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateDesiredLRPRoutesRequest{`,
+		`ProcessGuid:` + fmt.Sprintf("%v", this.ProcessGuid) + `,`,
+		`Routes:` + valueToStringDesiredLrpRequests(this.Routes) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *RemoveDesiredLRPRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -1176,6 +1555,17 @@ func (this *RemoveDesiredLRPRequest) String() string {
 	}, "")
 	return s
 }
+func (this *DesiredLRPProcessGuidsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DesiredLRPProcessGuidsResponse{`,
+		`Error:` + strings.Replace(fmt.Sprintf("%v", this.Error), "Error", "Error", 1) + `,`,
+		`ProcessGuids:` + fmt.Sprintf("%v", this.ProcessGuids) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringDesiredLrpRequests(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -1881,6 +2271,87 @@ func (m *DesireLRPRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DesireLRPsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDesiredLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DesireLRPsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DesireLRPsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DesiredLrps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDesiredLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDesiredLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DesiredLrps = append(m.DesiredLrps, &DesiredLRP{})
+			if err := m.DesiredLrps[len(m.DesiredLrps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDesiredLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDesiredLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *UpdateDesiredLRPRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1993,6 +2464,118 @@ func (m *UpdateDesiredLRPRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *UpdateDesiredLRPRoutesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDesiredLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateDesiredLRPRoutesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateDesiredLRPRoutesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessGuid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDesiredLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDesiredLrpRequests
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessGuid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDesiredLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDesiredLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Routes == nil {
+				m.Routes = &Routes{}
+			}
+			if err := m.Routes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDesiredLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDesiredLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *RemoveDesiredLRPRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2072,6 +2655,118 @@ func (m *RemoveDesiredLRPRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *DesiredLRPProcessGuidsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDesiredLrpRequests
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DesiredLRPProcessGuidsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DesiredLRPProcessGuidsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDesiredLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDesiredLrpRequests
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &Error{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessGuids", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDesiredLrpRequests
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDesiredLrpRequests
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProcessGuids = append(m.ProcessGuids, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDesiredLrpRequests(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDesiredLrpRequests
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipDesiredLrpRequests(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2180,33 +2875,37 @@ var (
 func init() { proto.RegisterFile("desired_lrp_requests.proto", fileDescriptorDesiredLrpRequests) }
 
 var fileDescriptorDesiredLrpRequests = []byte{
-	// 445 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x52, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0xf5, 0x16, 0x88, 0xd4, 0x71, 0x2a, 0xc1, 0x72, 0xa8, 0x09, 0xd5, 0x12, 0xb6, 0x07, 0x7a,
-	0x00, 0x17, 0x15, 0xf1, 0x03, 0x16, 0xa8, 0xaa, 0x94, 0x43, 0xb5, 0x08, 0x71, 0xb4, 0x52, 0x7b,
-	0xe3, 0x5a, 0xb2, 0xbd, 0xee, 0xae, 0x8d, 0xd4, 0x1b, 0x9f, 0xc0, 0x67, 0x20, 0xf1, 0x23, 0x3d,
-	0xf6, 0xc8, 0x09, 0x11, 0x73, 0xe1, 0x98, 0x4f, 0x40, 0xd9, 0x75, 0xf0, 0x26, 0xe1, 0x80, 0xc5,
-	0xcd, 0x3b, 0xf3, 0xe6, 0xbd, 0x37, 0x6f, 0x0c, 0xa3, 0x98, 0xab, 0x54, 0xf2, 0x38, 0xcc, 0x64,
-	0x19, 0x4a, 0x7e, 0x55, 0x73, 0x55, 0x29, 0xbf, 0x94, 0xa2, 0x12, 0x78, 0x90, 0x8b, 0x98, 0x67,
-	0x6a, 0xf4, 0x22, 0x49, 0xab, 0xcb, 0xfa, 0xc2, 0x8f, 0x44, 0x7e, 0x9c, 0x88, 0x44, 0x1c, 0xeb,
-	0xf6, 0x45, 0x3d, 0xd3, 0x2f, 0xfd, 0xd0, 0x5f, 0x66, 0x6c, 0xf4, 0xc0, 0xa2, 0x6c, 0x4b, 0x2e,
-	0x97, 0x52, 0x48, 0xf3, 0xa0, 0x01, 0x3c, 0x7e, 0x63, 0x10, 0x13, 0x76, 0x3e, 0x49, 0x67, 0x3c,
-	0xba, 0x8e, 0x32, 0xce, 0xb8, 0x2a, 0x45, 0xa1, 0x38, 0x3e, 0x84, 0x7b, 0x1a, 0xed, 0xa1, 0x31,
-	0x3a, 0x72, 0x4f, 0xf6, 0x7c, 0xe3, 0xc2, 0x7f, 0xbb, 0x2c, 0x32, 0xd3, 0xa3, 0x57, 0xf0, 0xb0,
-	0xe3, 0x50, 0xbd, 0x66, 0xf1, 0x6b, 0x18, 0x5a, 0x0e, 0x95, 0xb7, 0x33, 0xbe, 0x73, 0xe4, 0x9e,
-	0xe0, 0x15, 0xb6, 0xe3, 0x65, 0x6e, 0x8b, 0x9b, 0xc8, 0x52, 0xd1, 0x0f, 0x80, 0xd7, 0x24, 0x75,
-	0x54, 0xf8, 0x00, 0x06, 0xb1, 0xc8, 0xa7, 0x69, 0xa1, 0x25, 0x77, 0x83, 0xbb, 0x37, 0xdf, 0x9f,
-	0x38, 0xac, 0xad, 0xe1, 0x43, 0xd8, 0x2b, 0xa5, 0x88, 0xb8, 0x52, 0x61, 0x52, 0xa7, 0xb1, 0xd1,
-	0xda, 0x65, 0xc3, 0xb6, 0x78, 0xba, 0xac, 0xd1, 0xc2, 0x26, 0xee, 0xb7, 0xca, 0x2b, 0x70, 0xad,
-	0x55, 0xbc, 0x1d, 0x0d, 0xfd, 0xdb, 0x26, 0xd0, 0x6d, 0x42, 0xbf, 0x22, 0x78, 0xda, 0xb5, 0xde,
-	0x45, 0x97, 0x3c, 0xae, 0xb3, 0xb4, 0x48, 0xce, 0x8a, 0x99, 0xe8, 0x19, 0xe5, 0x14, 0x0e, 0xec,
-	0xff, 0x47, 0xfd, 0xe1, 0x0a, 0xd3, 0x25, 0x59, 0x1b, 0xed, 0x78, 0xdb, 0xd0, 0xba, 0x2a, 0x7b,
-	0xd4, 0xd9, 0xdb, 0xf0, 0x43, 0xcf, 0x80, 0x74, 0x63, 0xc1, 0xf5, 0x79, 0x97, 0xdc, 0xea, 0x04,
-	0xcf, 0x60, 0x68, 0x87, 0xbc, 0x76, 0x08, 0xd7, 0x4a, 0x9a, 0x9e, 0xc2, 0x7d, 0x43, 0xa5, 0x73,
-	0x36, 0xc3, 0x1b, 0x09, 0xa2, 0x7f, 0x4a, 0xb0, 0x82, 0xfd, 0xf7, 0x65, 0x3c, 0xad, 0xb8, 0x7d,
-	0xb7, 0x7e, 0x66, 0xf0, 0x4b, 0x18, 0xd4, 0x9a, 0xa3, 0xbd, 0x9a, 0xb7, 0xad, 0x69, 0x34, 0x58,
-	0x8b, 0xa3, 0x01, 0xec, 0x33, 0x9e, 0x8b, 0x8f, 0xff, 0xa1, 0x1a, 0x3c, 0xbf, 0x9d, 0x13, 0xe7,
-	0xdb, 0x9c, 0x38, 0x8b, 0x39, 0x41, 0x9f, 0x1a, 0x82, 0xbe, 0x34, 0x04, 0xdd, 0x34, 0x04, 0xdd,
-	0x36, 0x04, 0xfd, 0x68, 0x08, 0xfa, 0xd5, 0x10, 0x67, 0xd1, 0x10, 0xf4, 0xf9, 0x27, 0x71, 0x7e,
-	0x07, 0x00, 0x00, 0xff, 0xff, 0x41, 0x49, 0x15, 0xcc, 0x1d, 0x04, 0x00, 0x00,
+	// 511 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x31, 0x6f, 0xd3, 0x40,
+	0x14, 0xf6, 0x15, 0xb0, 0xd4, 0xe7, 0x14, 0x51, 0x77, 0x68, 0x08, 0xe5, 0x12, 0xae, 0x03, 0x1d,
+	0x20, 0x45, 0x45, 0x4c, 0x6c, 0x16, 0xa8, 0x2a, 0xca, 0x10, 0x19, 0x21, 0xc6, 0x28, 0xb5, 0x2f,
+	0xae, 0x51, 0xe2, 0x73, 0xef, 0x6c, 0xa4, 0x6e, 0x2c, 0xec, 0xfc, 0x0c, 0x24, 0xfe, 0x48, 0xc6,
+	0x8e, 0x88, 0x21, 0x22, 0x66, 0x61, 0xec, 0x4f, 0x40, 0xb9, 0xbb, 0xc4, 0x97, 0x44, 0x48, 0xb1,
+	0xba, 0xf9, 0xde, 0xbd, 0xf7, 0x7d, 0xef, 0xfb, 0xfc, 0x1d, 0x34, 0x42, 0x2a, 0x62, 0x4e, 0xc3,
+	0xde, 0x90, 0xa7, 0x3d, 0x4e, 0x2f, 0x73, 0x2a, 0x32, 0xd1, 0x4e, 0x39, 0xcb, 0x98, 0x6b, 0x8f,
+	0x58, 0x48, 0x87, 0xa2, 0xf1, 0x3c, 0x8a, 0xb3, 0x8b, 0xfc, 0xbc, 0x1d, 0xb0, 0xd1, 0x71, 0xc4,
+	0x22, 0x76, 0x2c, 0xaf, 0xcf, 0xf3, 0x81, 0x3c, 0xc9, 0x83, 0xfc, 0x52, 0x63, 0x8d, 0x5d, 0x03,
+	0x52, 0x97, 0x1c, 0xca, 0x39, 0xe3, 0xea, 0x40, 0x3c, 0x78, 0xf4, 0x46, 0x75, 0x74, 0xfc, 0x6e,
+	0x27, 0x1e, 0xd0, 0xe0, 0x2a, 0x18, 0x52, 0x9f, 0x8a, 0x94, 0x25, 0x82, 0xba, 0x87, 0x70, 0x4f,
+	0x76, 0xd7, 0x51, 0x0b, 0x1d, 0x39, 0x27, 0x3b, 0x6d, 0xb5, 0x45, 0xfb, 0xed, 0xac, 0xe8, 0xab,
+	0x3b, 0x72, 0x09, 0x7b, 0x25, 0x86, 0xa8, 0x34, 0xeb, 0xbe, 0x82, 0x9a, 0xb1, 0xa1, 0xa8, 0x6f,
+	0xb5, 0xee, 0x1c, 0x39, 0x27, 0xee, 0xbc, 0xb7, 0xc4, 0xf5, 0x1d, 0xdd, 0xd7, 0xe1, 0xa9, 0x20,
+	0x1f, 0xc1, 0x5d, 0xa2, 0x94, 0x56, 0xb9, 0x07, 0x60, 0x87, 0x6c, 0xd4, 0x8f, 0x13, 0x49, 0xb9,
+	0xed, 0xdd, 0x1d, 0x4f, 0x9a, 0x96, 0xaf, 0x6b, 0xee, 0x21, 0xec, 0xa4, 0x9c, 0x05, 0x54, 0x88,
+	0x5e, 0x94, 0xc7, 0xa1, 0xe2, 0xda, 0xf6, 0x6b, 0xba, 0x78, 0x3a, 0xab, 0x91, 0xc4, 0x04, 0xae,
+	0x26, 0xe5, 0x25, 0x38, 0x86, 0x94, 0xfa, 0x56, 0x0b, 0xfd, 0x47, 0x09, 0x94, 0x4a, 0xc8, 0x0f,
+	0x04, 0x4f, 0xca, 0xab, 0xf7, 0xc1, 0x05, 0x0d, 0xf3, 0x61, 0x9c, 0x44, 0x67, 0xc9, 0x80, 0x55,
+	0xb4, 0xb2, 0x0f, 0x07, 0x66, 0x7e, 0xc4, 0x02, 0xab, 0x17, 0xcf, 0xc0, 0xb4, 0xb5, 0xad, 0xf5,
+	0x85, 0x96, 0x59, 0xfd, 0x87, 0xe5, 0x7a, 0x2b, 0xfb, 0x90, 0x33, 0xc0, 0xe5, 0x98, 0x77, 0xd5,
+	0x2d, 0x9d, 0x9b, 0xff, 0x82, 0xa7, 0x50, 0x33, 0x4d, 0x5e, 0xfa, 0x11, 0x8e, 0xe1, 0x34, 0x39,
+	0x85, 0x07, 0x0a, 0x4a, 0xfa, 0xac, 0x86, 0x57, 0x1c, 0x44, 0x1b, 0x39, 0xf8, 0x0e, 0x76, 0x17,
+	0x40, 0x8b, 0x24, 0xac, 0xc6, 0x0a, 0x6d, 0x16, 0xab, 0x0c, 0xf6, 0x3f, 0xa4, 0x61, 0x3f, 0xa3,
+	0x46, 0x43, 0x45, 0x61, 0xee, 0x0b, 0xb0, 0x73, 0x89, 0xa1, 0x13, 0x50, 0x5f, 0x27, 0x55, 0x1c,
+	0xbe, 0xee, 0x23, 0x5f, 0x11, 0x3c, 0x5e, 0xa3, 0x65, 0x79, 0x46, 0x45, 0x65, 0xf2, 0xd7, 0x60,
+	0x73, 0x39, 0xa9, 0xc9, 0xf7, 0xe6, 0xe4, 0xdd, 0xd9, 0x6b, 0x57, 0xa0, 0xde, 0xfd, 0xf1, 0xa4,
+	0x89, 0x7e, 0x4d, 0x9a, 0xb6, 0x26, 0xd1, 0x23, 0xc4, 0x83, 0x7d, 0x9f, 0x8e, 0xd8, 0xe7, 0x5b,
+	0xa8, 0x27, 0x9f, 0xcc, 0x84, 0x18, 0xf9, 0xa8, 0x98, 0xe5, 0x4d, 0xde, 0xaa, 0xf7, 0xec, 0x7a,
+	0x8a, 0xad, 0x9f, 0x53, 0x6c, 0xdd, 0x4c, 0x31, 0xfa, 0x52, 0x60, 0xf4, 0xbd, 0xc0, 0x68, 0x5c,
+	0x60, 0x74, 0x5d, 0x60, 0xf4, 0xbb, 0xc0, 0xe8, 0x6f, 0x81, 0xad, 0x9b, 0x02, 0xa3, 0x6f, 0x7f,
+	0xb0, 0xf5, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xa4, 0x55, 0x65, 0xce, 0x5d, 0x05, 0x00, 0x00,
 }
@@ -1,6 +1,9 @@
 package models_test
 
 import (
+	"encoding/json"
+	"strings"
+
 	"code.cloudfoundry.org/bbs/models"
 	"code.cloudfoundry.org/bbs/models/test/model_helpers"
 
@@ -72,6 +75,77 @@ var _ = Describe("DesiredLRP Requests", func() {
 					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"process_guid"}))
 				})
 			})
+
+			Context("when an image source allowlist is set", func() {
+				BeforeEach(func() {
+					models.SetImageSourceAllowlist([]string{"docker"}, []string{"registry.example.com"})
+				})
+
+				AfterEach(func() {
+					models.SetImageSourceAllowlist(nil, nil)
+				})
+
+				It("accepts a rootfs from an allowed source", func() {
+					request.DesiredLrp.RootFs = "docker://registry.example.com/app"
+					Expect(request.Validate()).To(BeNil())
+				})
+
+				It("rejects a rootfs from a source that is not allowed", func() {
+					request.DesiredLrp.RootFs = "docker://untrusted.example.com/app"
+					Expect(request.Validate()).To(ConsistOf(models.ErrDisallowedImageSource{RootFs: "docker://untrusted.example.com/app"}))
+				})
+			})
+		})
+	})
+
+	Describe("DesireLRPsRequest", func() {
+		Describe("Validate", func() {
+			var request models.DesireLRPsRequest
+
+			BeforeEach(func() {
+				request = models.DesireLRPsRequest{
+					DesiredLrps: []*models.DesiredLRP{
+						model_helpers.NewValidDesiredLRP("web-guid"),
+						model_helpers.NewValidDesiredLRP("worker-guid"),
+					},
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when there are no DesiredLRPs", func() {
+				BeforeEach(func() {
+					request.DesiredLrps = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"desired_lrps"}))
+				})
+			})
+
+			Context("when one of the DesiredLRPs is blank", func() {
+				BeforeEach(func() {
+					request.DesiredLrps[1] = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"desired_lrp"}))
+				})
+			})
+
+			Context("when one of the DesiredLRPs is invalid", func() {
+				BeforeEach(func() {
+					request.DesiredLrps[0].ProcessGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"process_guid"}))
+				})
+			})
 		})
 	})
 
@@ -116,6 +190,57 @@ var _ = Describe("DesiredLRP Requests", func() {
 		})
 	})
 
+	Describe("UpdateDesiredLRPRoutesRequest", func() {
+		Describe("Validate", func() {
+			var request models.UpdateDesiredLRPRoutesRequest
+
+			BeforeEach(func() {
+				routeContent := json.RawMessage(`{"port":8080}`)
+				request = models.UpdateDesiredLRPRoutesRequest{
+					ProcessGuid: "some-guid",
+					Routes:      &models.Routes{"router": &routeContent},
+				}
+			})
+
+			Context("when valid", func() {
+				It("returns nil", func() {
+					Expect(request.Validate()).To(BeNil())
+				})
+			})
+
+			Context("when the ProcessGuid is blank", func() {
+				BeforeEach(func() {
+					request.ProcessGuid = ""
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"process_guid"}))
+				})
+			})
+
+			Context("when the Routes are missing", func() {
+				BeforeEach(func() {
+					request.Routes = nil
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"routes"}))
+				})
+			})
+
+			Context("when the Routes are too long", func() {
+				BeforeEach(func() {
+					routeContent := json.RawMessage(`"` + strings.Repeat("x", 128*1024) + `"`)
+					request.Routes = &models.Routes{"router": &routeContent}
+				})
+
+				It("returns a validation error", func() {
+					Expect(request.Validate()).To(ConsistOf(models.ErrInvalidField{"routes"}))
+				})
+			})
+		})
+	})
+
 	Describe("RemoveDesiredLRPRequest", func() {
 		Describe("Validate", func() {
 			var request models.RemoveDesiredLRPRequest
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/bbs/format"
@@ -288,6 +289,15 @@ var _ = Describe("DesiredLRP", func() {
 			_, runInfo := desiredLRP.CreateComponents(time.Unix(123, 456))
 			Expect(runInfo.CreatedAt).To(BeEquivalentTo((time.Unix(123, 456).UnixNano())))
 		})
+
+		It("keeps convergence suppression on the scheduling info", func() {
+			desiredLRP.SuppressConvergence = true
+			schedInfo, runInfo := desiredLRP.CreateComponents(time.Unix(123, 456))
+			Expect(schedInfo.SuppressConvergence).To(BeTrue())
+
+			newDesired := models.NewDesiredLRP(schedInfo, runInfo)
+			Expect(newDesired.SuppressConvergence).To(BeTrue())
+		})
 	})
 
 	Describe("serialization", func() {
@@ -400,6 +410,244 @@ var _ = Describe("DesiredLRP", func() {
 		})
 	})
 
+	Describe("Copy", func() {
+		var original, copied *models.DesiredLRP
+
+		BeforeEach(func() {
+			original = model_helpers.NewValidDesiredLRP("some-guid")
+			copied = original.Copy()
+		})
+
+		It("returns an equal desired lrp", func() {
+			Expect(copied).To(Equal(original))
+		})
+
+		It("does not share environment variables with the original", func() {
+			copied.EnvironmentVariables[0].Value = "changed"
+			copied.EnvironmentVariables = append(copied.EnvironmentVariables, &models.EnvironmentVariable{Name: "NEW", Value: "var"})
+
+			Expect(original.EnvironmentVariables).To(Equal([]*models.EnvironmentVariable{{Name: "FOO", Value: "bar"}}))
+		})
+
+		It("does not share routes with the original", func() {
+			newRoute := json.RawMessage(`{"new":"route"}`)
+			(*copied.Routes)["other-router"] = &newRoute
+			(*(*copied.Routes)["my-router"])[2] = 'X'
+
+			Expect(*original.Routes).To(HaveLen(1))
+			Expect(string(*(*original.Routes)["my-router"])).To(Equal(`{"foo":"bar"}`))
+		})
+
+		It("does not share actions with the original", func() {
+			copied.Setup.RunAction.Path = "changed"
+			copied.Action.RunAction.User = "changed"
+			copied.Monitor.SetDeprecatedTimeoutNs()
+
+			Expect(original.Setup.RunAction.Path).To(Equal("ls"))
+			Expect(original.Action.RunAction.User).To(Equal("name"))
+			Expect(original.Monitor.GetEmitProgressAction().Action.GetTimeoutAction().DeprecatedTimeoutNs).To(BeZero())
+		})
+
+		It("does not share the modification tag with the original", func() {
+			copied.ModificationTag.Increment()
+			Expect(original.ModificationTag.Index).To(BeEquivalentTo(0))
+		})
+
+		It("does not share egress rules or cached dependencies with the original", func() {
+			copied.EgressRules[0].Destinations[0] = "9.9.9.9/32"
+			copied.EgressRules[0].PortRange.End = 1
+			copied.CachedDependencies[0].From = "changed"
+
+			Expect(original.EgressRules[0].Destinations).To(Equal([]string{"1.1.1.1/32", "2.2.2.2/32"}))
+			Expect(original.EgressRules[0].PortRange.End).To(BeEquivalentTo(16000))
+			Expect(original.CachedDependencies[0].From).To(Equal("blobstore.com/bits/app-bits"))
+		})
+
+		It("does not share volume mounts or the network with the original", func() {
+			copied.VolumeMounts[0].Shared.VolumeId = "changed"
+			copied.Network.Properties["some-key"] = "changed"
+
+			Expect(original.VolumeMounts[0].Shared.VolumeId).To(Equal("my-volume"))
+			Expect(original.Network.Properties).To(HaveKeyWithValue("some-key", "some-value"))
+		})
+
+		It("does not share certificate properties or the check definition with the original", func() {
+			copied.CertificateProperties.OrganizationalUnit[0] = "changed"
+			copied.CheckDefinition.Checks[0].HttpCheck.Path = "/changed"
+
+			Expect(original.CertificateProperties.OrganizationalUnit).To(Equal([]string{"iamthelizardking", "iamthelizardqueen"}))
+			Expect(original.CheckDefinition.Checks[0].HttpCheck.Path).To(Equal("/healthcheck"))
+		})
+	})
+
+	Describe("IsValidIndex", func() {
+		var lrp *models.DesiredLRP
+
+		BeforeEach(func() {
+			lrp = &models.DesiredLRP{ProcessGuid: "some-guid", Instances: 3}
+		})
+
+		It("rejects negative indices", func() {
+			Expect(lrp.IsValidIndex(-1)).To(BeFalse())
+		})
+
+		It("accepts indices within the desired instances", func() {
+			Expect(lrp.IsValidIndex(0)).To(BeTrue())
+			Expect(lrp.IsValidIndex(2)).To(BeTrue())
+		})
+
+		It("rejects the index at the desired instance count and beyond", func() {
+			Expect(lrp.IsValidIndex(3)).To(BeFalse())
+			Expect(lrp.IsValidIndex(4)).To(BeFalse())
+		})
+
+		Context("when no instances are desired", func() {
+			It("rejects every index", func() {
+				lrp.Instances = 0
+				Expect(lrp.IsValidIndex(0)).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("IsHealthy", func() {
+		var lrp *models.DesiredLRP
+
+		actualGroup := func(index int32, state string, crashCount int32) *models.ActualLRPGroup {
+			actual := &models.ActualLRP{
+				ActualLRPKey: models.NewActualLRPKey("some-guid", index, "some-domain"),
+				State:        state,
+				CrashCount:   crashCount,
+			}
+			return &models.ActualLRPGroup{Instance: actual}
+		}
+
+		BeforeEach(func() {
+			lrp = model_helpers.NewValidDesiredLRP("some-guid")
+			lrp.Instances = 2
+		})
+
+		It("is healthy when every instance is running", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, 0),
+				actualGroup(1, models.ActualLRPStateRunning, 0),
+			}
+			Expect(lrp.IsHealthy(actuals)).To(BeTrue())
+		})
+
+		It("counts running evacuating instances", func() {
+			evacuating := actualGroup(1, models.ActualLRPStateRunning, 0)
+			evacuating.Evacuating, evacuating.Instance = evacuating.Instance, nil
+
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, 0),
+				evacuating,
+			}
+			Expect(lrp.IsHealthy(actuals)).To(BeTrue())
+		})
+
+		It("is unhealthy when an instance is missing", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, 0),
+			}
+			Expect(lrp.IsHealthy(actuals)).To(BeFalse())
+		})
+
+		It("is unhealthy when an instance is not yet running", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, 0),
+				actualGroup(1, models.ActualLRPStateClaimed, 0),
+			}
+			Expect(lrp.IsHealthy(actuals)).To(BeFalse())
+		})
+
+		It("is unhealthy when an instance is crash-looping", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, 0),
+				actualGroup(1, models.ActualLRPStateCrashed, 3),
+			}
+			Expect(lrp.IsHealthy(actuals)).To(BeFalse())
+		})
+
+		It("is unhealthy when a running instance has crashed before", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, 0),
+				actualGroup(1, models.ActualLRPStateRunning, 1),
+			}
+			Expect(lrp.IsHealthy(actuals)).To(BeFalse())
+		})
+
+		It("ignores extra instances beyond the desired count", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, 0),
+				actualGroup(1, models.ActualLRPStateRunning, 0),
+				actualGroup(2, models.ActualLRPStateUnclaimed, 0),
+			}
+			Expect(lrp.IsHealthy(actuals)).To(BeTrue())
+		})
+	})
+
+	Describe("UpdateProgress", func() {
+		var lrp *models.DesiredLRP
+
+		actualGroup := func(index int32, state, annotation string) *models.ActualLRPGroup {
+			actual := &models.ActualLRP{
+				ActualLRPKey: models.NewActualLRPKey("some-guid", index, "some-domain"),
+				State:        state,
+				Annotation:   annotation,
+			}
+			return &models.ActualLRPGroup{Instance: actual}
+		}
+
+		BeforeEach(func() {
+			lrp = model_helpers.NewValidDesiredLRP("some-guid")
+			lrp.Instances = 3
+			lrp.Annotation = "version-2"
+		})
+
+		It("reports every instance when the update has fully rolled out", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, "version-2"),
+				actualGroup(1, models.ActualLRPStateRunning, "version-2"),
+				actualGroup(2, models.ActualLRPStateRunning, "version-2"),
+			}
+			current, target := lrp.UpdateProgress(actuals)
+			Expect(current).To(Equal(3))
+			Expect(target).To(Equal(3))
+		})
+
+		It("reports the running instances on the desired version when the update is partially rolled out", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, "version-2"),
+				actualGroup(1, models.ActualLRPStateClaimed, "version-2"),
+				actualGroup(2, models.ActualLRPStateRunning, "version-1"),
+			}
+			current, target := lrp.UpdateProgress(actuals)
+			Expect(current).To(Equal(1))
+			Expect(target).To(Equal(3))
+		})
+
+		It("reports no instances when the update has not started", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, "version-1"),
+				actualGroup(1, models.ActualLRPStateRunning, "version-1"),
+				actualGroup(2, models.ActualLRPStateRunning, "version-1"),
+			}
+			current, target := lrp.UpdateProgress(actuals)
+			Expect(current).To(Equal(0))
+			Expect(target).To(Equal(3))
+		})
+
+		It("ignores extra instances beyond the desired count", func() {
+			actuals := []*models.ActualLRPGroup{
+				actualGroup(0, models.ActualLRPStateRunning, "version-2"),
+				actualGroup(3, models.ActualLRPStateRunning, "version-2"),
+			}
+			current, target := lrp.UpdateProgress(actuals)
+			Expect(current).To(Equal(1))
+			Expect(target).To(Equal(3))
+		})
+	})
+
 	Describe("Version Down To", func() {
 		Context("V1", func() {
 			BeforeEach(func() {
@@ -567,6 +815,59 @@ var _ = Describe("DesiredLRP", func() {
 		})
 	})
 
+	Describe("ValidateImageSources", func() {
+		var (
+			allowedSchemes []string
+			allowedHosts   []string
+		)
+
+		BeforeEach(func() {
+			allowedSchemes = []string{"docker", models.PreloadedRootFSScheme}
+			allowedHosts = []string{"registry.example.com"}
+		})
+
+		It("allows a rootfs from an allowed host", func() {
+			desiredLRP.RootFs = "docker://registry.example.com:5000/cloudfoundry/app"
+			Expect(desiredLRP.ValidateImageSources(allowedSchemes, allowedHosts)).To(Succeed())
+		})
+
+		It("rejects a rootfs from a host that is not allowed", func() {
+			desiredLRP.RootFs = "docker://untrusted.example.com/cloudfoundry/app"
+			err := desiredLRP.ValidateImageSources(allowedSchemes, allowedHosts)
+			Expect(err).To(Equal(models.ErrDisallowedImageSource{RootFs: "docker://untrusted.example.com/cloudfoundry/app"}))
+		})
+
+		It("allows a preloaded rootfs, which has no host", func() {
+			desiredLRP.RootFs = "preloaded:cflinuxfs2"
+			Expect(desiredLRP.ValidateImageSources(allowedSchemes, allowedHosts)).To(Succeed())
+		})
+
+		It("allows a preloaded layer rootfs, which has no registry", func() {
+			desiredLRP.RootFs = "preloaded+layer:cflinuxfs2?layer=https://blobstore.internal/layer.tgz"
+			Expect(desiredLRP.ValidateImageSources([]string{"preloaded+layer"}, allowedHosts)).To(Succeed())
+		})
+
+		It("checks a rootfs without a host as coming from the default registry", func() {
+			desiredLRP.RootFs = "docker:///library/ubuntu"
+			err := desiredLRP.ValidateImageSources(allowedSchemes, allowedHosts)
+			Expect(err).To(Equal(models.ErrDisallowedImageSource{RootFs: "docker:///library/ubuntu"}))
+
+			allowedHosts = append(allowedHosts, models.DefaultImageRegistryHost)
+			Expect(desiredLRP.ValidateImageSources(allowedSchemes, allowedHosts)).To(Succeed())
+		})
+
+		It("rejects a rootfs with a scheme that is not allowed", func() {
+			desiredLRP.RootFs = "preloaded:cflinuxfs2"
+			err := desiredLRP.ValidateImageSources([]string{"docker"}, allowedHosts)
+			Expect(err).To(Equal(models.ErrDisallowedImageSource{RootFs: "preloaded:cflinuxfs2"}))
+		})
+
+		It("allows any rootfs without an allowlist", func() {
+			desiredLRP.RootFs = "docker://untrusted.example.com/cloudfoundry/app"
+			Expect(desiredLRP.ValidateImageSources(nil, nil)).To(Succeed())
+		})
+	})
+
 	Describe("Validate", func() {
 		var assertDesiredLRPValidationFailsWithMessage = func(lrp models.DesiredLRP, substring string) {
 			validationErr := lrp.Validate()
@@ -702,6 +1003,43 @@ var _ = Describe("DesiredLRP", func() {
 			assertDesiredLRPValidationFailsWithMessage(desiredLRP, "annotation")
 		})
 
+		Context("when a maximum route count is set", func() {
+			setRoutes := func(count int) {
+				routes := make([]string, count)
+				for i := range routes {
+					routes[i] = fmt.Sprintf(`{"hostnames":["host-%d"],"port":8080}`, i)
+				}
+				raw := json.RawMessage("[" + strings.Join(routes, ",") + "]")
+				desiredLRP.Routes = &models.Routes{"cf-router": &raw}
+			}
+
+			BeforeEach(func() {
+				models.SetMaximumRouteCount(3)
+			})
+
+			AfterEach(func() {
+				models.SetMaximumRouteCount(0)
+			})
+
+			It("allows exactly the maximum number of routes", func() {
+				setRoutes(3)
+				Expect(desiredLRP.Validate()).To(Succeed())
+			})
+
+			It("rejects more routes than the maximum", func() {
+				setRoutes(4)
+				err := desiredLRP.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("routes has 4 routes, more than the maximum of 3"))
+			})
+
+			It("allows any number of routes once the maximum is removed", func() {
+				models.SetMaximumRouteCount(0)
+				setRoutes(4)
+				Expect(desiredLRP.Validate()).To(Succeed())
+			})
+		})
+
 		Context("when security group is present", func() {
 			It("must be valid", func() {
 				desiredLRP.EgressRules = []*models.SecurityGroupRule{{
@@ -732,6 +1070,17 @@ var _ = Describe("DesiredLRP", func() {
 				assertDesiredLRPValidationFailsWithMessage(desiredLRP, "cached_dependency")
 			})
 
+			It("requires them to be downloadable", func() {
+				desiredLRP.CachedDependencies = []*models.CachedDependency{
+					{
+						To:   "here",
+						From: "file:///etc/passwd",
+					},
+				}
+				desiredLRP.LegacyDownloadUser = "user"
+				assertDesiredLRPValidationFailsWithMessage(desiredLRP, "from")
+			})
+
 			It("requires a legacy download user", func() {
 				desiredLRP.CachedDependencies = []*models.CachedDependency{
 					{
@@ -793,6 +1142,85 @@ var _ = Describe("DesiredLRP", func() {
 				assertDesiredLRPValidationFailsWithMessage(desiredLRP, "image_username")
 			})
 		})
+
+		Context("when volume mounts are specified", func() {
+			var mount = func(driver, containerDir string) *models.VolumeMount {
+				return &models.VolumeMount{
+					Driver:       driver,
+					ContainerDir: containerDir,
+					Mode:         "rw",
+					Shared:       &models.SharedDevice{VolumeId: "my-volume"},
+				}
+			}
+
+			It("is valid when every mount has a driver and its own container path", func() {
+				desiredLRP.VolumeMounts = []*models.VolumeMount{
+					mount("my-driver", "/mnt/a"),
+					mount("my-driver", "/mnt/b"),
+				}
+				Expect(desiredLRP.Validate()).To(Succeed())
+			})
+
+			It("is invalid when two mounts share a container path", func() {
+				desiredLRP.VolumeMounts = []*models.VolumeMount{
+					mount("my-driver", "/mnt/a"),
+					mount("other-driver", "/mnt/a"),
+				}
+				Expect(desiredLRP.Validate()).To(ConsistOf(models.ErrDuplicateContainerDir{ContainerDir: "/mnt/a"}))
+			})
+
+			It("is invalid when a mount has no driver", func() {
+				desiredLRP.VolumeMounts = []*models.VolumeMount{
+					mount("", "/mnt/a"),
+				}
+				assertDesiredLRPValidationFailsWithMessage(desiredLRP, "invalid volume_mount driver")
+			})
+		})
+	})
+})
+
+var _ = Describe("FleetResourceTotals", func() {
+	newDesiredLRP := func(processGuid string, instances, memoryMb, diskMb int32) *models.DesiredLRP {
+		desiredLRP := model_helpers.NewValidDesiredLRP(processGuid)
+		desiredLRP.Instances = instances
+		desiredLRP.MemoryMb = memoryMb
+		desiredLRP.DiskMb = diskMb
+		return desiredLRP
+	}
+
+	It("returns zero totals for no DesiredLRPs", func() {
+		Expect(models.FleetResourceTotals(nil)).To(Equal(models.ResourceTotals{}))
+	})
+
+	It("multiplies the resources of each DesiredLRP by its instance count", func() {
+		totals := models.FleetResourceTotals([]*models.DesiredLRP{
+			newDesiredLRP("small", 3, 128, 256),
+		})
+
+		Expect(totals).To(Equal(models.ResourceTotals{Instances: 3, MemoryMb: 384, DiskMb: 768}))
+	})
+
+	It("sums across DesiredLRPs with different instance counts", func() {
+		totals := models.FleetResourceTotals([]*models.DesiredLRP{
+			newDesiredLRP("small", 3, 128, 256),
+			newDesiredLRP("large", 2, 1024, 2048),
+			newDesiredLRP("stopped", 0, 512, 512),
+			nil,
+		})
+
+		Expect(totals).To(Equal(models.ResourceTotals{
+			Instances: 5,
+			MemoryMb:  3*128 + 2*1024,
+			DiskMb:    3*256 + 2*2048,
+		}))
+	})
+
+	It("does not overflow for large fleets", func() {
+		totals := models.FleetResourceTotals([]*models.DesiredLRP{
+			newDesiredLRP("huge", 100000, 1<<20, 1<<20),
+		})
+
+		Expect(totals.MemoryMb).To(BeEquivalentTo(int64(100000) * (1 << 20)))
 	})
 })
 
@@ -948,6 +1376,79 @@ var _ = Describe("DesiredLRPSchedulingInfo", func() {
 		Entry("invalid resource", models.NewDesiredLRPSchedulingInfo(newValidLRPKey(), annotation, instances, models.DesiredLRPResource{}, routes, tag, nil, nil), "rootfs"),
 		Entry("invalid routes", models.NewDesiredLRPSchedulingInfo(newValidLRPKey(), annotation, instances, newValidResource(), largeRoutes, tag, nil, nil), "routes"),
 	)
+
+	Describe("DiffSchedulingInfos", func() {
+		var unchanged, updated, removed, added *models.DesiredLRPSchedulingInfo
+
+		newInfo := func(processGuid string, instances int32) *models.DesiredLRPSchedulingInfo {
+			info := model_helpers.NewValidDesiredLRP(processGuid).DesiredLRPSchedulingInfo()
+			info.Instances = instances
+			return &info
+		}
+
+		BeforeEach(func() {
+			unchanged = newInfo("unchanged", 1)
+			updated = newInfo("updated", 1)
+			removed = newInfo("removed", 1)
+			added = newInfo("added", 1)
+		})
+
+		It("reports nothing for identical snapshots", func() {
+			a, r, c := models.DiffSchedulingInfos(
+				[]*models.DesiredLRPSchedulingInfo{unchanged, updated},
+				[]*models.DesiredLRPSchedulingInfo{newInfo("unchanged", 1), newInfo("updated", 1)},
+			)
+			Expect(a).To(BeEmpty())
+			Expect(r).To(BeEmpty())
+			Expect(c).To(BeEmpty())
+		})
+
+		It("reports scheduling infos only in the new snapshot as added", func() {
+			a, r, c := models.DiffSchedulingInfos(
+				[]*models.DesiredLRPSchedulingInfo{unchanged},
+				[]*models.DesiredLRPSchedulingInfo{unchanged, added},
+			)
+			Expect(a).To(Equal([]*models.DesiredLRPSchedulingInfo{added}))
+			Expect(r).To(BeEmpty())
+			Expect(c).To(BeEmpty())
+		})
+
+		It("reports scheduling infos only in the old snapshot as removed", func() {
+			a, r, c := models.DiffSchedulingInfos(
+				[]*models.DesiredLRPSchedulingInfo{unchanged, removed},
+				[]*models.DesiredLRPSchedulingInfo{unchanged},
+			)
+			Expect(a).To(BeEmpty())
+			Expect(r).To(Equal([]*models.DesiredLRPSchedulingInfo{removed}))
+			Expect(c).To(BeEmpty())
+		})
+
+		It("reports the new version of scheduling infos whose fields changed", func() {
+			scaled := newInfo("updated", 3)
+			rerouted := newInfo("unchanged", 1)
+			rerouted.Annotation = "new annotation"
+
+			a, r, c := models.DiffSchedulingInfos(
+				[]*models.DesiredLRPSchedulingInfo{unchanged, updated},
+				[]*models.DesiredLRPSchedulingInfo{rerouted, scaled},
+			)
+			Expect(a).To(BeEmpty())
+			Expect(r).To(BeEmpty())
+			Expect(c).To(Equal([]*models.DesiredLRPSchedulingInfo{rerouted, scaled}))
+		})
+
+		It("reports additions, removals and changes together", func() {
+			scaled := newInfo("updated", 3)
+
+			a, r, c := models.DiffSchedulingInfos(
+				[]*models.DesiredLRPSchedulingInfo{unchanged, updated, removed},
+				[]*models.DesiredLRPSchedulingInfo{added, scaled, unchanged},
+			)
+			Expect(a).To(Equal([]*models.DesiredLRPSchedulingInfo{added}))
+			Expect(r).To(Equal([]*models.DesiredLRPSchedulingInfo{removed}))
+			Expect(c).To(Equal([]*models.DesiredLRPSchedulingInfo{scaled}))
+		})
+	})
 })
 
 var _ = Describe("DesiredLRPRunInfo", func() {
@@ -983,6 +1484,10 @@ var _ = Describe("DesiredLRPRunInfo", func() {
 		Entry("invalid http check definition", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, nil, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "legacy-jim", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", &models.CheckDefinition{[]*models.Check{&models.Check{HttpCheck: &models.HTTPCheck{Port: 65536}}}, "healthcheck_log_source"}), "port"),
 		Entry("invalid tcp check definition", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, nil, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "legacy-jim", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", &models.CheckDefinition{[]*models.Check{&models.Check{TcpCheck: &models.TCPCheck{}}}, "healthcheck_log_source"}), "port"),
 		Entry("invalid check in check definition", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, nil, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "legacy-jim", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", &models.CheckDefinition{[]*models.Check{&models.Check{HttpCheck: &models.HTTPCheck{}, TcpCheck: &models.TCPCheck{}}}, "healthcheck_log_source"}), "check"),
+		Entry("http check definition missing a path", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, nil, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "legacy-jim", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", &models.CheckDefinition{[]*models.Check{&models.Check{HttpCheck: &models.HTTPCheck{Port: 8080}}}, "healthcheck_log_source"}), "path"),
+		Entry("http check definition with an invalid port", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, nil, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "legacy-jim", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", &models.CheckDefinition{[]*models.Check{&models.Check{HttpCheck: &models.HTTPCheck{Port: 0, Path: "/healthcheck"}}}, "healthcheck_log_source"}), "port"),
+		Entry("nil check in check definition", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, nil, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "legacy-jim", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", &models.CheckDefinition{[]*models.Check{nil}, "healthcheck_log_source"}), "check"),
+		Entry("valid tcp check definition", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, nil, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "legacy-jim", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", &models.CheckDefinition{[]*models.Check{&models.Check{TcpCheck: &models.TCPCheck{Port: 8080}}}, "healthcheck_log_source"}), ""),
 		Entry("invalid cpu weight", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, nil, action, action, action, startTimeoutMs, privileged, 150, ports, egressRules, logSource, metricsGuid, "legacy-jim", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", httpCheckDef), "cpu_weight"),
 		Entry("invalid legacy download user", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, []*models.CachedDependency{{To: "here", From: "there"}}, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", httpCheckDef), "legacy_download_user"),
 		Entry("invalid cached dependency", models.NewDesiredLRPRunInfo(newValidLRPKey(), createdAt, envVars, []*models.CachedDependency{{To: "here"}}, action, action, action, startTimeoutMs, privileged, cpuWeight, ports, egressRules, logSource, metricsGuid, "user", trustedSystemCertificatesPath, []*models.VolumeMount{}, nil, nil, "", "", httpCheckDef), "cached_dependency"),
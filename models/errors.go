@@ -150,6 +150,45 @@ func (err ErrInvalidField) Error() string {
 	return "Invalid field: " + err.Field
 }
 
+// ErrTooManyRoutes is the validation error of desired LRPs with more routes
+// than SetMaximumRouteCount allows.
+type ErrTooManyRoutes struct {
+	Count   int
+	Maximum int
+}
+
+func (err ErrTooManyRoutes) Error() string {
+	return fmt.Sprintf("Invalid field: routes has %d routes, more than the maximum of %d", err.Count, err.Maximum)
+}
+
+// ErrDisallowedImageSource is the validation error of desired LRPs whose
+// rootfs is not allowed by SetImageSourceAllowlist.
+type ErrDisallowedImageSource struct {
+	RootFs string
+}
+
+func (err ErrDisallowedImageSource) Error() string {
+	return fmt.Sprintf("Invalid field: rootfs %q is not from an allowed image source", err.RootFs)
+}
+
+type ErrDuplicateProcessGuid struct {
+	ProcessGuid string
+}
+
+func (err ErrDuplicateProcessGuid) Error() string {
+	return fmt.Sprintf("Invalid field: process_guid %q appears more than once", err.ProcessGuid)
+}
+
+// ErrDuplicateContainerDir is the validation error of volume mounts that share
+// a container directory.
+type ErrDuplicateContainerDir struct {
+	ContainerDir string
+}
+
+func (err ErrDuplicateContainerDir) Error() string {
+	return fmt.Sprintf("Invalid field: volume_mounts container_dir %q appears more than once", err.ContainerDir)
+}
+
 type ErrInvalidModification struct {
 	InvalidField string
 }
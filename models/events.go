@@ -87,10 +87,18 @@ func (event DesiredLRPRemovedEvent) Key() string {
 	return event.DesiredLrp.GetProcessGuid()
 }
 
+// NewActualLRPChangedEvent returns the event announcing a write that changed
+// the actual LRP group from before to after, marked with the kinds of change
+// ActualLRPGroup.Diff finds. Consumers that predate the change kinds ignore
+// them.
 func NewActualLRPChangedEvent(before, after *ActualLRPGroup) *ActualLRPChangedEvent {
+	change := before.Diff(after)
 	return &ActualLRPChangedEvent{
-		Before: before,
-		After:  after,
+		Before:         before,
+		After:          after,
+		StateChanged:   change.StateChanged,
+		NetInfoChanged: change.NetInfoChanged,
+		CellChanged:    change.CellChanged,
 	}
 }
 
@@ -35,8 +35,11 @@ func (m *ActualLRPCreatedEvent) GetActualLrpGroup() *ActualLRPGroup {
 }
 
 type ActualLRPChangedEvent struct {
-	Before *ActualLRPGroup `protobuf:"bytes,1,opt,name=before" json:"before,omitempty"`
-	After  *ActualLRPGroup `protobuf:"bytes,2,opt,name=after" json:"after,omitempty"`
+	Before         *ActualLRPGroup `protobuf:"bytes,1,opt,name=before" json:"before,omitempty"`
+	After          *ActualLRPGroup `protobuf:"bytes,2,opt,name=after" json:"after,omitempty"`
+	StateChanged   bool            `protobuf:"varint,3,opt,name=state_changed,json=stateChanged" json:"state_changed,omitempty"`
+	NetInfoChanged bool            `protobuf:"varint,4,opt,name=net_info_changed,json=netInfoChanged" json:"net_info_changed,omitempty"`
+	CellChanged    bool            `protobuf:"varint,5,opt,name=cell_changed,json=cellChanged" json:"cell_changed,omitempty"`
 }
 
 func (m *ActualLRPChangedEvent) Reset()                    { *m = ActualLRPChangedEvent{} }
@@ -57,6 +60,27 @@ func (m *ActualLRPChangedEvent) GetAfter() *ActualLRPGroup {
 	return nil
 }
 
+func (m *ActualLRPChangedEvent) GetStateChanged() bool {
+	if m != nil {
+		return m.StateChanged
+	}
+	return false
+}
+
+func (m *ActualLRPChangedEvent) GetNetInfoChanged() bool {
+	if m != nil {
+		return m.NetInfoChanged
+	}
+	return false
+}
+
+func (m *ActualLRPChangedEvent) GetCellChanged() bool {
+	if m != nil {
+		return m.CellChanged
+	}
+	return false
+}
+
 type ActualLRPRemovedEvent struct {
 	ActualLrpGroup *ActualLRPGroup `protobuf:"bytes,1,opt,name=actual_lrp_group,json=actualLrpGroup" json:"actual_lrp_group,omitempty"`
 }
@@ -300,6 +324,15 @@ func (this *ActualLRPChangedEvent) Equal(that interface{}) bool {
 	if !this.After.Equal(that1.After) {
 		return false
 	}
+	if this.StateChanged != that1.StateChanged {
+		return false
+	}
+	if this.NetInfoChanged != that1.NetInfoChanged {
+		return false
+	}
+	if this.CellChanged != that1.CellChanged {
+		return false
+	}
 	return true
 }
 func (this *ActualLRPRemovedEvent) Equal(that interface{}) bool {
@@ -606,7 +639,7 @@ func (this *ActualLRPChangedEvent) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 9)
 	s = append(s, "&models.ActualLRPChangedEvent{")
 	if this.Before != nil {
 		s = append(s, "Before: "+fmt.Sprintf("%#v", this.Before)+",\n")
@@ -614,6 +647,9 @@ func (this *ActualLRPChangedEvent) GoString() string {
 	if this.After != nil {
 		s = append(s, "After: "+fmt.Sprintf("%#v", this.After)+",\n")
 	}
+	s = append(s, "StateChanged: "+fmt.Sprintf("%#v", this.StateChanged)+",\n")
+	s = append(s, "NetInfoChanged: "+fmt.Sprintf("%#v", this.NetInfoChanged)+",\n")
+	s = append(s, "CellChanged: "+fmt.Sprintf("%#v", this.CellChanged)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -802,6 +838,30 @@ func (m *ActualLRPChangedEvent) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n3
 	}
+	dAtA[i] = 0x18
+	i++
+	if m.StateChanged {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
+	dAtA[i] = 0x20
+	i++
+	if m.NetInfoChanged {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
+	dAtA[i] = 0x28
+	i++
+	if m.CellChanged {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
 	return i, nil
 }
 
@@ -1135,6 +1195,9 @@ func (m *ActualLRPChangedEvent) Size() (n int) {
 		l = m.After.Size()
 		n += 1 + l + sovEvents(uint64(l))
 	}
+	n += 2
+	n += 2
+	n += 2
 	return n
 }
 
@@ -1268,6 +1331,9 @@ func (this *ActualLRPChangedEvent) String() string {
 	s := strings.Join([]string{`&ActualLRPChangedEvent{`,
 		`Before:` + strings.Replace(fmt.Sprintf("%v", this.Before), "ActualLRPGroup", "ActualLRPGroup", 1) + `,`,
 		`After:` + strings.Replace(fmt.Sprintf("%v", this.After), "ActualLRPGroup", "ActualLRPGroup", 1) + `,`,
+		`StateChanged:` + fmt.Sprintf("%v", this.StateChanged) + `,`,
+		`NetInfoChanged:` + fmt.Sprintf("%v", this.NetInfoChanged) + `,`,
+		`CellChanged:` + fmt.Sprintf("%v", this.CellChanged) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1554,6 +1620,66 @@ func (m *ActualLRPChangedEvent) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateChanged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StateChanged = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NetInfoChanged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NetInfoChanged = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CellChanged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CellChanged = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvents(dAtA[iNdEx:])
@@ -2586,40 +2712,45 @@ var (
 func init() { proto.RegisterFile("events.proto", fileDescriptorEvents) }
 
 var fileDescriptorEvents = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xd1, 0x8a, 0xd3, 0x40,
-	0x14, 0x86, 0x33, 0xbb, 0x6d, 0x65, 0xa7, 0xa5, 0xac, 0x61, 0xad, 0xa5, 0xe8, 0xb4, 0x04, 0x85,
-	0x22, 0xb5, 0x05, 0xf5, 0x01, 0xdc, 0x56, 0xd1, 0x65, 0x2b, 0x48, 0xf0, 0x46, 0x10, 0xca, 0x34,
-	0x39, 0x6d, 0x43, 0xd3, 0x4c, 0x48, 0x26, 0x0b, 0xbd, 0xf3, 0x11, 0x7c, 0x0c, 0x1f, 0x65, 0xf1,
-	0xaa, 0x97, 0x5e, 0x15, 0x1b, 0x6f, 0x64, 0xaf, 0xf6, 0x11, 0x24, 0x93, 0x49, 0xcd, 0xb4, 0x8b,
-	0x0b, 0xe2, 0xdd, 0xcc, 0x39, 0xff, 0xf9, 0x73, 0xe6, 0xe3, 0x0f, 0xae, 0xc0, 0x05, 0x78, 0x3c,
-	0xec, 0xfa, 0x01, 0xe3, 0x4c, 0x2f, 0x2d, 0x98, 0x0d, 0x6e, 0xd8, 0x78, 0x3a, 0x75, 0xf8, 0x2c,
-	0x1a, 0x77, 0x2d, 0xb6, 0xe8, 0x4d, 0xd9, 0x94, 0xf5, 0x44, 0x7b, 0x1c, 0x4d, 0xc4, 0x4d, 0x5c,
-	0xc4, 0x29, 0x1d, 0x6b, 0x1c, 0x53, 0x8b, 0x47, 0xd4, 0x1d, 0xb9, 0x81, 0x2f, 0x2b, 0x77, 0x6d,
-	0x08, 0x9d, 0x00, 0xec, 0x5c, 0x09, 0x73, 0x1a, 0xce, 0xd3, 0xb3, 0xf1, 0x11, 0xdf, 0x3b, 0x15,
-	0x23, 0x43, 0xf3, 0xfd, 0x20, 0x00, 0xca, 0xc1, 0x7e, 0x9d, 0xec, 0xa1, 0xbf, 0xc4, 0x39, 0xaf,
-	0xd1, 0x34, 0x60, 0x91, 0x5f, 0x47, 0x2d, 0xd4, 0x2e, 0x3f, 0xab, 0x75, 0xd3, 0xdd, 0xba, 0xdb,
-	0xc1, 0x37, 0x49, 0xd7, 0xac, 0xa6, 0xfa, 0x61, 0xe0, 0x8b, 0xbb, 0x11, 0xe5, 0xad, 0x67, 0xd4,
-	0x9b, 0x66, 0xd6, 0x5d, 0x5c, 0x1a, 0xc3, 0x84, 0x05, 0x70, 0x8b, 0xa1, 0x54, 0xe9, 0x1d, 0x5c,
-	0xa4, 0x13, 0x0e, 0x41, 0xfd, 0xe0, 0xaf, 0xf2, 0x54, 0xa4, 0xbc, 0xc8, 0x84, 0x05, 0xbb, 0xf8,
-	0x7f, 0x2f, 0x7a, 0x87, 0x6b, 0xaf, 0x52, 0x9a, 0xbb, 0xb4, 0x9e, 0xe3, 0x72, 0x8e, 0xb3, 0xb4,
-	0xd5, 0x33, 0xdb, 0x3f, 0x43, 0x26, 0x96, 0xb2, 0x61, 0xe0, 0x1b, 0x9e, 0x62, 0x97, 0x27, 0xf4,
-	0x64, 0x87, 0xd0, 0x4d, 0x4e, 0x19, 0x9d, 0xb6, 0x4a, 0xe7, 0x26, 0xa9, 0x24, 0xa3, 0xac, 0xaf,
-	0xa0, 0xf9, 0xa7, 0xf5, 0xbf, 0x1d, 0x28, 0xd9, 0xa1, 0xe1, 0x2c, 0xb3, 0x7b, 0x8b, 0xab, 0x39,
-	0xd2, 0x73, 0x58, 0x4a, 0xc7, 0x93, 0x3d, 0xce, 0xe7, 0xb0, 0xec, 0x57, 0x2e, 0xd7, 0x4d, 0x6d,
-	0xb5, 0x6e, 0xa2, 0xab, 0x75, 0x53, 0x33, 0x2b, 0x5b, 0xe6, 0xe7, 0xb0, 0xd4, 0x29, 0xbe, 0x9f,
-	0x73, 0x72, 0xbc, 0x90, 0x53, 0xcf, 0x02, 0x61, 0x99, 0x3e, 0xf7, 0xc1, 0x9e, 0xe5, 0x99, 0x14,
-	0xed, 0x5b, 0x9f, 0x6c, 0xad, 0x73, 0x1a, 0xfd, 0x31, 0x2e, 0x5b, 0xc9, 0xf2, 0x23, 0x8b, 0x45,
-	0x1e, 0xaf, 0x1f, 0xb6, 0x50, 0xbb, 0xd8, 0x2f, 0x24, 0x83, 0x26, 0x16, 0x8d, 0x41, 0x52, 0xd7,
-	0x4f, 0x71, 0x25, 0x95, 0x05, 0x40, 0x43, 0xe6, 0xd5, 0x0b, 0x2d, 0xd4, 0x3e, 0xea, 0x93, 0x44,
-	0x77, 0xb5, 0x6e, 0xd6, 0xf2, 0xbd, 0x0e, 0x5b, 0x38, 0x1c, 0x16, 0x3e, 0x5f, 0x9a, 0xa9, 0xb5,
-	0x29, 0xca, 0x7a, 0x03, 0x17, 0x43, 0xc7, 0xb3, 0xa0, 0x5e, 0x6c, 0xa1, 0xf6, 0xa1, 0xfc, 0x46,
-	0x5a, 0x32, 0x7a, 0xb8, 0x2a, 0xd8, 0x85, 0xfd, 0xe5, 0x00, 0x5c, 0xf7, 0xcc, 0xd6, 0x1f, 0xe2,
-	0x3b, 0x16, 0xb8, 0xee, 0xc8, 0xb1, 0x05, 0xbd, 0x23, 0xa9, 0x2f, 0x59, 0xa2, 0x6d, 0xbc, 0xc0,
-	0xc7, 0x1f, 0x68, 0x38, 0x57, 0x52, 0xd8, 0xc2, 0x85, 0xe4, 0xd7, 0x96, 0xb4, 0x2b, 0x19, 0x9a,
-	0x44, 0x67, 0x8a, 0x8e, 0xf1, 0x49, 0x4e, 0xe5, 0xc3, 0xf6, 0x68, 0x27, 0x6c, 0xea, 0x5c, 0x16,
-	0x33, 0x43, 0x8d, 0x99, 0x2a, 0x92, 0x01, 0x93, 0x3b, 0x29, 0xd1, 0xba, 0x75, 0xa7, 0x7e, 0x67,
-	0xb5, 0x21, 0xda, 0xf7, 0x0d, 0xd1, 0xae, 0x37, 0x04, 0x7d, 0x8e, 0x09, 0xfa, 0x1a, 0x13, 0x74,
-	0x19, 0x13, 0xb4, 0x8a, 0x09, 0xfa, 0x11, 0x13, 0xf4, 0x2b, 0x26, 0xda, 0x75, 0x4c, 0xd0, 0x97,
-	0x9f, 0x44, 0xfb, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x35, 0x9e, 0x86, 0xb0, 0x27, 0x05, 0x00, 0x00,
+	// 631 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xd1, 0x8a, 0xd3, 0x4e,
+	0x14, 0xc6, 0x93, 0xdd, 0xb6, 0xff, 0xff, 0x4e, 0x63, 0xa9, 0x61, 0xed, 0x96, 0xa2, 0xd3, 0x12,
+	0x14, 0x8a, 0xd4, 0x16, 0xd4, 0x07, 0x70, 0xdb, 0x15, 0x2d, 0x5b, 0x41, 0x82, 0x37, 0x82, 0x50,
+	0xa6, 0xc9, 0xb4, 0x0d, 0x4d, 0x67, 0x42, 0x32, 0x5d, 0xe8, 0x9d, 0x8f, 0xe0, 0x63, 0xf8, 0x28,
+	0x8b, 0x78, 0xd1, 0x4b, 0xaf, 0x8a, 0x8d, 0x37, 0xb2, 0x57, 0xfb, 0x08, 0x92, 0xc9, 0xa4, 0xce,
+	0xb4, 0x8b, 0x0b, 0xe2, 0x5d, 0x72, 0xbe, 0xef, 0xfc, 0x72, 0xf6, 0x9c, 0x6f, 0x0b, 0x0c, 0x7c,
+	0x81, 0x09, 0x8b, 0xda, 0x41, 0x48, 0x19, 0x35, 0x0b, 0x73, 0xea, 0x62, 0x3f, 0xaa, 0x3d, 0x99,
+	0x78, 0x6c, 0xba, 0x18, 0xb5, 0x1d, 0x3a, 0xef, 0x4c, 0xe8, 0x84, 0x76, 0xb8, 0x3c, 0x5a, 0x8c,
+	0xf9, 0x1b, 0x7f, 0xe1, 0x4f, 0x69, 0x5b, 0xad, 0x8c, 0x1c, 0xb6, 0x40, 0xfe, 0xd0, 0x0f, 0x03,
+	0x51, 0xb9, 0xeb, 0xe2, 0xc8, 0x0b, 0xb1, 0x2b, 0x95, 0x00, 0x43, 0xd1, 0x2c, 0x7d, 0xb6, 0xde,
+	0x83, 0x7b, 0xa7, 0xbc, 0x65, 0x60, 0xbf, 0xed, 0x85, 0x18, 0x31, 0xec, 0xbe, 0x4c, 0xe6, 0x30,
+	0x5f, 0x00, 0x89, 0x35, 0x9c, 0x84, 0x74, 0x11, 0x54, 0xf5, 0x86, 0xde, 0x2c, 0x3e, 0xad, 0xb4,
+	0xd3, 0xd9, 0xda, 0xdb, 0xc6, 0x57, 0x89, 0x6a, 0x97, 0x52, 0xff, 0x20, 0x0c, 0xf8, 0xbb, 0xf5,
+	0xf5, 0x40, 0x66, 0x4f, 0x11, 0x99, 0x64, 0xec, 0x36, 0x28, 0x8c, 0xf0, 0x98, 0x86, 0xf8, 0x16,
+	0xa2, 0x70, 0x99, 0x2d, 0x90, 0x47, 0x63, 0x86, 0xc3, 0xea, 0xc1, 0x1f, 0xed, 0xa9, 0xc9, 0x3c,
+	0x03, 0x77, 0x22, 0x86, 0x18, 0x1e, 0x3a, 0xe9, 0x37, 0xab, 0x87, 0x0d, 0xbd, 0xf9, 0x7f, 0xb7,
+	0x7e, 0xb9, 0xae, 0x6b, 0x57, 0xeb, 0xfa, 0x89, 0x22, 0xb6, 0xe8, 0xdc, 0x63, 0x78, 0x1e, 0xb0,
+	0xa5, 0x6d, 0x70, 0x41, 0x0c, 0x6a, 0x0e, 0x40, 0x99, 0x60, 0x36, 0xf4, 0xc8, 0x98, 0x6e, 0x41,
+	0x39, 0x0e, 0xb2, 0x04, 0xa8, 0xb6, 0xab, 0x4b, 0xac, 0x12, 0xc1, 0xac, 0x4f, 0xc6, 0x34, 0xa3,
+	0x9d, 0x02, 0xc3, 0xc1, 0xbe, 0xbf, 0x25, 0xe5, 0x39, 0x09, 0x0a, 0x52, 0x45, 0xd6, 0x24, 0x4a,
+	0x31, 0xa9, 0x0b, 0x84, 0x72, 0x29, 0x1b, 0xcf, 0xe9, 0xc5, 0xbf, 0xbb, 0xd4, 0x1b, 0x50, 0x39,
+	0x4b, 0x53, 0xb2, 0x9b, 0x82, 0x67, 0xa0, 0x28, 0xe5, 0x47, 0x60, 0xcd, 0x0c, 0xfb, 0xbb, 0xc9,
+	0x06, 0xc2, 0x36, 0x08, 0x03, 0x8b, 0x28, 0x38, 0xf9, 0xf0, 0x8f, 0x77, 0x0e, 0x7f, 0x13, 0x29,
+	0x3b, 0x7a, 0x53, 0x3d, 0xfa, 0x4d, 0xd6, 0xd4, 0xa0, 0x8e, 0xaf, 0xac, 0xe6, 0xaf, 0xc6, 0xff,
+	0xa2, 0xe4, 0x36, 0x44, 0xd1, 0x34, 0xc3, 0xbd, 0x06, 0x25, 0x69, 0xd3, 0x33, 0xbc, 0x14, 0xc4,
+	0xe3, 0xbd, 0x3d, 0x9f, 0xe3, 0x65, 0xd7, 0x48, 0xae, 0xbb, 0x5a, 0xd7, 0xf5, 0xab, 0x75, 0x5d,
+	0xb3, 0x8d, 0xed, 0xce, 0xcf, 0xf1, 0xd2, 0x44, 0xe0, 0x44, 0x22, 0x79, 0x24, 0x62, 0x88, 0x38,
+	0x98, 0x23, 0xd3, 0x3f, 0xf7, 0xfe, 0x1e, 0xb2, 0x2f, 0x4c, 0xfb, 0xe8, 0xe3, 0x2d, 0x5a, 0xf2,
+	0x98, 0x8f, 0x40, 0xd1, 0x49, 0x86, 0x1f, 0x3a, 0x74, 0x41, 0x18, 0xff, 0x27, 0xc8, 0x77, 0x73,
+	0x49, 0xa3, 0x0d, 0xb8, 0xd0, 0x4b, 0xea, 0x3c, 0x99, 0xdc, 0x16, 0x62, 0x14, 0x51, 0xc2, 0x33,
+	0x7e, 0x24, 0x25, 0x53, 0xd2, 0x94, 0x64, 0x26, 0x75, 0x9b, 0x97, 0xcd, 0x1a, 0xc8, 0x47, 0x1e,
+	0x71, 0x30, 0x4f, 0xf5, 0xa1, 0xf8, 0x46, 0x5a, 0xb2, 0x3a, 0xa0, 0xc4, 0x77, 0x17, 0x75, 0x97,
+	0x3d, 0xec, 0xfb, 0x7d, 0xd7, 0x7c, 0x00, 0xfe, 0xe3, 0x71, 0xf7, 0x5c, 0xbe, 0xbd, 0x23, 0xe1,
+	0x2f, 0x38, 0x5c, 0xb6, 0x9e, 0x83, 0xf2, 0x3b, 0x14, 0xcd, 0x94, 0x14, 0x36, 0x40, 0x2e, 0xf9,
+	0xc9, 0x12, 0xdb, 0x36, 0xb2, 0xd5, 0x24, 0x3e, 0x9b, 0x2b, 0xd6, 0x07, 0xd1, 0x25, 0x87, 0xed,
+	0xe1, 0x4e, 0xd8, 0xd4, 0xbe, 0x2c, 0x66, 0x96, 0x1a, 0x33, 0xd5, 0x24, 0x02, 0x26, 0x66, 0x52,
+	0xa2, 0x75, 0xeb, 0x4c, 0xdd, 0xd6, 0x6a, 0x03, 0xb5, 0x6f, 0x1b, 0xa8, 0x5d, 0x6f, 0xa0, 0xfe,
+	0x31, 0x86, 0xfa, 0xe7, 0x18, 0xea, 0x97, 0x31, 0xd4, 0x57, 0x31, 0xd4, 0xbf, 0xc7, 0x50, 0xff,
+	0x19, 0x43, 0xed, 0x3a, 0x86, 0xfa, 0xa7, 0x1f, 0x50, 0xfb, 0x15, 0x00, 0x00, 0xff, 0xff, 0xe0,
+	0xd6, 0x04, 0xbe, 0xff, 0x05, 0x00, 0x00,
 }
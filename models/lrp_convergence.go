@@ -20,3 +20,33 @@ type ActualLRPKeyWithSchedulingInfo struct {
 	Key            *ActualLRPKey
 	SchedulingInfo *DesiredLRPSchedulingInfo
 }
+
+// ActualLRPRetireReason explains why convergence retires an actual LRP.
+type ActualLRPRetireReason string
+
+const (
+	// The desired LRP no longer has as many instances as the actual LRP's index.
+	RetireReasonExtraInstance ActualLRPRetireReason = "extra_instance"
+	// No desired LRP exists for the actual LRP.
+	RetireReasonNoDesiredLRP ActualLRPRetireReason = "no_desired_lrp"
+	// The actual LRP has been running for longer than the max instance
+	// lifetime, and is retired so that convergence replaces it.
+	RetireReasonMaxLifetime ActualLRPRetireReason = "max_lifetime"
+	// The actual LRP crashed too often to be restarted again, and is retired
+	// so that its index is started afresh.
+	RetireReasonNotRestartable ActualLRPRetireReason = "not_restartable"
+)
+
+type ActualLRPKeyWithRetireReason struct {
+	Key    *ActualLRPKey
+	Reason ActualLRPRetireReason
+}
+
+// ActualLRPKeysToRetire strips the retire reasons from the given keys.
+func ActualLRPKeysToRetire(keysWithReasons []*ActualLRPKeyWithRetireReason) []*ActualLRPKey {
+	keys := make([]*ActualLRPKey, 0, len(keysWithReasons))
+	for _, keyWithReason := range keysWithReasons {
+		keys = append(keys, keyWithReason.Key)
+	}
+	return keys
+}
@@ -6,3 +6,30 @@ const (
 	maximumAnnotationLength = 10 * 1024
 	maximumRouteLength      = 128 * 1024
 )
+
+// maximumRouteCount caps the number of routes of a desired LRP. Zero leaves
+// the number unbounded.
+var maximumRouteCount int
+
+// allowedImageSchemes and allowedImageHosts restrict the rootfs of newly
+// desired LRPs. Empty lists allow any scheme or host.
+var allowedImageSchemes, allowedImageHosts []string
+
+// SetImageSourceAllowlist makes desire LRP requests whose rootfs fails
+// DesiredLRP.ValidateImageSources against the given schemes and hosts invalid.
+// Empty lists allow any scheme or host. It is meant to be called once at
+// startup, before any validation.
+func SetImageSourceAllowlist(schemes, hosts []string) {
+	allowedImageSchemes = schemes
+	allowedImageHosts = hosts
+}
+
+// SetMaximumRouteCount makes desired LRPs and desired LRP updates with more
+// than max routes invalid. A non-positive max removes the cap. It is meant to
+// be called once at startup, before any validation.
+func SetMaximumRouteCount(max int) {
+	if max < 0 {
+		max = 0
+	}
+	maximumRouteCount = max
+}
@@ -34,8 +34,24 @@ func (m *PingResponse) GetAvailable() bool {
 	return false
 }
 
+type ConvergenceReadinessResponse struct {
+	Converged bool `protobuf:"varint,1,opt,name=converged" json:"converged"`
+}
+
+func (m *ConvergenceReadinessResponse) Reset()                    { *m = ConvergenceReadinessResponse{} }
+func (*ConvergenceReadinessResponse) ProtoMessage()               {}
+func (*ConvergenceReadinessResponse) Descriptor() ([]byte, []int) { return fileDescriptorPing, []int{1} }
+
+func (m *ConvergenceReadinessResponse) GetConverged() bool {
+	if m != nil {
+		return m.Converged
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*PingResponse)(nil), "models.PingResponse")
+	proto.RegisterType((*ConvergenceReadinessResponse)(nil), "models.ConvergenceReadinessResponse")
 }
 func (this *PingResponse) Equal(that interface{}) bool {
 	if that == nil {
@@ -67,6 +83,36 @@ func (this *PingResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ConvergenceReadinessResponse) Equal(that interface{}) bool {
+	if that == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	}
+
+	that1, ok := that.(*ConvergenceReadinessResponse)
+	if !ok {
+		that2, ok := that.(ConvergenceReadinessResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return true
+		}
+		return false
+	} else if this == nil {
+		return false
+	}
+	if this.Converged != that1.Converged {
+		return false
+	}
+	return true
+}
 func (this *PingResponse) GoString() string {
 	if this == nil {
 		return "nil"
@@ -77,6 +123,16 @@ func (this *PingResponse) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *ConvergenceReadinessResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&models.ConvergenceReadinessResponse{")
+	s = append(s, "Converged: "+fmt.Sprintf("%#v", this.Converged)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringPing(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -111,6 +167,32 @@ func (m *PingResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ConvergenceReadinessResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConvergenceReadinessResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0x8
+	i++
+	if m.Converged {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
+	return i, nil
+}
+
 func encodeFixed64Ping(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	dAtA[offset+1] = uint8(v >> 8)
@@ -145,6 +227,13 @@ func (m *PingResponse) Size() (n int) {
 	return n
 }
 
+func (m *ConvergenceReadinessResponse) Size() (n int) {
+	var l int
+	_ = l
+	n += 2
+	return n
+}
+
 func sovPing(x uint64) (n int) {
 	for {
 		n++
@@ -168,6 +257,16 @@ func (this *PingResponse) String() string {
 	}, "")
 	return s
 }
+func (this *ConvergenceReadinessResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConvergenceReadinessResponse{`,
+		`Converged:` + fmt.Sprintf("%v", this.Converged) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringPing(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -246,6 +345,76 @@ func (m *PingResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ConvergenceReadinessResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConvergenceReadinessResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConvergenceReadinessResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Converged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Converged = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipPing(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -354,16 +523,18 @@ var (
 func init() { proto.RegisterFile("ping.proto", fileDescriptorPing) }
 
 var fileDescriptorPing = []byte{
-	// 167 bytes of a gzipped FileDescriptorProto
+	// 201 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0xc8, 0xcc, 0x4b,
 	0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0xcb, 0xcd, 0x4f, 0x49, 0xcd, 0x29, 0x96, 0xd2,
 	0x4d, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xcf, 0x4f, 0xcf, 0xd7,
 	0x07, 0x4b, 0x27, 0x95, 0xa6, 0x81, 0x79, 0x60, 0x0e, 0x98, 0x05, 0xd1, 0xa6, 0x64, 0xc4, 0xc5,
 	0x13, 0x90, 0x99, 0x97, 0x1e, 0x94, 0x5a, 0x5c, 0x90, 0x9f, 0x57, 0x9c, 0x2a, 0xa4, 0xc4, 0xc5,
 	0x99, 0x58, 0x96, 0x98, 0x99, 0x93, 0x98, 0x94, 0x93, 0x2a, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0xe1,
-	0xc4, 0x72, 0xe2, 0x9e, 0x3c, 0x43, 0x10, 0x42, 0xd8, 0x49, 0xe7, 0xc2, 0x43, 0x39, 0x86, 0x1b,
-	0x0f, 0xe5, 0x18, 0x3e, 0x3c, 0x94, 0x63, 0x6c, 0x78, 0x24, 0xc7, 0xb8, 0xe2, 0x91, 0x1c, 0xe3,
-	0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0xf8, 0xe2, 0x91, 0x1c,
-	0xc3, 0x87, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x00, 0x02, 0x00, 0x00, 0xff, 0xff, 0xc6,
-	0x59, 0x30, 0x9a, 0xa5, 0x00, 0x00, 0x00,
+	0xc4, 0x72, 0xe2, 0x9e, 0x3c, 0x43, 0x10, 0x42, 0x58, 0xc9, 0x89, 0x4b, 0xc6, 0x39, 0x3f, 0xaf,
+	0x2c, 0xb5, 0x28, 0x3d, 0x35, 0x2f, 0x39, 0x35, 0x28, 0x35, 0x31, 0x25, 0x33, 0x2f, 0xb5, 0xb8,
+	0x18, 0xd9, 0x8c, 0x64, 0xa8, 0x7c, 0x0a, 0xaa, 0x19, 0x70, 0x61, 0x27, 0x9d, 0x0b, 0x0f, 0xe5,
+	0x18, 0x6e, 0x3c, 0x94, 0x63, 0xf8, 0xf0, 0x50, 0x8e, 0xb1, 0xe1, 0x91, 0x1c, 0xe3, 0x8a, 0x47,
+	0x72, 0x8c, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x8b,
+	0x47, 0x72, 0x0c, 0x1f, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x00, 0x08, 0x00, 0x00, 0xff,
+	0xff, 0xda, 0x0b, 0x57, 0x21, 0xe9, 0x00, 0x00, 0x00,
 }
@@ -71,11 +71,7 @@ func (r RestartCalculator) ShouldRestart(now, crashedAt int64, crashCount int32)
 		return true
 
 	case crashCount < r.MaxRestartAttempts:
-		backoffDuration := exponentialBackoff(crashCount-r.ImmediateRestarts, r.MaxBackoffCount)
-		if backoffDuration > r.MaxBackoffDuration {
-			backoffDuration = r.MaxBackoffDuration
-		}
-		nextRestartTime := crashedAt + backoffDuration.Nanoseconds()
+		nextRestartTime := crashedAt + r.Backoff(crashCount).Nanoseconds()
 		if nextRestartTime <= now {
 			return true
 		}
@@ -83,3 +79,62 @@ func (r RestartCalculator) ShouldRestart(now, crashedAt int64, crashCount int32)
 
 	return false
 }
+
+// Backoff returns how long an actual LRP that has crashed crashCount times
+// must stay crashed before it is restarted.
+func (r RestartCalculator) Backoff(crashCount int32) time.Duration {
+	if crashCount < r.ImmediateRestarts {
+		return 0
+	}
+
+	backoffDuration := exponentialBackoff(crashCount-r.ImmediateRestarts, r.MaxBackoffCount)
+	if backoffDuration > r.MaxBackoffDuration {
+		backoffDuration = r.MaxBackoffDuration
+	}
+	return backoffDuration
+}
+
+// RestartPolicy decides when convergence restarts a crashed actual LRP: only
+// while its crash count is below MaxRestarts, and only once Backoff of its
+// crash count has elapsed since it crashed. A nil Backoff restarts crashed
+// actual LRPs immediately.
+type RestartPolicy struct {
+	MaxRestarts int32
+	Backoff     func(crashCount int32) time.Duration
+}
+
+// NewRestartPolicy returns the restart policy equivalent to the given restart
+// calculator.
+func NewRestartPolicy(calc RestartCalculator) RestartPolicy {
+	maxRestarts := calc.MaxRestartAttempts
+	if maxRestarts < calc.ImmediateRestarts {
+		maxRestarts = calc.ImmediateRestarts
+	}
+	return RestartPolicy{
+		MaxRestarts: maxRestarts,
+		Backoff:     calc.Backoff,
+	}
+}
+
+func NewDefaultRestartPolicy() RestartPolicy {
+	return NewRestartPolicy(NewDefaultRestartCalculator())
+}
+
+func (p RestartPolicy) ShouldRestart(now, crashedAt int64, crashCount int32) bool {
+	if p.GivesUp(crashCount) {
+		return false
+	}
+
+	if p.Backoff == nil {
+		return true
+	}
+
+	backoff := p.Backoff(crashCount)
+	return backoff <= 0 || crashedAt+backoff.Nanoseconds() <= now
+}
+
+// GivesUp reports whether an actual LRP that crashed crashCount times is never
+// restarted again, however long it stays crashed.
+func (p RestartPolicy) GivesUp(crashCount int32) bool {
+	return crashCount >= p.MaxRestarts
+}
@@ -65,6 +65,41 @@ func (r *Routes) Equal(other Routes) bool {
 	return true
 }
 
+// Normalized returns nil for nil and empty routes, which are equivalent but
+// serialize differently, and the routes otherwise.
+func (r *Routes) Normalized() *Routes {
+	if r == nil || len(*r) == 0 {
+		return nil
+	}
+	return r
+}
+
+// Count returns the number of routes across all route types. Every element of
+// a route type whose value is a JSON array is a route; any other value counts
+// as a single route.
+func (r Routes) Count() int {
+	count := 0
+	for _, value := range r {
+		var routes []json.RawMessage
+		if value != nil && json.Unmarshal(*value, &routes) == nil {
+			count += len(routes)
+		} else {
+			count++
+		}
+	}
+	return count
+}
+
+func validateRouteCount(routes *Routes) error {
+	if maximumRouteCount == 0 || routes == nil {
+		return nil
+	}
+	if count := routes.Count(); count > maximumRouteCount {
+		return ErrTooManyRoutes{Count: count, Maximum: maximumRouteCount}
+	}
+	return nil
+}
+
 func (r Routes) Validate() error {
 	totalRoutesLength := 0
 	if r != nil {
@@ -48,4 +48,22 @@ var _ = Describe("Routes", func() {
 		"abc": &(json.RawMessage{'"', 'd', '"'}),
 		"def": &(json.RawMessage{'"', 'g', '"'}),
 	})
+	Describe("Count", func() {
+		It("counts every element of array route types and any other value as one route", func() {
+			arrayRoutes := json.RawMessage(`[{"hostnames":["a"]},{"hostnames":["b"]},{"hostnames":["c"]}]`)
+			objectRoute := json.RawMessage(`{"port":8080}`)
+			emptyRoutes := json.RawMessage(`[]`)
+
+			routes := models.Routes{
+				"cf-router":  &arrayRoutes,
+				"tcp-router": &objectRoute,
+				"empty":      &emptyRoutes,
+			}
+			Expect(routes.Count()).To(Equal(4))
+		})
+
+		It("is zero for no routes", func() {
+			Expect(models.Routes{}.Count()).To(Equal(0))
+		})
+	})
 })
@@ -0,0 +1,9 @@
+package models
+
+// TableStat describes the size of a database table. Rows is the backend's
+// estimate of the number of rows and Bytes of the space used by the table and
+// its indexes.
+type TableStat struct {
+	Rows  int64
+	Bytes int64
+}
@@ -58,7 +58,7 @@ func NewValidDesiredLRP(guid string) *models.DesiredLRP {
 					HttpCheck: &models.HTTPCheck{
 						Port:             8080,
 						RequestTimeoutMs: 100,
-						Path:             "",
+						Path:             "/healthcheck",
 					},
 				},
 			},
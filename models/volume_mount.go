@@ -59,3 +59,21 @@ func (v *VolumeMount) Validate() error {
 
 	return nil
 }
+
+// validateVolumeMounts validates each mount and that no two mounts share a
+// container directory, which would fail on the cell.
+func validateVolumeMounts(mounts []*VolumeMount) ValidationError {
+	var validationError ValidationError
+
+	containerDirs := make(map[string]struct{}, len(mounts))
+	for _, mount := range mounts {
+		validationError = validationError.Check(mount)
+
+		if _, ok := containerDirs[mount.ContainerDir]; ok {
+			validationError = validationError.Append(ErrDuplicateContainerDir{ContainerDir: mount.ContainerDir})
+		}
+		containerDirs[mount.ContainerDir] = struct{}{}
+	}
+
+	return validationError
+}
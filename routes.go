@@ -6,6 +6,10 @@ const (
 	// Ping
 	PingRoute = "Ping"
 
+	// Convergence
+	ConvergenceReadinessRoute = "ConvergenceReadiness"
+	ConvergenceHistoryRoute   = "ConvergenceHistory"
+
 	// Domains
 	DomainsRoute      = "Domains"
 	UpsertDomainRoute = "UpsertDomain"
@@ -13,15 +17,18 @@ const (
 	// Actual LRPs
 	ActualLRPGroupsRoute                     = "ActualLRPGroups"
 	ActualLRPGroupsByProcessGuidRoute        = "ActualLRPGroupsByProcessGuid"
+	ActualLRPGroupsByStateRoute              = "ActualLRPGroupsByState"
 	ActualLRPGroupByProcessGuidAndIndexRoute = "ActualLRPGroupsByProcessGuidAndIndex"
 
 	// Actual LRP Lifecycle
-	ClaimActualLRPRoute  = "ClaimActualLRP"
-	StartActualLRPRoute  = "StartActualLRP"
-	CrashActualLRPRoute  = "CrashActualLRP"
-	FailActualLRPRoute   = "FailActualLRP"
-	RemoveActualLRPRoute = "RemoveActualLRP"
-	RetireActualLRPRoute = "RetireActualLRP"
+	ClaimActualLRPRoute                = "ClaimActualLRP"
+	StartActualLRPRoute                = "StartActualLRP"
+	ActualLRPLifecycleBatchRoute       = "ActualLRPLifecycleBatch"
+	CrashActualLRPRoute                = "CrashActualLRP"
+	FailActualLRPRoute                 = "FailActualLRP"
+	RemoveActualLRPRoute               = "RemoveActualLRP"
+	RetireActualLRPRoute               = "RetireActualLRP"
+	RetireActualLRPsByProcessGuidRoute = "RetireActualLRPsByProcessGuid"
 
 	// Evacuation
 	RemoveEvacuatingActualLRPRoute = "RemoveEvacuatingActualLRP"
@@ -33,6 +40,7 @@ const (
 	// Desired LRPs
 	DesiredLRPsRoute               = "DesiredLRPs_r2"
 	DesiredLRPSchedulingInfosRoute = "DesiredLRPSchedulingInfos"
+	DesiredLRPProcessGuidsRoute    = "DesiredLRPProcessGuids"
 	DesiredLRPByProcessGuidRoute   = "DesiredLRPByProcessGuid_r2"
 
 	DesiredLRPsRoute_r1             = "DesiredLRPs_r1" // Deprecated
@@ -41,9 +49,11 @@ const (
 	DesiredLRPByProcessGuidRoute_r0 = "DesiredLRPByProcessGuid" // Deprecated
 
 	// Desire LRP Lifecycle
-	DesireDesiredLRPRoute = "DesireDesiredLRP_r2"
-	UpdateDesiredLRPRoute = "UpdateDesireLRP"
-	RemoveDesiredLRPRoute = "RemoveDesiredLRP"
+	DesireDesiredLRPRoute       = "DesireDesiredLRP_r2"
+	DesireDesiredLRPsRoute      = "DesireDesiredLRPs"
+	UpdateDesiredLRPRoute       = "UpdateDesireLRP"
+	UpdateDesiredLRPRoutesRoute = "UpdateDesiredLRPRoutes"
+	RemoveDesiredLRPRoute       = "RemoveDesiredLRP"
 
 	DesireDesiredLRPRoute_r1 = "DesireDesiredLRP_r1"
 	DesireDesiredLRPRoute_r0 = "DesireDesiredLRP"
@@ -80,6 +90,10 @@ var Routes = rata.Routes{
 	// Ping
 	{Path: "/v1/ping", Method: "POST", Name: PingRoute},
 
+	// Convergence
+	{Path: "/v1/convergence/readiness", Method: "GET", Name: ConvergenceReadinessRoute},
+	{Path: "/v1/convergence/history", Method: "GET", Name: ConvergenceHistoryRoute},
+
 	// Domains
 	{Path: "/v1/domains/list", Method: "POST", Name: DomainsRoute},
 	{Path: "/v1/domains/upsert", Method: "POST", Name: UpsertDomainRoute},
@@ -87,15 +101,18 @@ var Routes = rata.Routes{
 	// Actual LRPs
 	{Path: "/v1/actual_lrp_groups/list", Method: "POST", Name: ActualLRPGroupsRoute},
 	{Path: "/v1/actual_lrp_groups/list_by_process_guid", Method: "POST", Name: ActualLRPGroupsByProcessGuidRoute},
+	{Path: "/v1/actual_lrp_groups/list_by_state", Method: "POST", Name: ActualLRPGroupsByStateRoute},
 	{Path: "/v1/actual_lrp_groups/get_by_process_guid_and_index", Method: "POST", Name: ActualLRPGroupByProcessGuidAndIndexRoute},
 
 	// Actual LRP Lifecycle
 	{Path: "/v1/actual_lrps/claim", Method: "POST", Name: ClaimActualLRPRoute},
 	{Path: "/v1/actual_lrps/start", Method: "POST", Name: StartActualLRPRoute},
+	{Path: "/v1/actual_lrps/lifecycle_batch", Method: "POST", Name: ActualLRPLifecycleBatchRoute},
 	{Path: "/v1/actual_lrps/crash", Method: "POST", Name: CrashActualLRPRoute},
 	{Path: "/v1/actual_lrps/fail", Method: "POST", Name: FailActualLRPRoute},
 	{Path: "/v1/actual_lrps/remove", Method: "POST", Name: RemoveActualLRPRoute},
 	{Path: "/v1/actual_lrps/retire", Method: "POST", Name: RetireActualLRPRoute},
+	{Path: "/v1/actual_lrps/retire_by_process_guid", Method: "POST", Name: RetireActualLRPsByProcessGuidRoute},
 
 	// Evacuation
 	{Path: "/v1/actual_lrps/remove_evacuating", Method: "POST", Name: RemoveEvacuatingActualLRPRoute},
@@ -106,6 +123,7 @@ var Routes = rata.Routes{
 
 	// Desired LRPs
 	{Path: "/v1/desired_lrp_scheduling_infos/list", Method: "POST", Name: DesiredLRPSchedulingInfosRoute},
+	{Path: "/v1/desired_lrps/guids", Method: "GET", Name: DesiredLRPProcessGuidsRoute},
 
 	{Path: "/v1/desired_lrps/list.r2", Method: "POST", Name: DesiredLRPsRoute},
 	{Path: "/v1/desired_lrps/get_by_process_guid.r2", Method: "POST", Name: DesiredLRPByProcessGuidRoute},
@@ -117,8 +135,10 @@ var Routes = rata.Routes{
 
 	// Desire LPR Lifecycle
 	{Path: "/v1/desired_lrp/desire.r2", Method: "POST", Name: DesireDesiredLRPRoute},
+	{Path: "/v1/desired_lrps/desire", Method: "POST", Name: DesireDesiredLRPsRoute},
 	{Path: "/v1/desired_lrp/desire.r1", Method: "POST", Name: DesireDesiredLRPRoute_r1}, // Deprecated
 	{Path: "/v1/desired_lrp/update", Method: "POST", Name: UpdateDesiredLRPRoute},
+	{Path: "/v1/desired_lrp/update_routes", Method: "POST", Name: UpdateDesiredLRPRoutesRoute},
 	{Path: "/v1/desired_lrp/remove", Method: "POST", Name: RemoveDesiredLRPRoute},
 	{Path: "/v1/desired_lrp/desire", Method: "POST", Name: DesireDesiredLRPRoute_r0}, // Deprecated
 